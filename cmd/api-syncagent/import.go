@@ -0,0 +1,365 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	objectsync "github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type importOptions struct {
+	Kubeconfig        string
+	KcpKubeconfig     string
+	PublishedResource string
+	AgentName         string
+	Cluster           string
+	WorkspacePath     string
+	Namespace         string
+	TargetNamespace   string
+	Selector          string
+	PageSize          int64
+	ProgressInterval  int
+	DryRun            bool
+}
+
+func (o *importOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "kubeconfig file of the service cluster")
+	flags.StringVar(&o.KcpKubeconfig, "kcp-kubeconfig", o.KcpKubeconfig, "kubeconfig file of kcp")
+	flags.StringVar(&o.PublishedResource, "published-resource", o.PublishedResource, "name of the PublishedResource whose local objects should be imported")
+	flags.StringVar(&o.AgentName, "agent-name", o.AgentName, "name of the Sync Agent that will synchronize the imported objects")
+	flags.StringVar(&o.Cluster, "cluster", o.Cluster, "logical cluster name of the kcp workspace to import the objects into")
+	flags.StringVar(&o.WorkspacePath, "workspace-path", o.WorkspacePath, "path of the kcp workspace to import the objects into (optional, recorded on the local objects)")
+	flags.StringVar(&o.Namespace, "namespace", o.Namespace, "only import local objects from this namespace (default: all namespaces)")
+	flags.StringVar(&o.TargetNamespace, "target-namespace", o.TargetNamespace, "namespace in kcp to create namespaced objects in (default: the local object's namespace)")
+	flags.StringVarP(&o.Selector, "selector", "l", o.Selector, "only import local objects matching this label selector")
+	flags.Int64Var(&o.PageSize, "page-size", o.PageSize, "number of local objects to list at once")
+	flags.IntVar(&o.ProgressInterval, "progress-interval", o.ProgressInterval, "print the progress after this many objects")
+	flags.BoolVar(&o.DryRun, "dry-run", o.DryRun, "only print which objects would be imported")
+}
+
+func (o *importOptions) Validate() error {
+	errs := []error{}
+
+	if len(o.KcpKubeconfig) == 0 {
+		errs = append(errs, errors.New("--kcp-kubeconfig is required"))
+	}
+
+	if len(o.PublishedResource) == 0 {
+		errs = append(errs, errors.New("--published-resource is required"))
+	}
+
+	if len(o.AgentName) == 0 {
+		errs = append(errs, errors.New("--agent-name is required"))
+	}
+
+	if len(o.Cluster) == 0 {
+		errs = append(errs, errors.New("--cluster is required"))
+	}
+
+	if _, err := labels.Parse(o.Selector); err != nil {
+		errs = append(errs, fmt.Errorf("invalid --selector: %w", err))
+	}
+
+	if o.PageSize <= 0 {
+		errs = append(errs, errors.New("--page-size must be positive"))
+	}
+
+	if o.ProgressInterval <= 0 {
+		errs = append(errs, errors.New("--progress-interval must be positive"))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// importStatistics counts the outcome of importing each local object.
+type importStatistics struct {
+	processed int
+	imported  int
+	skipped   int
+	failed    int
+}
+
+func (s importStatistics) String() string {
+	return fmt.Sprintf("%d object(s) processed: %d imported, %d already imported, %d failed", s.processed, s.imported, s.skipped, s.failed)
+}
+
+// runImport implements the "import" subcommand, which creates objects in a kcp
+// workspace for existing objects on the service cluster and links them together,
+// so that the Sync Agent continues to synchronize them as if they had been
+// created in kcp. Local objects that are already linked to kcp are skipped, so an
+// interrupted import can simply be started again.
+func runImport(ctx context.Context, args []string, out io.Writer) error {
+	opts := &importOptions{
+		PageSize:         500,
+		ProgressInterval: 100,
+	}
+
+	flags := pflag.NewFlagSet("import", pflag.ContinueOnError)
+	opts.AddFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	localConfig, err := loadKubeconfig(opts.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load service cluster kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", syncagentv1alpha1.SchemeGroupVersion, err)
+	}
+
+	localClient, err := ctrlruntimeclient.New(localConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create service cluster client: %w", err)
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := localClient.Get(ctx, types.NamespacedName{Name: opts.PublishedResource}, pubRes); err != nil {
+		return fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	pubRes, _, err = profile.Resolve(ctx, localClient, pubRes)
+	if err != nil {
+		return fmt.Errorf("failed to apply profile: %w", err)
+	}
+
+	if pubRes.Spec.Origin == syncagentv1alpha1.PublishedResourceOriginService {
+		return errors.New("the PublishedResource's objects originate on the service cluster and are synchronized into kcp already")
+	}
+
+	kcpConfig, err := loadKubeconfig(opts.KcpKubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kcp kubeconfig: %w", err)
+	}
+
+	clusterName := logicalcluster.Name(opts.Cluster)
+
+	kcpClient, err := ctrlruntimeclient.New(workspaceConfig(kcpConfig, clusterName.Path()), ctrlruntimeclient.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create kcp client: %w", err)
+	}
+
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+	remoteGVK := projection.PublishedResourceProjectedGVK(pubRes)
+
+	// the resource must be bound in the workspace already
+	mapping, err := kcpClient.RESTMapper().RESTMapping(remoteGVK.GroupKind(), remoteGVK.Version)
+	if err != nil {
+		return fmt.Errorf("failed to find %v in workspace %s, is the APIExport bound?: %w", remoteGVK, clusterName, err)
+	}
+
+	namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+
+	selector, err := labels.Parse(opts.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid selector: %w", err)
+	}
+
+	importer := &importer{
+		opts:          opts,
+		out:           out,
+		localClient:   localClient,
+		kcpClient:     kcpClient,
+		remoteGVK:     remoteGVK,
+		namespaced:    namespaced,
+		clusterName:   clusterName,
+		workspacePath: logicalcluster.NewPath(opts.WorkspacePath),
+		namespaces:    sets.New[string](),
+	}
+
+	listOpts := &ctrlruntimeclient.ListOptions{
+		Namespace:     opts.Namespace,
+		LabelSelector: selector,
+		Limit:         opts.PageSize,
+	}
+
+	for {
+		localObjs := &unstructured.UnstructuredList{}
+		localObjs.SetAPIVersion(localGVK.GroupVersion().String())
+		localObjs.SetKind(localGVK.Kind + "List")
+
+		if err := localClient.List(ctx, localObjs, listOpts); err != nil {
+			return fmt.Errorf("failed to list local objects: %w", err)
+		}
+
+		for i := range localObjs.Items {
+			if err := ctx.Err(); err != nil {
+				fmt.Fprintln(out, importer.stats)
+				return fmt.Errorf("import was interrupted, run the command again to resume: %w", err)
+			}
+
+			importer.importObject(ctx, &localObjs.Items[i])
+		}
+
+		listOpts.Continue = localObjs.GetContinue()
+		if listOpts.Continue == "" {
+			break
+		}
+	}
+
+	fmt.Fprintln(out, importer.stats)
+
+	if importer.stats.failed > 0 {
+		return fmt.Errorf("%d object(s) could not be imported, run the command again to retry", importer.stats.failed)
+	}
+
+	return nil
+}
+
+type importer struct {
+	opts          *importOptions
+	out           io.Writer
+	localClient   ctrlruntimeclient.Client
+	kcpClient     ctrlruntimeclient.Client
+	remoteGVK     schema.GroupVersionKind
+	namespaced    bool
+	clusterName   logicalcluster.Name
+	workspacePath logicalcluster.Path
+
+	// namespaces that are known to exist in kcp
+	namespaces sets.Set[string]
+	stats      importStatistics
+}
+
+// importObject imports a single local object and reports failures, but does not
+// stop the import.
+func (i *importer) importObject(ctx context.Context, localObj *unstructured.Unstructured) {
+	i.stats.processed++
+
+	key := formatKey(ctrlruntimeclient.ObjectKeyFromObject(localObj))
+
+	skipped, err := i.importLocalObject(ctx, localObj)
+	switch {
+	case err != nil:
+		i.stats.failed++
+		fmt.Fprintf(i.out, "Failed to import %s: %v\n", key, err)
+	case skipped:
+		i.stats.skipped++
+	default:
+		i.stats.imported++
+		if i.opts.DryRun {
+			fmt.Fprintf(i.out, "Would import %s\n", key)
+		}
+	}
+
+	if i.stats.processed%i.opts.ProgressInterval == 0 {
+		fmt.Fprintln(i.out, i.stats)
+	}
+}
+
+func (i *importer) importLocalObject(ctx context.Context, localObj *unstructured.Unstructured) (skipped bool, err error) {
+	// objects that are linked to kcp already have been imported before (or were
+	// created by the Sync Agent in the first place)
+	if objectsync.RemoteNameForLocalObject(localObj) != nil {
+		return true, nil
+	}
+
+	if localObj.GetDeletionTimestamp() != nil {
+		return false, errors.New("object is being deleted")
+	}
+
+	namespace := ""
+	if i.namespaced {
+		namespace = i.opts.TargetNamespace
+		if namespace == "" {
+			namespace = localObj.GetNamespace()
+		}
+
+		if namespace == "" {
+			return false, errors.New("object is cluster-scoped, but the resource is namespaced in kcp; --target-namespace is required")
+		}
+	}
+
+	remoteObj, err := objectsync.NewImportedObject(localObj, i.remoteGVK, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	if i.opts.DryRun {
+		return false, nil
+	}
+
+	if err := i.ensureNamespace(ctx, namespace); err != nil {
+		return false, fmt.Errorf("failed to ensure namespace in kcp: %w", err)
+	}
+
+	// the object might have been created during a previous, interrupted import
+	if err := i.kcpClient.Create(ctx, remoteObj); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create object in kcp: %w", err)
+		}
+	}
+
+	original := localObj.DeepCopy()
+	objectsync.LinkLocalObject(localObj, remoteObj, i.clusterName, i.workspacePath, i.opts.AgentName)
+
+	if err := i.localClient.Patch(ctx, localObj, ctrlruntimeclient.MergeFrom(original)); err != nil {
+		return false, fmt.Errorf("failed to link local object: %w", err)
+	}
+
+	return false, nil
+}
+
+func (i *importer) ensureNamespace(ctx context.Context, namespace string) error {
+	if namespace == "" || i.namespaces.Has(namespace) {
+		return nil
+	}
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+		},
+	}
+
+	if err := i.kcpClient.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	i.namespaces.Insert(namespace)
+
+	return nil
+}
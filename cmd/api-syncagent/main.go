@@ -21,32 +21,50 @@ import (
 	"flag"
 	"fmt"
 	golog "log"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/zapr"
 	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 
+	"github.com/kcp-dev/api-syncagent/internal/audit"
+	"github.com/kcp-dev/api-syncagent/internal/controller/apibinding"
 	"github.com/kcp-dev/api-syncagent/internal/controller/apiexport"
 	"github.com/kcp-dev/api-syncagent/internal/controller/apiresourceschema"
+	"github.com/kcp-dev/api-syncagent/internal/controller/autopublish"
 	"github.com/kcp-dev/api-syncagent/internal/controller/syncmanager"
+	"github.com/kcp-dev/api-syncagent/internal/controller/workspacemapping"
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
+	"github.com/kcp-dev/api-syncagent/internal/conversion"
+	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/events"
 	"github.com/kcp-dev/api-syncagent/internal/kcp"
+	"github.com/kcp-dev/api-syncagent/internal/kubeconfig"
 	syncagentlog "github.com/kcp-dev/api-syncagent/internal/log"
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	"github.com/kcp-dev/api-syncagent/internal/policy"
+	objectsync "github.com/kcp-dev/api-syncagent/internal/sync"
 	"github.com/kcp-dev/api-syncagent/internal/version"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
 
 	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 	kcpdevcore "github.com/kcp-dev/kcp/sdk/apis/core"
 	kcpdevcorev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcptenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc" // exec plugins are supported by client-go itself
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
 	ctrlruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,8 +74,45 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
+// shutdownGracePeriod is added on top of the drain timeout to give all other
+// components time to stop after the sync controllers have been drained.
+const shutdownGracePeriod = 10 * time.Second
+
 func main() {
-	ctx := context.Background()
+	// cancelled on SIGTERM/SIGINT, which begins the graceful shutdown
+	ctx := ctrlruntime.SetupSignalHandler()
+
+	if len(os.Args) > 1 && os.Args[1] == "whereis" {
+		if err := runWhereis(ctx, os.Args[2:], os.Stdout); err != nil {
+			golog.Fatal(err)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "state" {
+		if err := runState(ctx, os.Args[2:], os.Stdout); err != nil {
+			golog.Fatal(err)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(ctx, os.Args[2:], os.Stdout); err != nil {
+			golog.Fatal(err)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		if err := runValidate(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			golog.Fatal(err)
+		}
+
+		return
+	}
 
 	opts := NewOptions()
 	opts.AddFlags(pflag.CommandLine)
@@ -92,14 +147,22 @@ func run(ctx context.Context, log *zap.SugaredLogger, opts *Options) error {
 		"version", v.GitVersion,
 		"name", opts.AgentName,
 		"apiexport", opts.APIExportRef,
+		"namespace", opts.Namespace,
+		"state-namespace", opts.StateNamespace,
+		"state-backend", opts.StateBackend,
 	).Info("Moin, I'm the kcp Sync Agent")
 
 	// create the ctrl-runtime manager
-	mgr, err := setupLocalManager(ctx, opts)
+	mgr, err := setupLocalManager(ctx, log, opts)
 	if err != nil {
 		return fmt.Errorf("failed to setup local manager: %w", err)
 	}
 
+	// ensure the namespaces are usable before any controller starts working in them
+	if err := verifyNamespaces(ctx, mgr.GetConfig(), opts); err != nil {
+		return fmt.Errorf("invalid namespace configuration: %w", err)
+	}
+
 	// load the kcp kubeconfig
 	kcpRestConfig, err := loadKubeconfig(opts.KcpKubeconfig)
 	if err != nil {
@@ -111,6 +174,47 @@ func run(ctx context.Context, log *zap.SugaredLogger, opts *Options) error {
 		return fmt.Errorf("kcp kubeconfig does not point to a specific workspace")
 	}
 
+	// rotate the kcp credentials for all clients, including those for the virtual
+	// workspaces, which are derived from this config
+	if opts.KubeconfigReloadInterval > 0 {
+		reloader, err := kubeconfig.NewReloader(log, opts.KcpKubeconfig, kcpRestConfig, loadKubeconfig, opts.KubeconfigReloadInterval)
+		if err != nil {
+			return fmt.Errorf("failed to setup kcp kubeconfig reloading: %w", err)
+		}
+
+		reloader.Install(kcpRestConfig)
+
+		if err := mgr.Add(reloader); err != nil {
+			return fmt.Errorf("failed to add kcp kubeconfig reloader: %w", err)
+		}
+	}
+
+	// report rejected credentials separately, as they cannot be fixed by retrying
+	authTracker := kubeconfig.NewAuthTracker(log.With("kubeconfig", opts.KcpKubeconfig))
+	kcpRestConfig.Wrap(authTracker.Wrap)
+
+	if err := mgr.AddReadyzCheck("kcp-authentication", authTracker.Check); err != nil {
+		return fmt.Errorf("failed to add kcp authentication readiness check: %w", err)
+	}
+
+	if opts.Preflight {
+		if err := runPreflightChecks(ctx, os.Stdout, mgr.GetConfig(), kcpRestConfig, opts); err != nil {
+			return fmt.Errorf("preflight checks failed: %w", err)
+		}
+	}
+
+	// measure (and optionally compress) everything written to kcp
+	kcpRestConfig.Wrap(metrics.InstrumentTransport(metrics.DirectionKcp, opts.CompressKcpRequests))
+
+	// newer kcp releases serve newer versions of the apis.kcp.io API group as well,
+	// but keep converting from/to the version the Sync Agent uses
+	apisVersions, err := checkKcpAPIs(kcpRestConfig)
+	if err != nil {
+		return fmt.Errorf("failed to check kcp API versions: %w", err)
+	}
+
+	log.Infow("Detected kcp APIs", "group", kcpdevv1alpha1.SchemeGroupVersion.Group, "versions", apisVersions, "using", kcpdevv1alpha1.SchemeGroupVersion.Version)
+
 	// We check if the APIExport exists and extract information we need to set up our kcpCluster.
 	apiExport, lcPath, lcName, err := resolveAPIExport(ctx, kcpRestConfig, opts.APIExportRef)
 	if err != nil {
@@ -131,15 +235,72 @@ func run(ctx context.Context, log *zap.SugaredLogger, opts *Options) error {
 		return fmt.Errorf("failed to add kcp cluster runnable: %w", err)
 	}
 
-	if err := apiresourceschema.Add(mgr, kcpCluster, lcName, log, 4, opts.AgentName, opts.PublishedResourceSelector); err != nil {
+	conversionWebhook, err := setupConversionRelay(mgr, log, opts)
+	if err != nil {
+		return fmt.Errorf("failed to setup conversion relay: %w", err)
+	}
+
+	prFilter := controllerutil.NewPublishedResourceFilter(opts.PublishedResourceSelector, opts.Shard, opts.Shards)
+
+	if err := apiresourceschema.Add(mgr, kcpCluster, lcName, log, 4, opts.AgentName, prFilter, conversionWebhook, opts.DiscoveryCacheTTL); err != nil {
 		return fmt.Errorf("failed to add apiresourceschema controller: %w", err)
 	}
 
-	if err := apiexport.Add(mgr, kcpCluster, lcName, log, opts.APIExportRef, opts.AgentName, opts.PublishedResourceSelector); err != nil {
+	if err := apiexport.Add(mgr, kcpCluster, lcName, log, opts.APIExportRef, opts.AgentName, prFilter, opts.SchemaGCGracePeriod, opts.ScopedPermissionClaims, opts.NamespaceCleanup); err != nil {
 		return fmt.Errorf("failed to add apiexport controller: %w", err)
 	}
 
-	if err := syncmanager.Add(ctx, mgr, kcpCluster, kcpRestConfig, log, apiExport, opts.PublishedResourceSelector, opts.Namespace, opts.AgentName); err != nil {
+	if opts.BindWorkspacesSelector != nil {
+		parent := lcPath
+		if opts.BindWorkspacesParent != "" {
+			parent = logicalcluster.NewPath(opts.BindWorkspacesParent)
+		}
+
+		workspacesCluster, err := setupWorkspacesCluster(kcpRestConfig, parent, opts.BindWorkspacesSelector)
+		if err != nil {
+			return fmt.Errorf("failed to initialize kcp workspaces cluster: %w", err)
+		}
+
+		if err := mgr.Add(workspacesCluster); err != nil {
+			return fmt.Errorf("failed to add kcp workspaces cluster runnable: %w", err)
+		}
+
+		if err := apibinding.Add(mgr, kcpCluster, workspacesCluster, kcpRestConfig, lcName, log, opts.APIExportRef); err != nil {
+			return fmt.Errorf("failed to add apibinding controller: %w", err)
+		}
+	}
+
+	if opts.AutoPublish {
+		if err := autopublish.Add(mgr, log); err != nil {
+			return fmt.Errorf("failed to add autopublish controller: %w", err)
+		}
+	}
+
+	if opts.WorkspaceMappings {
+		if err := workspacemapping.Add(mgr, log, opts.AgentName); err != nil {
+			return fmt.Errorf("failed to add workspacemapping controller: %w", err)
+		}
+	}
+
+	var auditLog *audit.Logger
+	if opts.AuditLog != "" {
+		auditLog, err = audit.NewLogger(opts.AuditLog)
+		if err != nil {
+			return fmt.Errorf("failed to setup audit log: %w", err)
+		}
+		defer auditLog.Close()
+	}
+
+	var writeHook objectsync.WriteHook
+	if opts.WritePolicyConfigMap != "" {
+		// use the uncached reader to not require permissions to watch all ConfigMaps
+		writeHook, err = policy.NewConfigMapPolicy(mgr.GetAPIReader(), opts.Namespace, opts.WritePolicyConfigMap)
+		if err != nil {
+			return fmt.Errorf("failed to setup write policies: %w", err)
+		}
+	}
+
+	if err := syncmanager.Add(ctx, mgr, kcpCluster, kcpRestConfig, log, apiExport, prFilter, opts.stateOptions(), opts.AgentName, auditLog, writeHook, opts.DrainTimeout, opts.cacheOptions(), opts.NamespaceCleanup); err != nil {
 		return fmt.Errorf("failed to add syncmanager controller: %w", err)
 	}
 
@@ -148,20 +309,40 @@ func run(ctx context.Context, log *zap.SugaredLogger, opts *Options) error {
 	return mgr.Start(ctx)
 }
 
-func setupLocalManager(ctx context.Context, opts *Options) (manager.Manager, error) {
+func setupLocalManager(ctx context.Context, log *zap.SugaredLogger, opts *Options) (manager.Manager, error) {
 	scheme := runtime.NewScheme()
 	restConfig := ctrlruntime.GetConfigOrDie()
+	applyLocalOverrides(restConfig, opts)
 
-	if opts.KubeconfigHostOverride != "" {
-		restConfig.Host = opts.KubeconfigHostOverride
-	}
+	// in-cluster credentials are rotated by client-go already, only kubeconfig
+	// files need to be reloaded
+	var reloader *kubeconfig.Reloader
+	if filename := localKubeconfigFile(); opts.KubeconfigReloadInterval > 0 && filename != "" {
+		load := func(filename string) (*rest.Config, error) {
+			config, err := loadKubeconfig(filename)
+			if err != nil {
+				return nil, err
+			}
 
-	if opts.KubeconfigCAFileOverride != "" {
-		// override the caData if it exists.
-		if len(restConfig.TLSClientConfig.CAData) > 0 {
-			restConfig.TLSClientConfig.CAData = nil
+			applyLocalOverrides(config, opts)
+
+			return config, nil
 		}
-		restConfig.TLSClientConfig.CAFile = opts.KubeconfigCAFileOverride
+
+		var err error
+		reloader, err = kubeconfig.NewReloader(log, filename, restConfig, load, opts.KubeconfigReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup kubeconfig reloading: %w", err)
+		}
+
+		reloader.Install(restConfig)
+	}
+
+	restConfig.Wrap(metrics.InstrumentTransport(metrics.DirectionServiceCluster, false))
+
+	metricsOptions := metricsserver.Options{BindAddress: opts.MetricsAddr}
+	if opts.EnablePprof {
+		metricsOptions.ExtraHandlers = pprofHandlers()
 	}
 
 	mgr, err := manager.New(restConfig, manager.Options{
@@ -169,16 +350,30 @@ func setupLocalManager(ctx context.Context, opts *Options) (manager.Manager, err
 		BaseContext: func() context.Context {
 			return ctx
 		},
-		Metrics:                 metricsserver.Options{BindAddress: opts.MetricsAddr},
+		Metrics:                 metricsOptions,
 		LeaderElection:          opts.EnableLeaderElection,
-		LeaderElectionID:        "syncagent." + opts.AgentName,
+		LeaderElectionID:        leaderElectionID(opts),
 		LeaderElectionNamespace: opts.Namespace,
 		HealthProbeBindAddress:  opts.HealthAddr,
+		// give the sync controllers enough time to drain before the manager gives up
+		GracefulShutdownTimeout: ptr.To(opts.DrainTimeout + shutdownGracePeriod),
+		// the process exits right after the manager has stopped, so the lease can be
+		// released immediately and another replica can take over without waiting
+		LeaderElectionReleaseOnCancel: true,
+		// deduplicate and rate limit events across all controllers; the manager lives
+		// as long as the process, so the broadcaster cannot leak
+		EventBroadcaster: events.NewBroadcaster(), //nolint:staticcheck
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if reloader != nil {
+		if err := mgr.Add(reloader); err != nil {
+			return nil, fmt.Errorf("failed to add kubeconfig reloader: %w", err)
+		}
+	}
+
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("failed to register local scheme %s: %w", corev1.SchemeGroupVersion, err)
 	}
@@ -194,6 +389,82 @@ func setupLocalManager(ctx context.Context, opts *Options) (manager.Manager, err
 	return mgr, nil
 }
 
+func applyLocalOverrides(restConfig *rest.Config, opts *Options) {
+	if opts.KubeconfigHostOverride != "" {
+		restConfig.Host = opts.KubeconfigHostOverride
+	}
+
+	if opts.KubeconfigCAFileOverride != "" {
+		// override the caData if it exists.
+		if len(restConfig.TLSClientConfig.CAData) > 0 {
+			restConfig.TLSClientConfig.CAData = nil
+		}
+		restConfig.TLSClientConfig.CAFile = opts.KubeconfigCAFileOverride
+	}
+}
+
+// localKubeconfigFile returns the kubeconfig file that ctrl-runtime loads the
+// service cluster config from, or an empty string if the in-cluster config or
+// multiple files are used.
+func localKubeconfigFile() string {
+	if f := flag.Lookup("kubeconfig"); f != nil && f.Value.String() != "" {
+		return f.Value.String()
+	}
+
+	if filename := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); !strings.Contains(filename, string(os.PathListSeparator)) {
+		return filename
+	}
+
+	return ""
+}
+
+// leaderElectionID returns the name of the lease used for leader election; every
+// shard elects its own leader.
+func leaderElectionID(opts *Options) string {
+	id := "syncagent." + opts.AgentName
+	if opts.Shards > 1 {
+		id = fmt.Sprintf("%s.shard-%d", id, opts.Shard)
+	}
+
+	return id
+}
+
+// setupConversionRelay adds the conversion relay server to the manager, if enabled,
+// and returns the webhook configuration to use in APIResourceSchemas.
+func setupConversionRelay(mgr manager.Manager, log *zap.SugaredLogger, opts *Options) (*conversion.WebhookConfig, error) {
+	if opts.ConversionRelayAddress == "" {
+		return nil, nil
+	}
+
+	caFile := opts.ConversionRelayCAFile
+	if caFile == "" {
+		caFile = opts.ConversionRelayCertFile
+	}
+
+	caBundle, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	discoveryClient, err := discovery.NewClient(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	relayLog := log.Named("conversion-relay")
+	relay := conversion.NewRelay(mgr.GetClient(), discoveryClient, relayLog)
+	server := conversion.NewServer(opts.ConversionRelayAddress, opts.ConversionRelayCertFile, opts.ConversionRelayKeyFile, relay, relayLog)
+
+	if err := mgr.Add(server); err != nil {
+		return nil, fmt.Errorf("failed to add conversion relay runnable: %w", err)
+	}
+
+	return &conversion.WebhookConfig{
+		BaseURL:  opts.ConversionRelayURL,
+		CABundle: caBundle,
+	}, nil
+}
+
 func resolveAPIExport(ctx context.Context, restConfig *rest.Config, apiExportRef string) (*kcpdevv1alpha1.APIExport, logicalcluster.Path, logicalcluster.Name, error) {
 	// construct temporary, uncached client
 	scheme := runtime.NewScheme()
@@ -249,6 +520,7 @@ func setupKcpCluster(restConfig *rest.Config, opts *Options) (cluster.Cluster, e
 
 	return cluster.New(restConfig, func(o *cluster.Options) {
 		o.Scheme = scheme
+		o.EventBroadcaster = events.NewBroadcaster() //nolint:staticcheck
 		// RBAC in kcp might be very tight and might not allow to list/watch all objects;
 		// restrict the cache's selectors accordingly so we can still make use of caching.
 		o.Cache = cache.Options{
@@ -262,9 +534,39 @@ func setupKcpCluster(restConfig *rest.Config, opts *Options) (cluster.Cluster, e
 	})
 }
 
+// setupWorkspacesCluster creates a cluster for the parent workspace whose child
+// workspaces are bound automatically. Only matching workspaces are cached.
+func setupWorkspacesCluster(restConfig *rest.Config, parent logicalcluster.Path, selector labels.Selector) (cluster.Cluster, error) {
+	scheme := runtime.NewScheme()
+
+	if err := kcptenancyv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to register scheme %s: %w", kcptenancyv1alpha1.SchemeGroupVersion, err)
+	}
+
+	return cluster.New(workspaceConfig(restConfig, parent), func(o *cluster.Options) {
+		o.Scheme = scheme
+		o.Cache = cache.Options{
+			Scheme: scheme,
+			ByObject: map[ctrlruntimeclient.Object]cache.ByObject{
+				&kcptenancyv1alpha1.Workspace{}: {
+					Label: selector,
+				},
+			},
+		}
+	})
+}
+
 func loadKubeconfig(filename string) (*rest.Config, error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.ExplicitPath = filename
 
-	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, nil).ClientConfig()
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, nil).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// never write refreshed OIDC tokens back into the (usually read-only) file
+	config.AuthConfigPersister = kubeconfig.InMemoryPersister{}
+
+	return config, nil
 }
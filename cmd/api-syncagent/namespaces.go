@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// namespaceEnvVariable is commonly set via the downward API.
+	namespaceEnvVariable = "POD_NAMESPACE"
+
+	// serviceAccountNamespaceFile is mounted into every Pod that uses a ServiceAccount token.
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// detectNamespace returns the namespace the Sync Agent is running in, if it can
+// be determined from the environment, or an empty string otherwise.
+func detectNamespace() string {
+	if ns := os.Getenv(namespaceEnvVariable); ns != "" {
+		return ns
+	}
+
+	content, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(content))
+}
+
+// verifyNamespaces ensures that the configured namespaces exist and that the Sync
+// Agent has the permissions it needs in them, so that misconfigurations are
+// detected at startup and not when the first object is synced.
+func verifyNamespaces(ctx context.Context, restConfig *rest.Config, opts *Options) error {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", corev1.SchemeGroupVersion, err)
+	}
+	if err := authorizationv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", authorizationv1.SchemeGroupVersion, err)
+	}
+
+	client, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	errs := []error{}
+
+	for _, namespace := range []string{opts.Namespace, opts.StateNamespace} {
+		ns := &corev1.Namespace{}
+		if err := client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			switch {
+			case apierrors.IsNotFound(err):
+				errs = append(errs, fmt.Errorf("namespace %q does not exist", namespace))
+			case apierrors.IsForbidden(err):
+				// not being allowed to get namespaces is fine, the permission checks below are what matters
+			default:
+				errs = append(errs, fmt.Errorf("failed to get namespace %q: %w", namespace, err))
+			}
+		}
+	}
+
+	// the state of synced objects is kept in Secrets or ObjectStates; when migrating
+	// between them, the old states need to be readable and deletable as well
+	stateVerbs := map[string][]string{
+		opts.StateBackend: {"get", "create", "update"},
+	}
+	if b := opts.PreviousStateBackend; b != "" && b != opts.StateBackend {
+		stateVerbs[b] = []string{"get", "update", "delete"}
+	}
+
+	for backend, verbs := range stateVerbs {
+		group, resource := "", "secrets"
+		if state.BackendType(backend) == state.BackendObjectState {
+			group, resource = syncagentv1alpha1.GroupName, "objectstates"
+		}
+
+		for _, verb := range verbs {
+			if err := checkAccess(ctx, client, opts.StateNamespace, group, resource, verb); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if opts.EnableLeaderElection {
+		for _, verb := range []string{"get", "create", "update"} {
+			if err := checkAccess(ctx, client, opts.Namespace, "coordination.k8s.io", "leases", verb); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func checkAccess(ctx context.Context, client ctrlruntimeclient.Client, namespace, group, resource, verb string) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Group:     group,
+				Resource:  resource,
+				Verb:      verb,
+			},
+		},
+	}
+
+	if err := client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to check permissions to %s %s in namespace %q: %w", verb, resource, namespace, err)
+	}
+
+	if !review.Status.Allowed {
+		return fmt.Errorf("not allowed to %s %s in namespace %q", verb, resource, namespace)
+	}
+
+	return nil
+}
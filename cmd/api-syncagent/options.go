@@ -19,12 +19,20 @@ package main
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/spf13/pflag"
 
+	"github.com/kcp-dev/api-syncagent/internal/controller/syncmanager/lifecycle"
 	"github.com/kcp-dev/api-syncagent/internal/log"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
 
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
@@ -40,9 +48,22 @@ type Options struct {
 	// referenced via APIExportRef lives.
 	KcpKubeconfig string
 
-	// Namespace is the namespace that the Sync Agent runs in.
+	// Namespace is the namespace that the Sync Agent runs in. If not given, it is
+	// determined automatically when running inside a Pod.
 	Namespace string
 
+	// StateNamespace is the namespace in which the Sync Agent stores the last known
+	// state of synced objects. Defaults to Namespace.
+	StateNamespace string
+
+	// StateBackend is the backend used to store the last known state of synced
+	// objects, one of "secret", "compressed-secret" or "objectstate".
+	StateBackend string
+
+	// PreviousStateBackend can be set when switching StateBackend to migrate the
+	// existing states from the previous backend on the fly.
+	PreviousStateBackend string
+
 	// Whether or not to perform leader election (requires permissions to
 	// manage coordination/v1 leases)
 	EnableLeaderElection bool
@@ -61,13 +82,102 @@ type Options struct {
 	PublishedResourceSelectorString string
 	PublishedResourceSelector       labels.Selector
 
+	// Shards is the number of Sync Agents that share the same agent name and APIExport
+	// and split the PublishedResources among themselves. Shard is the zero-based index
+	// of this Sync Agent.
+	Shards int
+	Shard  int
+
+	// BindWorkspacesSelectorString enables the automatic creation of APIBindings in
+	// all child workspaces of BindWorkspacesParent that match this label selector.
+	BindWorkspacesSelectorString string
+	BindWorkspacesSelector       labels.Selector
+	// BindWorkspacesParent is the workspace path whose child workspaces are bound.
+	// Defaults to the APIExport's workspace.
+	BindWorkspacesParent string
+
 	KubeconfigHostOverride   string
 	KubeconfigCAFileOverride string
 
+	// CompressKcpRequests enables gzip compression of larger request bodies sent
+	// to kcp. This requires kcp to support compressed requests.
+	CompressKcpRequests bool
+
+	// Preflight enables additional checks for connectivity and permissions
+	// before any controller is started.
+	Preflight bool
+
+	// SchemaGCGracePeriod enables the garbage collection of APIResourceSchemas of
+	// deleted PublishedResources (if they opted in) after the given duration. A zero
+	// value disables the garbage collection.
+	SchemaGCGracePeriod time.Duration
+
+	// WorkspaceMappings enables maintaining WorkspaceMapping objects on the service
+	// cluster, which requires the WorkspaceMapping CRD to be installed.
+	WorkspaceMappings bool
+
+	// AutoPublish enables generating PublishedResources for all CRDs on the service
+	// cluster that are annotated with syncagent.kcp.io/publish=true.
+	AutoPublish bool
+
+	// NamespaceCleanup enables deleting the namespaces created on the service cluster
+	// once their source namespace in kcp and all synced objects in them are gone.
+	NamespaceCleanup bool
+
+	// DiscoveryCacheTTL is the maximum duration for which discovery results from the
+	// service cluster are cached. CRD changes invalidate the cache immediately.
+	DiscoveryCacheTTL time.Duration
+
+	// ScopedPermissionClaims restricts the permission claims for related resources to
+	// the related objects, if their names can be determined statically.
+	ScopedPermissionClaims bool
+
+	// AuditLog enables the audit log of all write operations performed by the sync
+	// controllers; "-" writes to stdout, everything else is used as a file path.
+	AuditLog string
+
+	// WritePolicyConfigMap is the name of a ConfigMap in Namespace that contains CEL
+	// expressions which every object has to satisfy before it is written on the
+	// service cluster.
+	WritePolicyConfigMap string
+
+	// ConversionRelayAddress enables the conversion relay, which forwards conversion
+	// requests from kcp to the conversion webhooks on the service cluster.
+	ConversionRelayAddress string
+	// ConversionRelayURL is the base URL under which kcp can reach the relay.
+	ConversionRelayURL string
+	// ConversionRelayCertFile and ConversionRelayKeyFile are the serving certificate
+	// and key for the relay.
+	ConversionRelayCertFile string
+	ConversionRelayKeyFile  string
+	// ConversionRelayCAFile is the CA bundle kcp uses to verify the relay's
+	// certificate. Defaults to the certificate itself.
+	ConversionRelayCAFile string
+
+	// VWCacheStripManagedFields removes the managed fields from all objects cached
+	// from the virtual workspace.
+	VWCacheStripManagedFields bool
+	// VWCacheMaxAnnotationSize removes larger annotations from all objects cached
+	// from the virtual workspace. Zero disables the limit.
+	VWCacheMaxAnnotationSize int
+	// VWCacheMetadataOnly lists the kinds (as "Kind.group") for which only the
+	// metadata of objects is cached from the virtual workspace.
+	VWCacheMetadataOnly []string
+
+	// KubeconfigReloadInterval enables checking the kubeconfig files for changes in
+	// the given interval, so that rotated credentials are used without restarting
+	// the Sync Agent. A zero value disables the reloading.
+	KubeconfigReloadInterval time.Duration
+
+	// DrainTimeout is how long in-flight synchronizations may continue after the
+	// Sync Agent was asked to shut down.
+	DrainTimeout time.Duration
+
 	LogOptions log.Options
 
 	MetricsAddr string
 	HealthAddr  string
+	EnablePprof bool
 }
 
 func NewOptions() *Options {
@@ -75,6 +185,12 @@ func NewOptions() *Options {
 		LogOptions:                log.NewDefaultOptions(),
 		PublishedResourceSelector: labels.Everything(),
 		MetricsAddr:               "127.0.0.1:8085",
+		Namespace:                 detectNamespace(),
+		StateBackend:              string(state.BackendSecret),
+		DiscoveryCacheTTL:         10 * time.Minute,
+		DrainTimeout:              20 * time.Second,
+		VWCacheStripManagedFields: true,
+		Shards:                    1,
 	}
 }
 
@@ -82,15 +198,43 @@ func (o *Options) AddFlags(flags *pflag.FlagSet) {
 	o.LogOptions.AddPFlags(flags)
 
 	flags.StringVar(&o.KcpKubeconfig, "kcp-kubeconfig", o.KcpKubeconfig, "kubeconfig file of kcp")
-	flags.StringVar(&o.Namespace, "namespace", o.Namespace, "Kubernetes namespace the Sync Agent is running in")
+	flags.StringVar(&o.Namespace, "namespace", o.Namespace, "Kubernetes namespace the Sync Agent is running in (auto-detected when running in a Pod)")
+	flags.StringVar(&o.StateNamespace, "state-namespace", o.StateNamespace, "Kubernetes namespace to store the state of synced objects in (defaults to --namespace)")
+	flags.StringVar(&o.StateBackend, "state-backend", o.StateBackend, fmt.Sprintf("backend to store the state of synced objects in (one of %v)", state.BackendTypes))
+	flags.StringVar(&o.PreviousStateBackend, "previous-state-backend", o.PreviousStateBackend, "backend to migrate existing states from when changing --state-backend (optional)")
 	flags.StringVar(&o.AgentName, "agent-name", o.AgentName, "name of this Sync Agent, must not be changed after the first run, can be left blank to auto-generate a name")
 	flags.StringVar(&o.APIExportRef, "apiexport-ref", o.APIExportRef, "name of the APIExport in kcp that this Sync Agent is powering")
 	flags.StringVar(&o.PublishedResourceSelectorString, "published-resource-selector", o.PublishedResourceSelectorString, "restrict this Sync Agent to only process PublishedResources matching this label selector (optional)")
+	flags.IntVar(&o.Shards, "shards", o.Shards, "number of Sync Agents with the same agent name that split the PublishedResources among themselves")
+	flags.IntVar(&o.Shard, "shard", o.Shard, "zero-based index of this Sync Agent when using --shards")
+	flags.StringVar(&o.BindWorkspacesSelectorString, "bind-workspaces-selector", o.BindWorkspacesSelectorString, "automatically create APIBindings (accepting all permission claims) in child workspaces matching this label selector (optional)")
+	flags.StringVar(&o.BindWorkspacesParent, "bind-workspaces-parent", o.BindWorkspacesParent, "path of the workspace whose child workspaces are bound automatically (defaults to the APIExport's workspace)")
 	flags.BoolVar(&o.EnableLeaderElection, "enable-leader-election", o.EnableLeaderElection, "whether to perform leader election")
 	flags.StringVar(&o.KubeconfigHostOverride, "kubeconfig-host-override", o.KubeconfigHostOverride, "override the host configured in the local kubeconfig")
 	flags.StringVar(&o.KubeconfigCAFileOverride, "kubeconfig-ca-file-override", o.KubeconfigCAFileOverride, "override the server CA file configured in the local kubeconfig")
+	flags.BoolVar(&o.CompressKcpRequests, "compress-kcp-requests", o.CompressKcpRequests, "gzip-compress larger request bodies sent to kcp (requires kcp to accept compressed requests)")
+	flags.BoolVar(&o.Preflight, "preflight", o.Preflight, "verify connectivity and permissions before starting and exit if any check fails")
+	flags.DurationVar(&o.SchemaGCGracePeriod, "schema-gc-grace-period", o.SchemaGCGracePeriod, "remove APIResourceSchemas of deleted PublishedResources that opted into garbage collection from the APIExport after this duration (0 disables the garbage collection)")
+	flags.BoolVar(&o.WorkspaceMappings, "workspace-mappings", o.WorkspaceMappings, "maintain WorkspaceMapping objects that record the namespaces created for each kcp workspace (requires the WorkspaceMapping CRD)")
+	flags.BoolVar(&o.AutoPublish, "auto-publish", o.AutoPublish, "generate PublishedResources for CRDs annotated with syncagent.kcp.io/publish=true")
+	flags.BoolVar(&o.NamespaceCleanup, "namespace-cleanup", o.NamespaceCleanup, "delete namespaces created on the service cluster once their namespace in kcp has been deleted and all synced objects in them are gone")
+	flags.DurationVar(&o.DiscoveryCacheTTL, "discovery-cache-ttl", o.DiscoveryCacheTTL, "maximum duration to cache discovery results from the service cluster for (0 caches until a CRD changes)")
+	flags.BoolVar(&o.ScopedPermissionClaims, "scoped-permission-claims", o.ScopedPermissionClaims, "restrict the APIExport's permission claims for related resources to the related objects if their names are static templates")
+	flags.StringVar(&o.AuditLog, "audit-log", o.AuditLog, `file to append a JSON audit log of all synchronization writes to ("-" for stdout, optional)`)
+	flags.StringVar(&o.WritePolicyConfigMap, "write-policy-configmap", o.WritePolicyConfigMap, "name of a ConfigMap in the agent's namespace with CEL policies that objects must satisfy before they are written on the service cluster (optional)")
+	flags.StringVar(&o.ConversionRelayAddress, "conversion-relay-address", o.ConversionRelayAddress, "host and port to serve the conversion relay on (HTTPS, optional, enables the relay)")
+	flags.StringVar(&o.ConversionRelayURL, "conversion-relay-url", o.ConversionRelayURL, "HTTPS base URL under which kcp can reach the conversion relay")
+	flags.StringVar(&o.ConversionRelayCertFile, "conversion-relay-tls-cert-file", o.ConversionRelayCertFile, "serving certificate for the conversion relay")
+	flags.StringVar(&o.ConversionRelayKeyFile, "conversion-relay-tls-key-file", o.ConversionRelayKeyFile, "private key for the conversion relay's serving certificate")
+	flags.StringVar(&o.ConversionRelayCAFile, "conversion-relay-ca-file", o.ConversionRelayCAFile, "CA bundle for kcp to verify the conversion relay (defaults to the serving certificate)")
+	flags.BoolVar(&o.VWCacheStripManagedFields, "vw-cache-strip-managed-fields", o.VWCacheStripManagedFields, "remove the managed fields from objects cached from the virtual workspace")
+	flags.IntVar(&o.VWCacheMaxAnnotationSize, "vw-cache-max-annotation-size", o.VWCacheMaxAnnotationSize, "remove annotations larger than this many bytes from objects cached from the virtual workspace (0 disables the limit)")
+	flags.StringSliceVar(&o.VWCacheMetadataOnly, "vw-cache-metadata-only", o.VWCacheMetadataOnly, `kinds (as "Kind.group") for which only the metadata of objects is cached from the virtual workspace (can be given multiple times)`)
+	flags.DurationVar(&o.KubeconfigReloadInterval, "kubeconfig-reload-interval", o.KubeconfigReloadInterval, "check the kubeconfig files for rotated credentials in this interval (0 disables the reloading)")
+	flags.DurationVar(&o.DrainTimeout, "drain-timeout", o.DrainTimeout, "maximum duration to wait for in-flight synchronizations to finish when shutting down")
 	flags.StringVar(&o.MetricsAddr, "metrics-address", o.MetricsAddr, "host and port to serve Prometheus metrics via /metrics (HTTP)")
 	flags.StringVar(&o.HealthAddr, "health-address", o.HealthAddr, "host and port to serve probes via /readyz and /healthz (HTTP)")
+	flags.BoolVar(&o.EnablePprof, "enable-pprof", o.EnablePprof, "serve Go runtime profiles via /debug/pprof/ on the metrics address")
 }
 
 func (o *Options) Validate() error {
@@ -101,7 +245,7 @@ func (o *Options) Validate() error {
 	}
 
 	if len(o.Namespace) == 0 {
-		errs = append(errs, errors.New("--namespace is required"))
+		errs = append(errs, errors.New("--namespace is required, as it could not be detected automatically"))
 	}
 
 	if len(o.AgentName) > 0 {
@@ -124,6 +268,76 @@ func (o *Options) Validate() error {
 		}
 	}
 
+	if o.Shards < 1 {
+		errs = append(errs, errors.New("--shards must be at least 1"))
+	} else if o.Shard < 0 || o.Shard >= o.Shards {
+		errs = append(errs, fmt.Errorf("--shard must be between 0 and %d", o.Shards-1))
+	}
+
+	if s := o.BindWorkspacesSelectorString; len(s) > 0 {
+		if _, err := labels.Parse(s); err != nil {
+			errs = append(errs, fmt.Errorf("invalid --bind-workspaces-selector %q: %w", s, err))
+		}
+	}
+
+	if len(o.BindWorkspacesParent) > 0 {
+		if len(o.BindWorkspacesSelectorString) == 0 {
+			errs = append(errs, errors.New("--bind-workspaces-parent requires --bind-workspaces-selector to be set"))
+		}
+
+		if _, valid := logicalcluster.NewValidatedPath(o.BindWorkspacesParent); !valid {
+			errs = append(errs, fmt.Errorf("invalid --bind-workspaces-parent %q", o.BindWorkspacesParent))
+		}
+	}
+
+	if !slices.Contains(state.BackendTypes, state.BackendType(o.StateBackend)) {
+		errs = append(errs, fmt.Errorf("invalid --state-backend %q, must be one of %v", o.StateBackend, state.BackendTypes))
+	}
+
+	if b := o.PreviousStateBackend; len(b) > 0 && !slices.Contains(state.BackendTypes, state.BackendType(b)) {
+		errs = append(errs, fmt.Errorf("invalid --previous-state-backend %q, must be one of %v", b, state.BackendTypes))
+	}
+
+	if o.SchemaGCGracePeriod < 0 {
+		errs = append(errs, errors.New("--schema-gc-grace-period must not be negative"))
+	}
+
+	if o.DiscoveryCacheTTL < 0 {
+		errs = append(errs, errors.New("--discovery-cache-ttl must not be negative"))
+	}
+
+	if o.VWCacheMaxAnnotationSize < 0 {
+		errs = append(errs, errors.New("--vw-cache-max-annotation-size must not be negative"))
+	}
+
+	for _, kind := range o.VWCacheMetadataOnly {
+		if gk := schema.ParseGroupKind(kind); gk.Kind == "" {
+			errs = append(errs, fmt.Errorf("invalid --vw-cache-metadata-only kind %q, must be \"Kind.group\"", kind))
+		}
+	}
+
+	if o.KubeconfigReloadInterval < 0 {
+		errs = append(errs, errors.New("--kubeconfig-reload-interval must not be negative"))
+	}
+
+	if o.DrainTimeout < 0 {
+		errs = append(errs, errors.New("--drain-timeout must not be negative"))
+	}
+
+	if o.EnablePprof && (o.MetricsAddr == "" || o.MetricsAddr == "0") {
+		errs = append(errs, errors.New("--enable-pprof requires --metrics-address to be set"))
+	}
+
+	if len(o.ConversionRelayAddress) > 0 {
+		if !strings.HasPrefix(o.ConversionRelayURL, "https://") {
+			errs = append(errs, errors.New("--conversion-relay-url must be an HTTPS URL when the conversion relay is enabled"))
+		}
+
+		if len(o.ConversionRelayCertFile) == 0 || len(o.ConversionRelayKeyFile) == 0 {
+			errs = append(errs, errors.New("--conversion-relay-tls-cert-file and --conversion-relay-tls-key-file are required when the conversion relay is enabled"))
+		}
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -134,6 +348,10 @@ func (o *Options) Complete() error {
 		o.AgentName = o.APIExportRef + "-syncagent"
 	}
 
+	if len(o.StateNamespace) == 0 {
+		o.StateNamespace = o.Namespace
+	}
+
 	if s := o.PublishedResourceSelectorString; len(s) > 0 {
 		selector, err := labels.Parse(s)
 		if err != nil {
@@ -142,5 +360,34 @@ func (o *Options) Complete() error {
 		o.PublishedResourceSelector = selector
 	}
 
+	if s := o.BindWorkspacesSelectorString; len(s) > 0 {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid --bind-workspaces-selector %q: %w", s, err))
+		}
+		o.BindWorkspacesSelector = selector
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
+
+func (o *Options) stateOptions() sync.StateOptions {
+	return sync.StateOptions{
+		Namespace:       o.StateNamespace,
+		Backend:         state.BackendType(o.StateBackend),
+		PreviousBackend: state.BackendType(o.PreviousStateBackend),
+	}
+}
+
+func (o *Options) cacheOptions() lifecycle.CacheOptions {
+	metadataOnly := []schema.GroupKind{}
+	for _, kind := range o.VWCacheMetadataOnly {
+		metadataOnly = append(metadataOnly, schema.ParseGroupKind(kind))
+	}
+
+	return lifecycle.CacheOptions{
+		StripManagedFields: o.VWCacheStripManagedFields,
+		MaxAnnotationSize:  o.VWCacheMaxAnnotationSize,
+		MetadataOnly:       metadataOnly,
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kcp-dev/api-syncagent/internal/kcp"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errSkipped is returned by preflight checks that cannot be performed because an
+// earlier check has failed.
+var errSkipped = errors.New("skipped because a previous check failed")
+
+type preflightCheck struct {
+	description string
+	check       func(ctx context.Context) error
+}
+
+// runPreflightChecks verifies that the Sync Agent can reach kcp and the service
+// cluster and has all the permissions it needs. A checklist is printed to out and
+// an error is returned if any check failed.
+func runPreflightChecks(ctx context.Context, out io.Writer, localConfig, kcpConfig *rest.Config, opts *Options) error {
+	scheme := runtime.NewScheme()
+	if err := kcpdevv1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", kcpdevv1alpha1.SchemeGroupVersion, err)
+	}
+	if err := authorizationv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", authorizationv1.SchemeGroupVersion, err)
+	}
+
+	kcpClient, err := ctrlruntimeclient.New(kcpConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create kcp client: %w", err)
+	}
+
+	localClient, err := ctrlruntimeclient.New(localConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create service cluster client: %w", err)
+	}
+
+	var (
+		kcpReachable bool
+		apiExport    *kcpdevv1alpha1.APIExport
+	)
+
+	checks := []preflightCheck{
+		{
+			description: "kcp is reachable",
+			check: func(ctx context.Context) error {
+				if err := checkConnectivity(kcpConfig); err != nil {
+					return err
+				}
+
+				kcpReachable = true
+				return nil
+			},
+		},
+		{
+			description: fmt.Sprintf("kcp serves %s", kcpdevv1alpha1.SchemeGroupVersion),
+			check: func(ctx context.Context) error {
+				if !kcpReachable {
+					return errSkipped
+				}
+
+				_, err := checkKcpAPIs(kcpConfig)
+				return err
+			},
+		},
+		{
+			description: fmt.Sprintf("APIExport %q can be read", opts.APIExportRef),
+			check: func(ctx context.Context) error {
+				if !kcpReachable {
+					return errSkipped
+				}
+
+				export := &kcpdevv1alpha1.APIExport{}
+				if err := kcpClient.Get(ctx, types.NamespacedName{Name: opts.APIExportRef}, export); err != nil {
+					return err
+				}
+
+				apiExport = export
+				return nil
+			},
+		},
+		{
+			description: fmt.Sprintf("APIExport %q can be updated", opts.APIExportRef),
+			check: func(ctx context.Context) error {
+				if !kcpReachable {
+					return errSkipped
+				}
+
+				return checkPermission(ctx, kcpClient, kcpdevv1alpha1.SchemeGroupVersion.Group, "apiexports", opts.APIExportRef, "update")
+			},
+		},
+		{
+			description: "APIResourceSchemas can be created",
+			check: func(ctx context.Context) error {
+				if !kcpReachable {
+					return errSkipped
+				}
+
+				return checkPermission(ctx, kcpClient, kcpdevv1alpha1.SchemeGroupVersion.Group, "apiresourceschemas", "", "create")
+			},
+		},
+		{
+			description: "virtual workspace is accessible",
+			check: func(ctx context.Context) error {
+				if apiExport == nil {
+					return errSkipped
+				}
+
+				url, err := kcp.VirtualWorkspaceURL(ctx, kcpClient, apiExport)
+				if err != nil {
+					return err
+				}
+
+				if url == "" {
+					return errors.New("APIExport has no virtual workspace URL yet")
+				}
+
+				vwConfig := rest.CopyConfig(kcpConfig)
+				vwConfig.Host = strings.TrimSuffix(url, "/") + "/clusters/*"
+
+				return checkConnectivity(vwConfig)
+			},
+		},
+		{
+			description: "CustomResourceDefinitions on the service cluster can be read",
+			check: func(ctx context.Context) error {
+				for _, verb := range []string{"get", "list", "watch"} {
+					if err := checkPermission(ctx, localClient, "apiextensions.k8s.io", "customresourcedefinitions", "", verb); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		},
+	}
+
+	failed := 0
+
+	for _, c := range checks {
+		err := c.check(ctx)
+
+		switch {
+		case err == nil:
+			fmt.Fprintf(out, "[ OK ] %s\n", c.description)
+		case errors.Is(err, errSkipped):
+			failed++
+			fmt.Fprintf(out, "[SKIP] %s (%v)\n", c.description, err)
+		default:
+			failed++
+			fmt.Fprintf(out, "[FAIL] %s: %v\n", c.description, err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks did not succeed", failed, len(checks))
+	}
+
+	return nil
+}
+
+// checkKcpAPIs verifies that kcp serves the apis.kcp.io version the Sync Agent is
+// built against and returns all served versions.
+func checkKcpAPIs(config *rest.Config) ([]string, error) {
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	versions, err := kcp.ServedAPIsVersions(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, kcp.CheckAPIsVersions(versions)
+}
+
+func checkConnectivity(config *rest.Config) error {
+	client, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	if _, err := client.ServerGroups(); err != nil {
+		return fmt.Errorf("failed to perform discovery: %w", err)
+	}
+
+	return nil
+}
+
+func checkPermission(ctx context.Context, client ctrlruntimeclient.Client, group, resource, name, verb string) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    group,
+				Resource: resource,
+				Name:     name,
+				Verb:     verb,
+			},
+		},
+	}
+
+	if err := client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+
+	if !review.Status.Allowed {
+		return fmt.Errorf("not allowed to %s %s", verb, resource)
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stateActions are the supported actions of the "state" subcommand.
+var stateActions = []string{"inspect", "dump", "repair"}
+
+type stateOptions struct {
+	Action            string
+	Kubeconfig        string
+	PublishedResource string
+	Cluster           string
+	Namespace         string
+	Name              string
+	StateNamespace    string
+	StateBackend      string
+	All               bool
+}
+
+func (o *stateOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "kubeconfig file of the service cluster")
+	flags.StringVar(&o.PublishedResource, "published-resource", o.PublishedResource, "name of the PublishedResource the object belongs to")
+	flags.StringVar(&o.Cluster, "cluster", o.Cluster, "logical cluster name of the kcp workspace the remote object lives in")
+	flags.StringVar(&o.Namespace, "namespace", o.Namespace, "namespace of the remote object (leave empty for cluster-scoped objects)")
+	flags.StringVar(&o.Name, "name", o.Name, "name of the remote object")
+	flags.StringVar(&o.StateNamespace, "state-namespace", o.StateNamespace, "Kubernetes namespace the Sync Agent stores states in (defaults to the PublishedResource's state namespace)")
+	flags.StringVar(&o.StateBackend, "state-backend", o.StateBackend, fmt.Sprintf("backend the Sync Agent stores states in (one of %v)", state.BackendTypes))
+	flags.BoolVar(&o.All, "all", o.All, "dump or repair the states of all related objects as well, instead of only the remote object's state")
+}
+
+func (o *stateOptions) Validate() error {
+	errs := []error{}
+
+	if !slices.Contains(stateActions, o.Action) {
+		errs = append(errs, fmt.Errorf("invalid action %q, must be one of %v", o.Action, stateActions))
+	}
+
+	if len(o.PublishedResource) == 0 {
+		errs = append(errs, errors.New("--published-resource is required"))
+	}
+
+	if len(o.Cluster) == 0 {
+		errs = append(errs, errors.New("--cluster is required"))
+	}
+
+	if len(o.Name) == 0 {
+		errs = append(errs, errors.New("--name is required"))
+	}
+
+	if !slices.Contains(state.BackendTypes, state.BackendType(o.StateBackend)) {
+		errs = append(errs, fmt.Errorf("invalid --state-backend %q, must be one of %v", o.StateBackend, state.BackendTypes))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// runState implements the "state" subcommand, which works with the last-known
+// states the Sync Agent keeps for a given remote object:
+//
+//   - inspect lists the states stored together with the object's state.
+//   - dump prints the object's state (or all states with --all).
+//   - repair removes the object's state (or all states with --all), so that the
+//     Sync Agent falls back to a full update during the next reconciliation.
+func runState(ctx context.Context, args []string, out io.Writer) error {
+	opts := &stateOptions{
+		StateBackend: string(state.BackendSecret),
+	}
+
+	flags := pflag.NewFlagSet("state", pflag.ContinueOnError)
+	opts.AddFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("invalid command line: exactly one action (one of %v) is required", stateActions)
+	}
+
+	opts.Action = flags.Arg(0)
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	localConfig, err := loadKubeconfig(opts.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load service cluster kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", corev1.SchemeGroupVersion, err)
+	}
+
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", syncagentv1alpha1.SchemeGroupVersion, err)
+	}
+
+	localClient, err := ctrlruntimeclient.New(localConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create service cluster client: %w", err)
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := localClient.Get(ctx, types.NamespacedName{Name: opts.PublishedResource}, pubRes); err != nil {
+		return fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	pubRes, _, err = profile.Resolve(ctx, localClient, pubRes)
+	if err != nil {
+		return fmt.Errorf("failed to apply profile: %w", err)
+	}
+
+	if pubRes.Spec.Origin == syncagentv1alpha1.PublishedResourceOriginService {
+		return errors.New("the PublishedResource's objects originate on the service cluster, their states are not keyed by remote objects")
+	}
+
+	stateNamespace := opts.StateNamespace
+	if stateNamespace == "" {
+		stateNamespace = pubRes.Spec.StateNamespace
+	}
+
+	if stateNamespace == "" {
+		return errors.New("the PublishedResource does not configure a state namespace, --state-namespace is required")
+	}
+
+	clusterName := logicalcluster.Name(opts.Cluster)
+
+	// the state object is named after the remote object as the Sync Agent sees it
+	remoteObj := &unstructured.Unstructured{}
+	remoteObj.SetGroupVersionKind(projection.PublishedResourceProjectedGVK(pubRes))
+	remoteObj.SetNamespace(opts.Namespace)
+	remoteObj.SetName(opts.Name)
+
+	stateName := types.NamespacedName{
+		Namespace: stateNamespace,
+		Name:      state.ObjectName(clusterName, remoteObj),
+	}
+
+	backend := state.NewBackend(localClient, state.BackendType(opts.StateBackend), stateName, nil)
+	primaryKey := state.Key(clusterName, remoteObj)
+	originalKey := state.OriginalKey(clusterName, remoteObj)
+
+	if opts.Action == "inspect" {
+		return inspectStates(ctx, out, backend, stateName, primaryKey, originalKey)
+	}
+
+	keys := []string{primaryKey, originalKey}
+	if opts.All {
+		keys, err = backend.Keys(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list states: %w", err)
+		}
+	}
+
+	if opts.Action == "dump" {
+		return dumpStates(ctx, out, backend, keys)
+	}
+
+	return repairStates(ctx, out, backend, keys)
+}
+
+func inspectStates(ctx context.Context, out io.Writer, backend state.Backend, stateName types.NamespacedName, primaryKey, originalKey string) error {
+	keys, err := backend.Keys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list states: %w", err)
+	}
+
+	if len(keys) == 0 {
+		fmt.Fprintf(out, "State object %s does not exist or contains no states.\n", stateName)
+		return nil
+	}
+
+	fmt.Fprintf(out, "State object: %s\n", stateName)
+
+	for _, key := range keys {
+		description := "related object"
+		switch key {
+		case primaryKey:
+			description = "remote object"
+		case originalKey:
+			description = "remote object before mutations"
+		}
+
+		// broken states are reported instead of aborting, so they can be repaired
+		data, err := backend.Get(ctx, key)
+		if err != nil {
+			fmt.Fprintf(out, "  %s  unreadable: %v  (%s)\n", key, err, description)
+			continue
+		}
+
+		fmt.Fprintf(out, "  %s  %7d bytes  (%s)\n", key, len(data), description)
+	}
+
+	return nil
+}
+
+func dumpStates(ctx context.Context, out io.Writer, backend state.Backend, keys []string) error {
+	states := map[string]json.RawMessage{}
+
+	for _, key := range keys {
+		data, err := backend.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to get state %s: %w", key, err)
+		}
+
+		if data == nil {
+			continue
+		}
+
+		// states are JSON documents, but broken states should still be dumped
+		if !json.Valid(data) {
+			data, _ = json.Marshal(string(data))
+		}
+
+		states[key] = data
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(states)
+}
+
+func repairStates(ctx context.Context, out io.Writer, backend state.Backend, keys []string) error {
+	// states are not read before deleting them, as broken states might not be readable
+	existing, err := backend.Keys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list states: %w", err)
+	}
+
+	for _, key := range keys {
+		if !slices.Contains(existing, key) {
+			fmt.Fprintf(out, "State %s does not exist, nothing to repair.\n", key)
+			continue
+		}
+
+		if err := backend.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete state %s: %w", key, err)
+		}
+
+		fmt.Fprintf(out, "Deleted state %s.\n", key)
+	}
+
+	return nil
+}
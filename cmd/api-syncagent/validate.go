@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/validation"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+type validateOptions struct {
+	Filenames []string
+}
+
+func (o *validateOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVarP(&o.Filenames, "filename", "f", o.Filenames, "YAML file containing PublishedResources and/or PublishedResourceProfiles (can be given multiple times, use - to read from stdin)")
+}
+
+func (o *validateOptions) Validate() error {
+	if len(o.Filenames) == 0 {
+		return errors.New("at least one --filename is required")
+	}
+
+	return nil
+}
+
+// validateDocument is a single object read from one of the input files.
+type validateDocument struct {
+	source  string
+	kind    string
+	name    string
+	pubRes  *syncagentv1alpha1.PublishedResource
+	profile *syncagentv1alpha1.PublishedResourceProfile
+	errs    []error
+}
+
+// runValidate implements the "validate" subcommand, which statically validates
+// PublishedResources and PublishedResourceProfiles without requiring access to
+// any cluster. It performs the same checks as the Sync Agent does at runtime
+// and additionally reports unknown fields. Every problem is printed and the
+// command fails if any object is invalid, which makes it suitable for CI.
+func runValidate(args []string, in io.Reader, out io.Writer) error {
+	opts := &validateOptions{}
+
+	flags := pflag.NewFlagSet("validate", pflag.ContinueOnError)
+	opts.AddFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	var docs []*validateDocument
+
+	for _, filename := range opts.Filenames {
+		fileDocs, err := readValidateDocuments(filename, in)
+		if err != nil {
+			return err
+		}
+
+		docs = append(docs, fileDocs...)
+	}
+
+	if len(docs) == 0 {
+		return errors.New("no objects found")
+	}
+
+	// profiles can be given alongside the PublishedResources that use them, in
+	// which case the resources are validated the same way as the agent would
+	// see them
+	profiles := map[string]*syncagentv1alpha1.PublishedResourceProfile{}
+	for _, doc := range docs {
+		if doc.profile != nil {
+			profiles[doc.name] = doc.profile
+		}
+	}
+
+	for _, doc := range docs {
+		if doc.pubRes == nil || len(doc.errs) > 0 {
+			continue
+		}
+
+		pubRes := doc.pubRes
+		if name := pubRes.Spec.Profile; name != "" {
+			if prProfile := profiles[name]; prProfile != nil {
+				pubRes = profile.Apply(pubRes, prProfile)
+			} else {
+				fmt.Fprintf(out, "%s: %s/%s: profile %q was not given, validating without it\n", doc.source, doc.kind, doc.name, name)
+			}
+		}
+
+		doc.errs = fieldErrors(validation.ValidatePublishedResource(pubRes))
+	}
+
+	invalid := 0
+	for _, doc := range docs {
+		if len(doc.errs) == 0 {
+			fmt.Fprintf(out, "%s: %s/%s is valid\n", doc.source, doc.kind, doc.name)
+			continue
+		}
+
+		invalid++
+		for _, err := range doc.errs {
+			fmt.Fprintf(out, "%s: %s/%s: %v\n", doc.source, doc.kind, doc.name, err)
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d object(s) are invalid", invalid, len(docs))
+	}
+
+	return nil
+}
+
+func readValidateDocuments(filename string, stdin io.Reader) ([]*validateDocument, error) {
+	var (
+		source = filename
+		r      = stdin
+	)
+
+	if filename == "-" {
+		source = "<stdin>"
+	} else {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	docs := []*validateDocument{}
+
+	for index := 0; ; index++ {
+		data, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			continue
+		}
+
+		doc, err := decodeValidateDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode document #%d in %s: %w", index, source, err)
+		}
+
+		// empty documents, e.g. consisting only of comments
+		if doc == nil {
+			continue
+		}
+
+		doc.source = source
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+func decodeValidateDocument(data []byte) (*validateDocument, error) {
+	var obj metav1.PartialObjectMetadata
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	if obj.APIVersion == "" && obj.Kind == "" {
+		return nil, nil
+	}
+
+	if obj.APIVersion != syncagentv1alpha1.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("unsupported apiVersion %q, expected %q", obj.APIVersion, syncagentv1alpha1.SchemeGroupVersion.String())
+	}
+
+	doc := &validateDocument{
+		kind: obj.Kind,
+		name: obj.Name,
+	}
+
+	switch obj.Kind {
+	case "PublishedResource":
+		pubRes := &syncagentv1alpha1.PublishedResource{}
+		if err := yaml.UnmarshalStrict(data, pubRes); err != nil {
+			doc.errs = []error{err}
+		} else {
+			doc.pubRes = pubRes
+		}
+
+	case "PublishedResourceProfile":
+		prProfile := &syncagentv1alpha1.PublishedResourceProfile{}
+		if err := yaml.UnmarshalStrict(data, prProfile); err != nil {
+			doc.errs = []error{err}
+		} else if doc.errs = fieldErrors(validation.ValidatePublishedResourceProfile(prProfile)); len(doc.errs) == 0 {
+			doc.profile = prProfile
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", obj.Kind)
+	}
+
+	return doc, nil
+}
+
+func fieldErrors(errs field.ErrorList) []error {
+	result := make([]error, len(errs))
+	for i, err := range errs {
+		result[i] = err
+	}
+
+	return result
+}
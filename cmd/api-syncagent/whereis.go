@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/naming"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type whereisOptions struct {
+	Kubeconfig        string
+	KcpKubeconfig     string
+	PublishedResource string
+	Cluster           string
+	WorkspacePath     string
+	Namespace         string
+	Name              string
+}
+
+func (o *whereisOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "kubeconfig file of the service cluster")
+	flags.StringVar(&o.KcpKubeconfig, "kcp-kubeconfig", o.KcpKubeconfig, "kubeconfig file of kcp (optional, required to check the remote object)")
+	flags.StringVar(&o.PublishedResource, "published-resource", o.PublishedResource, "name of the PublishedResource the object belongs to")
+	flags.StringVar(&o.Cluster, "cluster", o.Cluster, "logical cluster name of the kcp workspace the remote object lives in")
+	flags.StringVar(&o.WorkspacePath, "workspace-path", o.WorkspacePath, "path of the kcp workspace the remote object lives in (required if the naming rules use the workspace path)")
+	flags.StringVar(&o.Namespace, "namespace", o.Namespace, "namespace of the remote object (leave empty for cluster-scoped objects)")
+	flags.StringVar(&o.Name, "name", o.Name, "name of the remote object")
+}
+
+func (o *whereisOptions) Validate() error {
+	errs := []error{}
+
+	if len(o.PublishedResource) == 0 {
+		errs = append(errs, errors.New("--published-resource is required"))
+	}
+
+	if len(o.Cluster) == 0 {
+		errs = append(errs, errors.New("--cluster is required"))
+	}
+
+	if len(o.Name) == 0 {
+		errs = append(errs, errors.New("--name is required"))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// runWhereis implements the "whereis" subcommand, which prints the local
+// namespace/name for a given remote object and checks whether the objects exist.
+func runWhereis(ctx context.Context, args []string, out io.Writer) error {
+	opts := &whereisOptions{}
+
+	flags := pflag.NewFlagSet("whereis", pflag.ContinueOnError)
+	opts.AddFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	localConfig, err := loadKubeconfig(opts.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load service cluster kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register scheme %s: %w", syncagentv1alpha1.SchemeGroupVersion, err)
+	}
+
+	localClient, err := ctrlruntimeclient.New(localConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create service cluster client: %w", err)
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := localClient.Get(ctx, types.NamespacedName{Name: opts.PublishedResource}, pubRes); err != nil {
+		return fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	pubRes, _, err = profile.Resolve(ctx, localClient, pubRes)
+	if err != nil {
+		return fmt.Errorf("failed to apply profile: %w", err)
+	}
+
+	clusterName := logicalcluster.Name(opts.Cluster)
+	workspacePath := logicalcluster.NewPath(opts.WorkspacePath)
+
+	if workspacePath.Empty() && naming.UsesWorkspacePath(pubRes.Spec.Naming) {
+		return errors.New("the PublishedResource's naming rules use the workspace path, --workspace-path is required")
+	}
+
+	if opts.KcpKubeconfig == "" && naming.UsesTemplates(pubRes.Spec.Naming) {
+		return errors.New("the PublishedResource's naming templates can use the object's labels and annotations, --kcp-kubeconfig is required")
+	}
+
+	remoteKey := types.NamespacedName{Namespace: opts.Namespace, Name: opts.Name}
+	remoteGVK := projection.PublishedResourceProjectedGVK(pubRes)
+
+	// without access to kcp, only the name and namespace of the remote object are known
+	remoteObj := &unstructured.Unstructured{}
+	remoteObj.SetNamespace(remoteKey.Namespace)
+	remoteObj.SetName(remoteKey.Name)
+
+	remoteStatus := "unknown (no --kcp-kubeconfig given)"
+	if opts.KcpKubeconfig != "" {
+		kcpConfig, err := loadKubeconfig(opts.KcpKubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to load kcp kubeconfig: %w", err)
+		}
+
+		kcpClient, err := ctrlruntimeclient.New(workspaceConfig(kcpConfig, clusterName.Path()), ctrlruntimeclient.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create kcp client: %w", err)
+		}
+
+		existing, err := getObject(ctx, kcpClient, remoteGVK, remoteKey)
+		if err != nil {
+			return fmt.Errorf("failed to check remote object: %w", err)
+		}
+
+		remoteStatus = objectStatus(existing)
+		if existing != nil {
+			remoteObj = existing
+		}
+	}
+
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+
+	localKey, err := naming.LocalObjectName(pubRes.Spec.Naming, clusterName, workspacePath, remoteObj)
+	if err != nil {
+		return fmt.Errorf("failed to determine local object name: %w", err)
+	}
+
+	// the namespace is ignored for cluster-scoped local objects
+	mapping, err := localClient.RESTMapper().RESTMapping(localGVK.GroupKind(), localGVK.Version)
+	if err != nil {
+		return fmt.Errorf("failed to determine scope of %v: %w", localGVK, err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		localKey.Namespace = ""
+	}
+
+	localObj, err := getObject(ctx, localClient, localGVK, localKey)
+	if err != nil {
+		return fmt.Errorf("failed to check local object: %w", err)
+	}
+
+	localStatus := objectStatus(localObj)
+
+	fmt.Fprintf(out, "Remote object: %s %s/%s (%s)\n", remoteGVK.Kind, clusterName, formatKey(remoteKey), remoteStatus)
+	fmt.Fprintf(out, "Local object:  %s %s (%s)\n", localGVK.Kind, formatKey(localKey), localStatus)
+
+	return nil
+}
+
+// workspaceConfig returns a copy of the kcp config that points to the given workspace.
+func workspaceConfig(config *rest.Config, path logicalcluster.Path) *rest.Config {
+	config = rest.CopyConfig(config)
+
+	if idx := strings.Index(config.Host, "/clusters/"); idx >= 0 {
+		config.Host = config.Host[:idx]
+	}
+
+	config.Host = strings.TrimSuffix(config.Host, "/") + path.RequestPath()
+
+	return config
+}
+
+// getObject returns the object or nil if it does not exist.
+func getObject(ctx context.Context, client ctrlruntimeclient.Client, gvk schema.GroupVersionKind, key types.NamespacedName) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func objectStatus(obj *unstructured.Unstructured) string {
+	if obj == nil {
+		return "does not exist"
+	}
+
+	return "exists"
+}
+
+func formatKey(key types.NamespacedName) string {
+	if key.Namespace == "" {
+		return key.Name
+	}
+
+	return key.String()
+}
@@ -31,22 +31,29 @@ import (
 )
 
 var (
-	kubeconfigPath string
+	kubeconfigPath      string
+	asPublishedResource bool
 )
 
 func main() {
 	ctx := context.Background()
 
 	pflag.StringVar(&kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file to use (defaults to $KUBECONFIG)")
+	pflag.BoolVar(&asPublishedResource, "as-publishedresource", false, "Output a PublishedResource for each GVK instead of its CRD")
 	pflag.Parse()
 
 	if pflag.NArg() == 0 {
-		log.Fatal("No argument given. Please specify a GVK in the form 'Kind.version.apigroup.com' to pull.")
+		log.Fatal("No argument given. Please specify one or more GVKs in the form 'Kind.version.apigroup.com' to pull.")
 	}
 
-	gvk, _ := schema.ParseKindArg(pflag.Arg(0))
-	if gvk == nil {
-		log.Fatal("Invalid GVK, please use the format 'Kind.version.apigroup.com'.")
+	gvks := []schema.GroupVersionKind{}
+	for _, arg := range pflag.Args() {
+		gvk, _ := schema.ParseKindArg(arg)
+		if gvk == nil {
+			log.Fatalf("Invalid GVK %q, please use the format 'Kind.version.apigroup.com'.", arg)
+		}
+
+		gvks = append(gvks, *gvk)
 	}
 
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
@@ -67,15 +74,29 @@ func main() {
 		log.Fatalf("Failed to create discovery client: %v.", err)
 	}
 
-	crd, err := discoveryClient.RetrieveCRD(ctx, *gvk)
-	if err != nil {
-		log.Fatalf("Failed to pull CRD: %v.", err)
-	}
-
-	enc, err := yaml.Marshal(crd)
-	if err != nil {
-		log.Fatalf("Failed to encode CRD as YAML: %v.", err)
+	for i, gvk := range gvks {
+		crd, err := discoveryClient.RetrieveCRD(ctx, gvk)
+		if err != nil {
+			log.Fatalf("Failed to pull CRD for %v: %v.", gvk, err)
+		}
+
+		var output any = crd
+		if asPublishedResource {
+			output, err = publishedResourceFor(crd, gvk)
+			if err != nil {
+				log.Fatalf("Failed to create PublishedResource for %v: %v.", gvk, err)
+			}
+		}
+
+		enc, err := yaml.Marshal(output)
+		if err != nil {
+			log.Fatalf("Failed to encode %v as YAML: %v.", gvk, err)
+		}
+
+		if i > 0 {
+			fmt.Println("---")
+		}
+
+		fmt.Println(string(enc))
 	}
-
-	fmt.Println(string(enc))
 }
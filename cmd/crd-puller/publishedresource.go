@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// publishedResourceFor returns a PublishedResource that publishes the given GVK, which
+// is meant as a starting point for service owners and can be edited before applying it.
+func publishedResourceFor(crd *apiextensionsv1.CustomResourceDefinition, gvk schema.GroupVersionKind) (map[string]any, error) {
+	names := crd.Spec.Names
+
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: syncagentv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "PublishedResource",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: publishedResourceName(names.Plural, gvk.Group),
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: gvk.Group,
+				Version:  gvk.Version,
+				Kind:     gvk.Kind,
+			},
+			// suggest the same identity as on the service cluster, so it is easy to adjust
+			Projection: &syncagentv1alpha1.ResourceProjection{
+				Group:      gvk.Group,
+				Version:    gvk.Version,
+				Kind:       names.Kind,
+				Plural:     names.Plural,
+				ShortNames: names.ShortNames,
+				Categories: names.Categories,
+			},
+			Naming: &syncagentv1alpha1.ResourceNaming{
+				Name:      "$remoteNamespaceHash-$remoteNameHash",
+				Namespace: "$remoteClusterName",
+			},
+		},
+	}
+
+	// cluster-scoped objects need the cluster name to be unique
+	if crd.Spec.Scope == apiextensionsv1.ClusterScoped {
+		pubRes.Spec.Naming = &syncagentv1alpha1.ResourceNaming{
+			Name: "$remoteClusterName-$remoteNameHash",
+		}
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pubRes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert PublishedResource: %w", err)
+	}
+
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj, "status")
+
+	// nil means "keep the original names", which does not need to be spelled out
+	if names.ShortNames == nil {
+		unstructured.RemoveNestedField(obj, "spec", "projection", "shortNames")
+	}
+	if names.Categories == nil {
+		unstructured.RemoveNestedField(obj, "spec", "projection", "categories")
+	}
+
+	return obj, nil
+}
+
+// publishedResourceName returns a valid object name like "publish-certificates-cert-manager-io".
+func publishedResourceName(plural, group string) string {
+	name := "publish-" + plural
+	if group != "" {
+		name += "-" + strings.ReplaceAll(group, ".", "-")
+	}
+
+	return strings.ToLower(name)
+}
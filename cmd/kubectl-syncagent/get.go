@@ -0,0 +1,314 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/naming"
+	"github.com/kcp-dev/api-syncagent/sdk/related"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+type getOptions struct {
+	commonOptions
+
+	Cluster        string
+	WorkspacePath  string
+	StateNamespace string
+	StateBackend   string
+}
+
+func (o *getOptions) AddFlags(flags *pflag.FlagSet) {
+	o.commonOptions.AddFlags(flags)
+
+	flags.StringVar(&o.Cluster, "cluster", o.Cluster, "logical cluster name of the kcp workspace the object lives in")
+	flags.StringVar(&o.WorkspacePath, "workspace-path", o.WorkspacePath, "path of the kcp workspace the object lives in (required if the naming rules use the workspace path)")
+	flags.StringVar(&o.StateNamespace, "state-namespace", o.StateNamespace, "Kubernetes namespace the Sync Agent stores states in (defaults to the PublishedResource's state namespace)")
+	flags.StringVar(&o.StateBackend, "state-backend", o.StateBackend, fmt.Sprintf("backend the Sync Agent stores states in (one of %v)", state.BackendTypes))
+}
+
+func (o *getOptions) Validate() error {
+	errs := []error{}
+
+	if err := o.commonOptions.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(o.KcpKubeconfig) == 0 {
+		errs = append(errs, errors.New("--kcp-kubeconfig is required"))
+	}
+
+	if len(o.Cluster) == 0 {
+		errs = append(errs, errors.New("--cluster is required"))
+	}
+
+	if !slices.Contains(state.BackendTypes, state.BackendType(o.StateBackend)) {
+		errs = append(errs, fmt.Errorf("invalid --state-backend %q, must be one of %v", o.StateBackend, state.BackendTypes))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// runGet implements the "get" command, which resolves an object in kcp to its copy
+// on the service cluster and prints the sync status, the changes since the last
+// synchronization and the related objects.
+func runGet(ctx context.Context, args []string, out io.Writer) error {
+	opts := &getOptions{
+		StateBackend: string(state.BackendSecret),
+	}
+
+	name, err := parseArgs("get", args, opts.AddFlags)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	localClient, localConfig, err := newLocalClient(opts.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	pubRes, err := getPublishedResource(ctx, localClient, opts.PublishedResource)
+	if err != nil {
+		return err
+	}
+
+	clusterName := logicalcluster.Name(opts.Cluster)
+	workspacePath := logicalcluster.NewPath(opts.WorkspacePath)
+
+	if workspacePath.Empty() && naming.UsesWorkspacePath(pubRes.Spec.Naming) {
+		return errors.New("the PublishedResource's naming rules use the workspace path, --workspace-path is required")
+	}
+
+	kcpClient, err := newKcpClient(opts.KcpKubeconfig, clusterName)
+	if err != nil {
+		return err
+	}
+
+	remoteKey := types.NamespacedName{Namespace: opts.Namespace, Name: name}
+	remoteGVK := projection.PublishedResourceProjectedGVK(pubRes)
+
+	remoteObj, err := getObject(ctx, kcpClient, remoteGVK, remoteKey)
+	if err != nil {
+		return fmt.Errorf("failed to get remote object: %w", err)
+	}
+
+	if remoteObj == nil {
+		return fmt.Errorf("%s %s does not exist in cluster %s", remoteGVK.Kind, formatKey(remoteKey), clusterName)
+	}
+
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+
+	localKey, err := naming.LocalObjectName(pubRes.Spec.Naming, clusterName, workspacePath, remoteObj)
+	if err != nil {
+		return fmt.Errorf("failed to determine local object name: %w", err)
+	}
+
+	// the namespace is ignored for cluster-scoped local objects
+	mapping, err := localClient.RESTMapper().RESTMapping(localGVK.GroupKind(), localGVK.Version)
+	if err != nil {
+		return fmt.Errorf("failed to determine scope of %v: %w", localGVK, err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		localKey.Namespace = ""
+	}
+
+	localObj, err := getObject(ctx, localClient, localGVK, localKey)
+	if err != nil {
+		return fmt.Errorf("failed to get local object: %w", err)
+	}
+
+	localStatus := "exists"
+	if localObj == nil {
+		localStatus = "does not exist"
+	}
+
+	printField(out, "Remote object", fmt.Sprintf("%s %s/%s", remoteGVK.Kind, clusterName, formatKey(remoteKey)))
+	printField(out, "Local object", fmt.Sprintf("%s %s (%s)", localGVK.Kind, formatKey(localKey), localStatus))
+	printField(out, "Sync status", syncStatus(remoteObj, localObj))
+
+	if err := printRelatedObjects(out, remoteObj); err != nil {
+		return err
+	}
+
+	stateNamespace := opts.StateNamespace
+	if stateNamespace == "" {
+		stateNamespace = pubRes.Spec.StateNamespace
+	}
+
+	if stateNamespace == "" {
+		fmt.Fprintln(out, "\nThe PublishedResource does not configure a state namespace, use --state-namespace to show the changes since the last synchronization.")
+		return nil
+	}
+
+	diff, err := lastStateDiff(ctx, localClient, localConfig, pubRes, state.BackendType(opts.StateBackend), stateNamespace, clusterName, remoteObj)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nChanges since the last synchronization:\n%s", diff)
+
+	return nil
+}
+
+// syncStatus summarizes the synchronization status using the metadata the Sync Agent
+// places on the objects.
+func syncStatus(remoteObj, localObj *unstructured.Unstructured) string {
+	remoteAnnotations := remoteObj.GetAnnotations()
+
+	if message := remoteAnnotations[syncagentv1alpha1.SyncErrorAnnotation]; message != "" {
+		return fmt.Sprintf("Failed (%s)", message)
+	}
+
+	if remoteAnnotations[syncagentv1alpha1.PausedAnnotation] == "true" || (localObj != nil && localObj.GetAnnotations()[syncagentv1alpha1.PausedAnnotation] == "true") {
+		return "Paused"
+	}
+
+	if remoteObj.GetDeletionTimestamp() != nil {
+		return "Deleting"
+	}
+
+	if !sync.IsSynchronized(remoteObj) || localObj == nil {
+		return "Pending"
+	}
+
+	if failed := remoteAnnotations[syncagentv1alpha1.FailedRelatedResourcesAnnotation]; failed != "" {
+		return fmt.Sprintf("Synchronized (failed related resources: %s)", failed)
+	}
+
+	return "Synchronized"
+}
+
+func printRelatedObjects(out io.Writer, remoteObj *unstructured.Unstructured) error {
+	refs, err := related.FromAnnotations(remoteObj.GetAnnotations())
+	if err != nil {
+		return err
+	}
+
+	if len(refs) == 0 {
+		printField(out, "Related objects", "none")
+		return nil
+	}
+
+	fmt.Fprintln(out, "Related objects:")
+
+	for _, ref := range refs {
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		fmt.Fprintf(out, "  %s: %s %s\n", ref.Identifier, ref.Kind, formatKey(key))
+	}
+
+	return nil
+}
+
+// lastStateDiff returns a unified diff between the last-known state of the remote
+// object and its current state.
+func lastStateDiff(
+	ctx context.Context,
+	localClient ctrlruntimeclient.Client,
+	localConfig *rest.Config,
+	pubRes *syncagentv1alpha1.PublishedResource,
+	backendType state.BackendType,
+	stateNamespace string,
+	clusterName logicalcluster.Name,
+	remoteObj *unstructured.Unstructured,
+) (string, error) {
+	stateName := types.NamespacedName{
+		Namespace: stateNamespace,
+		Name:      state.ObjectName(clusterName, remoteObj),
+	}
+
+	backend := state.NewBackend(localClient, backendType, stateName, nil)
+
+	lastKnown, err := backend.Get(ctx, state.Key(clusterName, remoteObj))
+	if err != nil {
+		return "", fmt.Errorf("failed to get last-known state: %w", err)
+	}
+
+	if lastKnown == nil {
+		return "  (no last-known state found)\n", nil
+	}
+
+	discoveryClient, err := discovery.NewClient(localConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+
+	localCRD, err := discoveryClient.RetrieveCRD(ctx, localGVK)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve CRD for %v: %w", localGVK, err)
+	}
+
+	current, err := sync.SnapshotObject(remoteObj, localCRD, localGVK.Version)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot remote object: %w", err)
+	}
+
+	lastKnownYAML, err := yaml.JSONToYAML(lastKnown)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode last-known state: %w", err)
+	}
+
+	currentYAML, err := yaml.JSONToYAML([]byte(current))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode remote object: %w", err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSpace(string(lastKnownYAML))),
+		B:        difflib.SplitLines(strings.TrimSpace(string(currentYAML))),
+		FromFile: "last-known state",
+		ToFile:   "current remote object",
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate diff: %w", err)
+	}
+
+	if diff == "" {
+		return "  (none)\n", nil
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const usage = `kubectl-syncagent shows how objects in kcp relate to their copies on the service cluster.
+
+Usage:
+  kubectl syncagent get NAME --published-resource PR --cluster CLUSTER [flags]
+  kubectl syncagent origin NAME --published-resource PR [flags]
+
+Commands:
+  get      Resolve an object in kcp to its copy on the service cluster and show its sync status.
+  origin   Resolve an object on the service cluster to the kcp object it originates from.
+
+Run "kubectl syncagent COMMAND --help" for the flags of each command.`
+
+func main() {
+	ctx := context.Background()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	var err error
+
+	switch os.Args[1] {
+	case "get":
+		err = runGet(ctx, os.Args[2:], os.Stdout)
+	case "origin":
+		err = runOrigin(ctx, os.Args[2:], os.Stdout)
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+	default:
+		err = fmt.Errorf("unknown command %q, run \"kubectl syncagent help\" for usage", os.Args[1])
+	}
+
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return
+		}
+
+		log.Fatal(err)
+	}
+}
+
+// commonOptions are the flags shared by all commands.
+type commonOptions struct {
+	Kubeconfig        string
+	KcpKubeconfig     string
+	PublishedResource string
+	Namespace         string
+}
+
+func (o *commonOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "kubeconfig file of the service cluster (defaults to $KUBECONFIG)")
+	flags.StringVar(&o.KcpKubeconfig, "kcp-kubeconfig", o.KcpKubeconfig, "kubeconfig file of kcp")
+	flags.StringVar(&o.PublishedResource, "published-resource", o.PublishedResource, "name of the PublishedResource the object belongs to")
+	flags.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "namespace of the object (leave empty for cluster-scoped objects)")
+}
+
+func (o *commonOptions) Validate() error {
+	if len(o.PublishedResource) == 0 {
+		return errors.New("--published-resource is required")
+	}
+
+	return nil
+}
+
+// parseArgs parses the flags and returns the single positional argument (the object name).
+func parseArgs(command string, args []string, addFlags func(*pflag.FlagSet)) (string, error) {
+	flags := pflag.NewFlagSet(command, pflag.ContinueOnError)
+	addFlags(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+
+	if flags.NArg() != 1 {
+		return "", errors.New("invalid command line: exactly one object name is required")
+	}
+
+	return flags.Arg(0), nil
+}
+
+// newLocalClient returns a client for the service cluster.
+func newLocalClient(kubeconfig string) (ctrlruntimeclient.Client, *rest.Config, error) {
+	config, err := loadKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load service cluster kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, nil, fmt.Errorf("failed to register scheme %s: %w", corev1.SchemeGroupVersion, err)
+	}
+
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, nil, fmt.Errorf("failed to register scheme %s: %w", syncagentv1alpha1.SchemeGroupVersion, err)
+	}
+
+	client, err := ctrlruntimeclient.New(config, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create service cluster client: %w", err)
+	}
+
+	return client, config, nil
+}
+
+// newKcpClient returns a client for the given kcp workspace.
+func newKcpClient(kubeconfig string, cluster logicalcluster.Name) (ctrlruntimeclient.Client, error) {
+	config, err := loadKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kcp kubeconfig: %w", err)
+	}
+
+	client, err := ctrlruntimeclient.New(workspaceConfig(config, cluster.Path()), ctrlruntimeclient.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kcp client: %w", err)
+	}
+
+	return client, nil
+}
+
+// getPublishedResource returns the PublishedResource with its profile applied, just
+// like the Sync Agent sees it. Only PublishedResources whose objects originate in
+// kcp are supported.
+func getPublishedResource(ctx context.Context, client ctrlruntimeclient.Client, name string) (*syncagentv1alpha1.PublishedResource, error) {
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := client.Get(ctx, types.NamespacedName{Name: name}, pubRes); err != nil {
+		return nil, fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	pubRes, _, err := profile.Resolve(ctx, client, pubRes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply profile: %w", err)
+	}
+
+	if pubRes.Spec.Origin == syncagentv1alpha1.PublishedResourceOriginService {
+		return nil, errors.New("the PublishedResource's objects originate on the service cluster, which is not supported")
+	}
+
+	return pubRes, nil
+}
+
+func loadKubeconfig(filename string) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = filename
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, nil).ClientConfig()
+}
+
+// workspaceConfig returns a copy of the kcp config that points to the given workspace.
+func workspaceConfig(config *rest.Config, path logicalcluster.Path) *rest.Config {
+	config = rest.CopyConfig(config)
+
+	if idx := strings.Index(config.Host, "/clusters/"); idx >= 0 {
+		config.Host = config.Host[:idx]
+	}
+
+	config.Host = strings.TrimSuffix(config.Host, "/") + path.RequestPath()
+
+	return config
+}
+
+// getObject returns the object or nil if it does not exist.
+func getObject(ctx context.Context, client ctrlruntimeclient.Client, gvk schema.GroupVersionKind, key types.NamespacedName) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func formatKey(key types.NamespacedName) string {
+	if key.Namespace == "" {
+		return key.Name
+	}
+
+	return key.String()
+}
+
+// printField prints a single, aligned "label: value" line.
+func printField(out io.Writer, label string, value string) {
+	fmt.Fprintf(out, "%-16s %s\n", label+":", value)
+}
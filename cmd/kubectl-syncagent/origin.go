@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type originOptions struct {
+	commonOptions
+}
+
+func (o *originOptions) AddFlags(flags *pflag.FlagSet) {
+	o.commonOptions.AddFlags(flags)
+}
+
+// runOrigin implements the "origin" command, which resolves an object on the service
+// cluster to the kcp object it was created from. If a kcp kubeconfig is given, the
+// origin object is checked and its sync status is printed as well.
+func runOrigin(ctx context.Context, args []string, out io.Writer) error {
+	opts := &originOptions{}
+
+	name, err := parseArgs("origin", args, opts.AddFlags)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid command line: %w", err)
+	}
+
+	localClient, _, err := newLocalClient(opts.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	pubRes, err := getPublishedResource(ctx, localClient, opts.PublishedResource)
+	if err != nil {
+		return err
+	}
+
+	localKey := types.NamespacedName{Namespace: opts.Namespace, Name: name}
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+
+	localObj, err := getObject(ctx, localClient, localGVK, localKey)
+	if err != nil {
+		return fmt.Errorf("failed to get local object: %w", err)
+	}
+
+	if localObj == nil {
+		return fmt.Errorf("%s %s does not exist on the service cluster", localGVK.Kind, formatKey(localKey))
+	}
+
+	// the same labels and annotations are used by the Sync Agent to map local objects back
+	req := sync.RemoteNameForLocalObject(localObj)
+	if req == nil {
+		return fmt.Errorf("%s %s was not created by the Sync Agent", localGVK.Kind, formatKey(localKey))
+	}
+
+	clusterName := logicalcluster.Name(req.ClusterName)
+	remoteGVK := projection.PublishedResourceProjectedGVK(pubRes)
+
+	printField(out, "Local object", fmt.Sprintf("%s %s", localGVK.Kind, formatKey(localKey)))
+	printField(out, "Remote object", fmt.Sprintf("%s %s/%s", remoteGVK.Kind, clusterName, formatKey(req.NamespacedName)))
+
+	if path := sync.RemoteWorkspacePathForLocalObject(localObj); !path.Empty() {
+		printField(out, "Workspace path", path.String())
+	}
+
+	if opts.KcpKubeconfig == "" {
+		printField(out, "Sync status", "unknown (no --kcp-kubeconfig given)")
+		return nil
+	}
+
+	kcpClient, err := newKcpClient(opts.KcpKubeconfig, clusterName)
+	if err != nil {
+		return err
+	}
+
+	remoteObj, err := getObject(ctx, kcpClient, remoteGVK, req.NamespacedName)
+	if err != nil {
+		return fmt.Errorf("failed to get remote object: %w", err)
+	}
+
+	if remoteObj == nil {
+		printField(out, "Sync status", "Orphaned (remote object does not exist)")
+		return nil
+	}
+
+	printField(out, "Sync status", syncStatus(remoteObj, localObj))
+
+	return printRelatedObjects(out, remoteObj)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/validation"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	publishedResourceFile string
+	objectFile            string
+	profileFile           string
+	crdFile               string
+	clusterName           string
+	workspacePath         string
+)
+
+func main() {
+	ctx := context.Background()
+
+	pflag.StringVar(&publishedResourceFile, "published-resource", "", "Path to the PublishedResource YAML file")
+	pflag.StringVar(&objectFile, "object", "", "Path to a YAML file containing a sample object as it would be created in kcp")
+	pflag.StringVar(&profileFile, "profile", "", "Path to the PublishedResourceProfile YAML file referenced by the PublishedResource (optional)")
+	pflag.StringVar(&crdFile, "crd", "", "Path to the CRD of the resource on the service cluster (optional, the scope is otherwise derived from the sample object)")
+	pflag.StringVar(&clusterName, "cluster", "preview", "Logical cluster name of the kcp workspace the sample object lives in")
+	pflag.StringVar(&workspacePath, "workspace-path", "", "Path of the kcp workspace the sample object lives in (optional)")
+	pflag.Parse()
+
+	if publishedResourceFile == "" || objectFile == "" {
+		log.Fatal("Both --published-resource and --object are required.")
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := readYAML(publishedResourceFile, pubRes); err != nil {
+		log.Fatalf("Failed to read PublishedResource: %v.", err)
+	}
+
+	if profileFile != "" {
+		prProfile := &syncagentv1alpha1.PublishedResourceProfile{}
+		if err := readYAML(profileFile, prProfile); err != nil {
+			log.Fatalf("Failed to read PublishedResourceProfile: %v.", err)
+		}
+
+		pubRes = profile.Apply(pubRes, prProfile)
+	} else if pubRes.Spec.Profile != "" {
+		log.Fatalf("The PublishedResource uses the profile %q, please specify it using --profile.", pubRes.Spec.Profile)
+	}
+
+	if errs := validation.ValidatePublishedResource(pubRes); len(errs) > 0 {
+		log.Fatalf("PublishedResource is invalid: %v.", errs.ToAggregate())
+	}
+
+	remoteObj := &unstructured.Unstructured{}
+	if err := readYAML(objectFile, &remoteObj.Object); err != nil {
+		log.Fatalf("Failed to read sample object: %v.", err)
+	}
+
+	var crd *apiextensionsv1.CustomResourceDefinition
+	if crdFile != "" {
+		crd = &apiextensionsv1.CustomResourceDefinition{}
+		if err := readYAML(crdFile, crd); err != nil {
+			log.Fatalf("Failed to read CRD: %v.", err)
+		}
+	} else {
+		crd = guessCRD(pubRes, remoteObj)
+	}
+
+	localObj, err := sync.Preview(ctx, pubRes, crd, remoteObj, logicalcluster.Name(clusterName), logicalcluster.NewPath(workspacePath))
+	if err != nil {
+		log.Fatalf("Failed to transform object: %v.", err)
+	}
+
+	enc, err := yaml.Marshal(localObj)
+	if err != nil {
+		log.Fatalf("Failed to encode object as YAML: %v.", err)
+	}
+
+	fmt.Print(string(enc))
+}
+
+func readYAML(filename string, dest any) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(data, dest)
+}
+
+// guessCRD returns a minimal CRD for the local resource. Without access to the service
+// cluster, the scope can only be assumed to be the same as in kcp.
+func guessCRD(pubRes *syncagentv1alpha1.PublishedResource, obj *unstructured.Unstructured) *apiextensionsv1.CustomResourceDefinition {
+	scope := apiextensionsv1.ClusterScoped
+	if obj.GetNamespace() != "" {
+		scope = apiextensionsv1.NamespaceScoped
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: pubRes.Spec.Resource.APIGroup,
+			Scope: scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    pubRes.Spec.Resource.Version,
+				Served:  true,
+				Storage: true,
+			}},
+		},
+	}
+}
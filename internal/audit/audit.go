@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// TargetKcp is used for objects written to kcp.
+	TargetKcp = "kcp"
+	// TargetServiceCluster is used for objects written to the service cluster.
+	TargetServiceCluster = "service-cluster"
+)
+
+// Entry is a single write operation performed by the Sync Agent.
+type Entry struct {
+	Time              time.Time `json:"time"`
+	Operation         string    `json:"operation"`
+	Subresource       string    `json:"subresource,omitempty"`
+	Target            string    `json:"target"`
+	Cluster           string    `json:"cluster,omitempty"`
+	PublishedResource string    `json:"publishedResource,omitempty"`
+	APIVersion        string    `json:"apiVersion"`
+	Kind              string    `json:"kind"`
+	Namespace         string    `json:"namespace,omitempty"`
+	Name              string    `json:"name,omitempty"`
+	PatchType         string    `json:"patchType,omitempty"`
+	// Fields summarizes which fields have been changed by a patch.
+	Fields []string `json:"fields,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// Logger writes audit entries as JSON lines. A nil Logger is valid and does not
+// record anything.
+type Logger struct {
+	lock    sync.Mutex
+	out     io.Writer
+	encoder *json.Encoder
+	closer  io.Closer
+	now     func() time.Time
+}
+
+// NewLogger creates a new Logger that appends to the given file; use "-" to
+// write to stdout instead.
+func NewLogger(destination string) (*Logger, error) {
+	if destination == "-" {
+		return newLogger(os.Stdout, nil), nil
+	}
+
+	f, err := os.OpenFile(destination, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return newLogger(f, f), nil
+}
+
+func newLogger(out io.Writer, closer io.Closer) *Logger {
+	return &Logger{
+		out:     out,
+		encoder: json.NewEncoder(out),
+		closer:  closer,
+		now:     time.Now,
+	}
+}
+
+// Log records a single entry. Failing to write the audit log is not fatal for
+// the synchronization and is therefore only reported on stderr.
+func (l *Logger) Log(entry Entry) {
+	if l == nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if entry.Time.IsZero() {
+		entry.Time = l.now().UTC()
+	}
+
+	if err := l.encoder.Encode(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write audit log entry: %v\n", err)
+	}
+}
+
+// Close closes the underlying file, if any.
+func (l *Logger) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+
+	return l.closer.Close()
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// maxFieldDepth is how deep the changed fields of a patch are listed, e.g.
+// "spec.replicas" or "metadata.labels".
+const maxFieldDepth = 2
+
+// auditedClient records all write operations in the audit log.
+type auditedClient struct {
+	ctrlruntimeclient.Client
+
+	logger            *Logger
+	target            string
+	publishedResource string
+}
+
+// WrapClient wraps the client so that all write operations are recorded. If the
+// logger is nil, the client is returned as-is.
+func (l *Logger) WrapClient(client ctrlruntimeclient.Client, target, publishedResource string) ctrlruntimeclient.Client {
+	if l == nil {
+		return client
+	}
+
+	return &auditedClient{
+		Client:            client,
+		logger:            l,
+		target:            target,
+		publishedResource: publishedResource,
+	}
+}
+
+func (c *auditedClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.record(ctx, "create", "", obj, nil, err)
+
+	return err
+}
+
+func (c *auditedClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.record(ctx, "delete", "", obj, nil, err)
+
+	return err
+}
+
+func (c *auditedClient) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	c.record(ctx, "update", "", obj, nil, err)
+
+	return err
+}
+
+func (c *auditedClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	// the patch data has to be determined before obj is updated with the response
+	p := summarizePatch(obj, patch)
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.record(ctx, "patch", "", obj, p, err)
+
+	return err
+}
+
+func (c *auditedClient) DeleteAllOf(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteAllOfOption) error {
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	c.record(ctx, "deleteAllOf", "", obj, nil, err)
+
+	return err
+}
+
+func (c *auditedClient) Status() ctrlruntimeclient.SubResourceWriter {
+	return &auditedSubResourceWriter{
+		SubResourceWriter: c.Client.Status(),
+		client:            c,
+		subResource:       "status",
+	}
+}
+
+func (c *auditedClient) SubResource(subResource string) ctrlruntimeclient.SubResourceClient {
+	client := c.Client.SubResource(subResource)
+
+	return &auditedSubResourceClient{
+		SubResourceReader: client,
+		auditedSubResourceWriter: auditedSubResourceWriter{
+			SubResourceWriter: client,
+			client:            c,
+			subResource:       subResource,
+		},
+	}
+}
+
+type patchSummary struct {
+	patchType string
+	fields    []string
+}
+
+func (c *auditedClient) record(ctx context.Context, operation, subResource string, obj ctrlruntimeclient.Object, patch *patchSummary, err error) {
+	entry := Entry{
+		Operation:         operation,
+		Subresource:       subResource,
+		Target:            c.target,
+		PublishedResource: c.publishedResource,
+		Namespace:         obj.GetNamespace(),
+		Name:              obj.GetName(),
+	}
+
+	if gvk, gvkErr := c.GroupVersionKindFor(obj); gvkErr == nil {
+		entry.APIVersion, entry.Kind = gvk.ToAPIVersionAndKind()
+	}
+
+	if cluster := logicalcluster.From(obj); !cluster.Empty() {
+		entry.Cluster = cluster.String()
+	} else if cluster, ok := kontext.ClusterFrom(ctx); ok {
+		entry.Cluster = cluster.String()
+	}
+
+	if patch != nil {
+		entry.PatchType = patch.patchType
+		entry.Fields = patch.fields
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	c.logger.Log(entry)
+}
+
+// summarizePatch determines the fields changed by a patch. For JSON patches, the
+// paths of all operations are used, for all other patch types the keys of the
+// patch object.
+func summarizePatch(obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch) *patchSummary {
+	summary := &patchSummary{
+		patchType: string(patch.Type()),
+	}
+
+	data, err := patch.Data(obj)
+	if err != nil {
+		return summary
+	}
+
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return summary
+	}
+
+	fields := []string{}
+
+	switch d := decoded.(type) {
+	case []any:
+		for _, op := range d {
+			if opMap, ok := op.(map[string]any); ok {
+				if path, ok := opMap["path"].(string); ok {
+					fields = append(fields, jsonPointerToField(path))
+				}
+			}
+		}
+	case map[string]any:
+		fields = collectFields(d, "", 1)
+	}
+
+	slices.Sort(fields)
+	summary.fields = slices.Compact(fields)
+
+	return summary
+}
+
+func collectFields(data map[string]any, prefix string, depth int) []string {
+	fields := []string{}
+
+	for key, value := range data {
+		path := prefix + key
+
+		// apply patches always contain the type meta
+		if depth == 1 && (key == "apiVersion" || key == "kind") {
+			continue
+		}
+
+		if nested, ok := value.(map[string]any); ok && depth < maxFieldDepth && len(nested) > 0 {
+			fields = append(fields, collectFields(nested, path+".", depth+1)...)
+		} else {
+			fields = append(fields, path)
+		}
+	}
+
+	return fields
+}
+
+// jsonPointerToField converts "/spec/foo" into "spec.foo", limited to maxFieldDepth.
+func jsonPointerToField(pointer string) string {
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	if len(parts) > maxFieldDepth {
+		parts = parts[:maxFieldDepth]
+	}
+
+	for i, part := range parts {
+		parts[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+type auditedSubResourceWriter struct {
+	ctrlruntimeclient.SubResourceWriter
+
+	client      *auditedClient
+	subResource string
+}
+
+func (w *auditedSubResourceWriter) Create(ctx context.Context, obj ctrlruntimeclient.Object, subResource ctrlruntimeclient.Object, opts ...ctrlruntimeclient.SubResourceCreateOption) error {
+	err := w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+	w.client.record(ctx, "create", w.subResource, obj, nil, err)
+
+	return err
+}
+
+func (w *auditedSubResourceWriter) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.SubResourceUpdateOption) error {
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	w.client.record(ctx, "update", w.subResource, obj, nil, err)
+
+	return err
+}
+
+func (w *auditedSubResourceWriter) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.SubResourcePatchOption) error {
+	p := summarizePatch(obj, patch)
+	err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	w.client.record(ctx, "patch", w.subResource, obj, p, err)
+
+	return err
+}
+
+type auditedSubResourceClient struct {
+	ctrlruntimeclient.SubResourceReader
+	auditedSubResourceWriter
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAuditedClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register scheme: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	buf := &bytes.Buffer{}
+	logger := newLogger(buf, nil)
+	logger.now = func() time.Time { return now }
+
+	client := logger.WrapClient(fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).Build(), TargetServiceCluster, "publish-configmaps")
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "default",
+			Annotations: map[string]string{"kcp.io/cluster": "abc123"},
+		},
+		Data: map[string]string{"foo": "bar"},
+	}
+
+	if err := client.Create(ctx, cm); err != nil {
+		t.Fatalf("Failed to create object: %v", err)
+	}
+
+	original := cm.DeepCopy()
+	cm.Data["foo"] = "baz"
+	cm.Labels = map[string]string{"new": "label"}
+
+	if err := client.Patch(ctx, cm, ctrlruntimeclient.MergeFrom(original)); err != nil {
+		t.Fatalf("Failed to patch object: %v", err)
+	}
+
+	if err := client.Delete(ctx, cm); err != nil {
+		t.Fatalf("Failed to delete object: %v", err)
+	}
+
+	// deleting again must fail and record the error
+	_ = client.Delete(ctx, cm)
+
+	base := Entry{
+		Time:              now,
+		Target:            TargetServiceCluster,
+		Cluster:           "abc123",
+		PublishedResource: "publish-configmaps",
+		APIVersion:        "v1",
+		Kind:              "ConfigMap",
+		Namespace:         "default",
+		Name:              "test",
+	}
+
+	expected := []Entry{}
+	for _, modify := range []func(*Entry){
+		func(e *Entry) { e.Operation = "create" },
+		func(e *Entry) {
+			e.Operation = "patch"
+			e.PatchType = "application/merge-patch+json"
+			e.Fields = []string{"data.foo", "metadata.labels"}
+		},
+		func(e *Entry) { e.Operation = "delete" },
+		func(e *Entry) {
+			e.Operation = "delete"
+			e.Error = `configmaps "test" not found`
+		},
+	} {
+		entry := base
+		modify(&entry)
+		expected = append(expected, entry)
+	}
+
+	decoder := json.NewDecoder(buf)
+	entries := []Entry{}
+	for decoder.More() {
+		entry := Entry{}
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("Failed to decode audit log: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if changes := diff.ObjectDiff(expected, entries); changes != "" {
+		t.Errorf("Did not get expected audit log:\n%s", changes)
+	}
+}
+
+func TestNilLogger(t *testing.T) {
+	var logger *Logger
+
+	client := fakectrlruntimeclient.NewClientBuilder().Build()
+	if wrapped := logger.WrapClient(client, TargetKcp, ""); wrapped != client {
+		t.Error("Expected nil logger to not wrap the client.")
+	}
+
+	// must not panic
+	logger.Log(Entry{})
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package announcement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "syncagent-announcement"
+)
+
+type Reconciler struct {
+	localClient ctrlruntimeclient.Client
+	vwClient    ctrlruntimeclient.Client
+	vwReader    ctrlruntimeclient.Reader
+	log         *zap.SugaredLogger
+	prFilter    *controllerutil.PublishedResourceFilter
+}
+
+// Create creates a new controller and importantly does *not* add it to the manager,
+// as this controller is started/stopped by the syncmanager controller instead.
+func Create(
+	localManager manager.Manager,
+	virtualWorkspaceCluster cluster.Cluster,
+	prFilter *controllerutil.PublishedResourceFilter,
+	log *zap.SugaredLogger,
+) (controller.Controller, error) {
+	reconciler := &Reconciler{
+		localClient: localManager.GetClient(),
+		vwClient:    virtualWorkspaceCluster.GetClient(),
+		vwReader:    virtualWorkspaceCluster.GetAPIReader(),
+		log:         log.Named(ControllerName),
+		prFilter:    prFilter,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: 1,
+		SkipNameValidation:      ptr.To(true),
+	}
+
+	c, err := controller.NewUnmanaged(ControllerName, localManager, ctrlOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Watch(source.Kind(localManager.GetCache(), &syncagentv1alpha1.Announcement{}, &handler.TypedEnqueueRequestForObject[*syncagentv1alpha1.Announcement]{})); err != nil {
+		return nil, err
+	}
+
+	// pending Announcements have to be processed once their PublishedResource
+	// exists or is not being unpublished anymore
+	enqueueAnnouncements := handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, pubRes *syncagentv1alpha1.PublishedResource) []reconcile.Request {
+		announcements := &syncagentv1alpha1.AnnouncementList{}
+		if err := reconciler.localClient.List(ctx, announcements); err != nil {
+			reconciler.log.Errorw("Failed to list Announcements", zap.Error(err))
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, announcement := range announcements.Items {
+			if announcement.Spec.PublishedResource == pubRes.Name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: announcement.Name}})
+			}
+		}
+
+		return requests
+	})
+
+	if err := c.Watch(source.Kind(localManager.GetCache(), &syncagentv1alpha1.PublishedResource{}, enqueueAnnouncements)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("announcement", request.Name)
+	log.Debug("Processing")
+
+	announcement := &syncagentv1alpha1.Announcement{}
+	if err := r.localClient.Get(ctx, request.NamespacedName, announcement); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if announcement.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{}, r.reconcile(ctx, log, announcement)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, announcement *syncagentv1alpha1.Announcement) error {
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	key := types.NamespacedName{Name: announcement.Spec.PublishedResource}
+
+	if err := r.localClient.Get(ctx, key, pubRes); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhasePending, "PublishedResource does not exist.", "")
+		}
+
+		return fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	// PublishedResources not handled by this agent are ignored, as they are most
+	// likely handled by another agent on the same service cluster
+	if !r.prFilter.Matches(pubRes) {
+		log.Debugw("Ignoring Announcement for PublishedResource not handled by this agent", "pr", pubRes.Name)
+		return nil
+	}
+
+	if pubRes.Spec.Unpublish || pubRes.DeletionTimestamp != nil {
+		return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhasePending, "PublishedResource is being unpublished.", announcement.Status.RemoteUID)
+	}
+
+	pubRes, _, err := profile.Resolve(ctx, r.localClient, pubRes)
+	if err != nil {
+		if statusErr := r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseFailed, err.Error(), announcement.Status.RemoteUID); statusErr != nil {
+			return errors.Join(err, statusErr)
+		}
+
+		// the profile might be created or fixed later on
+		return fmt.Errorf("failed to resolve PublishedResource: %w", err)
+	}
+
+	remoteObj, err := announcedObject(announcement, projection.PublishedResourceProjectedGVK(pubRes))
+	if err != nil {
+		return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseFailed, err.Error(), "")
+	}
+
+	wsCtx := kontext.WithCluster(ctx, logicalcluster.Name(announcement.Spec.Cluster))
+	log = log.With("cluster", announcement.Spec.Cluster, "object", ctrlruntimeclient.ObjectKeyFromObject(remoteObj))
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(remoteObj.GroupVersionKind())
+
+	if err := r.vwReader.Get(wsCtx, ctrlruntimeclient.ObjectKeyFromObject(remoteObj), existing); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get object: %w", err)
+	}
+
+	exists := existing.GetName() != ""
+
+	// the object was created before; never recreate it, as the consumer might
+	// have deliberately deleted it
+	if uid := announcement.Status.RemoteUID; uid != "" {
+		if exists && existing.GetUID() == uid {
+			return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseCreated, "", uid)
+		}
+
+		return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseDeleted, "Object has been deleted in kcp.", uid)
+	}
+
+	// the object might have been created in a previous reconciliation, but we failed to update the status
+	if exists {
+		return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseCreated, "", existing.GetUID())
+	}
+
+	log.Info("Creating object…")
+
+	if err := r.vwClient.Create(wsCtx, remoteObj); err != nil {
+		if statusErr := r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseFailed, err.Error(), ""); statusErr != nil {
+			return errors.Join(err, statusErr)
+		}
+
+		return fmt.Errorf("failed to create object: %w", err)
+	}
+
+	return r.setStatus(ctx, announcement, syncagentv1alpha1.AnnouncementPhaseCreated, "", remoteObj.GetUID())
+}
+
+// announcedObject turns the object embedded in the Announcement into an unstructured
+// object with the given GVK.
+func announcedObject(announcement *syncagentv1alpha1.Announcement, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	if len(announcement.Spec.Object.Raw) == 0 {
+		return nil, errors.New("no object specified")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(announcement.Spec.Object.Raw, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+
+	if obj.GetAPIVersion() != "" || obj.GetKind() != "" {
+		if obj.GroupVersionKind() != gvk {
+			return nil, fmt.Errorf("object must be of type %v, but is %v", gvk, obj.GroupVersionKind())
+		}
+	}
+
+	if obj.GetName() == "" {
+		return nil, errors.New("object has no name")
+	}
+
+	obj.SetGroupVersionKind(gvk)
+
+	return obj, nil
+}
+
+func (r *Reconciler) setStatus(ctx context.Context, announcement *syncagentv1alpha1.Announcement, phase syncagentv1alpha1.AnnouncementPhase, message string, uid types.UID) error {
+	status := syncagentv1alpha1.AnnouncementStatus{
+		Phase:     phase,
+		Message:   message,
+		RemoteUID: uid,
+	}
+
+	if announcement.Status == status {
+		return nil
+	}
+
+	oldAnnouncement := announcement.DeepCopy()
+	announcement.Status = status
+
+	if err := r.localClient.Status().Patch(ctx, announcement, ctrlruntimeclient.MergeFrom(oldAnnouncement)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package announcement contains a controller that creates objects in kcp workspaces
+on behalf of the service provider. Each Announcement on the service cluster results
+in exactly one object being created via the virtual workspace; from then on, the
+object is synced like any other object created by the consumer.
+*/
+package announcement
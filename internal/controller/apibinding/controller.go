@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apibinding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+	kcpdevcorev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+	kcptenancyv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/tenancy/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "syncagent-apibinding"
+)
+
+type Reconciler struct {
+	// workspacesClient is used to read the child workspaces in the parent workspace
+	workspacesClient ctrlruntimeclient.Client
+	kcpClient        ctrlruntimeclient.Client
+	kcpRestConfig    *rest.Config
+	log              *zap.SugaredLogger
+	lcName           logicalcluster.Name
+	apiExportName    string
+}
+
+// Add creates a new controller and adds it to the given manager. The workspaces
+// cluster must point to the parent workspace and its cache should already be
+// restricted to the workspaces that are meant to be bound.
+func Add(
+	mgr manager.Manager,
+	kcpCluster cluster.Cluster,
+	workspacesCluster cluster.Cluster,
+	kcpRestConfig *rest.Config,
+	lcName logicalcluster.Name,
+	log *zap.SugaredLogger,
+	apiExportName string,
+) error {
+	reconciler := &Reconciler{
+		workspacesClient: workspacesCluster.GetClient(),
+		kcpClient:        kcpCluster.GetClient(),
+		kcpRestConfig:    kcpRestConfig,
+		log:              log.Named(ControllerName),
+		lcName:           lcName,
+		apiExportName:    apiExportName,
+	}
+
+	// when the APIExport's permission claims change, all bindings need to be updated
+	enqueueAllWorkspaces := handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, _ *kcpdevv1alpha1.APIExport) []reconcile.Request {
+		workspaces := &kcptenancyv1alpha1.WorkspaceList{}
+		if err := reconciler.workspacesClient.List(ctx, workspaces); err != nil {
+			reconciler.log.Errorw("Failed to list workspaces", zap.Error(err))
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(workspaces.Items))
+		for _, ws := range workspaces.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: ws.Name}})
+		}
+
+		return requests
+	})
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 2,
+		}).
+		// Watch for new and changed workspaces in the parent workspace
+		WatchesRawSource(source.Kind(workspacesCluster.GetCache(), &kcptenancyv1alpha1.Workspace{}, &handler.TypedEnqueueRequestForObject[*kcptenancyv1alpha1.Workspace]{})).
+		// Watch for changes to the APIExport, as its permission claims might change
+		WatchesRawSource(source.Kind(kcpCluster.GetCache(), &kcpdevv1alpha1.APIExport{}, enqueueAllWorkspaces)).
+		Build(reconciler)
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("workspace", request.Name)
+	log.Debug("Processing")
+
+	ws := &kcptenancyv1alpha1.Workspace{}
+	if err := r.workspacesClient.Get(ctx, request.NamespacedName, ws); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	// wait for the workspace to be fully initialized; the status update will
+	// trigger a new reconciliation
+	if ws.DeletionTimestamp != nil || ws.Spec.Cluster == "" || ws.Status.Phase != kcpdevcorev1alpha1.LogicalClusterPhaseReady {
+		return reconcile.Result{}, nil
+	}
+
+	apiExport := &kcpdevv1alpha1.APIExport{}
+	if err := r.kcpClient.Get(kontext.WithCluster(ctx, r.lcName), types.NamespacedName{Name: r.apiExportName}, apiExport); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get APIExport: %w", err)
+	}
+
+	client, err := r.workspaceClient(logicalcluster.Name(ws.Spec.Cluster))
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to create client for workspace: %w", err)
+	}
+
+	return reconcile.Result{}, r.reconcileBinding(ctx, log, client, apiExport)
+}
+
+func (r *Reconciler) reconcileBinding(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, apiExport *kcpdevv1alpha1.APIExport) error {
+	binding := &kcpdevv1alpha1.APIBinding{}
+	err := client.Get(ctx, types.NamespacedName{Name: r.apiExportName}, binding)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get APIBinding: %w", err)
+	}
+
+	if apierrors.IsNotFound(err) {
+		binding = &kcpdevv1alpha1.APIBinding{}
+		binding.Name = r.apiExportName
+		binding.Spec.Reference.Export = &kcpdevv1alpha1.ExportBindingReference{
+			Path: r.lcName.String(),
+			Name: r.apiExportName,
+		}
+		binding.Spec.PermissionClaims = acceptClaims(nil, apiExport.Spec.PermissionClaims)
+
+		log.Info("Creating APIBinding…")
+		if err := client.Create(ctx, binding); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create APIBinding: %w", err)
+		}
+
+		return nil
+	}
+
+	// do not touch bindings that happen to have the same name, but bind something else
+	if ref := binding.Spec.Reference.Export; ref == nil || ref.Name != r.apiExportName || (ref.Path != r.lcName.String() && ref.Path != "") {
+		log.Warnw("Existing APIBinding does not bind to the APIExport, skipping", "apibinding", binding.Name)
+		return nil
+	}
+
+	claims := acceptClaims(binding.Spec.PermissionClaims, apiExport.Spec.PermissionClaims)
+	if len(claims) == len(binding.Spec.PermissionClaims) {
+		return nil
+	}
+
+	log.Info("Accepting new permission claims…")
+	binding.Spec.PermissionClaims = claims
+	if err := client.Update(ctx, binding); err != nil {
+		return fmt.Errorf("failed to update APIBinding: %w", err)
+	}
+
+	return nil
+}
+
+// acceptClaims returns the existing claims plus all claims that have not yet been
+// accepted or rejected, marked as accepted.
+func acceptClaims(existing []kcpdevv1alpha1.AcceptablePermissionClaim, claims []kcpdevv1alpha1.PermissionClaim) []kcpdevv1alpha1.AcceptablePermissionClaim {
+	result := append([]kcpdevv1alpha1.AcceptablePermissionClaim{}, existing...)
+
+	for _, claim := range claims {
+		known := false
+		for _, acceptable := range existing {
+			if equality.Semantic.DeepEqual(acceptable.PermissionClaim, claim) {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			result = append(result, kcpdevv1alpha1.AcceptablePermissionClaim{
+				PermissionClaim: claim,
+				State:           kcpdevv1alpha1.ClaimAccepted,
+			})
+		}
+	}
+
+	return result
+}
+
+// workspaceClient returns a client for the given logical cluster.
+func (r *Reconciler) workspaceClient(clusterName logicalcluster.Name) (ctrlruntimeclient.Client, error) {
+	config := rest.CopyConfig(r.kcpRestConfig)
+
+	if idx := strings.Index(config.Host, "/clusters/"); idx >= 0 {
+		config.Host = config.Host[:idx]
+	}
+
+	config.Host = strings.TrimSuffix(config.Host, "/") + clusterName.Path().RequestPath()
+
+	return ctrlruntimeclient.New(config, ctrlruntimeclient.Options{Scheme: r.kcpClient.Scheme()})
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package apibinding contains an optional controller that onboards kcp workspaces
+automatically: it watches the child workspaces of a parent workspace that match
+a label selector and creates an APIBinding for the Sync Agent's APIExport in each
+of them, accepting all permission claims of the APIExport.
+
+The controller never removes APIBindings and never overrides claims that have been
+rejected explicitly in a workspace.
+*/
+package apibinding
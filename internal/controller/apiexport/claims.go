@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// permissionClaims collects the resources (as "resource.group" strings) the
+// Sync Agent needs access to in kcp, either entirely or restricted to objects
+// with certain names and namespaces.
+type permissionClaims map[string]*resourceClaim
+
+type resourceClaim struct {
+	all       bool
+	selectors sets.Set[kcpdevv1alpha1.ResourceSelector]
+}
+
+// claimAll claims all objects of the given resource.
+func (c permissionClaims) claimAll(groupResource string) {
+	c[groupResource] = &resourceClaim{all: true}
+}
+
+// claimSelectors claims only the objects matching the selectors. If no selectors
+// are given or the resource is already claimed entirely, all objects are claimed.
+func (c permissionClaims) claimSelectors(groupResource string, selectors []kcpdevv1alpha1.ResourceSelector) {
+	if len(selectors) == 0 {
+		c.claimAll(groupResource)
+		return
+	}
+
+	claim, exists := c[groupResource]
+	if !exists {
+		claim = &resourceClaim{selectors: sets.New[kcpdevv1alpha1.ResourceSelector]()}
+		c[groupResource] = claim
+	}
+
+	if !claim.all {
+		claim.selectors.Insert(selectors...)
+	}
+}
+
+// sortedSelectors returns the claim's selectors in a stable order.
+func (c *resourceClaim) sortedSelectors() []kcpdevv1alpha1.ResourceSelector {
+	result := c.selectors.UnsortedList()
+	sortSelectors(result)
+
+	return result
+}
+
+func sortSelectors(selectors []kcpdevv1alpha1.ResourceSelector) {
+	slices.SortFunc(selectors, func(a, b kcpdevv1alpha1.ResourceSelector) int {
+		if a.Namespace != b.Namespace {
+			return cmp.Compare(a.Namespace, b.Namespace)
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// relatedResourceSelectors returns the selectors that match all objects of the
+// related resource in kcp. Selectors can only be determined if the object's name
+// is given as a static template; the namespace is only included if it is static
+// as well. For all other related resources, nil is returned.
+func relatedResourceSelectors(rr *syncagentv1alpha1.RelatedResourceSpec) []kcpdevv1alpha1.ResourceSelector {
+	name := staticValue(&rr.Object.RelatedResourceObjectSpec)
+	if name == "" {
+		return nil
+	}
+
+	selector := kcpdevv1alpha1.ResourceSelector{
+		Name: name,
+	}
+
+	if rr.Object.Namespace != nil {
+		selector.Namespace = staticValue(rr.Object.Namespace)
+	}
+
+	return []kcpdevv1alpha1.ResourceSelector{selector}
+}
+
+// staticValue returns the value of a template that does not contain any template
+// actions, or an empty string if the value depends on the synced objects.
+func staticValue(spec *syncagentv1alpha1.RelatedResourceObjectSpec) string {
+	if spec.Template == nil || strings.Contains(spec.Template.Template, "{{") {
+		return ""
+	}
+
+	return strings.TrimSpace(spec.Template.Template)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func templateSpec(template string) *syncagentv1alpha1.RelatedResourceObjectSpec {
+	return &syncagentv1alpha1.RelatedResourceObjectSpec{
+		Template: &syncagentv1alpha1.TemplateExpression{Template: template},
+	}
+}
+
+func TestRelatedResourceSelectors(t *testing.T) {
+	testcases := []struct {
+		name     string
+		object   syncagentv1alpha1.RelatedResourceObject
+		expected []kcpdevv1alpha1.ResourceSelector
+	}{
+		{
+			name: "static name in the same namespace",
+			object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: *templateSpec("credentials"),
+			},
+			expected: []kcpdevv1alpha1.ResourceSelector{{Name: "credentials"}},
+		},
+		{
+			name: "static name and namespace",
+			object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: *templateSpec(" credentials "),
+				Namespace:                 templateSpec("kube-system"),
+			},
+			expected: []kcpdevv1alpha1.ResourceSelector{{Name: "credentials", Namespace: "kube-system"}},
+		},
+		{
+			name: "static name in a dynamic namespace",
+			object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: *templateSpec("credentials"),
+				Namespace:                 templateSpec("{{ .Object.metadata.namespace }}"),
+			},
+			expected: []kcpdevv1alpha1.ResourceSelector{{Name: "credentials"}},
+		},
+		{
+			name: "dynamic name cannot be scoped",
+			object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: *templateSpec("{{ .Object.metadata.name }}-credentials"),
+			},
+		},
+		{
+			name: "references cannot be scoped",
+			object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+					Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			rr := &syncagentv1alpha1.RelatedResourceSpec{Object: testcase.object}
+
+			if changes := diff.ObjectDiff(testcase.expected, relatedResourceSelectors(rr)); changes != "" {
+				t.Errorf("Selectors do not match expectation:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestPermissionClaims(t *testing.T) {
+	credentials := kcpdevv1alpha1.ResourceSelector{Name: "credentials"}
+	config := kcpdevv1alpha1.ResourceSelector{Name: "config", Namespace: "kube-system"}
+
+	type claim struct {
+		selectors []kcpdevv1alpha1.ResourceSelector
+		all       bool
+	}
+
+	testcases := []struct {
+		name              string
+		claims            []claim
+		expectedAll       bool
+		expectedSelectors []kcpdevv1alpha1.ResourceSelector
+	}{
+		{
+			name:              "selectors are collected and sorted",
+			claims:            []claim{{selectors: []kcpdevv1alpha1.ResourceSelector{credentials}}, {selectors: []kcpdevv1alpha1.ResourceSelector{config, credentials}}},
+			expectedSelectors: []kcpdevv1alpha1.ResourceSelector{credentials, config},
+		},
+		{
+			name:        "claims without selectors claim everything",
+			claims:      []claim{{selectors: []kcpdevv1alpha1.ResourceSelector{credentials}}, {}},
+			expectedAll: true,
+		},
+		{
+			name:        "selectors do not narrow down a full claim",
+			claims:      []claim{{all: true}, {selectors: []kcpdevv1alpha1.ResourceSelector{credentials}}},
+			expectedAll: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			claims := permissionClaims{}
+			for _, c := range testcase.claims {
+				if c.all {
+					claims.claimAll("secrets")
+				} else {
+					claims.claimSelectors("secrets", c.selectors)
+				}
+			}
+
+			result := claims["secrets"]
+			if result.all != testcase.expectedAll {
+				t.Fatalf("Expected all=%v, but got %v.", testcase.expectedAll, result.all)
+			}
+
+			if !result.all {
+				if changes := diff.ObjectDiff(testcase.expectedSelectors, result.sortedSelectors()); changes != "" {
+					t.Errorf("Selectors do not match expectation:\n%s", changes)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIExportReconcilerMergesScopedClaims(t *testing.T) {
+	credentials := kcpdevv1alpha1.ResourceSelector{Name: "credentials"}
+	config := kcpdevv1alpha1.ResourceSelector{Name: "config", Namespace: "kube-system"}
+
+	secrets := kcpdevv1alpha1.GroupResource{Resource: "secrets"}
+
+	testcases := []struct {
+		name     string
+		existing []kcpdevv1alpha1.PermissionClaim
+		claim    *resourceClaim
+		expected []kcpdevv1alpha1.PermissionClaim
+	}{
+		{
+			name:     "new scoped claim is added",
+			claim:    &resourceClaim{selectors: sets.New(credentials)},
+			expected: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, ResourceSelector: []kcpdevv1alpha1.ResourceSelector{credentials}}},
+		},
+		{
+			name:     "existing scoped claim is extended",
+			existing: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, ResourceSelector: []kcpdevv1alpha1.ResourceSelector{config}}},
+			claim:    &resourceClaim{selectors: sets.New(credentials)},
+			expected: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, ResourceSelector: []kcpdevv1alpha1.ResourceSelector{credentials, config}}},
+		},
+		{
+			name:     "existing scoped claim is widened",
+			existing: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, ResourceSelector: []kcpdevv1alpha1.ResourceSelector{config}}},
+			claim:    &resourceClaim{all: true},
+			expected: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, All: true}},
+		},
+		{
+			name:     "existing full claim is not narrowed",
+			existing: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, All: true}},
+			claim:    &resourceClaim{selectors: sets.New(credentials)},
+			expected: []kcpdevv1alpha1.PermissionClaim{{GroupResource: secrets, All: true}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := &Reconciler{}
+			claims := permissionClaims{"secrets": testcase.claim}
+
+			existing := &kcpdevv1alpha1.APIExport{}
+			existing.Spec.PermissionClaims = testcase.existing
+
+			_, reconciler := r.createAPIExportReconciler(sets.New[string](), sets.New[string](), sets.New[string](), claims, "textor-the-doctor", "my-export")()
+
+			updated, err := reconciler(existing)
+			if err != nil {
+				t.Fatalf("Failed to reconcile APIExport: %v", err)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, updated.Spec.PermissionClaims); changes != "" {
+				t.Errorf("Permission claims do not match expectation:\n%s", changes)
+			}
+		})
+	}
+}
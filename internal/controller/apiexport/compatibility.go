@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// incompatibleSchemas compares the schemas of the given PublishedResources that
+// are not yet part of the APIExport with the schemas that are already published
+// for the same resources. It returns a message for every PublishedResource whose
+// schema would break existing consumers, keyed by the PublishedResource's name.
+func (r *Reconciler) incompatibleSchemas(ctx context.Context, pubResources []syncagentv1alpha1.PublishedResource) (map[string]string, error) {
+	apiExport := &kcpdevv1alpha1.APIExport{}
+	if err := r.kcpClient.Get(ctx, types.NamespacedName{Name: r.apiExportName}, apiExport); err != nil {
+		return nil, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	published := sets.New(apiExport.Spec.LatestResourceSchemas...)
+	result := map[string]string{}
+
+	for _, pubResource := range pubResources {
+		schemaName := pubResource.Status.ResourceSchemaName
+		if published.Has(schemaName) || pubResource.Annotations[syncagentv1alpha1.AllowIncompatibleSchemaAnnotation] == "true" {
+			continue
+		}
+
+		for _, existingName := range sets.List(published) {
+			if schemaResource(existingName) != schemaResource(schemaName) {
+				continue
+			}
+
+			problems, err := r.compareSchemas(ctx, existingName, schemaName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare APIResourceSchema %s with %s: %w", schemaName, existingName, err)
+			}
+
+			if len(problems) > 0 {
+				result[pubResource.Name] = fmt.Sprintf("APIResourceSchema %s is incompatible with the published APIResourceSchema %s: %s.", schemaName, existingName, strings.Join(problems, "; "))
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) compareSchemas(ctx context.Context, existingName, updatedName string) ([]string, error) {
+	existing := &kcpdevv1alpha1.APIResourceSchema{}
+	if err := r.kcpClient.Get(ctx, types.NamespacedName{Name: existingName}, existing); err != nil {
+		// schemas that have been deleted cannot be broken anymore
+		return nil, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	updated := &kcpdevv1alpha1.APIResourceSchema{}
+	if err := r.kcpClient.Get(ctx, types.NamespacedName{Name: updatedName}, updated); err != nil {
+		return nil, err
+	}
+
+	return projection.SchemaIncompatibilities(&existing.Spec, &updated.Spec)
+}
@@ -19,18 +19,25 @@ package apiexport
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"go.uber.org/zap"
 
 	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
 	predicateutil "github.com/kcp-dev/api-syncagent/internal/controllerutil/predicate"
+	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
 	"github.com/kcp-dev/api-syncagent/internal/resources/reconciling"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
 
 	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 
-	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
@@ -47,17 +54,29 @@ import (
 
 const (
 	ControllerName = "syncagent-apiexport"
+
+	// mapperCacheTTL is how long successfully resolved related resource kinds are cached.
+	mapperCacheTTL = 1 * time.Hour
+	// mapperNegativeCacheTTL is how long unknown related resource kinds are remembered
+	// before discovery is consulted again.
+	mapperNegativeCacheTTL = 1 * time.Minute
 )
 
 type Reconciler struct {
-	localClient   ctrlruntimeclient.Client
-	kcpClient     ctrlruntimeclient.Client
-	log           *zap.SugaredLogger
-	recorder      record.EventRecorder
-	lcName        logicalcluster.Name
-	apiExportName string
-	agentName     string
-	prFilter      labels.Selector
+	localClient         ctrlruntimeclient.Client
+	kcpClient           ctrlruntimeclient.Client
+	kcpReader           ctrlruntimeclient.Reader
+	mapper              *discovery.CachedResourceMapper
+	log                 *zap.SugaredLogger
+	recorder            record.EventRecorder
+	kcpRecorder         record.EventRecorder
+	lcName              logicalcluster.Name
+	apiExportName       string
+	agentName           string
+	prFilter            *controllerutil.PublishedResourceFilter
+	schemaGCGracePeriod time.Duration
+	scopedClaims        bool
+	claimNamespaces     bool
 }
 
 // Add creates a new controller and adds it to the given manager.
@@ -68,17 +87,28 @@ func Add(
 	log *zap.SugaredLogger,
 	apiExportName string,
 	agentName string,
-	prFilter labels.Selector,
+	prFilter *controllerutil.PublishedResourceFilter,
+	schemaGCGracePeriod time.Duration,
+	scopedClaims bool,
+	claimNamespaces bool,
 ) error {
 	reconciler := &Reconciler{
-		localClient:   mgr.GetClient(),
-		kcpClient:     kcpCluster.GetClient(),
-		lcName:        lcName,
-		log:           log.Named(ControllerName),
-		recorder:      mgr.GetEventRecorderFor(ControllerName),
-		apiExportName: apiExportName,
-		agentName:     agentName,
-		prFilter:      prFilter,
+		localClient: mgr.GetClient(),
+		kcpClient:   kcpCluster.GetClient(),
+		// listing all APIResourceSchemas is only required for the garbage collection,
+		// so do not maintain a cache for them
+		kcpReader:           kcpCluster.GetAPIReader(),
+		mapper:              discovery.NewCachedResourceMapper(kcpCluster.GetRESTMapper(), mapperCacheTTL, mapperNegativeCacheTTL),
+		lcName:              lcName,
+		log:                 log.Named(ControllerName),
+		recorder:            mgr.GetEventRecorderFor(ControllerName),
+		kcpRecorder:         kcpCluster.GetEventRecorderFor(ControllerName),
+		apiExportName:       apiExportName,
+		agentName:           agentName,
+		prFilter:            prFilter,
+		schemaGCGracePeriod: schemaGCGracePeriod,
+		scopedClaims:        scopedClaims,
+		claimNamespaces:     claimNamespaces,
 	}
 
 	hasARS := predicate.NewPredicateFuncs(func(object ctrlruntimeclient.Object) bool {
@@ -101,81 +131,228 @@ func Add(
 		// so there is no need here to add an additional filter.
 		WatchesRawSource(source.Kind(kcpCluster.GetCache(), &kcpdevv1alpha1.APIExport{}, controllerutil.EnqueueConst[*kcpdevv1alpha1.APIExport]("dummy"))).
 		// Watch for changes to PublishedResources on the local service cluster
-		Watches(&syncagentv1alpha1.PublishedResource{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy"), builder.WithPredicates(predicateutil.ByLabels(prFilter), hasARS)).
+		Watches(&syncagentv1alpha1.PublishedResource{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy"), builder.WithPredicates(predicateutil.Factory(prFilter.Matches), hasARS)).
+		// Watch for changes to profiles, as they can define related resources
+		Watches(&syncagentv1alpha1.PublishedResourceProfile{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy")).
 		Build(reconciler)
 	return err
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	r.log.Debug("Processing")
-	return reconcile.Result{}, r.reconcile(ctx)
+
+	requeueAfter, err := r.reconcile(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *Reconciler) reconcile(ctx context.Context) error {
+// reconcile updates the APIExport and returns after what time it should be
+// reconciled again, e.g. because some related resource kinds could not be resolved
+// or orphaned schemas are due to be removed.
+func (r *Reconciler) reconcile(ctx context.Context) (time.Duration, error) {
 	// find all PublishedResources
 	pubResources := &syncagentv1alpha1.PublishedResourceList{}
-	if err := r.localClient.List(ctx, pubResources, &ctrlruntimeclient.ListOptions{
-		LabelSelector: r.prFilter,
-	}); err != nil {
-		return fmt.Errorf("failed to list PublishedResources: %w", err)
+	if err := r.prFilter.List(ctx, r.localClient, pubResources); err != nil {
+		return 0, fmt.Errorf("failed to list PublishedResources: %w", err)
 	}
 
 	// filter out those PRs that have not yet been processed into an ARS
 	filteredPubResources := []syncagentv1alpha1.PublishedResource{}
+	originalPubResources := map[string]*syncagentv1alpha1.PublishedResource{}
+	unavailableProfiles := sets.New[string]()
 	for i, pubResource := range pubResources.Items {
-		if pubResource.Status.ResourceSchemaName != "" {
-			filteredPubResources = append(filteredPubResources, pubResources.Items[i])
+		if pubResource.Status.ResourceSchemaName == "" {
+			continue
+		}
+
+		// once a resource has been unpublished and no objects remain, its schema is
+		// removed from the APIExport
+		if pubResource.Spec.Unpublish && pubResource.Status.Unpublish != nil && pubResource.Status.Unpublish.RemainingObjects == 0 {
+			continue
 		}
+
+		// apply the profile, as it might contribute filters and related resources
+		// a broken profile must not block the APIExport for all other resources
+		effective, _, err := profile.Resolve(ctx, r.localClient, &pubResources.Items[i])
+		if err != nil {
+			r.log.Warnw("Failed to apply profile", "pr", pubResource.Name, zap.Error(err))
+			r.recorder.Event(&pubResources.Items[i], corev1.EventTypeWarning, "ProfileUnavailable", err.Error())
+
+			original := pubResources.Items[i].DeepCopy()
+			controllerutil.SetPublishedResourceCondition(original, metav1.Condition{
+				Type:    syncagentv1alpha1.ConditionExportUpdated,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ProfileUnavailable",
+				Message: err.Error(),
+			})
+
+			originalPubResources[pubResource.Name] = original
+			unavailableProfiles.Insert(pubResource.Name)
+
+			continue
+		}
+
+		filteredPubResources = append(filteredPubResources, *effective)
+		// the status is updated on a copy, so changes can be detected later
+		originalPubResources[pubResource.Name] = pubResources.Items[i].DeepCopy()
 	}
 
+	wsCtx := kontext.WithCluster(ctx, r.lcName)
+
+	// new schemas must not break consumers of the schemas that are already published
+	incompatible, err := r.incompatibleSchemas(wsCtx, filteredPubResources)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check schema compatibility: %w", err)
+	}
+
+	unresolved := false
+
 	// for each PR, we note down the created ARS and also the GVKs of related resources
 	arsList := sets.New[string]()
-	claimedResources := sets.New[string]()
-
-	// PublishedResources use kinds, but the PermissionClaims use resource names (plural),
-	// so we must translate accordingly
-	mapper := r.kcpClient.RESTMapper()
+	replacingSchemas := sets.New[string]()
+	claimedResources := permissionClaims{}
 
 	for _, pubResource := range filteredPubResources {
+		if message, ok := incompatible[pubResource.Name]; ok {
+			r.log.Warnw("Not publishing incompatible APIResourceSchema", "pr", pubResource.Name, "reason", message)
+
+			controllerutil.SetPublishedResourceCondition(originalPubResources[pubResource.Name], metav1.Condition{
+				Type:    syncagentv1alpha1.ConditionExportUpdated,
+				Status:  metav1.ConditionFalse,
+				Reason:  "IncompatibleSchema",
+				Message: message,
+			})
+
+			continue
+		}
+
 		arsList.Insert(pubResource.Status.ResourceSchemaName)
 
+		// schemas of resources that recreate their schemas replace older versions
+		if pubResource.Spec.SchemaUpdatePolicy == syncagentv1alpha1.SchemaUpdateRecreate {
+			replacingSchemas.Insert(pubResource.Status.ResourceSchemaName)
+		}
+
 		// to evaluate the namespace filter, the agent needs to fetch the namespace
-		if filter := pubResource.Spec.Filter; filter != nil && filter.Namespace != nil {
-			claimedResources.Insert("namespaces")
+		if filter := pubResource.Spec.Filter; filter != nil && (filter.Namespace != nil || filter.Expression != "") {
+			claimedResources.claimAll("namespaces")
+		}
+
+		// recording Events in kcp requires access to them
+		if pubResource.Spec.EnableRemoteEvents {
+			claimedResources.claimAll("events")
+		}
+
+		// likewise for propagating namespace labels and metadata
+		if len(pubResource.Spec.NamespaceLabels) > 0 || pubResource.Spec.NamespaceSync != nil {
+			claimedResources.claimAll("namespaces")
 		}
 
-		for _, rr := range pubResource.Spec.Related {
-			resource, err := mapper.ResourceFor(schema.GroupVersionResource{
-				Resource: rr.Kind,
+		// PublishedResources use kinds, but the PermissionClaims use resource names (plural),
+		// so we must translate accordingly; unknown kinds must not prevent the APIExport
+		// from being updated for all other resources
+		unknownKinds := []string{}
+		for _, rr := range flattenRelatedResources(pubResource.Spec.Related) {
+			gvk := projection.RelatedResourceGVK(&rr)
+
+			resource, err := r.mapper.ResourceFor(schema.GroupVersionResource{
+				Group:    gvk.Group,
+				Version:  gvk.Version,
+				Resource: gvk.Kind,
 			})
 			if err != nil {
-				return fmt.Errorf("unknown related resource kind %q: %w", rr.Kind, err)
+				r.log.Warnw("Failed to resolve related resource kind", "pr", pubResource.Name, "gvk", gvk, zap.Error(err))
+				unknownKinds = append(unknownKinds, gvk.GroupKind().String())
+				continue
 			}
 
-			claimedResources.Insert(resource.Resource)
+			// if configured, restrict the claim to the related objects, if they can be
+			// determined without knowing the synced objects
+			if r.scopedClaims {
+				claimedResources.claimSelectors(resource.GroupResource().String(), relatedResourceSelectors(&rr))
+			} else {
+				claimedResources.claimAll(resource.GroupResource().String())
+			}
 		}
+
+		unresolved = unresolved || len(unknownKinds) > 0
+
+		setRelatedResourcesCondition(originalPubResources[pubResource.Name], unknownKinds)
 	}
 
-	// Related resources (Secrets, ConfigMaps) are namespaced and so the Sync Agent will
+	// Related resources (like Secrets or ConfigMaps) are usually namespaced and so the Sync Agent will
 	// always need to be able to see and manage namespaces.
-	if claimedResources.Len() > 0 {
-		claimedResources.Insert("namespaces")
+	if len(claimedResources) > 0 {
+		claimedResources.claimAll("namespaces")
+	}
+
+	// the namespace cleanup needs to check whether namespaces still exist in kcp
+	if r.claimNamespaces && len(filteredPubResources) > 0 {
+		claimedResources.claimAll("namespaces")
 	}
 
-	if arsList.Len() == 0 {
+	var requeueAfter time.Duration
+
+	// unknown related resource kinds might become known later on
+	if unresolved {
+		requeueAfter = mapperNegativeCacheTTL
+	}
+
+	// find schemas whose PublishedResources have been deleted a while ago
+	prunableSchemas, gcRequeueAfter, err := r.collectSchemaGarbage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect orphaned APIResourceSchemas: %w", err)
+	}
+
+	if gcRequeueAfter > 0 && (requeueAfter == 0 || gcRequeueAfter < requeueAfter) {
+		requeueAfter = gcRequeueAfter
+	}
+
+	// never remove schemas that are still in use
+	prunableSchemas = prunableSchemas.Difference(arsList)
+
+	if arsList.Len() == 0 && prunableSchemas.Len() == 0 {
 		r.log.Debug("No ready PublishedResources available.")
-		return nil
+		return requeueAfter, r.updateStatuses(ctx, pubResources.Items, originalPubResources)
 	}
 
 	// reconcile an APIExport in kcp
 	factories := []reconciling.NamedAPIExportReconcilerFactory{
-		r.createAPIExportReconciler(arsList, claimedResources, r.agentName, r.apiExportName),
+		r.createAPIExportReconciler(arsList, prunableSchemas, replacingSchemas, claimedResources, r.agentName, r.apiExportName),
 	}
 
-	wsCtx := kontext.WithCluster(ctx, r.lcName)
+	exportErr := reconciling.ReconcileAPIExports(wsCtx, factories, "", r.kcpClient)
+
+	condition := metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionExportUpdated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Updated",
+		Message: fmt.Sprintf("APIResourceSchema is part of APIExport %s.", r.apiExportName),
+	}
+
+	if exportErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UpdateFailed"
+		condition.Message = exportErr.Error()
+	}
 
-	if err := reconciling.ReconcileAPIExports(wsCtx, factories, "", r.kcpClient); err != nil {
-		return fmt.Errorf("failed to reconcile APIExport: %w", err)
+	for _, pubResource := range originalPubResources {
+		if _, ok := incompatible[pubResource.Name]; ok || unavailableProfiles.Has(pubResource.Name) {
+			continue
+		}
+
+		controllerutil.SetPublishedResourceCondition(pubResource, condition)
+	}
+
+	if err := r.updateStatuses(ctx, pubResources.Items, originalPubResources); err != nil {
+		return 0, err
+	}
+
+	if exportErr != nil {
+		return 0, fmt.Errorf("failed to reconcile APIExport: %w", exportErr)
 	}
 
 	// try to get the virtual workspace URL of the APIExport;
@@ -206,5 +383,56 @@ func (r *Reconciler) reconcile(ctx context.Context) error {
 	// 	return fmt.Errorf("failed to wait for virtual workspace to be ready: %w", err)
 	// }
 
+	return requeueAfter, nil
+}
+
+// setRelatedResourcesCondition records which related resource kinds could not be resolved.
+func setRelatedResourcesCondition(pubRes *syncagentv1alpha1.PublishedResource, unknownKinds []string) {
+	// do not clutter the status of PRs without related resources
+	if len(pubRes.Spec.Related) == 0 && meta.FindStatusCondition(pubRes.Status.Conditions, syncagentv1alpha1.ConditionRelatedResourcesResolved) == nil {
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionRelatedResourcesResolved,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Resolved",
+		Message: "All related resource kinds are known.",
+	}
+
+	if len(unknownKinds) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "UnknownKind"
+		condition.Message = fmt.Sprintf("Unknown related resource kinds: %s", strings.Join(unknownKinds, ", "))
+	}
+
+	controllerutil.SetPublishedResourceCondition(pubRes, condition)
+}
+
+// updateStatuses persists the status changes made to the given PublishedResources.
+func (r *Reconciler) updateStatuses(ctx context.Context, originals []syncagentv1alpha1.PublishedResource, updated map[string]*syncagentv1alpha1.PublishedResource) error {
+	for _, original := range originals {
+		pubRes, ok := updated[original.Name]
+		if !ok {
+			continue
+		}
+
+		if err := controllerutil.PatchPublishedResourceStatus(ctx, r.localClient, &original, pubRes); err != nil {
+			return fmt.Errorf("failed to update status of PublishedResource %s: %w", original.Name, err)
+		}
+	}
+
 	return nil
 }
+
+// flattenRelatedResources returns all related resources, including nested ones.
+func flattenRelatedResources(related []syncagentv1alpha1.RelatedResourceSpec) []syncagentv1alpha1.RelatedResourceSpec {
+	result := []syncagentv1alpha1.RelatedResourceSpec{}
+
+	for _, rr := range related {
+		result = append(result, rr)
+		result = append(result, flattenRelatedResources(rr.Related)...)
+	}
+
+	return result
+}
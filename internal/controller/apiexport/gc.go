@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// collectSchemaGarbage finds all APIResourceSchemas created by this agent whose
+// PublishedResource has been deleted for longer than the grace period. Schemas that
+// become orphaned are marked with the current time, so that the grace period survives
+// agent restarts. The returned duration indicates when the next schema is due.
+func (r *Reconciler) collectSchemaGarbage(ctx context.Context) (sets.Set[string], time.Duration, error) {
+	prunable := sets.New[string]()
+
+	if r.schemaGCGracePeriod <= 0 {
+		return prunable, 0, nil
+	}
+
+	// Deliberately ignore the PublishedResource filter here, a resource that is
+	// merely not handled by this agent anymore has not been deleted.
+	pubResources := &syncagentv1alpha1.PublishedResourceList{}
+	if err := r.localClient.List(ctx, pubResources); err != nil {
+		return nil, 0, fmt.Errorf("failed to list PublishedResources: %w", err)
+	}
+
+	existing := sets.New[string]()
+	for _, pubResource := range pubResources.Items {
+		existing.Insert(pubResource.Name)
+	}
+
+	wsCtx := kontext.WithCluster(ctx, r.lcName)
+
+	apiExport := &kcpdevv1alpha1.APIExport{}
+	if err := r.kcpClient.Get(wsCtx, types.NamespacedName{Name: r.apiExportName}, apiExport); err != nil {
+		return nil, 0, fmt.Errorf("failed to get APIExport: %w", err)
+	}
+
+	exported := sets.New(apiExport.Spec.LatestResourceSchemas...)
+
+	schemas := &kcpdevv1alpha1.APIResourceSchemaList{}
+	if err := r.kcpReader.List(wsCtx, schemas); err != nil {
+		return nil, 0, fmt.Errorf("failed to list APIResourceSchemas: %w", err)
+	}
+
+	now := time.Now()
+	var requeueAfter time.Duration
+
+	for i, ars := range schemas.Items {
+		annotations := ars.GetAnnotations()
+
+		if annotations[syncagentv1alpha1.AgentNameAnnotation] != r.agentName || annotations[syncagentv1alpha1.SchemaGarbageCollectionAnnotation] != "true" {
+			continue
+		}
+
+		prName := annotations[syncagentv1alpha1.PublishedResourceAnnotation]
+		if prName == "" {
+			continue
+		}
+
+		orphanedSince, isOrphaned := annotations[syncagentv1alpha1.OrphanedSinceAnnotation]
+
+		// the PublishedResource (re-)appeared, the schema is in use again
+		if existing.Has(prName) {
+			if isOrphaned {
+				if err := r.setOrphanedSince(wsCtx, &schemas.Items[i], ""); err != nil {
+					return nil, 0, fmt.Errorf("failed to unmark APIResourceSchema %s: %w", ars.Name, err)
+				}
+			}
+
+			continue
+		}
+
+		since, err := time.Parse(time.RFC3339, orphanedSince)
+		if !isOrphaned || err != nil {
+			if err := r.setOrphanedSince(wsCtx, &schemas.Items[i], now.Format(time.RFC3339)); err != nil {
+				return nil, 0, fmt.Errorf("failed to mark APIResourceSchema %s as orphaned: %w", ars.Name, err)
+			}
+
+			r.kcpRecorder.Eventf(&schemas.Items[i], corev1.EventTypeWarning, "SchemaOrphaned", "PublishedResource %s does not exist anymore, schema will be removed from the APIExport after %v.", prName, r.schemaGCGracePeriod)
+
+			since = now
+		}
+
+		remaining := since.Add(r.schemaGCGracePeriod).Sub(now)
+		if remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+
+			continue
+		}
+
+		if !exported.Has(ars.Name) {
+			continue
+		}
+
+		r.kcpRecorder.Eventf(&schemas.Items[i], corev1.EventTypeNormal, "SchemaPruned", "Removing schema from APIExport %s, as PublishedResource %s has been deleted for more than %v.", r.apiExportName, prName, r.schemaGCGracePeriod)
+		prunable.Insert(ars.Name)
+	}
+
+	return prunable, requeueAfter, nil
+}
+
+func (r *Reconciler) setOrphanedSince(ctx context.Context, ars *kcpdevv1alpha1.APIResourceSchema, value string) error {
+	oldARS := ars.DeepCopy()
+
+	if value == "" {
+		delete(ars.Annotations, syncagentv1alpha1.OrphanedSinceAnnotation)
+	} else {
+		ars.Annotations[syncagentv1alpha1.OrphanedSinceAnnotation] = value
+	}
+
+	return r.kcpClient.Patch(ctx, ars, ctrlruntimeclient.MergeFrom(oldARS))
+}
@@ -19,19 +19,21 @@ package apiexport
 import (
 	"cmp"
 	"slices"
+	"strings"
 
 	"github.com/kcp-dev/api-syncagent/internal/resources/reconciling"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
 
 	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // createAPIExportReconciler creates the reconciler for the APIExport.
 // WARNING: The APIExport in this is NOT created by the Sync Agent, it's created
 // by a controller in kcp. Make sure you don't create a reconciling conflict!
-func (r *Reconciler) createAPIExportReconciler(availableResourceSchemas sets.Set[string], claimedResourceKinds sets.Set[string], agentName string, apiExportName string) reconciling.NamedAPIExportReconcilerFactory {
+func (r *Reconciler) createAPIExportReconciler(availableResourceSchemas sets.Set[string], prunableResourceSchemas sets.Set[string], replacingResourceSchemas sets.Set[string], claimedResources permissionClaims, agentName string, apiExportName string) reconciling.NamedAPIExportReconcilerFactory {
 	return func() (string, reconciling.APIExportReconciler) {
 		return apiExportName, func(existing *kcpdevv1alpha1.APIExport) (*kcpdevv1alpha1.APIExport, error) {
 			known := sets.New(existing.Spec.LatestResourceSchemas...)
@@ -39,10 +41,26 @@ func (r *Reconciler) createAPIExportReconciler(availableResourceSchemas sets.Set
 			if existing.Annotations == nil {
 				existing.Annotations = map[string]string{}
 			}
-			existing.Annotations[syncagentv1alpha1.AgentNameAnnotation] = agentName
+			// all shards of an agent share the APIExport; to not have them fight over
+			// the annotation (for example while the number of shards is changed), it
+			// is owned by the primary shard and only filled in by the others
+			if _, ok := existing.Annotations[syncagentv1alpha1.AgentNameAnnotation]; !ok || r.prFilter.PrimaryShard() {
+				existing.Annotations[syncagentv1alpha1.AgentNameAnnotation] = agentName
+			}
+
+			// we only ever add new schemas, unless orphaned schemas are garbage collected
+			result := known.Union(availableResourceSchemas).Difference(prunableResourceSchemas)
+
+			// recreated schemas replace older schemas for the same resource within the
+			// same update, so that kcp never sees two schemas for one resource
+			for _, schemaName := range sets.List(replacingResourceSchemas) {
+				for _, other := range sets.List(result) {
+					if other != schemaName && schemaResource(other) == schemaResource(schemaName) {
+						result.Delete(other)
+					}
+				}
+			}
 
-			// we only ever add new schemas
-			result := known.Union(availableResourceSchemas)
 			existing.Spec.LatestResourceSchemas = sets.List(result)
 
 			// To allow admins to configure additional permission claims, sometimes
@@ -50,24 +68,59 @@ func (r *Reconciler) createAPIExportReconciler(availableResourceSchemas sets.Set
 			// only ensure the ones originating from the published resources;
 			// step 1 is to collect all existing claims with the same properties
 			// as ours.
+			// Claims for resources provided by other APIExports require an identity hash,
+			// which the agent cannot know, so admins can add such claims manually and
+			// they will be considered as well.
 			existingClaims := sets.New[string]()
 			for _, claim := range existing.Spec.PermissionClaims {
-				if claim.All && claim.Group == "" && len(claim.ResourceSelector) == 0 {
-					existingClaims.Insert(claim.Resource)
+				if claim.All && len(claim.ResourceSelector) == 0 {
+					existingClaims.Insert(schema.GroupResource{Group: claim.Group, Resource: claim.Resource}.String())
 				}
 			}
 
-			missingClaims := claimedResourceKinds.Difference(existingClaims)
+			// add our missing claims; resources that are already claimed entirely
+			// do not need any additional scoped claims
+			for _, claimed := range sets.List(sets.KeySet(claimedResources)) {
+				if existingClaims.Has(claimed) {
+					continue
+				}
+
+				gr := schema.ParseGroupResource(claimed)
+				claim := claimedResources[claimed]
+
+				// there can only be one claim per resource, so existing scoped claims are
+				// widened or extended instead of adding another claim
+				if idx := slices.IndexFunc(existing.Spec.PermissionClaims, func(c kcpdevv1alpha1.PermissionClaim) bool {
+					return c.Group == gr.Group && c.Resource == gr.Resource && c.IdentityHash == "" && !c.All
+				}); idx >= 0 {
+					scoped := &existing.Spec.PermissionClaims[idx]
+
+					if claim.all {
+						scoped.All = true
+						scoped.ResourceSelector = nil
+					} else {
+						selectors := sets.New(scoped.ResourceSelector...).Union(claim.selectors).UnsortedList()
+						sortSelectors(selectors)
 
-			// add our missing claims
-			for _, claimed := range sets.List(missingClaims) {
-				existing.Spec.PermissionClaims = append(existing.Spec.PermissionClaims, kcpdevv1alpha1.PermissionClaim{
+						scoped.ResourceSelector = selectors
+					}
+
+					continue
+				}
+
+				newClaim := kcpdevv1alpha1.PermissionClaim{
 					GroupResource: kcpdevv1alpha1.GroupResource{
-						Group:    "",
-						Resource: claimed,
+						Group:    gr.Group,
+						Resource: gr.Resource,
 					},
-					All: true,
-				})
+					All: claim.all,
+				}
+
+				if !claim.all {
+					newClaim.ResourceSelector = claim.sortedSelectors()
+				}
+
+				existing.Spec.PermissionClaims = append(existing.Spec.PermissionClaims, newClaim)
 			}
 
 			// prevent reconcile loops by ensuring a stable order
@@ -87,3 +140,10 @@ func (r *Reconciler) createAPIExportReconciler(availableResourceSchemas sets.Set
 		}
 	}
 }
+
+// schemaResource returns the "<plural>.<group>" part of an APIResourceSchema name,
+// which is prefixed with a checksum by the Sync Agent.
+func schemaResource(schemaName string) string {
+	_, resource, _ := strings.Cut(schemaName, ".")
+	return resource
+}
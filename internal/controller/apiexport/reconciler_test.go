@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiexport
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// TestAPIExportReconcilerKeepsForeignSettings ensures that the agent modifies
+// APIExports in place, which is required to not lose settings that kcp keeps in
+// annotations when converting newer apis.kcp.io versions into v1alpha1.
+func TestAPIExportReconcilerKeepsForeignSettings(t *testing.T) {
+	const conversionAnnotation = "example.kcp.io/v1alpha2-settings"
+
+	adminClaim := kcpdevv1alpha1.PermissionClaim{
+		GroupResource: kcpdevv1alpha1.GroupResource{
+			Group:    "other.example.com",
+			Resource: "widgets",
+		},
+		All:          true,
+		IdentityHash: "abc123",
+	}
+
+	testcases := []struct {
+		name     string
+		existing *kcpdevv1alpha1.APIExport
+		schemas  sets.Set[string]
+		claims   permissionClaims
+		expected *kcpdevv1alpha1.APIExport
+	}{
+		{
+			name: "annotations written by kcp's conversion are kept",
+			existing: &kcpdevv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-export",
+					Annotations: map[string]string{
+						conversionAnnotation: `{"verbs":["get","list"]}`,
+					},
+				},
+			},
+			schemas: sets.New("v1.things.example.com"),
+			claims:  permissionClaims{},
+			expected: &kcpdevv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-export",
+					Annotations: map[string]string{
+						conversionAnnotation:                  `{"verbs":["get","list"]}`,
+						syncagentv1alpha1.AgentNameAnnotation: "textor-the-doctor",
+					},
+				},
+				Spec: kcpdevv1alpha1.APIExportSpec{
+					LatestResourceSchemas: []string{"v1.things.example.com"},
+				},
+			},
+		},
+		{
+			name: "claims configured by admins are kept next to the agent's claims",
+			existing: &kcpdevv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-export",
+					Annotations: map[string]string{
+						syncagentv1alpha1.AgentNameAnnotation: "textor-the-doctor",
+					},
+				},
+				Spec: kcpdevv1alpha1.APIExportSpec{
+					LatestResourceSchemas: []string{"v1.things.example.com"},
+					PermissionClaims:      []kcpdevv1alpha1.PermissionClaim{adminClaim},
+				},
+			},
+			schemas: sets.New("v2.things.example.com"),
+			claims: permissionClaims{
+				"secrets": &resourceClaim{all: true},
+			},
+			expected: &kcpdevv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-export",
+					Annotations: map[string]string{
+						syncagentv1alpha1.AgentNameAnnotation: "textor-the-doctor",
+					},
+				},
+				Spec: kcpdevv1alpha1.APIExportSpec{
+					LatestResourceSchemas: []string{"v1.things.example.com", "v2.things.example.com"},
+					PermissionClaims: []kcpdevv1alpha1.PermissionClaim{
+						{
+							GroupResource: kcpdevv1alpha1.GroupResource{
+								Resource: "secrets",
+							},
+							All: true,
+						},
+						adminClaim,
+					},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := &Reconciler{}
+
+			_, reconciler := r.createAPIExportReconciler(testcase.schemas, sets.New[string](), sets.New[string](), testcase.claims, "textor-the-doctor", "my-export")()
+
+			existing := testcase.existing.DeepCopy()
+
+			updated, err := reconciler(existing)
+			if err != nil {
+				t.Fatalf("Failed to reconcile APIExport: %v", err)
+			}
+
+			if updated != existing {
+				t.Error("Expected the APIExport to be modified in place.")
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, updated); changes != "" {
+				t.Errorf("APIExport does not match expectation:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestAPIExportReconcilerAgentNameAnnotation(t *testing.T) {
+	testcases := []struct {
+		name     string
+		existing string
+		shard    int
+		shards   int
+		expected string
+	}{
+		{
+			name:     "annotation is set if missing",
+			shards:   1,
+			expected: "textor-the-doctor",
+		},
+		{
+			name:     "unsharded agent takes over the annotation",
+			existing: "previous-agent",
+			shards:   1,
+			expected: "textor-the-doctor",
+		},
+		{
+			name:     "primary shard takes over the annotation",
+			existing: "previous-agent",
+			shard:    0,
+			shards:   3,
+			expected: "textor-the-doctor",
+		},
+		{
+			name:     "other shards keep the annotation",
+			existing: "previous-agent",
+			shard:    2,
+			shards:   3,
+			expected: "previous-agent",
+		},
+		{
+			name:     "other shards set the annotation if missing",
+			shard:    2,
+			shards:   3,
+			expected: "textor-the-doctor",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			r := &Reconciler{
+				prFilter: controllerutil.NewPublishedResourceFilter(nil, testcase.shard, testcase.shards),
+			}
+
+			existing := &kcpdevv1alpha1.APIExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-export",
+				},
+			}
+
+			if testcase.existing != "" {
+				existing.Annotations = map[string]string{
+					syncagentv1alpha1.AgentNameAnnotation: testcase.existing,
+				}
+			}
+
+			_, reconciler := r.createAPIExportReconciler(sets.New[string](), sets.New[string](), sets.New[string](), permissionClaims{}, "textor-the-doctor", "my-export")()
+
+			updated, err := reconciler(existing)
+			if err != nil {
+				t.Fatalf("Failed to reconcile APIExport: %v", err)
+			}
+
+			if value := updated.Annotations[syncagentv1alpha1.AgentNameAnnotation]; value != testcase.expected {
+				t.Errorf("Expected agent name annotation %q, but got %q.", testcase.expected, value)
+			}
+		})
+	}
+}
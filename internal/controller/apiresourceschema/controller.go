@@ -18,32 +18,42 @@ package apiresourceschema
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
-	"strings"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"go.uber.org/zap"
 
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
 	"github.com/kcp-dev/api-syncagent/internal/controllerutil/predicate"
-	"github.com/kcp-dev/api-syncagent/internal/crypto"
+	"github.com/kcp-dev/api-syncagent/internal/conversion"
 	"github.com/kcp-dev/api-syncagent/internal/discovery"
 	"github.com/kcp-dev/api-syncagent/internal/projection"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
 
 	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/rest"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/kontext"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -56,11 +66,14 @@ const (
 type Reconciler struct {
 	localClient ctrlruntimeclient.Client
 	kcpClient   ctrlruntimeclient.Client
-	restConfig  *rest.Config
+	discovery   *discovery.Client
 	log         *zap.SugaredLogger
 	recorder    record.EventRecorder
 	lcName      logicalcluster.Name
 	agentName   string
+
+	// conversionWebhook is only set if the conversion relay is enabled
+	conversionWebhook *conversion.WebhookConfig
 }
 
 // Add creates a new controller and adds it to the given manager.
@@ -71,27 +84,75 @@ func Add(
 	log *zap.SugaredLogger,
 	numWorkers int,
 	agentName string,
-	prFilter labels.Selector,
+	prFilter *controllerutil.PublishedResourceFilter,
+	conversionWebhook *conversion.WebhookConfig,
+	discoveryCacheTTL time.Duration,
 ) error {
+	// share one discovery client across all reconciliations, so that reconciling
+	// many PublishedResources does not query the discovery endpoints every time
+	discoveryClient, err := discovery.NewCachedClient(mgr.GetConfig(), discoveryCacheTTL)
+	if err != nil {
+		return fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
 	reconciler := &Reconciler{
 		localClient: mgr.GetClient(),
 		kcpClient:   kcpCluster.GetClient(),
-		restConfig:  mgr.GetConfig(),
+		discovery:   discoveryClient,
 		lcName:      lcName,
 		log:         log.Named(ControllerName),
 		recorder:    mgr.GetEventRecorderFor(ControllerName),
 		agentName:   agentName,
+
+		conversionWebhook: conversionWebhook,
+	}
+
+	// any change to a CRD can change the discovery results; PublishedResources
+	// additionally need to be reconciled when their CRD changes to detect drift
+	invalidateDiscovery := handler.Funcs{
+		CreateFunc: func(context.Context, event.CreateEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			discoveryClient.Invalidate()
+		},
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			discoveryClient.Invalidate()
+			reconciler.enqueuePublishedResourcesForCRD(ctx, e.ObjectNew, prFilter, q)
+		},
+		DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			discoveryClient.Invalidate()
+		},
 	}
 
-	_, err := builder.ControllerManagedBy(mgr).
+	_, err = builder.ControllerManagedBy(mgr).
 		Named(ControllerName).
 		WithOptions(controller.Options{MaxConcurrentReconciles: numWorkers}).
 		// Watch for changes to PublishedResources on the local service cluster
-		For(&syncagentv1alpha1.PublishedResource{}, builder.WithPredicates(predicate.ByLabels(prFilter))).
+		For(&syncagentv1alpha1.PublishedResource{}, builder.WithPredicates(predicate.Factory(prFilter.Matches))).
+		// Watch CRDs to keep the discovery cache up-to-date
+		Watches(&apiextensionsv1.CustomResourceDefinition{}, invalidateDiscovery).
 		Build(reconciler)
 	return err
 }
 
+// enqueuePublishedResourcesForCRD queues all PublishedResources for the given CRD.
+func (r *Reconciler) enqueuePublishedResourcesForCRD(ctx context.Context, obj ctrlruntimeclient.Object, prFilter *controllerutil.PublishedResourceFilter, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	crd, ok := obj.(*apiextensionsv1.CustomResourceDefinition)
+	if !ok {
+		return
+	}
+
+	pubResources := &syncagentv1alpha1.PublishedResourceList{}
+	if err := prFilter.List(ctx, r.localClient, pubResources); err != nil {
+		r.log.Errorw("Failed to list PublishedResources", zap.Error(err))
+		return
+	}
+
+	for _, pr := range pubResources.Items {
+		if pr.Spec.Resource.APIGroup == crd.Spec.Group && pr.Spec.Resource.Kind == crd.Spec.Names.Kind {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: pr.Name}})
+		}
+	}
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	log := r.log.With("publishedresource", request)
 	log.Debug("Processing")
@@ -109,54 +170,135 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, nil
 	}
 
-	result, err := r.reconcile(ctx, log, pubResource)
-	if err != nil {
+	result, upToDate, err := r.reconcile(ctx, log, pubResource)
+
+	var projectionErr *invalidProjectionError
+	if errors.As(err, &projectionErr) {
+		r.recorder.Event(pubResource, corev1.EventTypeWarning, "InvalidProjection", err.Error())
+	} else if err != nil {
 		r.recorder.Event(pubResource, corev1.EventTypeWarning, "ReconcilingError", err.Error())
 	}
+
+	if condErr := r.updateSchemaCondition(ctx, pubResource, upToDate, err); condErr != nil {
+		if err == nil {
+			err = fmt.Errorf("failed to update status: %w", condErr)
+		} else {
+			log.Errorw("Failed to update status", zap.Error(condErr))
+		}
+	}
 	if result == nil {
 		result = &reconcile.Result{}
 	}
 
+	// retrying cannot fix an invalid projection, only changing the PublishedResource can
+	if projectionErr != nil {
+		return *result, nil
+	}
+
 	return *result, err
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, pubResource *syncagentv1alpha1.PublishedResource) (*reconcile.Result, error) {
+// invalidProjectionError is returned when the projection rules of a PublishedResource
+// lead to a schema that kcp would reject.
+type invalidProjectionError struct {
+	err error
+}
+
+func (e *invalidProjectionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidProjectionError) Unwrap() error {
+	return e.err
+}
+
+// reconcile ensures the APIResourceSchema for the PublishedResource exists. Besides
+// the result, it returns the SchemaUpToDate condition once the published schema
+// has been compared to the local CRD.
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, pubResource *syncagentv1alpha1.PublishedResource) (*reconcile.Result, *metav1.Condition, error) {
 	// find the resource that the PublishedResource is referring to
 	localGVK := projection.PublishedResourceSourceGVK(pubResource)
 
-	client, err := discovery.NewClient(r.restConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	additionalVersions := []string{}
+	for _, version := range pubResource.Spec.Resource.Versions {
+		additionalVersions = append(additionalVersions, version.Name)
 	}
 
-	crd, err := client.RetrieveCRD(ctx, localGVK)
+	crd, err := r.discovery.RetrieveCRD(ctx, localGVK, additionalVersions...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover resource defined in PublishedResource: %w", err)
+		return nil, nil, fmt.Errorf("failed to discover resource defined in PublishedResource: %w", err)
+	}
+
+	// the discovery never includes the service cluster's conversion webhook, as kcp
+	// cannot reach it; if enabled, let kcp call the conversion relay instead (built-in
+	// APIs are converted inside the kube-apiserver and cannot use the relay)
+	if r.conversionWebhook != nil && len(crd.Spec.Versions) > 1 && !discovery.IsBuiltInGroup(crd.Spec.Group) {
+		webhook, err := r.discovery.ConversionWebhook(ctx, crd.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to determine conversion webhook: %w", err)
+		}
+
+		if webhook != nil {
+			crd.Spec.Conversion = r.conversionWebhook.Conversion(pubResource)
+		}
 	}
 
 	// project the CRD
 	projectedCRD, err := r.applyProjection(crd, pubResource)
 	if err != nil {
-		return nil, fmt.Errorf("failed to apply projection rules: %w", err)
+		return nil, nil, fmt.Errorf("failed to apply projection rules: %w", err)
+	}
+
+	if err := projection.ProjectSchema(projectedCRD, pubResource.Spec.SchemaProjection); err != nil {
+		return nil, nil, &invalidProjectionError{err: err}
+	}
+
+	// kcp would reject the schema anyway, but its error would not end up anywhere
+	// near the PublishedResource
+	if err := projection.ValidateNames(projectedCRD.Spec.Names); err != nil {
+		return nil, nil, &invalidProjectionError{err: err}
 	}
 
 	// to prevent changing the source GVK e.g. from "apps/v1 Daemonset" to "core/v1 Pod",
 	// we include the source GVK in hashed form in the final APIResourceSchema name.
-	arsName := r.getAPIResourceSchemaName(projectedCRD)
+	arsName := r.getAPIResourceSchemaName(projectedCRD, pubResource.Spec.SchemaUpdatePolicy)
 
-	// ARS'es cannot be updated, their entire spec is immutable. For now we do not care about
-	// CRDs being updated on the service cluster, but in the future (TODO) we must allow
-	// service owners to somehow publish updated CRDs without changing their API version.
+	// ARS'es cannot be updated, their entire spec is immutable. Unless the PublishedResource
+	// opted into recreating schemas, changes to the CRD on the service cluster are only
+	// reported as drift; otherwise the schema is part of the name and a changed CRD leads
+	// to a new ARS, which the apiexport controller then swaps into the APIExport.
 	wsCtx := kontext.WithCluster(ctx, r.lcName)
+
+	upToDate, err := r.checkSchemaDrift(wsCtx, log, pubResource, projectedCRD, arsName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check for schema drift: %w", err)
+	}
+
 	ars := &kcpdevv1alpha1.APIResourceSchema{}
 	err = r.kcpClient.Get(wsCtx, types.NamespacedName{Name: arsName}, ars, &ctrlruntimeclient.GetOptions{})
 
+	// remember both identities of the resource to allow mapping between them; the
+	// service owner's own annotations must never overwrite them
+	schemaAnnotations := map[string]string{}
+	if schemaProjection := pubResource.Spec.SchemaProjection; schemaProjection != nil {
+		maps.Copy(schemaAnnotations, schemaProjection.Annotations)
+	}
+	maps.Copy(schemaAnnotations, projection.PublishedResourceIdentity(pubResource).Annotations())
+
+	// opting into schema garbage collection is recorded on the schema itself, so it
+	// is still known after the PublishedResource has been deleted
+	if pubResource.Annotations[syncagentv1alpha1.SchemaGarbageCollectionAnnotation] == "true" {
+		schemaAnnotations[syncagentv1alpha1.SchemaGarbageCollectionAnnotation] = "true"
+	}
+
 	if apierrors.IsNotFound(err) {
-		if err := r.createAPIResourceSchema(wsCtx, log, projectedCRD, arsName); err != nil {
-			return nil, fmt.Errorf("failed to create APIResourceSchema: %w", err)
+		if err := r.createAPIResourceSchema(wsCtx, log, projectedCRD, arsName, schemaAnnotations); err != nil {
+			return nil, nil, fmt.Errorf("failed to create APIResourceSchema: %w", err)
 		}
 	} else if err != nil {
-		return nil, fmt.Errorf("failed to check for APIResourceSchema: %w", err)
+		return nil, nil, fmt.Errorf("failed to check for APIResourceSchema: %w", err)
+	} else if err := r.ensureAnnotations(wsCtx, ars, schemaAnnotations); err != nil {
+		return nil, nil, fmt.Errorf("failed to update APIResourceSchema annotations: %w", err)
 	}
 
 	// Update Status with ARS name
@@ -167,15 +309,152 @@ func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, pubR
 		if !reflect.DeepEqual(original, pubResource) {
 			log.Info("Patching PublishedResource status…")
 			if err := r.localClient.Status().Patch(ctx, pubResource, ctrlruntimeclient.MergeFrom(original)); err != nil {
-				return nil, fmt.Errorf("failed to update PublishedResource status: %w", err)
+				return nil, nil, fmt.Errorf("failed to update PublishedResource status: %w", err)
 			}
 		}
 	}
 
-	return nil, nil
+	return nil, upToDate, nil
+}
+
+func (r *Reconciler) updateSchemaCondition(ctx context.Context, pubResource *syncagentv1alpha1.PublishedResource, upToDate *metav1.Condition, reconcileErr error) error {
+	original := pubResource.DeepCopy()
+
+	condition := metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionSchemaCreated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Created",
+		Message: fmt.Sprintf("APIResourceSchema %s exists.", pubResource.Status.ResourceSchemaName),
+	}
+
+	if reconcileErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReconcileFailed"
+		condition.Message = reconcileErr.Error()
+
+		var projectionErr *invalidProjectionError
+		if errors.As(reconcileErr, &projectionErr) {
+			condition.Reason = "InvalidProjection"
+		}
+	}
+
+	controllerutil.SetPublishedResourceCondition(pubResource, condition)
+
+	// the drift is unknown if reconciling failed before the schemas could be compared
+	if upToDate != nil {
+		controllerutil.SetPublishedResourceCondition(pubResource, *upToDate)
+	}
+
+	return controllerutil.PatchPublishedResourceStatus(ctx, r.localClient, original, pubResource)
+}
+
+// checkSchemaDrift compares the APIResourceSchema that is currently published for the
+// PublishedResource with the projected CRD and returns the resulting SchemaUpToDate
+// condition. With the Manual policy, a drift is only reported; with the Recreate
+// policy, the changed schema leads to a new schema name and the drift is resolved by
+// creating the new schema.
+func (r *Reconciler) checkSchemaDrift(ctx context.Context, log *zap.SugaredLogger, pubResource *syncagentv1alpha1.PublishedResource, projectedCRD *apiextensionsv1.CustomResourceDefinition, arsName string) (*metav1.Condition, error) {
+	condition := &metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionSchemaUpToDate,
+		Status:  metav1.ConditionTrue,
+		Reason:  "UpToDate",
+		Message: "The APIResourceSchema matches the CRD on the service cluster.",
+	}
+
+	recreate := pubResource.Spec.SchemaUpdatePolicy == syncagentv1alpha1.SchemaUpdateRecreate
+
+	// Without the Recreate policy, a different schema name means the PublishedResource
+	// itself was changed and the new name is not a drift. With the Recreate policy, the
+	// schema is part of the name, so the current schema can only drift if the name changed.
+	published := pubResource.Status.ResourceSchemaName
+	if published == "" || (!recreate && published != arsName) || (recreate && published == arsName) {
+		return condition, nil
+	}
+
+	ars := &kcpdevv1alpha1.APIResourceSchema{}
+	if err := r.kcpClient.Get(ctx, types.NamespacedName{Name: published}, ars); err != nil {
+		if apierrors.IsNotFound(err) {
+			return condition, nil
+		}
+
+		return nil, err
+	}
+
+	drifted, err := schemaDrifted(ars, projectedCRD)
+	if err != nil {
+		return nil, err
+	}
+
+	if !drifted {
+		return condition, nil
+	}
+
+	if recreate {
+		log.Infow("CRD schema has changed, recreating APIResourceSchema…", "previous", published, "name", arsName)
+		r.recorder.Eventf(pubResource, corev1.EventTypeNormal, "SchemaRecreated", "CRD schema has changed, replacing APIResourceSchema %s with %s.", published, arsName)
+
+		return condition, nil
+	}
+
+	// only record the drift once instead of on every reconciliation
+	if !meta.IsStatusConditionFalse(pubResource.Status.Conditions, syncagentv1alpha1.ConditionSchemaUpToDate) {
+		r.recorder.Eventf(pubResource, corev1.EventTypeWarning, "SchemaDrifted", "CRD schema differs from APIResourceSchema %s.", published)
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = "SchemaDrifted"
+	condition.Message = fmt.Sprintf("The CRD on the service cluster differs from APIResourceSchema %s; set schemaUpdatePolicy to Recreate to publish the changes.", published)
+
+	return condition, nil
 }
 
-func (r *Reconciler) createAPIResourceSchema(ctx context.Context, log *zap.SugaredLogger, projectedCRD *apiextensionsv1.CustomResourceDefinition, arsName string) error {
+// schemaDrifted returns true if the given APIResourceSchema describes a different
+// schema than the projected CRD. Changes to the names or group are not a drift, as
+// they always lead to a new schema name.
+func schemaDrifted(ars *kcpdevv1alpha1.APIResourceSchema, projectedCRD *apiextensionsv1.CustomResourceDefinition) (bool, error) {
+	converted, err := kcpdevv1alpha1.CRDToAPIResourceSchema(projectedCRD, "irrelevant")
+	if err != nil {
+		return false, fmt.Errorf("failed to convert CRD: %w", err)
+	}
+
+	if ars.Spec.Group != converted.Spec.Group || ars.Spec.Names.Plural != converted.Spec.Names.Plural {
+		return false, nil
+	}
+
+	// the schemas are stored as raw JSON, which must be compared semantically
+	published, err := normalizeSchemaVersions(ars.Spec.Scope, ars.Spec.Versions)
+	if err != nil {
+		return false, err
+	}
+
+	current, err := normalizeSchemaVersions(converted.Spec.Scope, converted.Spec.Versions)
+	if err != nil {
+		return false, err
+	}
+
+	return !equality.Semantic.DeepEqual(published, current), nil
+}
+
+// normalizeSchemaVersions turns the scope and versions of an APIResourceSchema into
+// generic JSON data that does not depend on the formatting of the raw schemas.
+func normalizeSchemaVersions(scope apiextensionsv1.ResourceScope, versions []kcpdevv1alpha1.APIResourceVersion) (any, error) {
+	encoded, err := json.Marshal(map[string]any{
+		"scope":    scope,
+		"versions": versions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *Reconciler) createAPIResourceSchema(ctx context.Context, log *zap.SugaredLogger, projectedCRD *apiextensionsv1.CustomResourceDefinition, arsName string, annotations map[string]string) error {
 	// prefix is irrelevant as the reconciling framework will use arsName anyway
 	converted, err := kcpdevv1alpha1.CRDToAPIResourceSchema(projectedCRD, "irrelevant")
 	if err != nil {
@@ -188,16 +467,35 @@ func (r *Reconciler) createAPIResourceSchema(ctx context.Context, log *zap.Sugar
 		syncagentv1alpha1.SourceGenerationAnnotation: fmt.Sprintf("%d", projectedCRD.Generation),
 		syncagentv1alpha1.AgentNameAnnotation:        r.agentName,
 	}
+	maps.Copy(ars.Annotations, annotations)
 	ars.Spec.Group = converted.Spec.Group
 	ars.Spec.Names = converted.Spec.Names
 	ars.Spec.Scope = converted.Spec.Scope
 	ars.Spec.Versions = converted.Spec.Versions
+	ars.Spec.Conversion = converted.Spec.Conversion
 
 	log.With("name", arsName).Info("Creating APIResourceSchema…")
 
 	return r.kcpClient.Create(ctx, ars)
 }
 
+// ensureAnnotations adds the given annotations to pre-existing APIResourceSchemas;
+// while their spec is immutable, the metadata can still be changed.
+func (r *Reconciler) ensureAnnotations(ctx context.Context, ars *kcpdevv1alpha1.APIResourceSchema, annotations map[string]string) error {
+	oldARS := ars.DeepCopy()
+
+	if ars.Annotations == nil {
+		ars.Annotations = map[string]string{}
+	}
+	maps.Copy(ars.Annotations, annotations)
+
+	if reflect.DeepEqual(oldARS.Annotations, ars.Annotations) {
+		return nil
+	}
+
+	return r.kcpClient.Patch(ctx, ars, ctrlruntimeclient.MergeFrom(oldARS))
+}
+
 func (r *Reconciler) applyProjection(crd *apiextensionsv1.CustomResourceDefinition, pr *syncagentv1alpha1.PublishedResource) (*apiextensionsv1.CustomResourceDefinition, error) {
 	result := crd.DeepCopy()
 
@@ -207,41 +505,56 @@ func (r *Reconciler) applyProjection(crd *apiextensionsv1.CustomResourceDefiniti
 	result.Spec.Versions[0].Served = true
 	result.Spec.Versions[0].Storage = true
 
-	projection := pr.Spec.Projection
-	if projection == nil {
-		return result, nil
+	// rename all versions, the primary version is affected by the projection, additional
+	// versions by their own projection settings
+	projectedVersions := projection.PublishedResourceProjectedVersions(pr)
+	seen := sets.New[string]()
+
+	for i, version := range result.Spec.Versions {
+		projected, ok := projectedVersions[version.Name]
+		if !ok {
+			return nil, fmt.Errorf("CRD contains unexpected version %s", version.Name)
+		}
+
+		if seen.Has(projected) {
+			return nil, fmt.Errorf("multiple versions are projected to %s", projected)
+		}
+		seen.Insert(projected)
+
+		result.Spec.Versions[i].Name = projected
 	}
 
-	if projection.Group != "" {
-		result.Spec.Group = projection.Group
+	spec := pr.Spec.Projection
+	if spec == nil {
+		return result, nil
 	}
 
-	if projection.Version != "" {
-		result.Spec.Versions[0].Name = projection.Version
+	if spec.Group != "" {
+		result.Spec.Group = spec.Group
 	}
 
-	if projection.Kind != "" {
-		result.Spec.Names.Kind = projection.Kind
-		result.Spec.Names.ListKind = projection.Kind + "List"
+	if spec.Kind != "" {
+		result.Spec.Names.Kind = spec.Kind
+		result.Spec.Names.ListKind = spec.Kind + "List"
 
-		result.Spec.Names.Singular = strings.ToLower(result.Spec.Names.Kind)
-		result.Spec.Names.Plural = result.Spec.Names.Singular + "s"
+		result.Spec.Names.Singular = projection.SingularName(result.Spec.Names.Kind)
+		result.Spec.Names.Plural = projection.PluralName(result.Spec.Names.Kind)
 	}
 
-	if projection.Plural != "" {
-		result.Spec.Names.Plural = projection.Plural
+	if spec.Plural != "" {
+		result.Spec.Names.Plural = spec.Plural
 	}
 
-	if projection.Scope != "" {
-		result.Spec.Scope = apiextensionsv1.ResourceScope(projection.Scope)
+	if spec.Scope != "" {
+		result.Spec.Scope = apiextensionsv1.ResourceScope(spec.Scope)
 	}
 
-	if projection.Categories != nil {
-		result.Spec.Names.Categories = projection.Categories
+	if spec.Categories != nil {
+		result.Spec.Names.Categories = spec.Categories
 	}
 
-	if projection.ShortNames != nil {
-		result.Spec.Names.ShortNames = projection.ShortNames
+	if spec.ShortNames != nil {
+		result.Spec.Names.ShortNames = spec.ShortNames
 	}
 
 	return result, nil
@@ -249,9 +562,53 @@ func (r *Reconciler) applyProjection(crd *apiextensionsv1.CustomResourceDefiniti
 
 // getAPIResourceSchemaName generates the name for the ARS in kcp. Note that
 // kcp requires, just like CRDs, that ARS are named following a specific pattern.
-func (r *Reconciler) getAPIResourceSchemaName(crd *apiextensionsv1.CustomResourceDefinition) string {
+func (r *Reconciler) getAPIResourceSchemaName(crd *apiextensionsv1.CustomResourceDefinition, policy syncagentv1alpha1.SchemaUpdatePolicy) string {
 	checksum := crypto.Hash(crd.Spec.Names)
 
+	// Schemas are immutable, so publishing additional versions must lead to a new
+	// schema; for a single version, the name must remain stable for existing schemas.
+	if len(crd.Spec.Versions) > 1 {
+		versions := []string{}
+		for _, version := range crd.Spec.Versions {
+			versions = append(versions, version.Name)
+		}
+
+		checksum = crypto.Hash(struct {
+			Names    apiextensionsv1.CustomResourceDefinitionNames
+			Versions []string
+		}{
+			Names:    crd.Spec.Names,
+			Versions: versions,
+		})
+	}
+
+	// changing the conversion (i.e. by enabling the conversion relay) must lead to
+	// a new schema as well; the CA bundle is not included, so that rotating it does
+	// not lead to a new schema
+	if conv := crd.Spec.Conversion; conv != nil && conv.Webhook != nil && conv.Webhook.ClientConfig != nil {
+		checksum = crypto.Hash(struct {
+			Checksum   string
+			WebhookURL *string
+		}{
+			Checksum:   checksum,
+			WebhookURL: conv.Webhook.ClientConfig.URL,
+		})
+	}
+
+	// with the Recreate policy, every change to the schema must lead to a new schema;
+	// this is opt-in because it changes the name of all existing schemas
+	if policy == syncagentv1alpha1.SchemaUpdateRecreate {
+		checksum = crypto.Hash(struct {
+			Checksum string
+			Scope    apiextensionsv1.ResourceScope
+			Versions []apiextensionsv1.CustomResourceDefinitionVersion
+		}{
+			Checksum: checksum,
+			Scope:    crd.Spec.Scope,
+			Versions: crd.Spec.Versions,
+		})
+	}
+
 	// include a leading "v" to prevent SHA-1 hashes with digits to break the name
 	return fmt.Sprintf("v%s.%s.%s", checksum[:8], crd.Spec.Names.Plural, crd.Spec.Group)
 }
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiresourceschema
+
+import (
+	"testing"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func testCRD(properties map[string]apiextensionsv1.JSONSchemaProps) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "things.example.com",
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "things",
+				Singular: "thing",
+				Kind:     "Thing",
+				ListKind: "ThingList",
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:       "object",
+						Properties: properties,
+					},
+				},
+			}},
+		},
+	}
+}
+
+func testARS(t *testing.T, crd *apiextensionsv1.CustomResourceDefinition) *kcpdevv1alpha1.APIResourceSchema {
+	ars, err := kcpdevv1alpha1.CRDToAPIResourceSchema(crd, "irrelevant")
+	if err != nil {
+		t.Fatalf("Failed to convert CRD: %v", err)
+	}
+
+	return ars
+}
+
+func TestSchemaDrifted(t *testing.T) {
+	properties := map[string]apiextensionsv1.JSONSchemaProps{
+		"spec": {Type: "object"},
+	}
+
+	changedProperties := map[string]apiextensionsv1.JSONSchemaProps{
+		"spec":   {Type: "object"},
+		"status": {Type: "object"},
+	}
+
+	testcases := []struct {
+		name     string
+		ars      func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema
+		crd      *apiextensionsv1.CustomResourceDefinition
+		expected bool
+	}{
+		{
+			name: "identical schema",
+			ars: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema {
+				return testARS(t, testCRD(properties))
+			},
+			crd:      testCRD(properties),
+			expected: false,
+		},
+		{
+			name: "differently formatted but identical schema",
+			ars: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema {
+				ars := testARS(t, testCRD(properties))
+				ars.Spec.Versions[0].Schema = runtime.RawExtension{
+					Raw: []byte(`{ "properties": { "spec": { "type": "object" } }, "type": "object" }`),
+				}
+
+				return ars
+			},
+			crd:      testCRD(properties),
+			expected: false,
+		},
+		{
+			name: "changed schema",
+			ars: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema {
+				return testARS(t, testCRD(properties))
+			},
+			crd:      testCRD(changedProperties),
+			expected: true,
+		},
+		{
+			name: "changed scope",
+			ars: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema {
+				return testARS(t, testCRD(properties))
+			},
+			crd: func() *apiextensionsv1.CustomResourceDefinition {
+				crd := testCRD(properties)
+				crd.Spec.Scope = apiextensionsv1.ClusterScoped
+
+				return crd
+			}(),
+			expected: true,
+		},
+		{
+			name: "different resource is not a drift",
+			ars: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchema {
+				return testARS(t, testCRD(properties))
+			},
+			crd: func() *apiextensionsv1.CustomResourceDefinition {
+				crd := testCRD(changedProperties)
+				crd.Spec.Names.Plural = "widgets"
+
+				return crd
+			}(),
+			expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			drifted, err := schemaDrifted(testcase.ars(t), testcase.crd)
+			if err != nil {
+				t.Fatalf("Failed to compare schemas: %v", err)
+			}
+
+			if drifted != testcase.expected {
+				t.Fatalf("Expected drift to be %v, but got %v.", testcase.expected, drifted)
+			}
+		})
+	}
+}
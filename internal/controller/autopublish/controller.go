@@ -0,0 +1,199 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autopublish
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/validation"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "syncagent-autopublish"
+)
+
+// Reconciler maintains a PublishedResource for every CRD that has the publish
+// annotation.
+type Reconciler struct {
+	localClient ctrlruntimeclient.Client
+	log         *zap.SugaredLogger
+	recorder    record.EventRecorder
+}
+
+// Add creates a new controller and adds it to the given manager.
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+) error {
+	reconciler := &Reconciler{
+		localClient: mgr.GetClient(),
+		log:         log.Named(ControllerName),
+		recorder:    mgr.GetEventRecorderFor(ControllerName),
+	}
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		// Watch all CRDs, as removing the annotation must delete the PublishedResource
+		For(&apiextensionsv1.CustomResourceDefinition{}).
+		// Watch the generated PublishedResources to revert manual changes
+		Owns(&syncagentv1alpha1.PublishedResource{}).
+		Build(reconciler)
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("crd", request.Name)
+	log.Debug("Processing")
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := r.localClient.Get(ctx, request.NamespacedName, crd); err != nil {
+		// PublishedResources of deleted CRDs are garbage collected
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	existing := &syncagentv1alpha1.PublishedResource{}
+	err := r.localClient.Get(ctx, types.NamespacedName{Name: crd.Name}, existing)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	exists := err == nil
+
+	// never touch PublishedResources that have been created by someone else
+	if exists && !metav1.IsControlledBy(existing, crd) {
+		if publishRequested(crd) {
+			log.Debug("PublishedResource exists, but is not owned by the CRD")
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if !publishRequested(crd) || crd.DeletionTimestamp != nil {
+		if exists {
+			log.Info("Deleting PublishedResource…")
+			if err := r.localClient.Delete(ctx, existing); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete PublishedResource: %w", err)
+			}
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	desired, err := publishedResourceFor(crd)
+	if err != nil {
+		r.recorder.Event(crd, corev1.EventTypeWarning, "InvalidAnnotations", err.Error())
+		return reconcile.Result{}, nil
+	}
+
+	if !exists {
+		log.Info("Creating PublishedResource…")
+		if err := r.localClient.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+			return reconcile.Result{}, fmt.Errorf("failed to create PublishedResource: %w", err)
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Spec.Resource, desired.Spec.Resource) && equality.Semantic.DeepEqual(existing.Spec.Projection, desired.Spec.Projection) {
+		return reconcile.Result{}, nil
+	}
+
+	log.Info("Updating PublishedResource…")
+
+	existing.Spec.Resource = desired.Spec.Resource
+	existing.Spec.Projection = desired.Spec.Projection
+
+	if err := r.localClient.Update(ctx, existing); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update PublishedResource: %w", err)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func publishRequested(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	return crd.Annotations[syncagentv1alpha1.PublishAnnotation] == "true"
+}
+
+// publishedResourceFor returns the PublishedResource for the storage version of
+// the given CRD, projected according to the CRD's annotations.
+func publishedResourceFor(crd *apiextensionsv1.CustomResourceDefinition) (*syncagentv1alpha1.PublishedResource, error) {
+	version := ""
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = v.Name
+			break
+		}
+	}
+
+	if version == "" {
+		return nil, fmt.Errorf("CRD %s has no storage version", crd.Name)
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: crd.Name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         apiextensionsv1.SchemeGroupVersion.String(),
+				Kind:               "CustomResourceDefinition",
+				Name:               crd.Name,
+				UID:                crd.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			}},
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: crd.Spec.Group,
+				Version:  version,
+				Kind:     crd.Spec.Names.Kind,
+			},
+		},
+	}
+
+	projection := syncagentv1alpha1.ResourceProjection{
+		Group:  crd.Annotations[syncagentv1alpha1.PublishGroupAnnotation],
+		Kind:   crd.Annotations[syncagentv1alpha1.PublishKindAnnotation],
+		Plural: crd.Annotations[syncagentv1alpha1.PublishPluralAnnotation],
+	}
+
+	if projection.Group != "" || projection.Kind != "" || projection.Plural != "" {
+		pubRes.Spec.Projection = &projection
+	}
+
+	if errs := validation.ValidatePublishedResource(pubRes); len(errs) > 0 {
+		return nil, fmt.Errorf("generated PublishedResource is invalid: %w", errs.ToAggregate())
+	}
+
+	return pubRes, nil
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package autopublish contains a controller that generates PublishedResources for
+CRDs on the service cluster that have been annotated with
+syncagent.kcp.io/publish=true. This saves service owners from having to write a
+PublishedResource for every single CRD of larger APIs.
+
+Generated PublishedResources are named after their CRD and are owned by it, so
+they are garbage collected by Kubernetes once the CRD is deleted. Only the
+resource and projection are managed by the controller; all other fields can
+be changed freely. Removing the annotation deletes the PublishedResource.
+*/
+package autopublish
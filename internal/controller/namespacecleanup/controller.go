@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacecleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "syncagent-namespacecleanup"
+
+	// checkInterval is how often retained namespaces are checked again, as neither
+	// the deletion of namespaces in kcp nor of objects on the service cluster
+	// trigger a reconciliation.
+	checkInterval = 5 * time.Minute
+)
+
+// Reconciler deletes namespaces that the Sync Agent has created on the service
+// cluster, once their source namespace in kcp is gone and they no longer contain
+// any objects managed by the Sync Agent.
+type Reconciler struct {
+	localClient ctrlruntimeclient.Client
+	localReader ctrlruntimeclient.Reader
+	vwReader    ctrlruntimeclient.Reader
+	log         *zap.SugaredLogger
+	recorder    record.EventRecorder
+	prFilter    *controllerutil.PublishedResourceFilter
+	agentName   string
+}
+
+// Create creates a new controller and importantly does *not* add it to the manager,
+// as this controller is started/stopped by the syncmanager controller instead.
+func Create(
+	localManager manager.Manager,
+	virtualWorkspaceCluster cluster.Cluster,
+	prFilter *controllerutil.PublishedResourceFilter,
+	agentName string,
+	log *zap.SugaredLogger,
+) (controller.Controller, error) {
+	reconciler := &Reconciler{
+		localClient: localManager.GetClient(),
+		// objects are only listed to check whether any exist, so do not cache them
+		localReader: localManager.GetAPIReader(),
+		vwReader:    virtualWorkspaceCluster.GetAPIReader(),
+		log:         log.Named(ControllerName),
+		recorder:    localManager.GetEventRecorderFor(ControllerName),
+		prFilter:    prFilter,
+		agentName:   agentName,
+	}
+
+	ctrlOptions := controller.Options{
+		Reconciler:              reconciler,
+		MaxConcurrentReconciles: 1,
+		SkipNameValidation:      ptr.To(true),
+	}
+
+	c, err := controller.NewUnmanaged(ControllerName, localManager, ctrlOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// only watch namespaces created by this agent
+	selector := sync.CreatedNamespacesSelector(agentName)
+	createdByAgent := predicate.NewTypedPredicateFuncs(func(ns *corev1.Namespace) bool {
+		return selector.Matches(labels.Set(ns.Labels))
+	})
+
+	if err := c.Watch(source.Kind(localManager.GetCache(), &corev1.Namespace{}, &handler.TypedEnqueueRequestForObject[*corev1.Namespace]{}, createdByAgent)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.With("namespace", request.Name)
+	log.Debug("Processing")
+
+	ns := &corev1.Namespace{}
+	if err := r.localClient.Get(ctx, request.NamespacedName, ns); err != nil {
+		return reconcile.Result{}, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	if ns.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	clusterName, _ := sync.NamespaceWorkspace(ns)
+	sourceNamespace := sync.NamespaceSource(ns)
+
+	// namespaces created by older agent versions do not know their source namespace
+	if clusterName.Empty() || sourceNamespace == "" {
+		return reconcile.Result{}, nil
+	}
+
+	log = log.With("cluster", clusterName, "source-namespace", sourceNamespace)
+
+	sourceExists, err := r.sourceNamespaceExists(ctx, clusterName, sourceNamespace)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check source namespace: %w", err)
+	}
+
+	if sourceExists {
+		return reconcile.Result{RequeueAfter: checkInterval}, nil
+	}
+
+	empty, err := r.containsNoOwnedObjects(ctx, ns.Name)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check for remaining objects: %w", err)
+	}
+
+	if !empty {
+		log.Debug("Source namespace is gone, but objects remain")
+		return reconcile.Result{RequeueAfter: checkInterval}, nil
+	}
+
+	log.Info("Deleting namespace, as its source namespace is gone…")
+	if err := r.localClient.Delete(ctx, ns); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete namespace: %w", err)
+	}
+
+	r.recorder.Eventf(ns, corev1.EventTypeNormal, "NamespaceDeleted", "Namespace %s in kcp cluster %s has been deleted.", sourceNamespace, clusterName)
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) sourceNamespaceExists(ctx context.Context, clusterName logicalcluster.Name, name string) (bool, error) {
+	ns := &corev1.Namespace{}
+
+	err := r.vwReader.Get(kontext.WithCluster(ctx, clusterName), ctrlruntimeclient.ObjectKey{Name: name}, ns)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// containsNoOwnedObjects returns true if none of the resources handled by this agent
+// has objects managed by this agent in the given namespace.
+func (r *Reconciler) containsNoOwnedObjects(ctx context.Context, namespace string) (bool, error) {
+	gvks, err := r.managedKinds(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, gvk := range gvks.UnsortedList() {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		err := r.localReader.List(ctx, list,
+			ctrlruntimeclient.InNamespace(namespace),
+			ctrlruntimeclient.MatchingLabelsSelector{Selector: sync.OwnedObjectsSelector(r.agentName)},
+			ctrlruntimeclient.Limit(1),
+		)
+		if err != nil {
+			// resources that do not exist (anymore) cannot have objects
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return false, fmt.Errorf("failed to list %v: %w", gvk, err)
+		}
+
+		if len(list.Items) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// managedKinds returns the kinds of all objects that the sync controllers of this
+// agent create on the service cluster, including paused and unpublishing resources.
+func (r *Reconciler) managedKinds(ctx context.Context) (sets.Set[schema.GroupVersionKind], error) {
+	pubResources := &syncagentv1alpha1.PublishedResourceList{}
+	if err := r.prFilter.List(ctx, r.localClient, pubResources); err != nil {
+		return nil, fmt.Errorf("failed to list PublishedResources: %w", err)
+	}
+
+	gvks := sets.New[schema.GroupVersionKind]()
+
+	for i := range pubResources.Items {
+		pubRes, _, err := profile.Resolve(ctx, r.localClient, &pubResources.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve PublishedResource %s: %w", pubResources.Items[i].Name, err)
+		}
+
+		gvks.Insert(projection.PublishedResourceSourceGVK(pubRes))
+
+		for _, relRes := range flattenRelatedResources(pubRes.Spec.Related) {
+			gvks.Insert(projection.RelatedResourceGVK(&relRes))
+		}
+	}
+
+	return gvks, nil
+}
+
+func flattenRelatedResources(related []syncagentv1alpha1.RelatedResourceSpec) []syncagentv1alpha1.RelatedResourceSpec {
+	result := []syncagentv1alpha1.RelatedResourceSpec{}
+
+	for _, rr := range related {
+		result = append(result, rr)
+		result = append(result, flattenRelatedResources(rr.Related)...)
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package namespacecleanup contains a controller that removes namespaces the Sync
+Agent has created on the service cluster once the namespace in kcp they were
+created for has been deleted and all objects synced into them are gone. Like the
+announcement controller, it is started and stopped by the syncmanager, as it needs
+access to the virtual workspace.
+*/
+package namespacecleanup
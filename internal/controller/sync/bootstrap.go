@@ -0,0 +1,361 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	gosync "sync"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// initialSyncLabel is put on the Secrets that hold the initial sync progress.
+	initialSyncLabel = "syncagent.kcp.io/initial-sync"
+
+	defaultInitialSyncInterval = 1 * time.Second
+)
+
+// bookmark is the persisted progress of the initial sync of a single workspace.
+type bookmark struct {
+	Continue  string `json:"continue,omitempty"`
+	Processed int64  `json:"processed"`
+	Total     int64  `json:"total"`
+	Complete  bool   `json:"complete,omitempty"`
+}
+
+type clusterBootstrap struct {
+	complete bool
+	// keys of objects that have been enqueued by the bootstrapper
+	seen sets.Set[types.NamespacedName]
+	// keys of objects whose create events were held back
+	deferred sets.Set[types.NamespacedName]
+}
+
+// bootstrapper throttles the initial synchronization of workspaces. Instead of
+// reconciling every pre-existing object at once when the informer first lists them,
+// the objects of each workspace are listed page by page and enqueued with a delay
+// in between. The progress is persisted, so that a restarted agent can resume
+// instead of starting over.
+type bootstrapper struct {
+	log            *zap.SugaredLogger
+	pubRes         *syncagentv1alpha1.PublishedResource
+	remoteReader   ctrlruntimeclient.Reader
+	localClient    ctrlruntimeclient.Client
+	remoteDummy    *unstructured.Unstructured
+	stateNamespace string
+	chunkSize      int64
+	interval       time.Duration
+
+	lock     gosync.Mutex
+	ctx      context.Context
+	queue    workqueue.TypedRateLimitingInterface[reconcile.Request]
+	clusters map[logicalcluster.Name]*clusterBootstrap
+}
+
+func newBootstrapper(
+	log *zap.SugaredLogger,
+	pubRes *syncagentv1alpha1.PublishedResource,
+	remoteReader ctrlruntimeclient.Reader,
+	localClient ctrlruntimeclient.Client,
+	remoteDummy *unstructured.Unstructured,
+	stateNamespace string,
+) *bootstrapper {
+	settings := pubRes.Spec.InitialSync
+
+	interval := defaultInitialSyncInterval
+	if settings.Interval != nil {
+		interval = settings.Interval.Duration
+	}
+
+	return &bootstrapper{
+		log:            log.Named("initial-sync"),
+		pubRes:         pubRes,
+		remoteReader:   remoteReader,
+		localClient:    localClient,
+		remoteDummy:    remoteDummy,
+		stateNamespace: stateNamespace,
+		chunkSize:      settings.ChunkSize,
+		interval:       interval,
+		clusters:       map[logicalcluster.Name]*clusterBootstrap{},
+	}
+}
+
+// Start implements source.Source and gives the bootstrapper access to the
+// controller's workqueue.
+func (b *bootstrapper) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.ctx = ctx
+	b.queue = queue
+
+	return nil
+}
+
+// Predicate holds back create events for objects in workspaces whose initial
+// sync has not completed yet. All other events are let through.
+func (b *bootstrapper) Predicate() predicate.TypedPredicate[*unstructured.Unstructured] {
+	return predicate.TypedFuncs[*unstructured.Unstructured]{
+		CreateFunc: func(e event.TypedCreateEvent[*unstructured.Unstructured]) bool {
+			return b.admit(e.Object)
+		},
+	}
+}
+
+func (b *bootstrapper) admit(obj *unstructured.Unstructured) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// not started yet, fall back to regular processing
+	if b.queue == nil {
+		return true
+	}
+
+	clusterName := logicalcluster.From(obj)
+
+	state, exists := b.clusters[clusterName]
+	if !exists {
+		state = &clusterBootstrap{
+			seen:     sets.New[types.NamespacedName](),
+			deferred: sets.New[types.NamespacedName](),
+		}
+		b.clusters[clusterName] = state
+
+		go b.run(b.ctx, clusterName)
+	}
+
+	if state.complete {
+		return true
+	}
+
+	state.deferred.Insert(ctrlruntimeclient.ObjectKeyFromObject(obj))
+
+	return false
+}
+
+func (b *bootstrapper) run(ctx context.Context, clusterName logicalcluster.Name) {
+	log := b.log.With("cluster", clusterName)
+	progress := metrics.InitialSyncProgress.WithLabelValues(b.pubRes.Name, clusterName.String())
+
+	mark, err := b.loadBookmark(ctx, clusterName)
+	if err != nil {
+		log.Errorw("Failed to load initial sync progress, starting from the beginning", zap.Error(err))
+		mark = &bookmark{}
+	}
+
+	if mark.Continue != "" {
+		log.Infow("Resuming initial sync", "processed", mark.Processed, "total", mark.Total)
+	}
+
+	for !mark.Complete {
+		if err := b.processChunk(ctx, clusterName, mark); err != nil {
+			log.Errorw("Failed to process chunk", zap.Error(err))
+		} else {
+			progress.Set(mark.percentage())
+			log.Debugw("Processed chunk", "processed", mark.Processed, "total", mark.Total)
+
+			if err := b.saveBookmark(ctx, clusterName, mark); err != nil {
+				log.Errorw("Failed to persist initial sync progress", zap.Error(err))
+			}
+		}
+
+		if mark.Complete {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(b.interval):
+		}
+	}
+
+	progress.Set(100)
+	b.complete(clusterName)
+
+	log.Info("Initial sync completed")
+}
+
+func (b *bootstrapper) processChunk(ctx context.Context, clusterName logicalcluster.Name, mark *bookmark) error {
+	remoteObjs := &unstructured.UnstructuredList{}
+	remoteObjs.SetAPIVersion(b.remoteDummy.GetAPIVersion())
+	remoteObjs.SetKind(b.remoteDummy.GetKind() + "List")
+
+	opts := []ctrlruntimeclient.ListOption{ctrlruntimeclient.Limit(b.chunkSize)}
+	if mark.Continue != "" {
+		opts = append(opts, ctrlruntimeclient.Continue(mark.Continue))
+	}
+
+	err := b.remoteReader.List(kontext.WithCluster(ctx, clusterName), remoteObjs, opts...)
+	if apierrors.IsResourceExpired(err) {
+		// the continue token is too old, start over
+		*mark = bookmark{}
+		return fmt.Errorf("continue token has expired: %w", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	b.lock.Lock()
+	state := b.clusters[clusterName]
+	for _, obj := range remoteObjs.Items {
+		key := ctrlruntimeclient.ObjectKeyFromObject(&obj)
+		state.seen.Insert(key)
+
+		b.queue.Add(reconcile.Request{
+			NamespacedName: key,
+			ClusterName:    clusterName.String(),
+		})
+	}
+	b.lock.Unlock()
+
+	mark.Processed += int64(len(remoteObjs.Items))
+	mark.Total = mark.Processed
+	if remaining := remoteObjs.GetRemainingItemCount(); remaining != nil {
+		mark.Total += *remaining
+	}
+
+	mark.Continue = remoteObjs.GetContinue()
+	mark.Complete = mark.Continue == ""
+
+	return nil
+}
+
+// completed returns true if the initial sync of the given workspace has finished.
+// Workspaces that the bootstrapper has not seen yet are not completed.
+func (b *bootstrapper) completed(clusterName logicalcluster.Name) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	state, exists := b.clusters[clusterName]
+
+	return exists && state.complete
+}
+
+// complete marks the initial sync of a workspace as done and enqueues all objects
+// whose create events were held back but which have not been listed (i.e. objects
+// that were created after the listing had moved past them, or objects that were
+// already processed before the agent was restarted).
+func (b *bootstrapper) complete(clusterName logicalcluster.Name) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	state := b.clusters[clusterName]
+
+	for key := range state.deferred.Difference(state.seen) {
+		b.queue.Add(reconcile.Request{
+			NamespacedName: key,
+			ClusterName:    clusterName.String(),
+		})
+	}
+
+	state.complete = true
+	state.seen = nil
+	state.deferred = nil
+}
+
+func (m *bookmark) percentage() float64 {
+	if m.Complete || m.Total == 0 {
+		return 100
+	}
+
+	return math.Floor(float64(m.Processed) / float64(m.Total) * 100)
+}
+
+func (b *bootstrapper) secretName(clusterName logicalcluster.Name) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: b.stateNamespace,
+		Name:      fmt.Sprintf("initial-sync-%s-%s", clusterName, crypto.ShortHash(b.pubRes.Name)),
+	}
+}
+
+func (b *bootstrapper) loadBookmark(ctx context.Context, clusterName logicalcluster.Name) (*bookmark, error) {
+	secret := &corev1.Secret{}
+	if err := b.localClient.Get(ctx, b.secretName(clusterName), secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &bookmark{}, nil
+		}
+
+		return nil, err
+	}
+
+	mark := &bookmark{}
+	if err := json.Unmarshal(secret.Data["bookmark"], mark); err != nil {
+		return nil, fmt.Errorf("failed to decode bookmark: %w", err)
+	}
+
+	return mark, nil
+}
+
+func (b *bootstrapper) saveBookmark(ctx context.Context, clusterName logicalcluster.Name, mark *bookmark) error {
+	encoded, err := json.Marshal(mark)
+	if err != nil {
+		return fmt.Errorf("failed to encode bookmark: %w", err)
+	}
+
+	key := b.secretName(clusterName)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &corev1.Secret{}
+		if err := b.localClient.Get(ctx, key, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					Labels: map[string]string{
+						initialSyncLabel: "true",
+					},
+				},
+				Data: map[string][]byte{"bookmark": encoded},
+			}
+
+			return b.localClient.Create(ctx, secret)
+		}
+
+		secret.Data = map[string][]byte{"bookmark": encoded}
+
+		return b.localClient.Update(ctx, secret)
+	})
+}
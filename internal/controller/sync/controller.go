@@ -19,16 +19,23 @@ package sync
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
 	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 
+	"github.com/kcp-dev/api-syncagent/internal/audit"
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
 	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
 	"github.com/kcp-dev/api-syncagent/internal/mutation"
 	"github.com/kcp-dev/api-syncagent/internal/projection"
 	"github.com/kcp-dev/api-syncagent/internal/sync"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/filter"
 
 	kcpcore "github.com/kcp-dev/kcp/sdk/apis/core"
 	kcpdevcorev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
@@ -38,6 +45,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
@@ -52,6 +62,18 @@ import (
 
 const (
 	ControllerName = "syncagent-sync"
+
+	// excludedAnnotation is placed on objects in kcp that are excluded by the filter,
+	// if the PublishedResource is configured to do so.
+	excludedAnnotation = "syncagent.kcp.io/excluded"
+	excludedMessage    = "This object does not match the filter configured by the service provider and is not synchronized."
+
+	// limitExceededAnnotation is placed on objects in kcp that are not synchronized
+	// because the PublishedResource's object limits were reached.
+	limitExceededAnnotation = "syncagent.kcp.io/limit-exceeded"
+
+	// limitRequeueInterval is how often objects blocked by a limit are checked again.
+	limitRequeueInterval = time.Minute
 )
 
 type Reconciler struct {
@@ -59,8 +81,24 @@ type Reconciler struct {
 	vwClient    ctrlruntimeclient.Client
 	log         *zap.SugaredLogger
 	syncer      *sync.ResourceSyncer
+	localDummy  *unstructured.Unstructured
 	remoteDummy *unstructured.Unstructured
 	pubRes      *syncagentv1alpha1.PublishedResource
+	statistics  *Statistics
+	recorder    record.EventRecorder
+
+	// filterExpression is the compiled CEL expression from the filter, if any
+	filterExpression *filter.Expression
+
+	// skipCache remembers which workspaces must not be synchronized
+	skipCache *workspaceSkipCache
+
+	// filtered remembers which objects are currently excluded by the filter
+	filtered *filteredObjects
+
+	// drainTimeout is how long in-flight reconciliations may continue after the
+	// controller has been stopped
+	drainTimeout time.Duration
 }
 
 // Create creates a new controller and importantly does *not* add it to the manager,
@@ -71,10 +109,14 @@ func Create(
 	virtualWorkspaceCluster cluster.Cluster,
 	pubRes *syncagentv1alpha1.PublishedResource,
 	discoveryClient *discovery.Client,
-	stateNamespace string,
+	stateOptions sync.StateOptions,
 	agentName string,
 	log *zap.SugaredLogger,
 	numWorkers int,
+	statistics *Statistics,
+	auditLog *audit.Logger,
+	writeHook sync.WriteHook,
+	drainTimeout time.Duration,
 ) (controller.Controller, error) {
 	log = log.Named(ControllerName)
 
@@ -94,21 +136,55 @@ func Create(
 		return nil, fmt.Errorf("failed to find local CRD: %w", err)
 	}
 
+	// optionally limit the rate of requests to kcp for this resource
+	vwClient := newThrottledClient(virtualWorkspaceCluster.GetClient(), pubRes.Spec.Sync)
+
+	// optionally record all writes on both sides
+	vwClient = auditLog.WrapClient(vwClient, audit.TargetKcp, pubRes.Name)
+	localClient := auditLog.WrapClient(localManager.GetClient(), audit.TargetServiceCluster, pubRes.Name)
+	// run the hooks before writes are audited, so the audit log shows the final objects
+	localClient = sync.NewHookedClient(localClient, writeHook)
+
+	// a PublishedResource can store its object states in its own namespace
+	stateOptions = stateOptions.ForPublishedResource(pubRes)
+	if err := sync.EnsureStateNamespace(ctx, localManager.GetClient(), pubRes, agentName); err != nil {
+		return nil, fmt.Errorf("failed to ensure state namespace: %w", err)
+	}
+
 	// create the syncer that holds the meat&potatoes of the synchronization logic
-	mutator := mutation.NewMutator(pubRes.Spec.Mutation)
-	syncer, err := sync.NewResourceSyncer(log, localManager.GetClient(), virtualWorkspaceCluster.GetClient(), pubRes, localCRD, mutator, stateNamespace, agentName)
+	mutator := mutation.NewMutator(pubRes.Spec.Mutation, agentName)
+	syncer, err := sync.NewResourceSyncer(log, localClient, vwClient, pubRes, localCRD, discoveryClient, mutator, localManager.GetEventRecorderFor(ControllerName), stateOptions, agentName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create syncer: %w", err)
 	}
 
+	// summarize the sync latency in the PublishedResource's status
+	syncer.SetLatencyObserver(statistics.RecordLatency)
+
+	var filterExpression *filter.Expression
+	if pubRes.Spec.Filter != nil && pubRes.Spec.Filter.Expression != "" {
+		filterExpression, err = filter.NewExpression(pubRes.Spec.Filter.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile filter expression: %w", err)
+		}
+	}
+
 	// setup the reconciler
 	reconciler := &Reconciler{
-		localClient: localManager.GetClient(),
-		vwClient:    virtualWorkspaceCluster.GetClient(),
-		log:         log,
-		remoteDummy: remoteDummy,
-		syncer:      syncer,
-		pubRes:      pubRes,
+		localClient:  localClient,
+		vwClient:     vwClient,
+		log:          log,
+		localDummy:   localDummy,
+		remoteDummy:  remoteDummy,
+		syncer:       syncer,
+		pubRes:       pubRes,
+		statistics:   statistics,
+		recorder:     localManager.GetEventRecorderFor(ControllerName),
+		drainTimeout: drainTimeout,
+
+		filterExpression: filterExpression,
+		skipCache:        newWorkspaceSkipCache(clock.RealClock{}),
+		filtered:         newFilteredObjects(),
 	}
 
 	ctrlOptions := controller.Options{
@@ -117,6 +193,16 @@ func Create(
 		SkipNameValidation:      ptr.To(true),
 	}
 
+	if settings := pubRes.Spec.Sync; settings != nil {
+		if settings.MaxConcurrentReconciles > 0 {
+			ctrlOptions.MaxConcurrentReconciles = settings.MaxConcurrentReconciles
+		}
+
+		if settings.RequeueBackoff != nil {
+			ctrlOptions.RateLimiter = newRateLimiter(settings.RequeueBackoff)
+		}
+	}
+
 	// It doesn't really matter what manager is used here, as starting/stopping happens
 	// outside of the manager's control anyway.
 	c, err := controller.NewUnmanaged(ControllerName, localManager, ctrlOptions)
@@ -124,8 +210,59 @@ func Create(
 		return nil, err
 	}
 
+	// objects originating on the service cluster are watched there and published into kcp
+	if pubRes.Spec.Origin == syncagentv1alpha1.PublishedResourceOriginService {
+		if err := watchServiceObjects(c, localManager, localDummy); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	// optionally throttle the initial sync of workspaces; the bootstrapper must be
+	// started before the remote objects are watched
+	remotePredicates := []predicate.TypedPredicate[*unstructured.Unstructured]{}
+
+	resync := &resyncer{
+		log:         log.Named("resync"),
+		pubResName:  pubRes.Name,
+		cache:       virtualWorkspaceCluster.GetCache(),
+		remoteDummy: remoteDummy,
+	}
+
+	if settings := pubRes.Spec.Sync; settings != nil && settings.ResyncPeriod != nil {
+		resync.period = settings.ResyncPeriod.Duration
+	}
+
+	if pubRes.Spec.InitialSync != nil {
+		bootstrapper := newBootstrapper(log, pubRes, virtualWorkspaceCluster.GetAPIReader(), localManager.GetClient(), remoteDummy, stateOptions.Namespace)
+
+		if err := c.Watch(bootstrapper); err != nil {
+			return nil, err
+		}
+
+		remotePredicates = append(remotePredicates, bootstrapper.Predicate())
+
+		// workspaces that are still being bootstrapped must not be flooded by the resync
+		resync.admit = bootstrapper.completed
+	}
+
+	// enqueue all existing objects, so they are processed using the current configuration
+	if err := c.Watch(resync); err != nil {
+		return nil, err
+	}
+
+	// optionally look for local objects whose object in kcp has disappeared unnoticed
+	if pubRes.Spec.Orphans != nil {
+		orphans := newOrphanScanner(log.Named("orphans"), pubRes, agentName, localClient, virtualWorkspaceCluster.GetAPIReader(), localDummy, remoteDummy, localManager.GetEventRecorderFor(ControllerName))
+
+		if err := c.Watch(orphans); err != nil {
+			return nil, err
+		}
+	}
+
 	// watch the target resource in the virtual workspace
-	if err := c.Watch(source.Kind(virtualWorkspaceCluster.GetCache(), remoteDummy, &handler.TypedEnqueueRequestForObject[*unstructured.Unstructured]{})); err != nil {
+	if err := c.Watch(source.Kind(virtualWorkspaceCluster.GetCache(), remoteDummy, &handler.TypedEnqueueRequestForObject[*unstructured.Unstructured]{}, remotePredicates...)); err != nil {
 		return nil, err
 	}
 
@@ -148,13 +285,106 @@ func Create(
 		return nil, err
 	}
 
+	// when namespace metadata is propagated, changes to namespaces must be reflected on all objects within
+	if len(pubRes.Spec.NamespaceLabels) > 0 || pubRes.Spec.NamespaceSync != nil {
+		vwCache := virtualWorkspaceCluster.GetCache()
+
+		enqueueRemoteObjsForNamespace := handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, ns *corev1.Namespace) []reconcile.Request {
+			clusterName := logicalcluster.From(ns)
+
+			remoteObjs := &unstructured.UnstructuredList{}
+			remoteObjs.SetAPIVersion(remoteDummy.GetAPIVersion())
+			remoteObjs.SetKind(remoteDummy.GetKind() + "List")
+
+			if err := vwCache.List(kontext.WithCluster(ctx, clusterName), remoteObjs, ctrlruntimeclient.InNamespace(ns.Name)); err != nil {
+				log.Errorw("Failed to list objects in namespace", "namespace", ns.Name, "cluster", clusterName, zap.Error(err))
+				return nil
+			}
+
+			requests := []reconcile.Request{}
+			for _, obj := range remoteObjs.Items {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(&obj),
+					ClusterName:    clusterName.String(),
+				})
+			}
+
+			return requests
+		})
+
+		if err := c.Watch(source.Kind(vwCache, &corev1.Namespace{}, enqueueRemoteObjsForNamespace)); err != nil {
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
+// newRateLimiter returns a rate limiter like the controller-runtime default, but with
+// the exponential per-item backoff configured by the user.
+func newRateLimiter(backoff *syncagentv1alpha1.RequeueBackoff) workqueue.TypedRateLimiter[reconcile.Request] {
+	initial := 5 * time.Millisecond
+	if backoff.Initial != nil {
+		initial = backoff.Initial.Duration
+	}
+
+	maxDelay := 1000 * time.Second
+	if backoff.Max != nil {
+		maxDelay = backoff.Max.Duration
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](initial, maxDelay),
+		// 10 qps, 100 bucket size, same as the default
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+	)
+}
+
+// watchServiceObjects sets up the watch for PublishedResources with origin "service",
+// where the local objects determine the workspace they are published into.
+func watchServiceObjects(c controller.Controller, localManager manager.Manager, localDummy *unstructured.Unstructured) error {
+	enqueueTarget := handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, o *unstructured.Unstructured) []reconcile.Request {
+		req := sync.TargetForServiceObject(o)
+		if req == nil {
+			return nil
+		}
+
+		return []reconcile.Request{*req}
+	})
+
+	return c.Watch(source.Kind(localManager.GetCache(), localDummy, enqueueTarget))
+}
+
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// do not start working on new objects while shutting down
+	if ctx.Err() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// allow an in-flight reconciliation to finish writing the objects and their
+	// last-known state instead of aborting it halfway through
+	ctx, cancel := controllerutil.DrainContext(ctx, r.drainTimeout)
+	defer cancel()
+
 	log := r.log.With("request", request, "cluster", request.ClusterName)
 	log.Debug("Processing")
 
+	// platform admins can quarantine entire workspaces
+	skip, err := r.workspaceSkipped(ctx, logicalcluster.Name(request.ClusterName))
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to check whether workspace is skipped: %w", err)
+	}
+
+	if skip {
+		log.Debug("Workspace is skipped")
+		metrics.SkippedObjects.WithLabelValues(r.pubRes.Name).Inc()
+		return reconcile.Result{RequeueAfter: skipCacheTTL}, nil
+	}
+
+	if r.pubRes.Spec.Origin == syncagentv1alpha1.PublishedResourceOriginService {
+		return r.reconcileServiceObject(ctx, log, request)
+	}
+
 	wsCtx := kontext.WithCluster(ctx, logicalcluster.Name(request.ClusterName))
 
 	remoteObj := r.remoteDummy.DeepCopy()
@@ -164,12 +394,13 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	// object was not found anymore
 	if remoteObj.GetName() == "" {
+		r.filtered.include(request)
 		return reconcile.Result{}, nil
 	}
 
-	// if there is a namespace, get it if a namespace filter is also configured
+	// if there is a namespace, get it if a namespace filter or label propagation is also configured
 	var namespace *corev1.Namespace
-	if filter := r.pubRes.Spec.Filter; filter != nil && filter.Namespace != nil && remoteObj.GetNamespace() != "" {
+	if r.needsNamespace() && remoteObj.GetNamespace() != "" {
 		namespace = &corev1.Namespace{}
 		key := types.NamespacedName{Name: remoteObj.GetNamespace()}
 
@@ -179,17 +410,74 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	}
 
 	// apply filtering rules to scope down the number of objects we sync
-	include, err := r.objectMatchesFilter(remoteObj, namespace)
+	include, err := r.objectMatchesFilter(ctx, remoteObj, namespace)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to apply filtering rules: %w", err)
 	}
 
 	if !include {
+		log.Debug("Object does not match filter, skipping")
+		if r.filtered.exclude(request) {
+			metrics.FilteredObjects.WithLabelValues(r.pubRes.Name).Inc()
+		}
+
+		if r.pubRes.Spec.Filter.AnnotateExcluded {
+			if err := r.setExcludedAnnotation(wsCtx, remoteObj, true); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to annotate excluded object: %w", err)
+			}
+		}
+
 		return reconcile.Result{}, nil
 	}
 
+	r.filtered.include(request)
+
+	// the object might have been excluded previously
+	if _, exists := remoteObj.GetAnnotations()[excludedAnnotation]; exists {
+		// the patch will trigger a new reconciliation
+		return reconcile.Result{}, r.setExcludedAnnotation(wsCtx, remoteObj, false)
+	}
+
+	// new objects must not exceed the configured limits
+	if r.pubRes.Spec.Limits != nil && !sync.IsSynchronized(remoteObj) && remoteObj.GetDeletionTimestamp() == nil {
+		limit, message, err := r.exceededLimit(ctx, logicalcluster.Name(request.ClusterName))
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to check object limits: %w", err)
+		}
+
+		if limit != "" {
+			log.Infow("Object limit reached, not synchronizing object", "limit", limit)
+
+			if remoteObj.GetAnnotations()[limitExceededAnnotation] != message {
+				metrics.LimitedObjects.WithLabelValues(r.pubRes.Name, limit).Inc()
+				r.recorder.Eventf(r.pubRes, corev1.EventTypeWarning, "LimitExceeded", "Object %s in workspace %s is not synchronized: %s", ctrlruntimeclient.ObjectKeyFromObject(remoteObj), request.ClusterName, message)
+
+				if err := r.setAnnotation(wsCtx, remoteObj, limitExceededAnnotation, message); err != nil {
+					return reconcile.Result{}, fmt.Errorf("failed to annotate limited object: %w", err)
+				}
+			}
+
+			// check again later, other objects might have been deleted in the meantime
+			return reconcile.Result{RequeueAfter: limitRequeueInterval}, nil
+		}
+	}
+
+	// the object might have been blocked by a limit previously
+	if _, exists := remoteObj.GetAnnotations()[limitExceededAnnotation]; exists {
+		// the patch will trigger a new reconciliation
+		return reconcile.Result{}, r.setAnnotation(wsCtx, remoteObj, limitExceededAnnotation, "")
+	}
+
 	syncContext := sync.NewContext(ctx, wsCtx)
 
+	if namespace != nil && len(r.pubRes.Spec.NamespaceLabels) > 0 {
+		syncContext = syncContext.WithNamespaceLabels(propagatedNamespaceLabels(namespace, r.pubRes.Spec.NamespaceLabels))
+	}
+
+	if namespace != nil && r.pubRes.Spec.NamespaceSync != nil {
+		syncContext = syncContext.WithNamespace(namespace)
+	}
+
 	// if desired, fetch the cluster path as well (some downstream service providers might make use of it,
 	// but since it requires an additional permission claim, it's optional)
 	if r.pubRes.Spec.EnableWorkspacePaths {
@@ -204,20 +492,225 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	// sync main object
 	requeue, err := r.syncer.Process(syncContext, remoteObj)
+	r.statistics.Record(err)
 	if err != nil {
+		// let the consumer know why their object is not synchronized; an Event is only
+		// recorded when the error changes, so that retries do not flood the workspace
+		message := sanitizeSyncError(err)
+		if remoteObj.GetAnnotations()[syncagentv1alpha1.SyncErrorAnnotation] != message {
+			r.syncer.RecordSyncError(syncContext, remoteObj, message)
+		}
+
+		if annotateErr := r.setAnnotation(wsCtx, remoteObj, syncagentv1alpha1.SyncErrorAnnotation, message); ctrlruntimeclient.IgnoreNotFound(annotateErr) != nil {
+			log.Warnw("Failed to annotate object with sync error", zap.Error(annotateErr))
+		}
+
 		return reconcile.Result{}, err
 	}
 
+	// the object might have failed to sync previously
+	if err := r.setAnnotation(wsCtx, remoteObj, syncagentv1alpha1.SyncErrorAnnotation, ""); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to remove sync error annotation: %w", err)
+	}
+
+	return requeueResult(requeue), nil
+}
+
+// reconcileServiceObject publishes a local object into the kcp workspace named by its
+// target cluster label.
+func (r *Reconciler) reconcileServiceObject(ctx context.Context, log *zap.SugaredLogger, request reconcile.Request) (reconcile.Result, error) {
+	localObj := r.localDummy.DeepCopy()
+	if err := r.localClient.Get(ctx, request.NamespacedName, localObj); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to retrieve local object: %w", err)
+	}
+
+	// object was not found anymore
+	if localObj.GetName() == "" {
+		r.filtered.include(request)
+		return reconcile.Result{}, nil
+	}
+
+	// if the label has been removed, the object must not be blocked from being deleted
+	targetCluster := localObj.GetLabels()[syncagentv1alpha1.TargetClusterLabel]
+	if targetCluster == "" {
+		log.Info("Object has no target cluster anymore, releasing it")
+		return reconcile.Result{}, r.syncer.ReleaseLocal(ctx, localObj)
+	}
+
+	// the label has been changed, the request for the new cluster is processed separately
+	if targetCluster != request.ClusterName {
+		return reconcile.Result{}, nil
+	}
+
+	include, err := r.matchesFilter(localObj, r.resourceFilter())
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to apply filtering rules: %w", err)
+	}
+
+	if !include {
+		log.Debug("Object does not match filter, skipping")
+		if r.filtered.exclude(request) {
+			metrics.FilteredObjects.WithLabelValues(r.pubRes.Name).Inc()
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	r.filtered.include(request)
+
+	wsCtx := kontext.WithCluster(ctx, logicalcluster.Name(targetCluster))
+	syncContext := sync.NewContext(ctx, wsCtx)
+
+	requeue, err := r.syncer.ProcessLocal(syncContext, localObj)
+	r.statistics.Record(err)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return requeueResult(requeue), nil
+}
+
+func requeueResult(requeue bool) reconcile.Result {
 	result := reconcile.Result{}
 	if requeue {
 		// 5s was chosen at random, winning narrowly against 6s and 4.7s
 		result.RequeueAfter = 5 * time.Second
 	}
 
-	return result, nil
+	return result
 }
 
-func (r *Reconciler) objectMatchesFilter(remoteObj *unstructured.Unstructured, namespace *corev1.Namespace) (bool, error) {
+func (r *Reconciler) resourceFilter() *metav1.LabelSelector {
+	if r.pubRes.Spec.Filter == nil {
+		return nil
+	}
+
+	return r.pubRes.Spec.Filter.Resource
+}
+
+// setExcludedAnnotation adds or removes the annotation that informs consumers about an
+// object being excluded by the filter; it does nothing if the annotation is already
+// in the desired state.
+func (r *Reconciler) setExcludedAnnotation(ctx context.Context, remoteObj *unstructured.Unstructured, excluded bool) error {
+	value := ""
+	if excluded {
+		value = excludedMessage
+	}
+
+	return r.setAnnotation(ctx, remoteObj, excludedAnnotation, value)
+}
+
+// setAnnotation sets the annotation on the remote object to the given value or removes
+// it if the value is empty; it does nothing if the annotation is already in the
+// desired state.
+func (r *Reconciler) setAnnotation(ctx context.Context, remoteObj *unstructured.Unstructured, key, value string) error {
+	annotations := remoteObj.GetAnnotations()
+
+	current, exists := annotations[key]
+	if (value == "" && !exists) || (value != "" && current == value) {
+		return nil
+	}
+
+	oldObj := remoteObj.DeepCopy()
+
+	if value != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[key] = value
+	} else {
+		delete(annotations, key)
+	}
+
+	remoteObj.SetAnnotations(annotations)
+
+	return r.vwClient.Patch(ctx, remoteObj, ctrlruntimeclient.MergeFrom(oldObj))
+}
+
+// exceededLimit checks whether synchronizing another object from the given workspace
+// would exceed the configured limits. If so, the name of the limit and a message for
+// the consumer are returned.
+func (r *Reconciler) exceededLimit(ctx context.Context, clusterName logicalcluster.Name) (string, string, error) {
+	limits := r.pubRes.Spec.Limits
+
+	if limits.MaxObjectsPerWorkspace > 0 {
+		count, err := r.countSynchronizedObjects(kontext.WithCluster(ctx, clusterName))
+		if err != nil {
+			return "", "", err
+		}
+
+		if count >= limits.MaxObjectsPerWorkspace {
+			return "maxObjectsPerWorkspace", fmt.Sprintf("The maximum of %d objects per workspace has been reached.", limits.MaxObjectsPerWorkspace), nil
+		}
+	}
+
+	if limits.MaxObjects > 0 {
+		// without a cluster in the context, objects from all workspaces are listed
+		count, err := r.countSynchronizedObjects(ctx)
+		if err != nil {
+			return "", "", err
+		}
+
+		if count >= limits.MaxObjects {
+			return "maxObjects", fmt.Sprintf("The maximum of %d objects has been reached.", limits.MaxObjects), nil
+		}
+	}
+
+	return "", "", nil
+}
+
+// countSynchronizedObjects counts the remote objects that the Sync Agent has started to
+// synchronize. The objects are read from the cache.
+func (r *Reconciler) countSynchronizedObjects(ctx context.Context) (int64, error) {
+	remoteObjs := &unstructured.UnstructuredList{}
+	remoteObjs.SetAPIVersion(r.remoteDummy.GetAPIVersion())
+	remoteObjs.SetKind(r.remoteDummy.GetKind() + "List")
+
+	if err := r.vwClient.List(ctx, remoteObjs); err != nil {
+		return 0, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	var count int64
+	for i := range remoteObjs.Items {
+		if sync.IsSynchronized(&remoteObjs.Items[i]) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *Reconciler) needsNamespace() bool {
+	if filter := r.pubRes.Spec.Filter; filter != nil && (filter.Namespace != nil || filter.Expression != "") {
+		return true
+	}
+
+	return len(r.pubRes.Spec.NamespaceLabels) > 0 || r.pubRes.Spec.NamespaceSync != nil
+}
+
+// propagatedNamespaceLabels returns the labels from the namespace that should be
+// placed on the local object.
+func propagatedNamespaceLabels(namespace *corev1.Namespace, mappings []syncagentv1alpha1.NamespaceLabelMapping) map[string]string {
+	result := map[string]string{}
+
+	for _, mapping := range mappings {
+		value, ok := namespace.Labels[mapping.Label]
+		if !ok {
+			continue
+		}
+
+		target := mapping.TargetLabel
+		if target == "" {
+			target = mapping.Label
+		}
+
+		result[target] = value
+	}
+
+	return result
+}
+
+func (r *Reconciler) objectMatchesFilter(ctx context.Context, remoteObj *unstructured.Unstructured, namespace *corev1.Namespace) (bool, error) {
 	if r.pubRes.Spec.Filter == nil {
 		return true, nil
 	}
@@ -232,9 +725,39 @@ func (r *Reconciler) objectMatchesFilter(remoteObj *unstructured.Unstructured, n
 		return false, err
 	}
 
+	if ns := remoteObj.GetNamespace(); ns != "" && !matchesNamespaceName(ns, r.pubRes.Spec.Filter.NamespaceNames) {
+		return false, nil
+	}
+
+	if r.filterExpression != nil {
+		return r.filterExpression.Matches(ctx, remoteObj, namespace)
+	}
+
 	return true, nil
 }
 
+// matchesNamespaceName checks the name of a namespace against the include and
+// exclude patterns of the given filter. Patterns have been validated already, so
+// errors from path.Match cannot occur.
+func matchesNamespaceName(namespace string, names *syncagentv1alpha1.NamespaceNameFilter) bool {
+	if names == nil {
+		return true
+	}
+
+	matchesAny := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, namespace)
+			return matched
+		})
+	}
+
+	if len(names.Include) > 0 && !matchesAny(names.Include) {
+		return false
+	}
+
+	return !matchesAny(names.Exclude)
+}
+
 func (r *Reconciler) matchesFilter(obj metav1.Object, selector *metav1.LabelSelector) (bool, error) {
 	if selector == nil {
 		return true, nil
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	gosync "sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// filteredObjects remembers which objects are currently excluded by the filter,
+// so that excluded objects are only counted once when they become excluded, and
+// not again on every (periodic) reconciliation.
+type filteredObjects struct {
+	lock     gosync.Mutex
+	requests sets.Set[reconcile.Request]
+}
+
+func newFilteredObjects() *filteredObjects {
+	return &filteredObjects{
+		requests: sets.New[reconcile.Request](),
+	}
+}
+
+// exclude marks the object as excluded and returns true if it was not excluded
+// before.
+func (f *filteredObjects) exclude(request reconcile.Request) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.requests.Has(request) {
+		return false
+	}
+
+	f.requests.Insert(request)
+
+	return true
+}
+
+// include forgets the object, because it matches the filter (again) or has been
+// deleted.
+func (f *filteredObjects) include(request reconcile.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests.Delete(request)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFilteredObjects(t *testing.T) {
+	thing := reconcile.Request{ClusterName: "root", NamespacedName: types.NamespacedName{Namespace: "default", Name: "thing"}}
+	otherCluster := reconcile.Request{ClusterName: "other", NamespacedName: thing.NamespacedName}
+
+	type step struct {
+		request reconcile.Request
+		exclude bool
+		counted bool
+	}
+
+	testcases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "repeatedly excluded object is only counted once",
+			steps: []step{
+				{request: thing, exclude: true, counted: true},
+				{request: thing, exclude: true},
+				{request: thing, exclude: true},
+			},
+		},
+		{
+			name: "object is counted again after it was included in between",
+			steps: []step{
+				{request: thing, exclude: true, counted: true},
+				{request: thing},
+				{request: thing, exclude: true, counted: true},
+			},
+		},
+		{
+			name: "objects in different workspaces are counted separately",
+			steps: []step{
+				{request: thing, exclude: true, counted: true},
+				{request: otherCluster, exclude: true, counted: true},
+				{request: thing, exclude: true},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			filtered := newFilteredObjects()
+
+			for i, s := range testcase.steps {
+				if !s.exclude {
+					filtered.include(s.request)
+					continue
+				}
+
+				if counted := filtered.exclude(s.request); counted != s.counted {
+					t.Fatalf("Step %d: expected counted=%v, but got %v.", i, s.counted, counted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// defaultOrphanInterval is how often orphans are checked for if the
+// PublishedResource does not configure an interval.
+const defaultOrphanInterval = time.Hour
+
+// orphanScanner periodically looks for local objects whose object in kcp does not
+// exist anymore. Usually the cleanup finalizer prevents this, but if a remote object
+// is force-deleted while the agent is not running, no event would ever trigger the
+// cleanup of the local copy. Remote objects are fetched without a cache, so that
+// objects are not mistaken for orphans just because the cache is not up-to-date.
+type orphanScanner struct {
+	log          *zap.SugaredLogger
+	pubRes       *syncagentv1alpha1.PublishedResource
+	agentName    string
+	localClient  ctrlruntimeclient.Client
+	remoteReader ctrlruntimeclient.Reader
+	localDummy   *unstructured.Unstructured
+	remoteDummy  *unstructured.Unstructured
+	recorder     record.EventRecorder
+	policy       syncagentv1alpha1.OrphanPolicy
+	interval     time.Duration
+}
+
+func newOrphanScanner(
+	log *zap.SugaredLogger,
+	pubRes *syncagentv1alpha1.PublishedResource,
+	agentName string,
+	localClient ctrlruntimeclient.Client,
+	remoteReader ctrlruntimeclient.Reader,
+	localDummy *unstructured.Unstructured,
+	remoteDummy *unstructured.Unstructured,
+	recorder record.EventRecorder,
+) *orphanScanner {
+	settings := pubRes.Spec.Orphans
+
+	scanner := &orphanScanner{
+		log:          log,
+		pubRes:       pubRes,
+		agentName:    agentName,
+		localClient:  localClient,
+		remoteReader: remoteReader,
+		localDummy:   localDummy,
+		remoteDummy:  remoteDummy,
+		recorder:     recorder,
+		policy:       settings.Policy,
+		interval:     defaultOrphanInterval,
+	}
+
+	if scanner.policy == "" {
+		scanner.policy = syncagentv1alpha1.OrphanPolicyReport
+	}
+
+	if settings.Interval != nil && settings.Interval.Duration > 0 {
+		scanner.interval = settings.Interval.Duration
+	}
+
+	return scanner
+}
+
+// Start implements source.Source. The scanner does not enqueue anything, it is
+// only a source so that it runs exactly as long as the sync controller.
+func (s *orphanScanner) Start(ctx context.Context, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	go s.run(ctx)
+
+	return nil
+}
+
+func (s *orphanScanner) run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.scan(ctx); err != nil {
+			s.log.Errorw("Failed to check for orphaned objects", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *orphanScanner) scan(ctx context.Context) error {
+	localObjs := &unstructured.UnstructuredList{}
+	localObjs.SetAPIVersion(s.localDummy.GetAPIVersion())
+	localObjs.SetKind(s.localDummy.GetKind() + "List")
+
+	if err := s.localClient.List(ctx, localObjs); err != nil {
+		return err
+	}
+
+	orphans := 0
+	for i := range localObjs.Items {
+		localObj := &localObjs.Items[i]
+
+		orphan, err := s.isOrphan(ctx, localObj)
+		if err != nil {
+			s.log.Warnw("Failed to check object", "object", ctrlruntimeclient.ObjectKeyFromObject(localObj), zap.Error(err))
+			continue
+		}
+
+		if !orphan {
+			continue
+		}
+
+		orphans++
+		log := s.log.With("object", ctrlruntimeclient.ObjectKeyFromObject(localObj))
+
+		if s.policy != syncagentv1alpha1.OrphanPolicyDelete {
+			log.Info("Found orphaned object")
+			continue
+		}
+
+		log.Info("Deleting orphaned object…")
+		if err := s.localClient.Delete(ctx, localObj); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			log.Errorw("Failed to delete orphaned object", zap.Error(err))
+			continue
+		}
+
+		metrics.DeletedOrphans.WithLabelValues(s.pubRes.Name).Inc()
+		s.recorder.Eventf(s.pubRes, corev1.EventTypeNormal, "OrphanDeleted", "Deleted local object %s, as its object in kcp does not exist anymore.", ctrlruntimeclient.ObjectKeyFromObject(localObj))
+	}
+
+	metrics.OrphanedObjects.WithLabelValues(s.pubRes.Name).Set(float64(orphans))
+
+	if orphans > 0 && s.policy != syncagentv1alpha1.OrphanPolicyDelete {
+		s.recorder.Eventf(s.pubRes, corev1.EventTypeWarning, "OrphansFound", "Found %d local objects whose objects in kcp do not exist anymore.", orphans)
+	}
+
+	return nil
+}
+
+// isOrphan returns true if the given local object was synchronized from kcp by this
+// PublishedResource and the remote object does not exist anymore.
+func (s *orphanScanner) isOrphan(ctx context.Context, localObj *unstructured.Unstructured) (bool, error) {
+	if localObj.GetDeletionTimestamp() != nil || !sync.OwnedBy(localObj, s.agentName) {
+		return false, nil
+	}
+
+	// objects synchronized by other PublishedResources for the same type are ignored;
+	// objects synchronized before the identity was recorded are still considered
+	if owner, ok := localObj.GetAnnotations()[syncagentv1alpha1.PublishedResourceAnnotation]; ok && owner != s.pubRes.Name {
+		return false, nil
+	}
+
+	req := sync.RemoteNameForLocalObject(localObj)
+	if req == nil {
+		return false, nil
+	}
+
+	remoteObj := s.remoteDummy.DeepCopy()
+	wsCtx := kontext.WithCluster(ctx, logicalcluster.Name(req.ClusterName))
+
+	err := s.remoteReader.Get(wsCtx, req.NamespacedName, remoteObj)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+
+	return false, err
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/workqueue"
+	ctrlruntimecache "sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resyncer enqueues all remote objects once the controller has started and then
+// optionally in a fixed interval. Since sync controllers are restarted whenever their
+// PublishedResource changes, this guarantees that all existing objects are processed
+// using the new configuration, instead of only once they change in kcp.
+type resyncer struct {
+	log         *zap.SugaredLogger
+	pubResName  string
+	cache       ctrlruntimecache.Cache
+	remoteDummy *unstructured.Unstructured
+	period      time.Duration
+	// admit can be used to exclude workspaces from the resync; this is used to not
+	// interfere with throttled initial syncs.
+	admit func(logicalcluster.Name) bool
+}
+
+// Start implements source.Source.
+func (r *resyncer) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+	go r.run(ctx, queue)
+
+	return nil
+}
+
+func (r *resyncer) run(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if !r.cache.WaitForCacheSync(ctx) {
+		return
+	}
+
+	r.enqueueAll(ctx, queue)
+
+	if r.period <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.enqueueAll(ctx, queue)
+		}
+	}
+}
+
+func (r *resyncer) enqueueAll(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	remoteObjs := &unstructured.UnstructuredList{}
+	remoteObjs.SetAPIVersion(r.remoteDummy.GetAPIVersion())
+	remoteObjs.SetKind(r.remoteDummy.GetKind() + "List")
+
+	if err := r.cache.List(ctx, remoteObjs); err != nil {
+		r.log.Errorw("Failed to list objects for resync", zap.Error(err))
+		return
+	}
+
+	enqueued := 0
+	for i := range remoteObjs.Items {
+		obj := &remoteObjs.Items[i]
+		clusterName := logicalcluster.From(obj)
+
+		if r.admit != nil && !r.admit(clusterName) {
+			continue
+		}
+
+		// the queue deduplicates requests for objects that are already queued
+		queue.Add(reconcile.Request{
+			NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(obj),
+			ClusterName:    clusterName.String(),
+		})
+		enqueued++
+	}
+
+	metrics.ResyncedObjects.WithLabelValues(r.pubResName).Add(float64(enqueued))
+	r.log.Debugw("Enqueued objects for resync", "objects", enqueued)
+}
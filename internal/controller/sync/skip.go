@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	gosync "sync"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevcorev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// skipCacheTTL is how long the skip annotation of a workspace is cached. Objects
+// in skipped workspaces are checked again after this duration, as changes to the
+// LogicalCluster do not trigger a reconciliation.
+const skipCacheTTL = time.Minute
+
+// workspaceSkipCache remembers which workspaces have been marked to be skipped,
+// so that the LogicalCluster does not have to be fetched for every reconciliation.
+type workspaceSkipCache struct {
+	lock    gosync.Mutex
+	entries map[logicalcluster.Name]skipCacheEntry
+	clock   clock.PassiveClock
+}
+
+type skipCacheEntry struct {
+	skip    bool
+	expires time.Time
+}
+
+func newWorkspaceSkipCache(clock clock.PassiveClock) *workspaceSkipCache {
+	return &workspaceSkipCache{
+		entries: map[logicalcluster.Name]skipCacheEntry{},
+		clock:   clock,
+	}
+}
+
+func (c *workspaceSkipCache) get(clusterName logicalcluster.Name) (skip bool, found bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, exists := c.entries[clusterName]
+	if !exists || c.clock.Now().After(entry.expires) {
+		delete(c.entries, clusterName)
+		return false, false
+	}
+
+	return entry.skip, true
+}
+
+func (c *workspaceSkipCache) set(clusterName logicalcluster.Name, skip bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries[clusterName] = skipCacheEntry{
+		skip:    skip,
+		expires: c.clock.Now().Add(skipCacheTTL),
+	}
+}
+
+// workspaceSkipped returns true if the workspace's LogicalCluster has the skip
+// annotation.
+func (r *Reconciler) workspaceSkipped(ctx context.Context, clusterName logicalcluster.Name) (bool, error) {
+	if skip, found := r.skipCache.get(clusterName); found {
+		return skip, nil
+	}
+
+	lc := &kcpdevcorev1alpha1.LogicalCluster{}
+	key := types.NamespacedName{Name: kcpdevcorev1alpha1.LogicalClusterName}
+
+	if err := r.vwClient.Get(kontext.WithCluster(ctx, clusterName), key, lc); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to retrieve remote logicalcluster: %w", err)
+	}
+
+	skip := lc.Annotations[syncagentv1alpha1.SkipAnnotation] == "true"
+	r.skipCache.set(clusterName, skip)
+
+	return skip, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevcorev1alpha1 "github.com/kcp-dev/kcp/sdk/apis/core/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkspaceSkipCache(t *testing.T) {
+	type step struct {
+		// advance steps the fake clock forward before the step is performed
+		advance time.Duration
+		// set stores the value, otherwise the cache is queried
+		set  *bool
+		skip bool
+
+		expectedSkip  bool
+		expectedFound bool
+	}
+
+	yes, no := true, false
+
+	testcases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "unknown workspace is not found",
+			steps: []step{
+				{},
+			},
+		},
+		{
+			name: "cached values are returned until they expire",
+			steps: []step{
+				{set: &yes},
+				{advance: skipCacheTTL / 2, expectedSkip: true, expectedFound: true},
+				{advance: skipCacheTTL / 2, expectedSkip: true, expectedFound: true},
+				{advance: time.Second},
+			},
+		},
+		{
+			name: "workspaces that are not skipped are cached as well",
+			steps: []step{
+				{set: &no},
+				{expectedFound: true},
+			},
+		},
+		{
+			name: "setting a value restarts the TTL",
+			steps: []step{
+				{set: &no},
+				{advance: skipCacheTTL - time.Second, set: &yes},
+				{advance: skipCacheTTL - time.Second, expectedSkip: true, expectedFound: true},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			cache := newWorkspaceSkipCache(fakeClock)
+
+			for i, s := range testcase.steps {
+				fakeClock.Step(s.advance)
+
+				if s.set != nil {
+					cache.set("my-workspace", *s.set)
+					continue
+				}
+
+				skip, found := cache.get("my-workspace")
+				if skip != s.expectedSkip || found != s.expectedFound {
+					t.Fatalf("Step %d: expected skip=%v, found=%v, but got skip=%v, found=%v.", i, s.expectedSkip, s.expectedFound, skip, found)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkspaceSkipped(t *testing.T) {
+	testcases := []struct {
+		name        string
+		annotations map[string]string
+		missing     bool
+		expected    bool
+	}{
+		{
+			name:        "annotated workspace is skipped",
+			annotations: map[string]string{syncagentv1alpha1.SkipAnnotation: "true"},
+			expected:    true,
+		},
+		{
+			name:        "other annotation values do not skip the workspace",
+			annotations: map[string]string{syncagentv1alpha1.SkipAnnotation: "yes"},
+		},
+		{
+			name: "workspace without annotation is not skipped",
+		},
+		{
+			name:    "missing LogicalCluster is not skipped",
+			missing: true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := kcpdevcorev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register scheme: %v", err)
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			builder := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme)
+			if !testcase.missing {
+				builder.WithObjects(&kcpdevcorev1alpha1.LogicalCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        kcpdevcorev1alpha1.LogicalClusterName,
+						Annotations: testcase.annotations,
+					},
+				})
+			}
+
+			r := &Reconciler{
+				vwClient:  builder.Build(),
+				skipCache: newWorkspaceSkipCache(clocktesting.NewFakeClock(time.Now())),
+			}
+
+			for range 2 {
+				skip, err := r.workspaceSkipped(context.Background(), logicalcluster.Name("my-workspace"))
+				if err != nil {
+					t.Fatalf("Failed to check workspace: %v", err)
+				}
+
+				if skip != testcase.expected {
+					t.Fatalf("Expected skip=%v, but got %v.", testcase.expected, skip)
+				}
+			}
+
+			if skip, found := r.skipCache.get("my-workspace"); !found || skip != testcase.expected {
+				t.Fatalf("Expected result to be cached, but got skip=%v, found=%v.", skip, found)
+			}
+		})
+	}
+}
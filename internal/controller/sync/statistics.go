@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Statistics counts the outcomes of reconciliations in a sync controller. It is
+// safe for concurrent use and a nil Statistics ignores all records.
+type Statistics struct {
+	syncedObjects atomic.Int64
+	errors        atomic.Int64
+	lastSyncTime  atomic.Int64
+
+	latencyLock sync.Mutex
+	// ring buffer of the most recent latencies
+	latencies []time.Duration
+	next      int
+}
+
+// maxLatencySamples is the number of recent latencies the percentiles are computed from.
+const maxLatencySamples = 1000
+
+// NewStatistics returns a new, empty set of statistics.
+func NewStatistics() *Statistics {
+	return &Statistics{}
+}
+
+// Record counts the outcome of a single reconciliation.
+func (s *Statistics) Record(err error) {
+	if s == nil {
+		return
+	}
+
+	if err != nil {
+		s.errors.Add(1)
+		return
+	}
+
+	s.syncedObjects.Add(1)
+	s.lastSyncTime.Store(time.Now().Unix())
+}
+
+// RecordLatency remembers the time it took to synchronize a change.
+func (s *Statistics) RecordLatency(latency time.Duration) {
+	if s == nil {
+		return
+	}
+
+	s.latencyLock.Lock()
+	defer s.latencyLock.Unlock()
+
+	if len(s.latencies) < maxLatencySamples {
+		s.latencies = append(s.latencies, latency)
+		return
+	}
+
+	s.latencies[s.next] = latency
+	s.next = (s.next + 1) % maxLatencySamples
+}
+
+func (s *Statistics) latencySummary() *syncagentv1alpha1.SyncLatency {
+	s.latencyLock.Lock()
+	sorted := slices.Clone(s.latencies)
+	s.latencyLock.Unlock()
+
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	slices.Sort(sorted)
+
+	percentile := func(p int) metav1.Duration {
+		idx := (len(sorted)*p+99)/100 - 1
+		return metav1.Duration{Duration: sorted[max(idx, 0)]}
+	}
+
+	return &syncagentv1alpha1.SyncLatency{
+		Samples: len(sorted),
+		P50:     percentile(50),
+		P95:     percentile(95),
+		P99:     percentile(99),
+	}
+}
+
+// Snapshot returns the current counters.
+func (s *Statistics) Snapshot() *syncagentv1alpha1.SyncStatistics {
+	if s == nil {
+		return nil
+	}
+
+	result := &syncagentv1alpha1.SyncStatistics{
+		SyncedObjects: s.syncedObjects.Load(),
+		Errors:        s.errors.Load(),
+	}
+
+	if lastSync := s.lastSyncTime.Load(); lastSync > 0 {
+		result.LastSyncTime = &metav1.Time{Time: time.Unix(lastSync, 0)}
+	}
+
+	result.Latency = s.latencySummary()
+
+	return result
+}
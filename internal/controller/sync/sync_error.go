@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxSyncErrorLength is the maximum length of the error message shown to consumers.
+	maxSyncErrorLength = 512
+
+	genericSyncError = "The object could not be synchronized due to an internal error, please contact the service provider."
+)
+
+// sanitizeSyncError turns a synchronization error into a message that is safe to show
+// to consumers in kcp. Only messages of API servers rejecting the synchronized object
+// itself (because it is invalid or was denied by an admission webhook or policy) are
+// passed on, as these explain what is wrong with the object. All other errors, even
+// those returned by an API server, might contain internal details of the service
+// cluster and are replaced with a generic message.
+func sanitizeSyncError(err error) string {
+	var writeErr *sync.DestinationWriteError
+	if !errors.As(err, &writeErr) {
+		return genericSyncError
+	}
+
+	var status apierrors.APIStatus
+	if !errors.As(writeErr.Err, &status) || !isRejection(status.Status()) {
+		return genericSyncError
+	}
+
+	message := strings.Join(strings.Fields(status.Status().Message), " ")
+	if message == "" {
+		return genericSyncError
+	}
+
+	if runes := []rune(message); len(runes) > maxSyncErrorLength {
+		message = string(runes[:maxSyncErrorLength-1]) + "…"
+	}
+
+	return message
+}
+
+// isRejection returns true if the status describes an object that failed validation
+// or was denied during admission. Authorization errors also use the Forbidden reason,
+// but never claim to have denied the request.
+func isRejection(status metav1.Status) bool {
+	if status.Reason == metav1.StatusReasonInvalid {
+		return true
+	}
+
+	return strings.Contains(status.Message, "denied the request") || strings.Contains(status.Message, "denied request")
+}
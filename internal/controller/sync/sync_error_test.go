@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSanitizeSyncError(t *testing.T) {
+	thingGroupKind := schema.GroupKind{Group: "example.com", Kind: "Thing"}
+
+	destinationError := func(err error) error {
+		return fmt.Errorf("failed to synchronize object state: %w", &sync.DestinationWriteError{Err: err})
+	}
+
+	invalidErr := apierrors.NewInvalid(thingGroupKind, "my-thing", field.ErrorList{
+		field.Invalid(field.NewPath("spec", "size"), 0, "must be positive"),
+	})
+
+	webhookErr := apierrors.NewForbidden(schema.GroupResource{Group: "example.com", Resource: "things"}, "my-thing",
+		errors.New(`admission webhook "things.example.com" denied the request: size is too large`))
+
+	policyErr := apierrors.NewInvalid(thingGroupKind, "my-thing", field.ErrorList{
+		field.Invalid(field.NewPath("spec"), nil, "ValidatingAdmissionPolicy 'sizes' with binding 'sizes' denied request: size is too large"),
+	})
+
+	rbacErr := apierrors.NewForbidden(schema.GroupResource{Group: "example.com", Resource: "things"}, "my-thing",
+		errors.New(`User "system:serviceaccount:kcp-system:api-syncagent" cannot patch resource "things"`))
+
+	stateConflictErr := apierrors.NewConflict(corev1.Resource("secrets"), "obj-state-abc123-def456", errors.New("the object has been modified"))
+
+	testcases := []struct {
+		name      string
+		err       error
+		expected  string
+		truncated bool
+	}{
+		{
+			name:     "plain error",
+			err:      errors.New("failed to connect to 10.0.0.1"),
+			expected: genericSyncError,
+		},
+		{
+			name:     "validation error of the destination object",
+			err:      destinationError(invalidErr),
+			expected: invalidErr.Error(),
+		},
+		{
+			name:     "admission webhook denial of the destination object",
+			err:      destinationError(webhookErr),
+			expected: webhookErr.Error(),
+		},
+		{
+			name:     "admission policy denial of the destination object",
+			err:      destinationError(policyErr),
+			expected: policyErr.Error(),
+		},
+		{
+			name:     "authorization error for the destination object",
+			err:      destinationError(rbacErr),
+			expected: genericSyncError,
+		},
+		{
+			name:     "conflict on the destination object",
+			err:      destinationError(apierrors.NewConflict(schema.GroupResource{Group: "example.com", Resource: "things"}, "my-thing", errors.New("modified"))),
+			expected: genericSyncError,
+		},
+		{
+			name:     "validation error of another object",
+			err:      fmt.Errorf("failed to update sync state: %w", apierrors.NewInvalid(schema.GroupKind{Kind: "Secret"}, "obj-state-abc123-def456", nil)),
+			expected: genericSyncError,
+		},
+		{
+			name:     "state conflict",
+			err:      fmt.Errorf("failed to update sync state: %w", stateConflictErr),
+			expected: genericSyncError,
+		},
+		{
+			name:     "authorization error for another object",
+			err:      fmt.Errorf("failed to get state: %w", rbacErr),
+			expected: genericSyncError,
+		},
+		{
+			name:      "long messages are truncated",
+			err:       destinationError(apierrors.NewInvalid(thingGroupKind, "my-thing", field.ErrorList{field.Invalid(field.NewPath("spec"), nil, strings.Repeat("x", 1000))})),
+			truncated: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			message := sanitizeSyncError(testcase.err)
+
+			if testcase.truncated {
+				if runes := []rune(message); len(runes) != maxSyncErrorLength || !strings.HasSuffix(message, "…") {
+					t.Fatalf("Expected message to be truncated to %d characters, got %q.", maxSyncErrorLength, message)
+				}
+
+				return
+			}
+
+			if message != testcase.expected {
+				t.Errorf("Expected %q, got %q.", testcase.expected, message)
+			}
+		})
+	}
+}
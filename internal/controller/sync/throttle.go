@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// throttledClient limits the rate of write requests. Reads are usually served from
+// a cache and are therefore not limited.
+type throttledClient struct {
+	ctrlruntimeclient.Client
+
+	limiter *rate.Limiter
+}
+
+// newThrottledClient wraps the client if the settings configure a QPS, otherwise
+// the client is returned as-is.
+func newThrottledClient(client ctrlruntimeclient.Client, settings *syncagentv1alpha1.SyncSettings) ctrlruntimeclient.Client {
+	if settings == nil || settings.QPS <= 0 {
+		return client
+	}
+
+	burst := settings.Burst
+	if burst <= 0 {
+		burst = settings.QPS
+	}
+
+	return &throttledClient{
+		Client:  client,
+		limiter: rate.NewLimiter(rate.Limit(settings.QPS), int(burst)),
+	}
+}
+
+func (c *throttledClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Delete(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.UpdateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *throttledClient) DeleteAllOf(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.DeleteAllOfOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *throttledClient) Status() ctrlruntimeclient.SubResourceWriter {
+	return &throttledSubResourceWriter{
+		SubResourceWriter: c.Client.Status(),
+		limiter:           c.limiter,
+	}
+}
+
+func (c *throttledClient) SubResource(subResource string) ctrlruntimeclient.SubResourceClient {
+	client := c.Client.SubResource(subResource)
+
+	return &throttledSubResourceClient{
+		SubResourceReader: client,
+		throttledSubResourceWriter: throttledSubResourceWriter{
+			SubResourceWriter: client,
+			limiter:           c.limiter,
+		},
+	}
+}
+
+type throttledSubResourceWriter struct {
+	ctrlruntimeclient.SubResourceWriter
+
+	limiter *rate.Limiter
+}
+
+func (w *throttledSubResourceWriter) Create(ctx context.Context, obj ctrlruntimeclient.Object, subResource ctrlruntimeclient.Object, opts ...ctrlruntimeclient.SubResourceCreateOption) error {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (w *throttledSubResourceWriter) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.SubResourceUpdateOption) error {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *throttledSubResourceWriter) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.SubResourcePatchOption) error {
+	if err := w.limiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
+
+type throttledSubResourceClient struct {
+	ctrlruntimeclient.SubResourceReader
+	throttledSubResourceWriter
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	gosync "sync"
+	"time"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// heartbeatInterval is how often the heartbeat in the SyncAgentStatus is refreshed.
+const heartbeatInterval = 1 * time.Minute
+
+// updateAgentStatus reflects the current state of the reconciler in the
+// SyncAgentStatus object named after this agent.
+func (r *Reconciler) updateAgentStatus(ctx context.Context, apiExport *kcpdevv1alpha1.APIExport) error {
+	return r.patchAgentStatus(ctx, func(status *syncagentv1alpha1.SyncAgentStatusStatus) {
+		status.APIExport = r.apiExport.Name
+		status.APIExportResourceVersion = apiExport.ResourceVersion
+		status.VirtualWorkspaceURL = r.vwURL
+		status.ConnectionState = r.connectionState()
+		status.SyncControllers = r.runningSyncControllers()
+
+		// the reconciler is requeued at least once per heartbeatInterval; refreshing
+		// the heartbeat a bit earlier ensures it is never skipped due to jitter
+		now := time.Now()
+		if status.LastHeartbeatTime == nil || now.Sub(status.LastHeartbeatTime.Time) >= heartbeatInterval/2 {
+			heartbeat := metav1.NewTime(now)
+			status.LastHeartbeatTime = &heartbeat
+		}
+	})
+}
+
+// patchAgentStatus applies the given modification to the SyncAgentStatus,
+// creating the object if necessary. The object is read directly from the API
+// server, so that the agent does not need to cache (and watch) it.
+func (r *Reconciler) patchAgentStatus(ctx context.Context, modify func(status *syncagentv1alpha1.SyncAgentStatusStatus)) error {
+	agentStatus := &syncagentv1alpha1.SyncAgentStatus{}
+
+	err := r.localManager.GetAPIReader().Get(ctx, types.NamespacedName{Name: r.agentName}, agentStatus)
+	if apierrors.IsNotFound(err) {
+		agentStatus = &syncagentv1alpha1.SyncAgentStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: r.agentName,
+			},
+		}
+
+		err = r.localManager.GetClient().Create(ctx, agentStatus)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get SyncAgentStatus: %w", err)
+	}
+
+	status := agentStatus.Status.DeepCopy()
+	modify(status)
+	status.ControllerStops = r.controllerStops.list()
+
+	connected := metav1.ConditionFalse
+	if status.ConnectionState == syncagentv1alpha1.ConnectionStateConnected {
+		connected = metav1.ConditionTrue
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionConnected,
+		Status:  connected,
+		Reason:  string(status.ConnectionState),
+		Message: connectionMessages[status.ConnectionState],
+	})
+
+	if equality.Semantic.DeepEqual(agentStatus.Status, *status) {
+		return nil
+	}
+
+	agentStatus.Status = *status
+	if err := r.localManager.GetClient().Status().Update(ctx, agentStatus); err != nil {
+		return fmt.Errorf("failed to update SyncAgentStatus: %w", err)
+	}
+
+	return nil
+}
+
+var connectionMessages = map[syncagentv1alpha1.ConnectionState]string{
+	syncagentv1alpha1.ConnectionStatePending:      "The APIExport does not provide a virtual workspace URL yet.",
+	syncagentv1alpha1.ConnectionStateConnected:    "The virtual workspace cluster is running.",
+	syncagentv1alpha1.ConnectionStateDisconnected: "The virtual workspace cluster has stopped.",
+	syncagentv1alpha1.ConnectionStateDraining:     "The agent is shutting down.",
+}
+
+func (r *Reconciler) connectionState() syncagentv1alpha1.ConnectionState {
+	switch {
+	case r.draining:
+		return syncagentv1alpha1.ConnectionStateDraining
+	case r.vwCluster == nil:
+		return syncagentv1alpha1.ConnectionStatePending
+	case !r.vwCluster.Running():
+		return syncagentv1alpha1.ConnectionStateDisconnected
+	default:
+		return syncagentv1alpha1.ConnectionStateConnected
+	}
+}
+
+func (r *Reconciler) runningSyncControllers() int {
+	running := 0
+	for _, ctrl := range r.syncWorkers {
+		if ctrl.Running() {
+			running++
+		}
+	}
+
+	return running
+}
+
+// controllerStopTracker counts how often the controllers managed by the reconciler
+// were stopped, so that the counters can not only be exposed as metrics, but also
+// in the SyncAgentStatus.
+type controllerStopTracker struct {
+	lock  gosync.Mutex
+	stops map[controllerStopKey]*syncagentv1alpha1.ControllerStops
+}
+
+type controllerStopKey struct {
+	controller string
+	reason     string
+}
+
+func newControllerStopTracker() *controllerStopTracker {
+	return &controllerStopTracker{
+		stops: map[controllerStopKey]*syncagentv1alpha1.ControllerStops{},
+	}
+}
+
+// record counts a stop of the given controller type in the tracker and the metrics.
+// It is safe to be called concurrently.
+func (t *controllerStopTracker) record(controller, reason string) {
+	metrics.RecordControllerStop(controller, reason)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := controllerStopKey{controller: controller, reason: reason}
+
+	stops, ok := t.stops[key]
+	if !ok {
+		stops = &syncagentv1alpha1.ControllerStops{
+			Controller: controller,
+			Reason:     reason,
+		}
+		t.stops[key] = stops
+	}
+
+	stops.Count++
+	stops.LastStopTime = metav1.Now()
+}
+
+// list returns all counters, sorted by controller and reason.
+func (t *controllerStopTracker) list() []syncagentv1alpha1.ControllerStops {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	result := []syncagentv1alpha1.ControllerStops{}
+	for _, stops := range t.stops {
+		result = append(result, *stops.DeepCopy())
+	}
+
+	slices.SortFunc(result, func(a, b syncagentv1alpha1.ControllerStops) int {
+		return cmp.Or(cmp.Compare(a.Controller, b.Controller), cmp.Compare(a.Reason, b.Reason))
+	})
+
+	return result
+}
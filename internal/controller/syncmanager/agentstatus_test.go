@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+)
+
+func TestControllerStopTracker(t *testing.T) {
+	tracker := newControllerStopTracker()
+
+	tracker.record(syncControllerType, metrics.ReasonPublishedResourceUpdated)
+	tracker.record(announcementControllerType, metrics.ReasonVirtualWorkspaceURLChanged)
+	tracker.record(syncControllerType, metrics.ReasonPublishedResourceUpdated)
+	tracker.record(syncControllerType, metrics.ReasonControllerFailed)
+
+	expected := []struct {
+		controller string
+		reason     string
+		count      int64
+	}{
+		{controller: announcementControllerType, reason: metrics.ReasonVirtualWorkspaceURLChanged, count: 1},
+		{controller: syncControllerType, reason: metrics.ReasonControllerFailed, count: 1},
+		{controller: syncControllerType, reason: metrics.ReasonPublishedResourceUpdated, count: 2},
+	}
+
+	stops := tracker.list()
+	if len(stops) != len(expected) {
+		t.Fatalf("Expected %d counters, but got %d: %+v", len(expected), len(stops), stops)
+	}
+
+	for i, exp := range expected {
+		if stops[i].Controller != exp.controller || stops[i].Reason != exp.reason || stops[i].Count != exp.count {
+			t.Errorf("Expected counter %d to be %s/%s=%d, but got %s/%s=%d.", i, exp.controller, exp.reason, exp.count, stops[i].Controller, stops[i].Reason, stops[i].Count)
+		}
+
+		if stops[i].LastStopTime.IsZero() {
+			t.Errorf("Expected counter %d to have a stop time.", i)
+		}
+	}
+}
@@ -20,24 +20,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	gosync "sync"
+	"time"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"go.uber.org/zap"
 
+	"github.com/kcp-dev/api-syncagent/internal/audit"
+	"github.com/kcp-dev/api-syncagent/internal/controller/announcement"
 	"github.com/kcp-dev/api-syncagent/internal/controller/sync"
 	"github.com/kcp-dev/api-syncagent/internal/controller/syncmanager/lifecycle"
 	"github.com/kcp-dev/api-syncagent/internal/controllerutil"
 	"github.com/kcp-dev/api-syncagent/internal/controllerutil/predicate"
 	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/kcp"
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	objectsync "github.com/kcp-dev/api-syncagent/internal/sync"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/validation"
 
 	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
 
-	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
@@ -51,8 +64,25 @@ import (
 const (
 	ControllerName = "syncagent-syncmanager"
 
+	// controller types used in metrics
+	syncControllerType             = "sync"
+	announcementControllerType     = "announcement"
+	namespaceCleanupControllerType = "namespacecleanup"
+
 	// numSyncWorkers is the number of concurrent workers within each sync controller.
 	numSyncWorkers = 4
+
+	// unpublishCheckInterval is how often remaining objects in kcp are checked for
+	// while PublishedResources are being unpublished.
+	unpublishCheckInterval = 30 * time.Second
+
+	// statisticsInterval is how often the sync statistics are written into the
+	// PublishedResources' status.
+	statisticsInterval = 1 * time.Minute
+
+	// virtualWorkspaceRetryInterval is how often the virtual workspace URL is checked
+	// for while the APIExport's virtual workspace is not ready yet.
+	virtualWorkspaceRetryInterval = 10 * time.Second
 )
 
 type Reconciler struct {
@@ -68,9 +98,22 @@ type Reconciler struct {
 	log             *zap.SugaredLogger
 	recorder        record.EventRecorder
 	discoveryClient *discovery.Client
-	prFilter        labels.Selector
-	stateNamespace  string
+	prFilter        *controllerutil.PublishedResourceFilter
+	stateOptions    objectsync.StateOptions
 	agentName       string
+	auditLog        *audit.Logger
+	writeHook       objectsync.WriteHook
+	drainTimeout    time.Duration
+	cacheOptions    lifecycle.CacheOptions
+
+	// namespaceCleanup enables the deletion of namespaces whose source
+	// namespace in kcp is gone
+	namespaceCleanup bool
+
+	// lock prevents the reconciler from starting new controllers while the
+	// existing ones are being drained during shutdown
+	lock     gosync.Mutex
+	draining bool
 
 	apiExport *kcpdevv1alpha1.APIExport
 
@@ -81,9 +124,33 @@ type Reconciler struct {
 	vwCluster *lifecycle.Cluster
 
 	// a map of sync controllers, one for each PublishedResource, using their
-	// UIDs and resourceVersion as the map keys; using the version ensures that
-	// when a PR changes, the old controller is orphaned and will be shut down.
+	// UIDs and generation as the map keys; using the generation ensures that
+	// when a PR's spec changes, the old controller is orphaned and will be shut
+	// down, while status updates do not cause needless restarts.
 	syncWorkers map[string]lifecycle.Controller
+
+	// statistics for each PublishedResource (by name), shared by all sync
+	// controllers for the same PublishedResource, and when they have last been
+	// written into the PublishedResource's status
+	syncStatistics    map[string]*sync.Statistics
+	statisticsFlushed map[string]time.Time
+
+	// tracks failing sync controllers to delay their restarts
+	crashLoops *crashLoopBackOff
+
+	// counts how often controllers were stopped, for the SyncAgentStatus
+	controllerStops *controllerStopTracker
+
+	// the controller that creates objects in kcp for Announcements; it
+	// shares the lifecycle of the vwCluster
+	announcementWorker *lifecycle.Controller
+
+	// the controller that deletes namespaces whose source namespace in kcp
+	// is gone; it shares the lifecycle of the vwCluster
+	namespaceCleanupWorker *lifecycle.Controller
+
+	// a snapshot of the components above for the health and readiness checks
+	health *healthTracker
 }
 
 // Add creates a new controller and adds it to the given manager.
@@ -94,9 +161,14 @@ func Add(
 	kcpRestConfig *rest.Config,
 	log *zap.SugaredLogger,
 	apiExport *kcpdevv1alpha1.APIExport,
-	prFilter labels.Selector,
-	stateNamespace string,
+	prFilter *controllerutil.PublishedResourceFilter,
+	stateOptions objectsync.StateOptions,
 	agentName string,
+	auditLog *audit.Logger,
+	writeHook objectsync.WriteHook,
+	drainTimeout time.Duration,
+	cacheOptions lifecycle.CacheOptions,
+	namespaceCleanup bool,
 ) error {
 	discoveryClient, err := discovery.NewClient(localManager.GetConfig())
 	if err != nil {
@@ -104,18 +176,37 @@ func Add(
 	}
 
 	reconciler := &Reconciler{
-		ctx:             ctx,
-		localManager:    localManager,
-		apiExport:       apiExport,
-		kcpCluster:      kcpCluster,
-		kcpRestConfig:   kcpRestConfig,
-		log:             log,
-		recorder:        localManager.GetEventRecorderFor(ControllerName),
-		syncWorkers:     map[string]lifecycle.Controller{},
-		discoveryClient: discoveryClient,
-		prFilter:        prFilter,
-		stateNamespace:  stateNamespace,
-		agentName:       agentName,
+		ctx:               ctx,
+		localManager:      localManager,
+		apiExport:         apiExport,
+		kcpCluster:        kcpCluster,
+		kcpRestConfig:     kcpRestConfig,
+		log:               log,
+		recorder:          localManager.GetEventRecorderFor(ControllerName),
+		syncWorkers:       map[string]lifecycle.Controller{},
+		syncStatistics:    map[string]*sync.Statistics{},
+		statisticsFlushed: map[string]time.Time{},
+		discoveryClient:   discoveryClient,
+		prFilter:          prFilter,
+		stateOptions:      stateOptions,
+		agentName:         agentName,
+		auditLog:          auditLog,
+		writeHook:         writeHook,
+		drainTimeout:      drainTimeout,
+		cacheOptions:      cacheOptions,
+		namespaceCleanup:  namespaceCleanup,
+		crashLoops:        newCrashLoopBackOff(clock.RealClock{}),
+		controllerStops:   newControllerStopTracker(),
+		health:            newHealthTracker(kcpCluster),
+	}
+
+	if err := reconciler.health.addChecks(localManager); err != nil {
+		return err
+	}
+
+	// stop all sync controllers gracefully when the manager shuts down
+	if err := localManager.Add(manager.RunnableFunc(reconciler.drain)); err != nil {
+		return fmt.Errorf("failed to add drain runnable: %w", err)
 	}
 
 	_, err = builder.ControllerManagedBy(localManager).
@@ -129,12 +220,22 @@ func Add(
 		// so there is no need here to add an additional filter.
 		WatchesRawSource(source.Kind(kcpCluster.GetCache(), &kcpdevv1alpha1.APIExport{}, controllerutil.EnqueueConst[*kcpdevv1alpha1.APIExport]("dummy"))).
 		// Watch for changes to the PublishedResources
-		Watches(&syncagentv1alpha1.PublishedResource{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy"), builder.WithPredicates(predicate.ByLabels(prFilter))).
+		Watches(&syncagentv1alpha1.PublishedResource{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy"), builder.WithPredicates(predicate.Factory(prFilter.Matches))).
+		// Watch for changes to profiles, as they influence the effective PublishedResources
+		Watches(&syncagentv1alpha1.PublishedResourceProfile{}, controllerutil.EnqueueConst[ctrlruntimeclient.Object]("dummy")).
 		Build(reconciler)
 	return err
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// do not start new controllers while shutting down
+	if r.draining {
+		return reconcile.Result{}, nil
+	}
+
 	log := r.log.Named(ControllerName)
 	log.Debug("Processing")
 
@@ -146,50 +247,265 @@ func (r *Reconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconc
 		return reconcile.Result{}, fmt.Errorf("failed to retrieve APIExport: %w", err)
 	}
 
-	return reconcile.Result{}, r.reconcile(ctx, log, apiExport)
-}
+	result, err := r.reconcile(ctx, log, apiExport)
 
-func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, apiExport *kcpdevv1alpha1.APIExport) error {
-	// We're not yet making use of APIEndpointSlices, as we don't even fully
-	// support a sharded kcp setup yet. Hence for now we're safe just using
-	// this deprecated VW URL.
-	//nolint:staticcheck
-	urls := apiExport.Status.VirtualWorkspaces
+	// requeue regularly to keep the heartbeat in the agent status fresh
+	if result.RequeueAfter == 0 || result.RequeueAfter > heartbeatInterval {
+		result.RequeueAfter = heartbeatInterval
+	}
+
+	// report on the connection state regardless of whether the reconciliation succeeded
+	if statusErr := r.updateAgentStatus(ctx, apiExport); statusErr != nil {
+		err = utilerrors.NewAggregate([]error{err, statusErr})
+	}
+
+	return result, err
+}
 
-	// the virtual workspace is not ready yet
-	if len(urls) == 0 {
-		return nil
+func (r *Reconciler) reconcile(ctx context.Context, log *zap.SugaredLogger, apiExport *kcpdevv1alpha1.APIExport) (reconcile.Result, error) {
+	// We do not fully support a sharded kcp setup yet, so using the first URL
+	// is sufficient for now.
+	vwURL, err := kcp.VirtualWorkspaceURL(ctx, r.kcpCluster.GetAPIReader(), apiExport)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to determine virtual workspace URL: %w", err)
 	}
 
-	vwURL := urls[0].URL
+	// the virtual workspace is not ready yet (or kcp had a hiccup and wrote a status
+	// without an actual URL); changes to APIExportEndpointSlices are not watched,
+	// so check again later
+	if vwURL == "" {
+		return reconcile.Result{RequeueAfter: virtualWorkspaceRetryInterval}, nil
+	}
 
 	// if the VW URL changed, stop the cluster and all sync controllers
 	if r.vwURL != "" && vwURL != r.vwURL {
 		r.stopSyncControllers(log)
+		r.stopAnnouncementController(log)
+		r.stopNamespaceCleanupController(log, errors.New("virtual workspace cluster is recreating"), metrics.ReasonVirtualWorkspaceURLChanged)
 		r.stopVirtualWorkspaceCluster(log)
-	}
+		r.health.record(r, nil)
 
-	// if kcp had a hiccup and wrote a status without an actual URL
-	if vwURL == "" {
-		return nil
+		metrics.VirtualWorkspaceRestarts.WithLabelValues(metrics.ReasonVirtualWorkspaceURLChanged).Inc()
 	}
 
 	// make sure we have a running cluster object for the virtual workspace
 	if err := r.ensureVirtualWorkspaceCluster(log, vwURL); err != nil {
-		return fmt.Errorf("failed to ensure virtual workspace cluster: %w", err)
+		return reconcile.Result{}, fmt.Errorf("failed to ensure virtual workspace cluster: %w", err)
 	}
 
 	// find all PublishedResources
 	pubResources := &syncagentv1alpha1.PublishedResourceList{}
-	if err := r.localManager.GetClient().List(ctx, pubResources, &ctrlruntimeclient.ListOptions{
-		LabelSelector: r.prFilter,
-	}); err != nil {
-		return fmt.Errorf("failed to list PublishedResources: %w", err)
+	if err := r.prFilter.List(ctx, r.localManager.GetClient(), pubResources); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list PublishedResources: %w", err)
+	}
+
+	// apply the referenced profiles; PublishedResources with missing profiles are
+	// skipped until the profile becomes available
+	effectivePubResources := map[string]*syncagentv1alpha1.PublishedResource{}
+	unpublishing := []*syncagentv1alpha1.PublishedResource{}
+	paused := map[string]*syncagentv1alpha1.PublishedResource{}
+
+	// remember why PublishedResources do not have a running sync controller
+	controllerConditions := map[string]metav1.Condition{}
+
+	for i := range pubResources.Items {
+		pubRes := &pubResources.Items[i]
+
+		// resources being unpublished do not get a sync controller anymore
+		if isUnpublishing(pubRes) {
+			unpublishing = append(unpublishing, pubRes)
+			controllerConditions[pubRes.Name] = syncControllerCondition(metav1.ConditionFalse, "Unpublishing", "The resource is being unpublished.")
+			continue
+		}
+
+		if err := r.ensureUnpublishFinalizer(ctx, pubRes); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to ensure finalizer on PublishedResource %s: %w", pubRes.Name, err)
+		}
+
+		// paused resources keep their schema in the APIExport, but are not synchronized
+		if pubRes.Spec.Paused {
+			paused[pubRes.Name] = pubRes
+			controllerConditions[pubRes.Name] = syncControllerCondition(metav1.ConditionFalse, "Paused", "The synchronization is paused.")
+			continue
+		}
+
+		effective, prProfile, err := profile.Resolve(ctx, r.localManager.GetClient(), pubRes)
+		if err != nil {
+			log.Warnw("Skipping PublishedResource", "pr", pubRes.Name, zap.Error(err))
+			r.recorder.Event(pubRes, corev1.EventTypeWarning, "ProfileUnavailable", err.Error())
+			controllerConditions[pubRes.Name] = syncControllerCondition(metav1.ConditionFalse, "ProfileUnavailable", err.Error())
+			continue
+		}
+
+		// validate the effective PublishedResource, as profiles can introduce settings
+		// that are not covered by the CRD validation of the PublishedResource itself
+		if errs := validation.ValidatePublishedResource(effective); len(errs) > 0 {
+			err := errs.ToAggregate()
+			log.Warnw("Skipping invalid PublishedResource", "pr", pubRes.Name, zap.Error(err))
+			r.recorder.Event(pubRes, corev1.EventTypeWarning, "InvalidSpec", err.Error())
+			controllerConditions[pubRes.Name] = syncControllerCondition(metav1.ConditionFalse, "InvalidSpec", err.Error())
+			continue
+		}
+
+		effectivePubResources[getPublishedResourceKey(pubRes, prProfile)] = effective
+	}
+
+	// make sure that for every PublishedResource, a matching sync controller exists;
+	// a single broken PublishedResource must not prevent all others from being synced
+	startErrors, backOffs := r.ensureSyncControllers(ctx, log, effectivePubResources, paused)
+	for name, err := range startErrors {
+		controllerConditions[name] = syncControllerCondition(metav1.ConditionFalse, "StartFailed", err.Error())
+	}
+
+	for name, backOff := range backOffs {
+		message := fmt.Sprintf("The sync controller has failed %d time(s) and will be restarted in %v.", backOff.failures, backOff.remaining.Round(time.Second))
+		controllerConditions[name] = syncControllerCondition(metav1.ConditionFalse, "CrashLoopBackOff", message)
+	}
+
+	// make sure Announcements are being processed
+	if err := r.ensureAnnouncementController(log); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure announcement controller: %w", err)
+	}
+
+	// optionally clean up namespaces whose source namespace is gone
+	if err := r.ensureNamespaceCleanupController(log); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure namespace cleanup controller: %w", err)
+	}
+
+	r.health.record(r, effectivePubResources)
+
+	// take care of objects in kcp that were created using a previous projection;
+	// failures are recorded in the status before the reconciliation is retried
+	projectionErrors := map[string]error{}
+	projectionConditions := map[string]metav1.Condition{}
+	for _, pubRes := range effectivePubResources {
+		err := r.reconcileProjection(ctx, log.With("pr", pubRes.Name), pubRes)
+		if err != nil {
+			r.recorder.Event(pubRes, corev1.EventTypeWarning, "ProjectionFailed", err.Error())
+			projectionErrors[pubRes.Name] = err
+		}
+
+		projectionConditions[pubRes.Name] = projectionCondition(err)
+	}
+
+	// report on and finish unpublishing resources; as changes to objects in kcp do
+	// not trigger this controller, check back periodically while objects remain
+	result := reconcile.Result{}
+	for _, pubRes := range unpublishing {
+		remaining, err := r.reconcileUnpublish(ctx, log.With("pr", pubRes.Name), pubRes)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to unpublish PublishedResource %s: %w", pubRes.Name, err)
+		}
+
+		if remaining > 0 {
+			result.RequeueAfter = unpublishCheckInterval
+		}
+	}
+
+	if err := r.updateStatuses(ctx, pubResources.Items, controllerConditions, projectionConditions); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(startErrors) > 0 || len(projectionErrors) > 0 {
+		errs := []error{}
+		for name, err := range startErrors {
+			errs = append(errs, fmt.Errorf("failed to start sync controller for PublishedResource %s: %w", name, err))
+		}
+
+		for name, err := range projectionErrors {
+			errs = append(errs, fmt.Errorf("failed to reconcile projection of PublishedResource %s: %w", name, err))
+		}
+
+		return reconcile.Result{}, utilerrors.NewAggregate(errs)
+	}
+
+	// restart failed controllers once their backoff has expired
+	for _, backOff := range backOffs {
+		if result.RequeueAfter == 0 || backOff.remaining < result.RequeueAfter {
+			result.RequeueAfter = backOff.remaining
+		}
+	}
+
+	// regularly update the statistics
+	if len(r.syncWorkers) > 0 && (result.RequeueAfter == 0 || result.RequeueAfter > statisticsInterval) {
+		result.RequeueAfter = statisticsInterval
+	}
+
+	return result, nil
+}
+
+func syncControllerCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionSyncControllerRunning,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// projectionCondition returns the ProjectionReconciled condition for the given
+// result of reconcileProjection.
+func projectionCondition(err error) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:    syncagentv1alpha1.ConditionProjectionReconciled,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ProjectionFailed",
+			Message: err.Error(),
+		}
+	}
+
+	return metav1.Condition{
+		Type:    syncagentv1alpha1.ConditionProjectionReconciled,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Reconciled",
+		Message: "All objects in kcp use the current projection.",
+	}
+}
+
+// updateStatuses sets the SyncControllerRunning condition and the statistics on all
+// PublishedResources. PublishedResources without a condition in the given map are
+// considered to have a running sync controller. The ProjectionReconciled condition
+// is only set for PublishedResources whose projection has been reconciled.
+func (r *Reconciler) updateStatuses(ctx context.Context, pubResources []syncagentv1alpha1.PublishedResource, conditions, projectionConditions map[string]metav1.Condition) error {
+	client := r.localManager.GetClient()
+	now := time.Now()
+	known := sets.New[string]()
+
+	for i := range pubResources {
+		pubRes := &pubResources[i]
+		original := pubRes.DeepCopy()
+		known.Insert(pubRes.Name)
+
+		condition, exists := conditions[pubRes.Name]
+		if !exists {
+			condition = syncControllerCondition(metav1.ConditionTrue, "Running", "The sync controller is running.")
+		}
+
+		controllerutil.SetPublishedResourceCondition(pubRes, condition)
+
+		if condition, exists := projectionConditions[pubRes.Name]; exists {
+			controllerutil.SetPublishedResourceCondition(pubRes, condition)
+		}
+
+		statisticsDue := now.Sub(r.statisticsFlushed[pubRes.Name]) >= statisticsInterval
+		if stats, ok := r.syncStatistics[pubRes.Name]; ok && statisticsDue {
+			pubRes.Status.Statistics = stats.Snapshot()
+			r.statisticsFlushed[pubRes.Name] = now
+		}
+
+		// the PublishedResource might just have been deleted after unpublishing finished
+		if err := controllerutil.PatchPublishedResourceStatus(ctx, client, original, pubRes); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to update status of PublishedResource %s: %w", pubRes.Name, err)
+		}
 	}
 
-	// make sure that for every PublishedResource, a matching sync controller exists
-	if err := r.ensureSyncControllers(ctx, log, pubResources.Items); err != nil {
-		return fmt.Errorf("failed to ensure sync controllers: %w", err)
+	// forget about PublishedResources that do not exist anymore
+	for name := range r.syncStatistics {
+		if !known.Has(name) {
+			delete(r.syncStatistics, name)
+			delete(r.statisticsFlushed, name)
+		}
 	}
 
 	return nil
@@ -199,7 +515,7 @@ func (r *Reconciler) ensureVirtualWorkspaceCluster(log *zap.SugaredLogger, vwURL
 	if r.vwCluster == nil {
 		log.Info("Setting up virtual workspace cluster…")
 
-		stoppableCluster, err := lifecycle.NewCluster(vwURL, r.kcpRestConfig)
+		stoppableCluster, err := lifecycle.NewCluster(vwURL, r.kcpRestConfig, r.cacheOptions)
 		if err != nil {
 			return fmt.Errorf("failed to initialize cluster: %w", err)
 		}
@@ -231,14 +547,37 @@ func (r *Reconciler) stopVirtualWorkspaceCluster(log *zap.SugaredLogger) {
 	r.vwURL = ""
 }
 
-func getPublishedResourceKey(pr *syncagentv1alpha1.PublishedResource) string {
-	return fmt.Sprintf("%s-%s", pr.UID, pr.ResourceVersion)
+// getPublishedResourceKey returns the key for the sync controller of a PublishedResource.
+// If a profile is used, its version is included as well, so that changes to the profile
+// also lead to the controller being restarted.
+func getPublishedResourceKey(pr *syncagentv1alpha1.PublishedResource, prProfile *syncagentv1alpha1.PublishedResourceProfile) string {
+	key := fmt.Sprintf("%s-%d", pr.UID, pr.Generation)
+	if prProfile != nil {
+		key = fmt.Sprintf("%s-%s", key, prProfile.ResourceVersion)
+	}
+
+	return key
+}
+
+// controllerBackOff describes a sync controller that is not restarted because it
+// has failed too often.
+type controllerBackOff struct {
+	failures  int
+	remaining time.Duration
 }
 
-func (r *Reconciler) ensureSyncControllers(ctx context.Context, log *zap.SugaredLogger, publishedResources []syncagentv1alpha1.PublishedResource) error {
-	currentPRWorkers := sets.New[string]()
-	for _, pr := range publishedResources {
-		currentPRWorkers.Insert(getPublishedResourceKey(&pr))
+// ensureSyncControllers starts and stops sync controllers as needed and returns
+// the errors for all PublishedResources (by name) whose controller could not be started,
+// as well as those whose controller is not restarted yet because it keeps failing.
+func (r *Reconciler) ensureSyncControllers(ctx context.Context, log *zap.SugaredLogger, publishedResources, paused map[string]*syncagentv1alpha1.PublishedResource) (map[string]error, map[string]controllerBackOff) {
+	currentPRWorkers := sets.KeySet(publishedResources)
+	startErrors := map[string]error{}
+	backOffs := map[string]controllerBackOff{}
+
+	// remember failures so that broken controllers are not restarted in a tight loop
+	recordFailure := func(pubRes *syncagentv1alpha1.PublishedResource, key string, cause error) {
+		failures, delay := r.crashLoops.Failed(pubRes.Name, key)
+		r.recorder.Eventf(pubRes, corev1.EventTypeWarning, "CrashLoopBackOff", "Sync controller has failed %d time(s), restarting in %v: %v", failures, delay, cause)
 	}
 
 	// stop controllers that are no longer needed
@@ -250,32 +589,61 @@ func (r *Reconciler) ensureSyncControllers(ctx context.Context, log *zap.Sugared
 			continue
 		}
 
-		log.Infow("Stopping sync controller…", "key", key)
+		var (
+			cause  error
+			reason string
+		)
 
-		var cause error
-		if ctrl.Running() {
-			cause = errors.New("PublishedResource not available anymore")
-		} else {
+		switch {
+		case !ctrl.Running():
 			cause = errors.New("gc'ing failed controller")
+			reason = metrics.ReasonControllerFailed
+
+			if pubRes, exists := publishedResources[key]; exists {
+				recordFailure(pubRes, key, errors.New("controller stopped unexpectedly"))
+			}
+		case hasControllerForUID(publishedResources, key):
+			cause = errors.New("PublishedResource has changed")
+			reason = metrics.ReasonPublishedResourceUpdated
+		case hasControllerForUID(paused, key):
+			cause = errors.New("PublishedResource has been paused")
+			reason = metrics.ReasonPublishedResourcePaused
+		default:
+			cause = errors.New("PublishedResource not available anymore")
+			reason = metrics.ReasonPublishedResourceRemoved
 		}
 
+		log.Infow("Stopping sync controller…", "key", key, "reason", reason)
+
 		// can only fail if the controller wasn't running; a situation we do not care about here
 		_ = ctrl.Stop(log, cause)
 		delete(r.syncWorkers, key)
+
+		r.controllerStops.record(syncControllerType, reason)
 	}
 
 	// start missing controllers
-	for idx := range publishedResources {
-		pubRes := publishedResources[idx]
-		key := getPublishedResourceKey(&pubRes)
-
+	for key, pubRes := range publishedResources {
 		// controller already exists
 		if _, exists := r.syncWorkers[key]; exists {
 			continue
 		}
 
+		// controller has failed recently and needs to wait a bit longer
+		if failures, remaining := r.crashLoops.Remaining(pubRes.Name, key); remaining > 0 {
+			log.Debugw("Delaying restart of failing sync controller", "key", key, "failures", failures, "remaining", remaining)
+			backOffs[pubRes.Name] = controllerBackOff{failures: failures, remaining: remaining}
+			continue
+		}
+
 		log.Infow("Starting new sync controller…", "key", key)
 
+		statistics, ok := r.syncStatistics[pubRes.Name]
+		if !ok {
+			statistics = sync.NewStatistics()
+			r.syncStatistics[pubRes.Name] = statistics
+		}
+
 		// create the sync controller;
 		// use the reconciler's log without any additional reconciling context
 		syncController, err := sync.Create(
@@ -284,32 +652,66 @@ func (r *Reconciler) ensureSyncControllers(ctx context.Context, log *zap.Sugared
 			ctx,
 			r.localManager,
 			r.vwCluster.GetCluster(),
-			&pubRes,
+			pubRes,
 			r.discoveryClient,
-			r.stateNamespace,
+			r.stateOptions,
 			r.agentName,
 			r.log,
 			numSyncWorkers,
+			statistics,
+			r.auditLog,
+			r.writeHook,
+			r.drainTimeout,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to create sync controller: %w", err)
+			startErrors[pubRes.Name] = fmt.Errorf("failed to create sync controller: %w", err)
+			recordFailure(pubRes, key, err)
+			continue
 		}
 
 		// wrap it so we can start/stop it easily
-		wrappedController, err := lifecycle.NewController(syncController)
+		wrappedController, err := lifecycle.NewController(syncController, "controller", syncControllerType, "published_resource", pubRes.Name)
 		if err != nil {
-			return fmt.Errorf("failed to wrap sync controller: %w", err)
+			startErrors[pubRes.Name] = fmt.Errorf("failed to wrap sync controller: %w", err)
+			recordFailure(pubRes, key, err)
+			continue
 		}
 
 		// let 'er rip (remember to use the long-lived app root context here)
 		if err := wrappedController.Start(r.ctx, log); err != nil {
-			return fmt.Errorf("failed to start sync controller: %w", err)
+			startErrors[pubRes.Name] = fmt.Errorf("failed to start sync controller: %w", err)
+			recordFailure(pubRes, key, err)
+			continue
 		}
 
 		r.syncWorkers[key] = wrappedController
+		r.crashLoops.Started(pubRes.Name, key)
+
+		metrics.ControllerStarts.WithLabelValues(syncControllerType).Inc()
 	}
 
-	return nil
+	metrics.RunningControllers.Set(float64(len(r.syncWorkers)))
+
+	// forget about failures of PublishedResources that do not exist anymore
+	names := sets.New[string]()
+	for _, pubRes := range publishedResources {
+		names.Insert(pubRes.Name)
+	}
+	r.crashLoops.Forget(names.Has)
+
+	return startErrors, backOffs
+}
+
+// hasControllerForUID returns true if the given sync controller key belongs to
+// one of the given PublishedResources, albeit possibly in a different version.
+func hasControllerForUID(publishedResources map[string]*syncagentv1alpha1.PublishedResource, key string) bool {
+	for _, pubRes := range publishedResources {
+		if strings.HasPrefix(key, string(pubRes.UID)+"-") {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (r *Reconciler) stopSyncControllers(log *zap.SugaredLogger) {
@@ -321,5 +723,53 @@ func (r *Reconciler) stopSyncControllers(log *zap.SugaredLogger) {
 		}
 
 		delete(r.syncWorkers, uid)
+
+		r.controllerStops.record(syncControllerType, metrics.ReasonVirtualWorkspaceURLChanged)
+	}
+
+	metrics.RunningControllers.Set(0)
+}
+
+func (r *Reconciler) ensureAnnouncementController(log *zap.SugaredLogger) error {
+	if r.announcementWorker != nil {
+		if r.announcementWorker.Running() {
+			return nil
+		}
+
+		r.controllerStops.record(announcementControllerType, metrics.ReasonControllerFailed)
+	}
+
+	log.Info("Starting announcement controller…")
+
+	announcementController, err := announcement.Create(r.localManager, r.vwCluster.GetCluster(), r.prFilter, r.log)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement controller: %w", err)
+	}
+
+	wrappedController, err := lifecycle.NewController(announcementController)
+	if err != nil {
+		return fmt.Errorf("failed to wrap announcement controller: %w", err)
+	}
+
+	if err := wrappedController.Start(r.ctx, log); err != nil {
+		return fmt.Errorf("failed to start announcement controller: %w", err)
 	}
+
+	r.announcementWorker = &wrappedController
+
+	metrics.ControllerStarts.WithLabelValues(announcementControllerType).Inc()
+
+	return nil
+}
+
+func (r *Reconciler) stopAnnouncementController(log *zap.SugaredLogger) {
+	if r.announcementWorker != nil && r.announcementWorker.Running() {
+		if err := r.announcementWorker.Stop(log, errors.New("virtual workspace cluster is recreating")); err != nil {
+			log.Errorw("Failed to stop announcement controller", zap.Error(err))
+		}
+
+		r.controllerStops.record(announcementControllerType, metrics.ReasonVirtualWorkspaceURLChanged)
+	}
+
+	r.announcementWorker = nil
 }
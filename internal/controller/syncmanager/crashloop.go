@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	// crashLoopInitialBackoff is how long a failed sync controller is not restarted
+	// after its first failure; the delay doubles with every subsequent failure.
+	crashLoopInitialBackoff = 10 * time.Second
+	// crashLoopMaxBackoff caps the delay between restarts.
+	crashLoopMaxBackoff = 5 * time.Minute
+	// crashLoopResetAfter is how long a sync controller needs to have been running
+	// for its previous failures to be forgotten.
+	crashLoopResetAfter = 10 * time.Minute
+)
+
+// crashLoopBackOff delays the restart of sync controllers that keep failing, so that
+// a single broken PublishedResource is not endlessly recreated on every reconciliation.
+// It is only used from within the reconciler and therefore not thread-safe.
+type crashLoopBackOff struct {
+	states map[string]*crashLoopState
+	clock  clock.PassiveClock
+}
+
+type crashLoopState struct {
+	// key is the controller key (see getPublishedResourceKey); when it changes, the
+	// PublishedResource has changed and deserves a fresh start
+	key         string
+	failures    int
+	lastFailure time.Time
+	startedAt   time.Time
+}
+
+func newCrashLoopBackOff(clock clock.PassiveClock) *crashLoopBackOff {
+	return &crashLoopBackOff{
+		states: map[string]*crashLoopState{},
+		clock:  clock,
+	}
+}
+
+func (b *crashLoopBackOff) state(name, key string) *crashLoopState {
+	state, exists := b.states[name]
+	if !exists || state.key != key {
+		state = &crashLoopState{key: key}
+		b.states[name] = state
+	}
+
+	return state
+}
+
+// Started records that the sync controller for the PublishedResource has been started.
+func (b *crashLoopBackOff) Started(name, key string) {
+	b.state(name, key).startedAt = b.clock.Now()
+}
+
+// Failed records a failure of the sync controller and returns the number of
+// consecutive failures and how long to wait before restarting it.
+func (b *crashLoopBackOff) Failed(name, key string) (int, time.Duration) {
+	state := b.state(name, key)
+	now := b.clock.Now()
+
+	// a controller that ran fine for a while is not crash-looping
+	if !state.startedAt.IsZero() && now.Sub(state.startedAt) >= crashLoopResetAfter {
+		state.failures = 0
+	}
+
+	state.failures++
+	state.lastFailure = now
+	state.startedAt = time.Time{}
+
+	return state.failures, backoffDelay(state.failures)
+}
+
+// Remaining returns how long to wait before the sync controller may be restarted.
+func (b *crashLoopBackOff) Remaining(name, key string) (int, time.Duration) {
+	state, exists := b.states[name]
+	if !exists || state.key != key || state.failures == 0 {
+		return 0, 0
+	}
+
+	remaining := state.lastFailure.Add(backoffDelay(state.failures)).Sub(b.clock.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return state.failures, remaining
+}
+
+// Forget removes all information about PublishedResources not in the given set.
+func (b *crashLoopBackOff) Forget(keep func(name string) bool) {
+	for name := range b.states {
+		if !keep(name) {
+			delete(b.states, name)
+		}
+	}
+}
+
+func backoffDelay(failures int) time.Duration {
+	delay := crashLoopInitialBackoff
+	for i := 1; i < failures && delay < crashLoopMaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, crashLoopMaxBackoff)
+}
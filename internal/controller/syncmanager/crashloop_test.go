@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"testing"
+	"time"
+
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	testcases := []struct {
+		name     string
+		failures int
+		expected time.Duration
+	}{
+		{name: "first failure uses the initial backoff", failures: 1, expected: 10 * time.Second},
+		{name: "second failure doubles the backoff", failures: 2, expected: 20 * time.Second},
+		{name: "third failure doubles the backoff again", failures: 3, expected: 40 * time.Second},
+		{name: "fifth failure is still below the maximum", failures: 5, expected: 160 * time.Second},
+		{name: "sixth failure is capped at the maximum", failures: 6, expected: crashLoopMaxBackoff},
+		{name: "many failures do not overflow the maximum", failures: 100, expected: crashLoopMaxBackoff},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if delay := backoffDelay(testcase.failures); delay != testcase.expected {
+				t.Fatalf("Expected %v after %d failure(s), but got %v.", testcase.expected, testcase.failures, delay)
+			}
+		})
+	}
+}
+
+func TestCrashLoopBackOff(t *testing.T) {
+	type step struct {
+		// advance steps the fake clock forward before the step is performed
+		advance time.Duration
+		// action is one of "start", "fail" or "check"
+		action string
+		key    string
+
+		expectedFailures  int
+		expectedRemaining time.Duration
+	}
+
+	testcases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "unknown controller can be started immediately",
+			steps: []step{
+				{action: "check", key: "a"},
+			},
+		},
+		{
+			name: "consecutive failures increase the delay",
+			steps: []step{
+				{action: "start", key: "a"},
+				{action: "fail", key: "a", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+				{advance: 4 * time.Second, action: "check", key: "a", expectedFailures: 1, expectedRemaining: 6 * time.Second},
+				{advance: 6 * time.Second, action: "check", key: "a", expectedFailures: 1},
+				{action: "start", key: "a"},
+				{advance: time.Second, action: "fail", key: "a", expectedFailures: 2, expectedRemaining: 20 * time.Second},
+			},
+		},
+		{
+			name: "remaining delay never becomes negative",
+			steps: []step{
+				{action: "fail", key: "a", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+				{advance: time.Hour, action: "check", key: "a", expectedFailures: 1},
+			},
+		},
+		{
+			name: "controller running long enough is not crash-looping",
+			steps: []step{
+				{action: "fail", key: "a", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+				{advance: 10 * time.Second, action: "start", key: "a"},
+				{advance: crashLoopResetAfter, action: "fail", key: "a", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+			},
+		},
+		{
+			name: "changed PublishedResource gets a fresh start",
+			steps: []step{
+				{action: "fail", key: "a", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+				{action: "fail", key: "a", expectedFailures: 2, expectedRemaining: 20 * time.Second},
+				{action: "check", key: "b"},
+				{action: "fail", key: "b", expectedFailures: 1, expectedRemaining: 10 * time.Second},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			backOff := newCrashLoopBackOff(fakeClock)
+
+			for i, s := range testcase.steps {
+				fakeClock.Step(s.advance)
+
+				var (
+					failures  int
+					remaining time.Duration
+				)
+
+				switch s.action {
+				case "start":
+					backOff.Started("my-pr", s.key)
+					continue
+				case "fail":
+					failures, remaining = backOff.Failed("my-pr", s.key)
+				case "check":
+					failures, remaining = backOff.Remaining("my-pr", s.key)
+				}
+
+				if failures != s.expectedFailures || remaining != s.expectedRemaining {
+					t.Fatalf("Step %d (%s): expected %d failure(s) and %v remaining, but got %d and %v.", i, s.action, s.expectedFailures, s.expectedRemaining, failures, remaining)
+				}
+			}
+		})
+	}
+}
+
+func TestCrashLoopBackOffForget(t *testing.T) {
+	backOff := newCrashLoopBackOff(clocktesting.NewFakeClock(time.Now()))
+	backOff.Failed("kept", "a")
+	backOff.Failed("removed", "a")
+
+	backOff.Forget(func(name string) bool { return name == "kept" })
+
+	if failures, _ := backOff.Remaining("kept", "a"); failures != 1 {
+		t.Errorf("Expected failures of kept PublishedResource to be remembered, but got %d.", failures)
+	}
+
+	if failures, _ := backOff.Remaining("removed", "a"); failures != 0 {
+		t.Errorf("Expected failures of removed PublishedResource to be forgotten, but got %d.", failures)
+	}
+}
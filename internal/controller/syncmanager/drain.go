@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"context"
+	"errors"
+	gosync "sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+)
+
+// agentStatusTimeout is how long the agent tries to update its status while
+// shutting down.
+const agentStatusTimeout = 5 * time.Second
+
+// drain blocks until the manager is shutting down and then stops all sync controllers,
+// waiting for their in-flight reconciliations to finish (each one is given up to the
+// drain timeout). This prevents the process from exiting while objects and their
+// last-known states are only partially written.
+func (r *Reconciler) drain(ctx context.Context) error {
+	<-ctx.Done()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.draining = true
+
+	log := r.log.Named(ControllerName)
+	log.Infow("Draining sync controllers…", "controllers", len(r.syncWorkers), "timeout", r.drainTimeout)
+
+	cause := errors.New("the agent is shutting down")
+
+	// stop all controllers concurrently, so the total shutdown time is
+	// bounded by the drain timeout and not a multiple of it
+	var wg gosync.WaitGroup
+	for key, ctrl := range r.syncWorkers {
+		if !ctrl.Running() {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := ctrl.Stop(log.With("key", key), cause); err != nil {
+				log.Errorw("Failed to stop controller", "key", key, zap.Error(err))
+			}
+
+			r.controllerStops.record(syncControllerType, metrics.ReasonShutdown)
+		}()
+	}
+
+	wg.Wait()
+
+	clear(r.syncWorkers)
+	metrics.RunningControllers.Set(0)
+
+	if r.announcementWorker != nil && r.announcementWorker.Running() {
+		if err := r.announcementWorker.Stop(log, cause); err != nil {
+			log.Errorw("Failed to stop announcement controller", zap.Error(err))
+		}
+
+		r.controllerStops.record(announcementControllerType, metrics.ReasonShutdown)
+	}
+
+	r.announcementWorker = nil
+	r.stopNamespaceCleanupController(log, cause, metrics.ReasonShutdown)
+
+	log.Info("All sync controllers have been drained.")
+
+	// the manager's context is already cancelled, but the agent status should
+	// still reflect the shutdown
+	statusCtx, cancel := context.WithTimeout(context.Background(), agentStatusTimeout)
+	defer cancel()
+
+	err := r.patchAgentStatus(statusCtx, func(status *syncagentv1alpha1.SyncAgentStatusStatus) {
+		status.ConnectionState = syncagentv1alpha1.ConnectionStateDraining
+		status.SyncControllers = 0
+	})
+	if err != nil {
+		log.Warnw("Failed to update agent status", zap.Error(err))
+	}
+
+	return nil
+}
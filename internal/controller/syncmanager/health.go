@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	gosync "sync"
+	"time"
+
+	"github.com/kcp-dev/api-syncagent/internal/controller/syncmanager/lifecycle"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// cacheSyncTimeout is how long the readiness check waits for the kcp cache
+// to report being synced.
+const cacheSyncTimeout = 2 * time.Second
+
+// healthTracker keeps a snapshot of the dynamically started clusters and
+// controllers, so that health probes can inspect them without having to
+// synchronize with the (potentially long running) reconciliations.
+type healthTracker struct {
+	lock gosync.RWMutex
+
+	kcpCluster         cluster.Cluster
+	vwCluster          *lifecycle.Cluster
+	syncWorkers        map[string]lifecycle.Controller
+	announcementWorker *lifecycle.Controller
+}
+
+func newHealthTracker(kcpCluster cluster.Cluster) *healthTracker {
+	return &healthTracker{
+		kcpCluster:  kcpCluster,
+		syncWorkers: map[string]lifecycle.Controller{},
+	}
+}
+
+// addChecks registers the liveness and readiness checks with the manager.
+func (t *healthTracker) addChecks(mgr manager.Manager) error {
+	if err := mgr.AddHealthzCheck("syncmanager", t.healthCheck); err != nil {
+		return fmt.Errorf("failed to add health check: %w", err)
+	}
+
+	if err := mgr.AddReadyzCheck("syncmanager", t.readyCheck); err != nil {
+		return fmt.Errorf("failed to add readiness check: %w", err)
+	}
+
+	return nil
+}
+
+// record replaces the snapshot with the current state of the reconciler. The
+// sync controllers are tracked by the names of their PublishedResources.
+func (t *healthTracker) record(r *Reconciler, publishedResources map[string]*syncagentv1alpha1.PublishedResource) {
+	syncWorkers := map[string]lifecycle.Controller{}
+	for key, ctrl := range r.syncWorkers {
+		if pubRes, ok := publishedResources[key]; ok {
+			syncWorkers[pubRes.Name] = ctrl
+		}
+	}
+
+	var announcementWorker *lifecycle.Controller
+	if r.announcementWorker != nil {
+		worker := *r.announcementWorker
+		announcementWorker = &worker
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.vwCluster = r.vwCluster
+	t.syncWorkers = syncWorkers
+	t.announcementWorker = announcementWorker
+}
+
+// healthCheck fails if the virtual workspace cluster has stopped even though
+// the reconciler still considers it to be running. Since the syncmanager would
+// not notice this until the APIExport changes, the agent has to be restarted
+// in this case. Failed sync controllers on the other hand are restarted by the
+// syncmanager and only make the agent unready.
+func (t *healthTracker) healthCheck(_ *http.Request) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.checkCluster()
+}
+
+// readyCheck additionally requires the kcp connection to be established and
+// all controllers to be running.
+func (t *healthTracker) readyCheck(req *http.Request) error {
+	ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+	defer cancel()
+
+	var errs []error
+
+	if !t.kcpCluster.GetCache().WaitForCacheSync(ctx) {
+		errs = append(errs, errors.New("kcp cache is not synced"))
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if err := t.checkCluster(); err != nil {
+		errs = append(errs, err)
+	} else {
+		errs = append(errs, t.checkControllers()...)
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (t *healthTracker) checkCluster() error {
+	// nothing has been started yet, e.g. because this agent is not the leader
+	// or the virtual workspace is not ready yet
+	if t.vwCluster != nil && !t.vwCluster.Running() {
+		return errors.New("virtual workspace cluster has stopped")
+	}
+
+	return nil
+}
+
+func (t *healthTracker) checkControllers() []error {
+	var errs []error
+
+	if t.announcementWorker != nil && !t.announcementWorker.Running() {
+		errs = append(errs, errors.New("announcement controller has stopped"))
+	}
+
+	names := make([]string, 0, len(t.syncWorkers))
+	for name := range t.syncWorkers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		ctrl := t.syncWorkers[name]
+		if !ctrl.Running() {
+			errs = append(errs, fmt.Errorf("sync controller for PublishedResource %s has stopped", name))
+		}
+	}
+
+	return errs
+}
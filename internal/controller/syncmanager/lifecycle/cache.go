@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// CacheOptions control which parts of the objects in the virtual workspace are kept
+// in memory. The sync controllers always read the objects they reconcile directly
+// from kcp, the cache is only used to enqueue objects and hence only needs their
+// metadata.
+type CacheOptions struct {
+	// StripManagedFields removes the managed fields from all cached objects.
+	StripManagedFields bool
+
+	// MaxAnnotationSize removes annotations whose values are larger than this number
+	// of bytes from all cached objects. Zero disables the limit.
+	MaxAnnotationSize int
+
+	// MetadataOnly lists the kinds (as seen in kcp) for which only the metadata of
+	// objects is cached. This is useful for resources with huge payloads.
+	MetadataOnly []schema.GroupKind
+}
+
+// transform returns the transform function for the cache, or nil if the cached
+// objects do not need to be modified.
+func (o CacheOptions) transform() toolscache.TransformFunc {
+	if !o.StripManagedFields && o.MaxAnnotationSize <= 0 && len(o.MetadataOnly) == 0 {
+		return nil
+	}
+
+	return func(in any) (any, error) {
+		// reduce objects of the configured kinds to their metadata
+		if u, ok := in.(*unstructured.Unstructured); ok && slices.Contains(o.MetadataOnly, u.GroupVersionKind().GroupKind()) {
+			u.Object = map[string]any{
+				"apiVersion": u.Object["apiVersion"],
+				"kind":       u.Object["kind"],
+				"metadata":   u.Object["metadata"],
+			}
+		}
+
+		obj, err := meta.Accessor(in)
+		if err != nil {
+			// ignore tombstones and other objects without metadata
+			return in, nil
+		}
+
+		if o.StripManagedFields && obj.GetManagedFields() != nil {
+			obj.SetManagedFields(nil)
+		}
+
+		if o.MaxAnnotationSize > 0 {
+			obj.SetAnnotations(stripLargeAnnotations(obj.GetAnnotations(), o.MaxAnnotationSize))
+		}
+
+		return in, nil
+	}
+}
+
+// stripLargeAnnotations removes all annotations whose values exceed the given size,
+// except for the cluster annotation, which is required to map objects to workspaces.
+func stripLargeAnnotations(annotations map[string]string, maxSize int) map[string]string {
+	for key, value := range annotations {
+		if len(value) > maxSize && key != logicalcluster.AnnotationKey {
+			delete(annotations, key)
+		}
+	}
+
+	return annotations
+}
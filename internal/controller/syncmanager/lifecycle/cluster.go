@@ -125,7 +125,7 @@ func generatePath(originalPath string, workspacePath logicalcluster.Path) string
 	return path
 }
 
-func NewCluster(address string, baseRestConfig *rest.Config) (*Cluster, error) {
+func NewCluster(address string, baseRestConfig *rest.Config, cacheOptions CacheOptions) (*Cluster, error) {
 	// note that this cluster and all its components are kcp-aware
 	config := rest.CopyConfig(baseRestConfig)
 	config.Host = address
@@ -150,6 +150,9 @@ func NewCluster(address string, baseRestConfig *rest.Config) (*Cluster, error) {
 		o.NewAPIReader = kcp.NewClusterAwareAPIReader
 		o.NewClient = kcp.NewClusterAwareClient
 		o.MapperProvider = newWildcardClusterMapperProvider
+		o.Cache = cache.Options{
+			DefaultTransform: cacheOptions.transform(),
+		}
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize cluster: %w", err)
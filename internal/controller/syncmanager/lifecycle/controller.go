@@ -19,6 +19,7 @@ package lifecycle
 import (
 	"context"
 	"errors"
+	"runtime/pprof"
 
 	"go.uber.org/zap"
 
@@ -36,11 +37,22 @@ type Controller struct {
 
 	// a function that is used to stop the vwController
 	cancelFunc context.CancelCauseFunc
+
+	// labels that are attached to all goroutines of the controller
+	profilingLabels pprof.LabelSet
 }
 
-func NewController(upstream controller.Controller) (Controller, error) {
+// NewController wraps the given controller. The optional profiling labels (pairs
+// of keys and values) are attached to all goroutines started by the controller,
+// so that they can be told apart in CPU and goroutine profiles.
+func NewController(upstream controller.Controller, profilingLabels ...string) (Controller, error) {
+	if len(profilingLabels)%2 != 0 {
+		return Controller{}, errors.New("profiling labels must be pairs of keys and values")
+	}
+
 	return Controller{
-		obj: upstream,
+		obj:             upstream,
+		profilingLabels: pprof.Labels(profilingLabels...),
 	}, nil
 }
 
@@ -64,10 +76,13 @@ func (c *Controller) Start(ctx context.Context, log *zap.SugaredLogger) error {
 		defer close(c.stopped)
 
 		// this call blocks until ctrlCtx is done or an error occurs
-		// like failing to start the watches
-		if err := c.obj.Start(ctrlCtx); err != nil {
-			log.Errorw("Controller has failed", zap.Error(err))
-		}
+		// like failing to start the watches; goroutines started by the
+		// controller inherit the labels
+		pprof.Do(ctrlCtx, c.profilingLabels, func(ctrlCtx context.Context) {
+			if err := c.obj.Start(ctrlCtx); err != nil {
+				log.Errorw("Controller has failed", zap.Error(err))
+			}
+		})
 
 		cancel(errors.New("closing to prevent leakage"))
 
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/controller/namespacecleanup"
+	"github.com/kcp-dev/api-syncagent/internal/controller/syncmanager/lifecycle"
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+)
+
+// ensureNamespaceCleanupController starts the namespace cleanup controller, if it is
+// enabled and not running yet. Like the announcement controller, it shares the
+// lifecycle of the vwCluster.
+func (r *Reconciler) ensureNamespaceCleanupController(log *zap.SugaredLogger) error {
+	if !r.namespaceCleanup {
+		return nil
+	}
+
+	if r.namespaceCleanupWorker != nil {
+		if r.namespaceCleanupWorker.Running() {
+			return nil
+		}
+
+		r.controllerStops.record(namespaceCleanupControllerType, metrics.ReasonControllerFailed)
+	}
+
+	log.Info("Starting namespace cleanup controller…")
+
+	cleanupController, err := namespacecleanup.Create(r.localManager, r.vwCluster.GetCluster(), r.prFilter, r.agentName, r.log)
+	if err != nil {
+		return fmt.Errorf("failed to create namespace cleanup controller: %w", err)
+	}
+
+	wrappedController, err := lifecycle.NewController(cleanupController)
+	if err != nil {
+		return fmt.Errorf("failed to wrap namespace cleanup controller: %w", err)
+	}
+
+	if err := wrappedController.Start(r.ctx, log); err != nil {
+		return fmt.Errorf("failed to start namespace cleanup controller: %w", err)
+	}
+
+	r.namespaceCleanupWorker = &wrappedController
+
+	metrics.ControllerStarts.WithLabelValues(namespaceCleanupControllerType).Inc()
+
+	return nil
+}
+
+func (r *Reconciler) stopNamespaceCleanupController(log *zap.SugaredLogger, cause error, reason string) {
+	if r.namespaceCleanupWorker != nil && r.namespaceCleanupWorker.Running() {
+		if err := r.namespaceCleanupWorker.Stop(log, cause); err != nil {
+			log.Errorw("Failed to stop namespace cleanup controller", zap.Error(err))
+		}
+
+		r.controllerStops.record(namespaceCleanupControllerType, reason)
+	}
+
+	r.namespaceCleanupWorker = nil
+}
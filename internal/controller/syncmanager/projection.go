@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// reconcileProjection records the currently projected GVK of a PublishedResource
+// in its status and handles objects in kcp that still use a previously projected
+// GVK, according to the PublishedResource's ProjectionChangePolicy.
+func (r *Reconciler) reconcileProjection(ctx context.Context, log *zap.SugaredLogger, pubRes *syncagentv1alpha1.PublishedResource) error {
+	projectedGVK := projection.PublishedResourceProjectedGVK(pubRes)
+	current := syncagentv1alpha1.GroupVersionKind{
+		Group:   projectedGVK.Group,
+		Version: projectedGVK.Version,
+		Kind:    projectedGVK.Kind,
+	}
+
+	// collect all previous GVKs that might still have objects in kcp
+	previous := []syncagentv1alpha1.GroupVersionKind{}
+	for _, leftover := range pubRes.Status.ProjectionLeftovers {
+		if leftover.GroupVersionKind != current {
+			previous = append(previous, leftover.GroupVersionKind)
+		}
+	}
+
+	if last := pubRes.Status.ProjectedGVK; last != nil && *last != current && !slices.Contains(previous, *last) {
+		log.Infow("Projection has changed", "previous", last, "current", current)
+		previous = append(previous, *last)
+	}
+
+	leftovers := []syncagentv1alpha1.ProjectionLeftover{}
+	for _, gvk := range previous {
+		remaining, err := r.handleProjectionLeftovers(ctx, log, pubRes, gvk, projectedGVK)
+		if err != nil {
+			return fmt.Errorf("failed to handle leftover %s objects: %w", gvk.Kind, err)
+		}
+
+		if remaining > 0 {
+			leftovers = append(leftovers, syncagentv1alpha1.ProjectionLeftover{
+				GroupVersionKind: gvk,
+				Objects:          remaining,
+			})
+		}
+	}
+
+	if len(leftovers) == 0 {
+		leftovers = nil
+	}
+
+	oldPubRes := pubRes.DeepCopy()
+	pubRes.Status.ProjectedGVK = &current
+	pubRes.Status.ProjectionLeftovers = leftovers
+
+	if equality.Semantic.DeepEqual(oldPubRes.Status, pubRes.Status) {
+		return nil
+	}
+
+	if err := r.localManager.GetClient().Status().Patch(ctx, pubRes, ctrlruntimeclient.MergeFrom(oldPubRes)); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// handleProjectionLeftovers finds all objects of the given previous GVK across all
+// workspaces and applies the ProjectionChangePolicy to them. It returns the number
+// of objects that still remain using the previous GVK. As kcp does not serve the
+// previous GVK anymore, the objects are found via their local copies.
+func (r *Reconciler) handleProjectionLeftovers(ctx context.Context, log *zap.SugaredLogger, pubRes *syncagentv1alpha1.PublishedResource, previous syncagentv1alpha1.GroupVersionKind, current schema.GroupVersionKind) (int, error) {
+	previousGVK := schema.GroupVersionKind{
+		Group:   previous.Group,
+		Version: previous.Version,
+		Kind:    previous.Kind,
+	}
+
+	localClient := r.localManager.GetClient()
+
+	leftovers, err := sync.FindProjectionLeftovers(ctx, localClient, pubRes, previousGVK, r.agentName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find objects: %w", err)
+	}
+
+	remaining := len(leftovers)
+	if remaining == 0 {
+		return 0, nil
+	}
+
+	switch pubRes.Spec.ProjectionChangePolicy {
+	case syncagentv1alpha1.ProjectionChangePolicyWarn:
+		r.recorder.Eventf(pubRes, corev1.EventTypeWarning, "ProjectionLeftovers", "%d object(s) of previous kind %s (%s) still exist in kcp.", remaining, previous.Kind, previousGVK.GroupVersion())
+
+	case syncagentv1alpha1.ProjectionChangePolicyMigrate:
+		remoteClient := r.vwCluster.GetCluster().GetClient()
+
+		for _, leftover := range leftovers {
+			objLog := log.With("cluster", leftover.Remote.ClusterName, "namespace", leftover.Remote.Namespace, "name", leftover.Remote.Name)
+
+			wsCtx := kontext.WithCluster(ctx, logicalcluster.Name(leftover.Remote.ClusterName))
+			if err := sync.MigrateProjectionLeftover(wsCtx, localClient, remoteClient, pubRes, leftover, previousGVK, current, r.stateOptions); err != nil {
+				objLog.Warnw("Failed to migrate object", zap.Error(err))
+				continue
+			}
+
+			objLog.Info("Migrated object to new projection")
+			remaining--
+		}
+	}
+
+	return remaining, nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	"github.com/kcp-dev/api-syncagent/internal/profile"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// unpublishFinalizer is placed on PublishedResources to ensure that they are only
+// deleted once no more objects exist in kcp.
+const unpublishFinalizer = "syncagent.kcp.io/unpublish"
+
+// isUnpublishing returns true if the PublishedResource should not be synced anymore.
+func isUnpublishing(pubRes *syncagentv1alpha1.PublishedResource) bool {
+	return pubRes.Spec.Unpublish || pubRes.DeletionTimestamp != nil
+}
+
+// ensureUnpublishFinalizer adds the unpublish finalizer to a PublishedResource
+// and clears a leftover unpublish status in case unpublishing was aborted.
+func (r *Reconciler) ensureUnpublishFinalizer(ctx context.Context, pubRes *syncagentv1alpha1.PublishedResource) error {
+	client := r.localManager.GetClient()
+
+	if !slices.Contains(pubRes.Finalizers, unpublishFinalizer) {
+		oldPubRes := pubRes.DeepCopy()
+		pubRes.Finalizers = append(pubRes.Finalizers, unpublishFinalizer)
+
+		if err := client.Patch(ctx, pubRes, ctrlruntimeclient.MergeFrom(oldPubRes)); err != nil {
+			return fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	if pubRes.Status.Unpublish != nil {
+		oldPubRes := pubRes.DeepCopy()
+		pubRes.Status.Unpublish = nil
+
+		if err := client.Status().Patch(ctx, pubRes, ctrlruntimeclient.MergeFrom(oldPubRes)); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileUnpublish takes care of a PublishedResource that is being unpublished; its
+// sync controller has been stopped already. Remote objects that are in deletion are
+// still processed to not leave them stuck on the cleanup finalizer. Once no more
+// objects remain in kcp, the PublishedResource is released. The function returns the
+// number of remaining objects.
+func (r *Reconciler) reconcileUnpublish(ctx context.Context, log *zap.SugaredLogger, pubRes *syncagentv1alpha1.PublishedResource) (int, error) {
+	// profiles might affect the projection, but a missing profile must not block deletion
+	effective, _, err := profile.Resolve(ctx, r.localManager.GetClient(), pubRes)
+	if err != nil {
+		log.Warnw("Failed to resolve profile, ignoring it", zap.Error(err))
+		effective = pubRes
+	}
+
+	remoteObjects, err := r.listRemoteObjects(ctx, effective)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list remaining objects: %w", err)
+	}
+
+	if err := r.processDeletedRemoteObjects(ctx, log, effective, remoteObjects); err != nil {
+		return 0, fmt.Errorf("failed to clean up deleted objects: %w", err)
+	}
+
+	remaining := len(remoteObjects)
+
+	oldPubRes := pubRes.DeepCopy()
+	pubRes.Status.Unpublish = &syncagentv1alpha1.UnpublishStatus{
+		RemainingObjects:   remaining,
+		ResourceSchemaName: pubRes.Status.ResourceSchemaName,
+	}
+
+	if !equality.Semantic.DeepEqual(oldPubRes.Status, pubRes.Status) {
+		log.Infow("Unpublishing resource", "remaining", remaining)
+
+		if err := r.localManager.GetClient().Status().Patch(ctx, pubRes, ctrlruntimeclient.MergeFrom(oldPubRes)); err != nil {
+			return 0, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	if remaining > 0 || pubRes.DeletionTimestamp == nil {
+		return remaining, nil
+	}
+
+	if idx := slices.Index(pubRes.Finalizers, unpublishFinalizer); idx >= 0 {
+		log.Info("No objects remain in kcp, releasing PublishedResource")
+
+		oldPubRes := pubRes.DeepCopy()
+		pubRes.Finalizers = slices.Delete(pubRes.Finalizers, idx, idx+1)
+
+		if err := r.localManager.GetClient().Patch(ctx, pubRes, ctrlruntimeclient.MergeFrom(oldPubRes)); err != nil {
+			return 0, fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+	}
+
+	return 0, nil
+}
+
+// listRemoteObjects returns all objects of the PublishedResource's projected kind
+// across all workspaces.
+func (r *Reconciler) listRemoteObjects(ctx context.Context, pubRes *syncagentv1alpha1.PublishedResource) ([]unstructured.Unstructured, error) {
+	gvk := projection.PublishedResourceProjectedGVK(pubRes)
+
+	objects := &unstructured.UnstructuredList{}
+	objects.SetAPIVersion(gvk.GroupVersion().String())
+	objects.SetKind(gvk.Kind + "List")
+
+	// bypass the cache, as the sync controller and its informers are gone
+	wildcardCtx := kontext.WithCluster(ctx, logicalcluster.Name(logicalcluster.Wildcard.String()))
+	if err := r.vwCluster.GetCluster().GetAPIReader().List(wildcardCtx, objects); err != nil {
+		// the kind is not served (anymore), so no objects can remain
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return objects.Items, nil
+}
+
+// processDeletedRemoteObjects runs the regular synchronization for all remote objects
+// that are in deletion, so that their local copies are removed and the cleanup
+// finalizer is released.
+func (r *Reconciler) processDeletedRemoteObjects(ctx context.Context, log *zap.SugaredLogger, pubRes *syncagentv1alpha1.PublishedResource, remoteObjects []unstructured.Unstructured) error {
+	var syncer *sync.ResourceSyncer
+
+	for i := range remoteObjects {
+		remoteObj := &remoteObjects[i]
+		if remoteObj.GetDeletionTimestamp() == nil {
+			continue
+		}
+
+		// lazily create the syncer, as it requires finding the local CRD
+		if syncer == nil {
+			localCRD, err := r.discoveryClient.RetrieveCRD(ctx, projection.PublishedResourceSourceGVK(pubRes))
+			if err != nil {
+				return fmt.Errorf("failed to find local CRD: %w", err)
+			}
+
+			syncer, err = sync.NewResourceSyncer(log, r.localManager.GetClient(), r.vwCluster.GetCluster().GetClient(), pubRes, localCRD, r.discoveryClient, mutation.NewMutator(pubRes.Spec.Mutation, r.agentName), r.recorder, r.stateOptions, r.agentName)
+			if err != nil {
+				return fmt.Errorf("failed to create syncer: %w", err)
+			}
+		}
+
+		wsCtx := kontext.WithCluster(ctx, logicalcluster.From(remoteObj))
+		if _, err := syncer.Process(sync.NewContext(ctx, wsCtx), remoteObj); err != nil {
+			log.Warnw("Failed to process deleted object", "cluster", logicalcluster.From(remoteObj), "namespace", remoteObj.GetNamespace(), "name", remoteObj.GetName(), zap.Error(err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacemapping
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/controllerutil/predicate"
+	"github.com/kcp-dev/api-syncagent/internal/sync"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ControllerName = "syncagent-workspacemapping"
+)
+
+// Reconciler maintains one WorkspaceMapping for every kcp workspace for which the
+// Sync Agent has created namespaces on the service cluster.
+type Reconciler struct {
+	localClient ctrlruntimeclient.Client
+	log         *zap.SugaredLogger
+	agentName   string
+}
+
+// Add creates a new controller and adds it to the given manager.
+func Add(
+	mgr manager.Manager,
+	log *zap.SugaredLogger,
+	agentName string,
+) error {
+	reconciler := &Reconciler{
+		localClient: mgr.GetClient(),
+		log:         log.Named(ControllerName),
+		agentName:   agentName,
+	}
+
+	// reconcile per kcp workspace, not per namespace
+	enqueueWorkspace := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		ns, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return nil
+		}
+
+		clusterName, _ := sync.NamespaceWorkspace(ns)
+		if clusterName.Empty() {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: clusterName.String()}}}
+	})
+
+	enqueueMappedWorkspace := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj ctrlruntimeclient.Object) []reconcile.Request {
+		mapping, ok := obj.(*syncagentv1alpha1.WorkspaceMapping)
+		if !ok || mapping.ClusterName == "" {
+			return nil
+		}
+
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: mapping.ClusterName}}}
+	})
+
+	_, err := builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		// Watch for changes to the namespaces created by this agent
+		Watches(&corev1.Namespace{}, enqueueWorkspace, builder.WithPredicates(predicate.ByLabels(sync.CreatedNamespacesSelector(agentName)))).
+		// Watch the mappings to revert manual changes
+		Watches(&syncagentv1alpha1.WorkspaceMapping{}, enqueueMappedWorkspace).
+		Build(reconciler)
+	return err
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	clusterName := logicalcluster.Name(request.Name)
+
+	log := r.log.With("cluster", clusterName)
+	log.Debug("Processing")
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.localClient.List(ctx, namespaces, ctrlruntimeclient.MatchingLabelsSelector{Selector: sync.CreatedNamespacesSelector(r.agentName)}); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	desired := &syncagentv1alpha1.WorkspaceMapping{}
+	desired.Name = mappingName(r.agentName, clusterName)
+	desired.ClusterName = clusterName.String()
+
+	for _, ns := range namespaces.Items {
+		nsCluster, path := sync.NamespaceWorkspace(&ns)
+		if nsCluster != clusterName || ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		desired.Namespaces = append(desired.Namespaces, ns.Name)
+
+		if desired.WorkspacePath == "" && !path.Empty() {
+			desired.WorkspacePath = path.String()
+		}
+	}
+
+	slices.Sort(desired.Namespaces)
+
+	existing := &syncagentv1alpha1.WorkspaceMapping{}
+	err := r.localClient.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get WorkspaceMapping: %w", err)
+	}
+
+	exists := err == nil
+
+	switch {
+	// all namespaces are gone
+	case len(desired.Namespaces) == 0:
+		if exists {
+			log.Info("Deleting WorkspaceMapping…")
+			if err := r.localClient.Delete(ctx, existing); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to delete WorkspaceMapping: %w", err)
+			}
+		}
+
+	case !exists:
+		log.Info("Creating WorkspaceMapping…")
+		if err := r.localClient.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+			return reconcile.Result{}, fmt.Errorf("failed to create WorkspaceMapping: %w", err)
+		}
+
+	case existing.ClusterName != desired.ClusterName || existing.WorkspacePath != desired.WorkspacePath || !slices.Equal(existing.Namespaces, desired.Namespaces):
+		log.Debug("Updating WorkspaceMapping…")
+
+		existing.ClusterName = desired.ClusterName
+		existing.WorkspacePath = desired.WorkspacePath
+		existing.Namespaces = desired.Namespaces
+
+		if err := r.localClient.Update(ctx, existing); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update WorkspaceMapping: %w", err)
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func mappingName(agentName string, clusterName logicalcluster.Name) string {
+	return fmt.Sprintf("%s-%s", agentName, clusterName)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readinessConditions are the conditions that must all be true for a
+// PublishedResource to be ready.
+var readinessConditions = []string{
+	syncagentv1alpha1.ConditionSchemaCreated,
+	syncagentv1alpha1.ConditionExportUpdated,
+	syncagentv1alpha1.ConditionSyncControllerRunning,
+}
+
+// SetPublishedResourceCondition sets the given condition on the PublishedResource
+// and updates its Ready condition accordingly. It returns true if the status has
+// changed.
+func SetPublishedResourceCondition(pubRes *syncagentv1alpha1.PublishedResource, condition metav1.Condition) bool {
+	condition.ObservedGeneration = pubRes.Generation
+
+	changed := meta.SetStatusCondition(&pubRes.Status.Conditions, condition)
+	changed = meta.SetStatusCondition(&pubRes.Status.Conditions, readyCondition(pubRes)) || changed
+
+	return changed
+}
+
+func readyCondition(pubRes *syncagentv1alpha1.PublishedResource) metav1.Condition {
+	ready := metav1.Condition{
+		Type:               syncagentv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Ready",
+		Message:            "The resource is published and objects are being synchronized.",
+		ObservedGeneration: pubRes.Generation,
+	}
+
+	for _, conditionType := range readinessConditions {
+		condition := meta.FindStatusCondition(pubRes.Status.Conditions, conditionType)
+		if condition == nil {
+			ready.Status = metav1.ConditionUnknown
+			ready.Reason = "Pending"
+			ready.Message = fmt.Sprintf("Waiting for the %s condition.", conditionType)
+
+			return ready
+		}
+
+		if condition.Status != metav1.ConditionTrue {
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = conditionType + "NotTrue"
+			ready.Message = fmt.Sprintf("%s: %s", conditionType, condition.Message)
+
+			return ready
+		}
+	}
+
+	if meta.IsStatusConditionFalse(pubRes.Status.Conditions, syncagentv1alpha1.ConditionRelatedResourcesResolved) {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "RelatedResourcesUnresolved"
+		ready.Message = "Some related resource kinds could not be resolved."
+	}
+
+	if condition := meta.FindStatusCondition(pubRes.Status.Conditions, syncagentv1alpha1.ConditionProjectionReconciled); condition != nil && condition.Status == metav1.ConditionFalse {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "ProjectionNotReconciled"
+		ready.Message = fmt.Sprintf("%s: %s", syncagentv1alpha1.ConditionProjectionReconciled, condition.Message)
+	}
+
+	return ready
+}
+
+// PatchPublishedResourceStatus patches the status of the PublishedResource if it
+// differs from the original. Multiple controllers update the conditions, so an
+// optimistic lock is used to not accidentally overwrite each other's changes.
+func PatchPublishedResourceStatus(ctx context.Context, client ctrlruntimeclient.Client, original, pubRes *syncagentv1alpha1.PublishedResource) error {
+	if equality.Semantic.DeepEqual(original.Status, pubRes.Status) {
+		return nil
+	}
+
+	return client.Status().Patch(ctx, pubRes, ctrlruntimeclient.MergeFromWithOptions(original, ctrlruntimeclient.MergeFromWithOptimisticLock{}))
+}
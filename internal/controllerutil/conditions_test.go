@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetPublishedResourceCondition(t *testing.T) {
+	running := []metav1.Condition{
+		{Type: syncagentv1alpha1.ConditionSchemaCreated, Status: metav1.ConditionTrue},
+		{Type: syncagentv1alpha1.ConditionExportUpdated, Status: metav1.ConditionTrue},
+	}
+
+	testcases := []struct {
+		name           string
+		existing       []metav1.Condition
+		condition      metav1.Condition
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "missing conditions make the resource pending",
+			condition:      metav1.Condition{Type: syncagentv1alpha1.ConditionSyncControllerRunning, Status: metav1.ConditionTrue, Reason: "Running"},
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: "Pending",
+		},
+		{
+			name:           "all readiness conditions are true",
+			existing:       running,
+			condition:      metav1.Condition{Type: syncagentv1alpha1.ConditionSyncControllerRunning, Status: metav1.ConditionTrue, Reason: "Running"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "Ready",
+		},
+		{
+			name:           "paused sync controller",
+			existing:       running,
+			condition:      metav1.Condition{Type: syncagentv1alpha1.ConditionSyncControllerRunning, Status: metav1.ConditionFalse, Reason: "Paused"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: syncagentv1alpha1.ConditionSyncControllerRunning + "NotTrue",
+		},
+		{
+			name: "failed projection",
+			existing: append([]metav1.Condition{
+				{Type: syncagentv1alpha1.ConditionSyncControllerRunning, Status: metav1.ConditionTrue, Reason: "Running"},
+			}, running...),
+			condition:      metav1.Condition{Type: syncagentv1alpha1.ConditionProjectionReconciled, Status: metav1.ConditionFalse, Reason: "ProjectionFailed"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "ProjectionNotReconciled",
+		},
+		{
+			name: "reconciled projection",
+			existing: append([]metav1.Condition{
+				{Type: syncagentv1alpha1.ConditionSyncControllerRunning, Status: metav1.ConditionTrue, Reason: "Running"},
+			}, running...),
+			condition:      metav1.Condition{Type: syncagentv1alpha1.ConditionProjectionReconciled, Status: metav1.ConditionTrue, Reason: "Reconciled"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "Ready",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := &syncagentv1alpha1.PublishedResource{}
+			pubRes.Status.Conditions = append([]metav1.Condition{}, testcase.existing...)
+
+			if !SetPublishedResourceCondition(pubRes, testcase.condition) {
+				t.Fatal("Expected the status to change.")
+			}
+
+			ready := meta.FindStatusCondition(pubRes.Status.Conditions, syncagentv1alpha1.ConditionReady)
+			if ready == nil {
+				t.Fatal("Expected a Ready condition.")
+			}
+
+			if ready.Status != testcase.expectedStatus {
+				t.Fatalf("Expected Ready to be %q, but got %q.", testcase.expectedStatus, ready.Status)
+			}
+
+			if ready.Reason != testcase.expectedReason {
+				t.Fatalf("Expected reason %q, but got %q.", testcase.expectedReason, ready.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errDrainTimeout is the cause for contexts that were cancelled because an
+// operation did not finish in time after the shutdown began.
+var errDrainTimeout = errors.New("drain timeout exceeded during shutdown")
+
+// DrainContext returns a context that is not immediately cancelled when parent is
+// cancelled, but only after the given timeout. This allows in-flight operations
+// (like a reconciliation that is halfway through writing objects) to finish cleanly
+// when the Sync Agent is shutting down. The returned function must be called once
+// the operation is done to release resources.
+func DrainContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(parent))
+
+	stop := context.AfterFunc(parent, func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+			cancel(errDrainTimeout)
+		case <-ctx.Done():
+		}
+	})
+
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type drainTestKey struct{}
+
+func TestDrainContext(t *testing.T) {
+	testcases := []struct {
+		name          string
+		timeout       time.Duration
+		cancelParent  bool
+		wait          time.Duration
+		expectedErr   error
+		expectedCause error
+	}{
+		{
+			name:    "context is alive while the parent is",
+			timeout: time.Hour,
+		},
+		{
+			name:         "context outlives its cancelled parent until the timeout",
+			timeout:      time.Hour,
+			cancelParent: true,
+			wait:         50 * time.Millisecond,
+		},
+		{
+			name:          "context is cancelled once the timeout has passed",
+			timeout:       10 * time.Millisecond,
+			cancelParent:  true,
+			wait:          time.Second,
+			expectedErr:   context.Canceled,
+			expectedCause: errDrainTimeout,
+		},
+		{
+			name:    "timeout does not apply while the parent is alive",
+			timeout: time.Nanosecond,
+			wait:    50 * time.Millisecond,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			parent, cancelParent := context.WithCancel(context.WithValue(context.Background(), drainTestKey{}, "value"))
+			defer cancelParent()
+
+			ctx, done := DrainContext(parent, testcase.timeout)
+			defer done()
+
+			if ctx.Value(drainTestKey{}) != "value" {
+				t.Error("Expected values of the parent context to be kept.")
+			}
+
+			if testcase.cancelParent {
+				cancelParent()
+			}
+
+			if testcase.expectedErr != nil {
+				select {
+				case <-ctx.Done():
+				case <-time.After(testcase.wait):
+					t.Fatal("Expected context to be cancelled, but it is still alive.")
+				}
+			} else {
+				time.Sleep(testcase.wait)
+			}
+
+			if err := ctx.Err(); !errors.Is(err, testcase.expectedErr) {
+				t.Fatalf("Expected error %v, but got %v.", testcase.expectedErr, err)
+			}
+
+			if testcase.expectedCause != nil {
+				if cause := context.Cause(ctx); !errors.Is(cause, testcase.expectedCause) {
+					t.Fatalf("Expected cause %v, but got %v.", testcase.expectedCause, cause)
+				}
+			}
+
+			// releasing the context always cancels it
+			done()
+
+			if ctx.Err() == nil {
+				t.Fatal("Expected context to be cancelled after it was released.")
+			}
+		})
+	}
+}
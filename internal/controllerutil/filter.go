@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"strconv"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PublishedResourceFilter decides which PublishedResources are processed by this
+// Sync Agent. Next to an optional label selector, PublishedResources can be
+// split among multiple agents (shards) sharing the same APIExport.
+type PublishedResourceFilter struct {
+	selector labels.Selector
+	shard    int
+	shards   int
+}
+
+// NewPublishedResourceFilter returns a filter for the given label selector and
+// shard. If shards is less than 2, sharding is disabled.
+func NewPublishedResourceFilter(selector labels.Selector, shard int, shards int) *PublishedResourceFilter {
+	if selector == nil {
+		selector = labels.Everything()
+	}
+
+	return &PublishedResourceFilter{
+		selector: selector,
+		shard:    shard,
+		shards:   shards,
+	}
+}
+
+// Matches returns true if the given PublishedResource is processed by this agent.
+func (f *PublishedResourceFilter) Matches(obj ctrlruntimeclient.Object) bool {
+	if !f.selector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+
+	return f.shards < 2 || ShardOf(obj, f.shards) == f.shard
+}
+
+// PrimaryShard returns true if this agent is the first (or only) shard. The primary
+// shard always exists, regardless of the number of shards, and therefore owns
+// settings that are shared by all shards.
+func (f *PublishedResourceFilter) PrimaryShard() bool {
+	return f == nil || f.shards < 2 || f.shard == 0
+}
+
+// List returns all PublishedResources that match the filter.
+func (f *PublishedResourceFilter) List(ctx context.Context, client ctrlruntimeclient.Reader, list *syncagentv1alpha1.PublishedResourceList) error {
+	if err := client.List(ctx, list, &ctrlruntimeclient.ListOptions{LabelSelector: f.selector}); err != nil {
+		return err
+	}
+
+	list.Items = slices.DeleteFunc(list.Items, func(pr syncagentv1alpha1.PublishedResource) bool {
+		return !f.Matches(&pr)
+	})
+
+	return nil
+}
+
+// ShardOf returns the zero-based index of the shard responsible for the given
+// PublishedResource. An explicit and valid shard label takes precedence, otherwise
+// the name is hashed, so that the assignment is stable across restarts.
+func ShardOf(obj ctrlruntimeclient.Object, shards int) int {
+	if shards < 2 {
+		return 0
+	}
+
+	if value, ok := obj.GetLabels()[syncagentv1alpha1.ShardLabel]; ok {
+		if shard, err := strconv.Atoi(value); err == nil && shard >= 0 && shard < shards {
+			return shard
+		}
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(obj.GetName()))
+
+	return int(hash.Sum32() % uint32(shards))
+}
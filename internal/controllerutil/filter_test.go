@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllerutil
+
+import (
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func newPublishedResource(name string, prLabels map[string]string) *syncagentv1alpha1.PublishedResource {
+	return &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: prLabels,
+		},
+	}
+}
+
+func TestShardOf(t *testing.T) {
+	testcases := []struct {
+		name     string
+		labels   map[string]string
+		shards   int
+		expected int
+	}{
+		// The expected shards are pinned, so that a change to the hashing that would
+		// move PublishedResources between running agents does not go unnoticed.
+		{name: "things", shards: 2, expected: 0},
+		{name: "things", shards: 3, expected: 0},
+		{name: "things", shards: 5, expected: 1},
+		{name: "widgets", shards: 3, expected: 1},
+		{name: "widgets", shards: 5, expected: 3},
+		{name: "certificates", shards: 2, expected: 1},
+		{name: "certificates", shards: 3, expected: 2},
+		{name: "databases", shards: 5, expected: 2},
+
+		// sharding disabled
+		{name: "certificates", shards: 1, expected: 0},
+		{name: "certificates", shards: 0, expected: 0},
+		{name: "certificates", labels: map[string]string{syncagentv1alpha1.ShardLabel: "1"}, shards: 1, expected: 0},
+
+		// explicit shard labels take precedence
+		{name: "things", labels: map[string]string{syncagentv1alpha1.ShardLabel: "2"}, shards: 3, expected: 2},
+		{name: "things", labels: map[string]string{syncagentv1alpha1.ShardLabel: "0"}, shards: 3, expected: 0},
+
+		// invalid shard labels fall back to hashing
+		{name: "things", labels: map[string]string{syncagentv1alpha1.ShardLabel: "3"}, shards: 3, expected: 0},
+		{name: "things", labels: map[string]string{syncagentv1alpha1.ShardLabel: "-1"}, shards: 3, expected: 0},
+		{name: "things", labels: map[string]string{syncagentv1alpha1.ShardLabel: "one"}, shards: 3, expected: 0},
+	}
+
+	for _, testcase := range testcases {
+		t.Run("", func(t *testing.T) {
+			pr := newPublishedResource(testcase.name, testcase.labels)
+
+			// hashing must be stable across calls
+			for range 3 {
+				if shard := ShardOf(pr, testcase.shards); shard != testcase.expected {
+					t.Fatalf("Expected %q (labels %v) to be on shard %d of %d, but got %d.", testcase.name, testcase.labels, testcase.expected, testcase.shards, shard)
+				}
+			}
+		})
+	}
+}
+
+func TestPublishedResourceFilter(t *testing.T) {
+	testcases := []struct {
+		name    string
+		filter  *PublishedResourceFilter
+		pr      *syncagentv1alpha1.PublishedResource
+		matches bool
+		primary bool
+	}{
+		{
+			name:    "no selector and no sharding matches everything",
+			filter:  NewPublishedResourceFilter(nil, 0, 1),
+			pr:      newPublishedResource("certificates", nil),
+			matches: true,
+			primary: true,
+		},
+		{
+			name:    "selector is applied",
+			filter:  NewPublishedResourceFilter(labels.SelectorFromSet(labels.Set{"team": "a"}), 0, 1),
+			pr:      newPublishedResource("certificates", map[string]string{"team": "b"}),
+			primary: true,
+		},
+		{
+			name:    "PublishedResource of another shard is not matched",
+			filter:  NewPublishedResourceFilter(nil, 0, 2),
+			pr:      newPublishedResource("certificates", nil),
+			primary: true,
+		},
+		{
+			name:    "PublishedResource of this shard is matched",
+			filter:  NewPublishedResourceFilter(nil, 1, 2),
+			pr:      newPublishedResource("certificates", nil),
+			matches: true,
+		},
+		{
+			name:   "selector and shard must both match",
+			filter: NewPublishedResourceFilter(labels.SelectorFromSet(labels.Set{"team": "a"}), 1, 2),
+			pr:     newPublishedResource("certificates", map[string]string{"team": "b"}),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if matches := testcase.filter.Matches(testcase.pr); matches != testcase.matches {
+				t.Errorf("Expected matches=%v, but got %v.", testcase.matches, matches)
+			}
+
+			if primary := testcase.filter.PrimaryShard(); primary != testcase.primary {
+				t.Errorf("Expected primary=%v, but got %v.", testcase.primary, primary)
+			}
+		})
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/discovery"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PathPrefix is the path under which the relay serves the conversion
+	// endpoints, followed by the name of the PublishedResource.
+	PathPrefix = "/convert/"
+
+	// maxReviewSize limits the size of incoming ConversionReviews.
+	maxReviewSize = 32 << 20
+
+	// webhookTimeout is how long to wait for the service cluster's webhook.
+	webhookTimeout = 30 * time.Second
+)
+
+// Relay is an http.Handler that receives ConversionReviews from kcp and forwards
+// them to the conversion webhook of the CRD on the service cluster. Since the API
+// in kcp can be projected, the group, versions and kind of all objects are
+// translated back and forth.
+type Relay struct {
+	client          ctrlruntimeclient.Client
+	discoveryClient *discovery.Client
+	log             *zap.SugaredLogger
+
+	lock gosync.Mutex
+	// webhooks are cached by PublishedResource name
+	webhooks map[string]*webhookTarget
+}
+
+type webhookTarget struct {
+	generation int64
+	url        string
+	client     *http.Client
+}
+
+// NewRelay creates a new relay. The client is used to retrieve PublishedResources,
+// the discovery client to find their CRDs.
+func NewRelay(client ctrlruntimeclient.Client, discoveryClient *discovery.Client, log *zap.SugaredLogger) *Relay {
+	return &Relay{
+		client:          client,
+		discoveryClient: discoveryClient,
+		log:             log,
+		webhooks:        map[string]*webhookTarget{},
+	}
+}
+
+// WebhookURL returns the URL to put into the APIResourceSchema of the given
+// PublishedResource, given the base URL under which kcp can reach the relay.
+func WebhookURL(baseURL string, pubRes *syncagentv1alpha1.PublishedResource) string {
+	return strings.TrimSuffix(baseURL, "/") + PathPrefix + pubRes.Name
+}
+
+func (r *Relay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prName := strings.TrimPrefix(req.URL.Path, PathPrefix)
+	if prName == "" || strings.Contains(prName, "/") {
+		http.NotFound(w, req)
+		return
+	}
+
+	log := r.log.With("publishedresource", prName)
+
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxReviewSize)).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if review.Request == nil {
+		http.Error(w, "ConversionReview does not contain a request", http.StatusBadRequest)
+		return
+	}
+
+	response, err := r.relay(req.Context(), prName, review.Request)
+	if err != nil {
+		log.Errorw("Failed to relay conversion request", zap.Error(err))
+
+		response = &apiextensionsv1.ConversionResponse{
+			Result: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	response.UID = review.Request.UID
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&apiextensionsv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}); err != nil {
+		log.Errorw("Failed to write conversion response", zap.Error(err))
+	}
+}
+
+func (r *Relay) relay(ctx context.Context, prName string, request *apiextensionsv1.ConversionRequest) (*apiextensionsv1.ConversionResponse, error) {
+	pubRes := &syncagentv1alpha1.PublishedResource{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: prName}, pubRes); err != nil {
+		return nil, fmt.Errorf("failed to get PublishedResource: %w", err)
+	}
+
+	target, err := r.webhookTarget(ctx, pubRes)
+	if err != nil {
+		return nil, err
+	}
+
+	t := newTranslator(pubRes)
+
+	localRequest, err := t.toLocal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate request: %w", err)
+	}
+
+	localResponse, err := forward(ctx, target, localRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.toRemote(localResponse)
+}
+
+// webhookTarget returns the (cached) conversion webhook of the CRD behind the
+// given PublishedResource.
+func (r *Relay) webhookTarget(ctx context.Context, pubRes *syncagentv1alpha1.PublishedResource) (*webhookTarget, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if target, ok := r.webhooks[pubRes.Name]; ok && target.generation == pubRes.Generation {
+		return target, nil
+	}
+
+	crd, err := r.discoveryClient.RetrieveCRD(ctx, projection.PublishedResourceSourceGVK(pubRes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resource defined in PublishedResource: %w", err)
+	}
+
+	webhook, err := r.discoveryClient.ConversionWebhook(ctx, crd.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine conversion webhook: %w", err)
+	}
+
+	if webhook == nil {
+		return nil, fmt.Errorf("CRD %s does not use a conversion webhook", crd.Name)
+	}
+
+	target, err := newWebhookTarget(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	target.generation = pubRes.Generation
+	r.webhooks[pubRes.Name] = target
+
+	return target, nil
+}
+
+func newWebhookTarget(webhook *apiextensionsv1.WebhookConversion) (*webhookTarget, error) {
+	if !slices.Contains(webhook.ConversionReviewVersions, "v1") {
+		return nil, fmt.Errorf("conversion webhook does not support ConversionReview v1, only %v", webhook.ConversionReviewVersions)
+	}
+
+	cfg := webhook.ClientConfig
+	if cfg == nil {
+		return nil, errors.New("conversion webhook has no client config")
+	}
+
+	var url string
+	switch {
+	case cfg.URL != nil:
+		url = *cfg.URL
+	case cfg.Service != nil:
+		port := int32(443)
+		if cfg.Service.Port != nil {
+			port = *cfg.Service.Port
+		}
+
+		url = fmt.Sprintf("https://%s.%s.svc:%d", cfg.Service.Name, cfg.Service.Namespace, port)
+		if cfg.Service.Path != nil {
+			url += *cfg.Service.Path
+		}
+	default:
+		return nil, errors.New("conversion webhook has neither URL nor service configured")
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(cfg.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.CABundle) {
+			return nil, errors.New("conversion webhook has an invalid CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &webhookTarget{
+		url: url,
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// forward sends the request to the webhook and returns its response.
+func forward(ctx context.Context, target *webhookTarget, request *apiextensionsv1.ConversionRequest) (*apiextensionsv1.ConversionResponse, error) {
+	body, err := json.Marshal(&apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			Kind:       "ConversionReview",
+		},
+		Request: request,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ConversionReview: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := target.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call conversion webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("conversion webhook responded with status %d", resp.StatusCode)
+	}
+
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxReviewSize)).Decode(review); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook response: %w", err)
+	}
+
+	if review.Response == nil {
+		return nil, errors.New("conversion webhook did not return a response")
+	}
+
+	return review.Response, nil
+}
+
+// translator maps the group, versions and kind between kcp and the service cluster.
+type translator struct {
+	localGroupKind  schema.GroupKind
+	remoteGroupKind schema.GroupKind
+	// local version => remote version
+	versions map[string]string
+}
+
+func newTranslator(pubRes *syncagentv1alpha1.PublishedResource) *translator {
+	return &translator{
+		localGroupKind:  projection.PublishedResourceSourceGVK(pubRes).GroupKind(),
+		remoteGroupKind: projection.PublishedResourceProjectedGVK(pubRes).GroupKind(),
+		versions:        projection.PublishedResourceProjectedVersions(pubRes),
+	}
+}
+
+func (t *translator) toLocal(request *apiextensionsv1.ConversionRequest) (*apiextensionsv1.ConversionRequest, error) {
+	remoteVersions := map[string]string{}
+	for local, remote := range t.versions {
+		remoteVersions[remote] = local
+	}
+
+	desired, err := translateAPIVersion(request.DesiredAPIVersion, t.remoteGroupKind.Group, t.localGroupKind.Group, remoteVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := translateObjects(request.Objects, t.remoteGroupKind, t.localGroupKind, remoteVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiextensionsv1.ConversionRequest{
+		UID:               request.UID,
+		DesiredAPIVersion: desired,
+		Objects:           objects,
+	}, nil
+}
+
+func (t *translator) toRemote(response *apiextensionsv1.ConversionResponse) (*apiextensionsv1.ConversionResponse, error) {
+	result := response.DeepCopy()
+
+	if response.Result.Status != metav1.StatusSuccess {
+		return result, nil
+	}
+
+	objects, err := translateObjects(response.ConvertedObjects, t.localGroupKind, t.remoteGroupKind, t.versions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate response: %w", err)
+	}
+
+	result.ConvertedObjects = objects
+
+	return result, nil
+}
+
+func translateAPIVersion(apiVersion string, fromGroup, toGroup string, versions map[string]string) (string, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+
+	if gv.Group != fromGroup {
+		return "", fmt.Errorf("unexpected API group %q", gv.Group)
+	}
+
+	version, ok := versions[gv.Version]
+	if !ok {
+		return "", fmt.Errorf("unexpected version %q", gv.Version)
+	}
+
+	return schema.GroupVersion{Group: toGroup, Version: version}.String(), nil
+}
+
+func translateObjects(objects []runtime.RawExtension, from, to schema.GroupKind, versions map[string]string) ([]runtime.RawExtension, error) {
+	result := make([]runtime.RawExtension, 0, len(objects))
+
+	for _, object := range objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(object.Raw); err != nil {
+			return nil, fmt.Errorf("failed to decode object: %w", err)
+		}
+
+		if obj.GetKind() != from.Kind {
+			return nil, fmt.Errorf("unexpected kind %q", obj.GetKind())
+		}
+
+		apiVersion, err := translateAPIVersion(obj.GetAPIVersion(), from.Group, to.Group, versions)
+		if err != nil {
+			return nil, err
+		}
+
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(to.Kind)
+
+		encoded, err := obj.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode object: %w", err)
+		}
+
+		result = append(result, runtime.RawExtension{Raw: encoded})
+	}
+
+	return result, nil
+}
+
+// WebhookConfig describes how kcp can reach the relay.
+type WebhookConfig struct {
+	// BaseURL is the externally reachable HTTPS URL of the relay server.
+	BaseURL string
+	// CABundle is used by kcp to verify the relay's serving certificate.
+	CABundle []byte
+}
+
+// Conversion returns the conversion settings for the APIResourceSchema of the given
+// PublishedResource, pointing kcp to the relay.
+func (c *WebhookConfig) Conversion(pubRes *syncagentv1alpha1.PublishedResource) *apiextensionsv1.CustomResourceConversion {
+	url := WebhookURL(c.BaseURL, pubRes)
+
+	return &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				URL:      &url,
+				CABundle: c.CABundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func encodeObject(t *testing.T, obj map[string]any) runtime.RawExtension {
+	encoded, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Failed to encode object: %v", err)
+	}
+
+	return runtime.RawExtension{Raw: encoded}
+}
+
+func decodeObject(t *testing.T, raw runtime.RawExtension) map[string]any {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+		t.Fatalf("Failed to decode object: %v", err)
+	}
+
+	return obj.Object
+}
+
+func TestRelayConversion(t *testing.T) {
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "publish-things"},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: "local.example.corp",
+				Version:  "v2",
+				Kind:     "Thing",
+				Versions: []syncagentv1alpha1.SourceResourceVersion{{Name: "v1"}},
+			},
+			Projection: &syncagentv1alpha1.ResourceProjection{
+				Group: "remote.example.corp",
+				Kind:  "RemoteThing",
+			},
+		},
+	}
+
+	// a webhook that renames spec.name (v1) to spec.username (v2)
+	webhook := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		review := &apiextensionsv1.ConversionReview{}
+		if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+			t.Errorf("Failed to decode review: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if review.Request.DesiredAPIVersion != "local.example.corp/v2" {
+			t.Errorf("Expected request for local.example.corp/v2, but got %q.", review.Request.DesiredAPIVersion)
+		}
+
+		response := &apiextensionsv1.ConversionResponse{
+			UID:    review.Request.UID,
+			Result: metav1.Status{Status: metav1.StatusSuccess},
+		}
+
+		for _, raw := range review.Request.Objects {
+			obj := decodeObject(t, raw)
+			if obj["kind"] != "Thing" || obj["apiVersion"] != "local.example.corp/v1" {
+				t.Errorf("Received object with unexpected type %v %v.", obj["apiVersion"], obj["kind"])
+			}
+
+			spec := obj["spec"].(map[string]any)
+			spec["username"] = spec["name"]
+			delete(spec, "name")
+			obj["apiVersion"] = review.Request.DesiredAPIVersion
+
+			response.ConvertedObjects = append(response.ConvertedObjects, encodeObject(t, obj))
+		}
+
+		review.Request = nil
+		review.Response = response
+
+		if err := json.NewEncoder(w).Encode(review); err != nil {
+			t.Errorf("Failed to encode review: %v", err)
+		}
+	}))
+	defer webhook.Close()
+
+	url := webhook.URL
+	target, err := newWebhookTarget(&apiextensionsv1.WebhookConversion{
+		ClientConfig: &apiextensionsv1.WebhookClientConfig{
+			URL:      &url,
+			CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: webhook.Certificate().Raw}),
+		},
+		ConversionReviewVersions: []string{"v1"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create webhook target: %v", err)
+	}
+
+	request := &apiextensionsv1.ConversionRequest{
+		UID:               "abc",
+		DesiredAPIVersion: "remote.example.corp/v2",
+		Objects: []runtime.RawExtension{encodeObject(t, map[string]any{
+			"apiVersion": "remote.example.corp/v1",
+			"kind":       "RemoteThing",
+			"metadata":   map[string]any{"name": "my-thing"},
+			"spec":       map[string]any{"name": "Colonel Mustard"},
+		})},
+	}
+
+	translator := newTranslator(pubRes)
+
+	localRequest, err := translator.toLocal(request)
+	if err != nil {
+		t.Fatalf("Failed to translate request: %v", err)
+	}
+
+	localResponse, err := forward(context.Background(), target, localRequest)
+	if err != nil {
+		t.Fatalf("Failed to forward request: %v", err)
+	}
+
+	response, err := translator.toRemote(localResponse)
+	if err != nil {
+		t.Fatalf("Failed to translate response: %v", err)
+	}
+
+	if response.UID != "abc" {
+		t.Errorf("Expected UID to be kept, but got %q.", response.UID)
+	}
+
+	if len(response.ConvertedObjects) != 1 {
+		t.Fatalf("Expected 1 converted object, but got %d.", len(response.ConvertedObjects))
+	}
+
+	expected := map[string]any{
+		"apiVersion": "remote.example.corp/v2",
+		"kind":       "RemoteThing",
+		"metadata":   map[string]any{"name": "my-thing"},
+		"spec":       map[string]any{"username": "Colonel Mustard"},
+	}
+
+	if changes := diff.ObjectDiff(expected, decodeObject(t, response.ConvertedObjects[0])); changes != "" {
+		t.Errorf("Did not get expected object:\n%s", changes)
+	}
+}
+
+func TestTranslateUnexpectedTypes(t *testing.T) {
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: "local.example.corp",
+				Version:  "v1",
+				Kind:     "Thing",
+			},
+		},
+	}
+
+	testcases := []struct {
+		name   string
+		object map[string]any
+	}{
+		{
+			name:   "unknown version",
+			object: map[string]any{"apiVersion": "local.example.corp/v9", "kind": "Thing"},
+		},
+		{
+			name:   "unknown group",
+			object: map[string]any{"apiVersion": "other.example.corp/v1", "kind": "Thing"},
+		},
+		{
+			name:   "unknown kind",
+			object: map[string]any{"apiVersion": "local.example.corp/v1", "kind": "Other"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := newTranslator(pubRes).toLocal(&apiextensionsv1.ConversionRequest{
+				DesiredAPIVersion: "local.example.corp/v1",
+				Objects:           []runtime.RawExtension{encodeObject(t, testcase.object)},
+			})
+			if err == nil {
+				t.Fatal("Expected an error, but got none.")
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Server serves a Relay via HTTPS. It runs on all replicas of the Sync Agent,
+// regardless of leader election.
+type Server struct {
+	address  string
+	certFile string
+	keyFile  string
+	relay    *Relay
+	log      *zap.SugaredLogger
+}
+
+var _ manager.LeaderElectionRunnable = &Server{}
+
+func NewServer(address, certFile, keyFile string, relay *Relay, log *zap.SugaredLogger) *Server {
+	return &Server{
+		address:  address,
+		certFile: certFile,
+		keyFile:  keyFile,
+		relay:    relay,
+		log:      log,
+	}
+}
+
+// Start serves the relay until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(PathPrefix, s.relay)
+
+	server := &http.Server{
+		Addr:              s.address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.log.Errorw("Failed to shut down conversion relay", zap.Error(err))
+		}
+	}()
+
+	s.log.Infow("Starting conversion relay…", "address", s.address)
+
+	if err := server.ListenAndServeTLS(s.certFile, s.keyFile); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve conversion relay: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
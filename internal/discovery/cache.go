@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/utils/clock"
+)
+
+// cachedDiscovery keeps discovery results (including the OpenAPI schema) in memory
+// until either the TTL has passed or the cache is explicitly invalidated, e.g.
+// because a CRD has changed.
+type cachedDiscovery struct {
+	discovery.CachedDiscoveryInterface
+
+	ttl   time.Duration
+	clock clock.PassiveClock
+
+	lock    sync.Mutex
+	expires time.Time
+	openapi *openapiv2.Document
+}
+
+var _ discovery.CachedDiscoveryInterface = &cachedDiscovery{}
+
+func newCachedDiscovery(delegate discovery.DiscoveryInterface, ttl time.Duration, clock clock.PassiveClock) *cachedDiscovery {
+	return &cachedDiscovery{
+		CachedDiscoveryInterface: memory.NewMemCacheClient(delegate),
+		ttl:                      ttl,
+		clock:                    clock,
+		expires:                  clock.Now().Add(ttl),
+	}
+}
+
+// Invalidate drops all cached results, so the next request will query the
+// discovery endpoints again.
+func (d *cachedDiscovery) Invalidate() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.invalidateLocked()
+}
+
+func (d *cachedDiscovery) invalidateLocked() {
+	d.CachedDiscoveryInterface.Invalidate()
+	d.openapi = nil
+	d.expires = d.clock.Now().Add(d.ttl)
+}
+
+// expire invalidates the cache if its TTL has passed.
+func (d *cachedDiscovery) expire() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.ttl > 0 && d.clock.Now().After(d.expires) {
+		d.invalidateLocked()
+	}
+}
+
+func (d *cachedDiscovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	d.expire()
+
+	return d.CachedDiscoveryInterface.ServerGroupsAndResources()
+}
+
+func (d *cachedDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	d.expire()
+
+	resList, err := d.CachedDiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
+
+	// the group version might have been registered after the cache was filled
+	if errors.Is(err, memory.ErrCacheNotFound) {
+		d.Invalidate()
+		resList, err = d.CachedDiscoveryInterface.ServerResourcesForGroupVersion(groupVersion)
+	}
+
+	return resList, err
+}
+
+func (d *cachedDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	d.expire()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.openapi == nil {
+		doc, err := d.CachedDiscoveryInterface.OpenAPISchema()
+		if err != nil {
+			return nil, err
+		}
+
+		d.openapi = doc
+	}
+
+	return d.openapi, nil
+}
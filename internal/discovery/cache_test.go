@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	openapiv2 "github.com/google/gnostic-models/openapiv2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// countingDiscovery counts the requests that reach the discovery endpoints.
+type countingDiscovery struct {
+	*fakediscovery.FakeDiscovery
+
+	groupRequests   int
+	openAPIRequests int
+}
+
+func (d *countingDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	d.groupRequests++
+	return d.FakeDiscovery.ServerGroups()
+}
+
+func (d *countingDiscovery) OpenAPISchema() (*openapiv2.Document, error) {
+	d.openAPIRequests++
+	return d.FakeDiscovery.OpenAPISchema()
+}
+
+func TestCachedDiscovery(t *testing.T) {
+	type step struct {
+		// advance steps the fake clock forward before the step is performed
+		advance    time.Duration
+		invalidate bool
+
+		expectedGroupRequests   int
+		expectedOpenAPIRequests int
+	}
+
+	testcases := []struct {
+		name  string
+		ttl   time.Duration
+		steps []step
+	}{
+		{
+			name: "results are cached",
+			ttl:  time.Minute,
+			steps: []step{
+				{expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+				{advance: 30 * time.Second, expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+			},
+		},
+		{
+			name: "results expire after the TTL",
+			ttl:  time.Minute,
+			steps: []step{
+				{expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+				{advance: 61 * time.Second, expectedGroupRequests: 2, expectedOpenAPIRequests: 2},
+				{advance: 30 * time.Second, expectedGroupRequests: 2, expectedOpenAPIRequests: 2},
+			},
+		},
+		{
+			name: "results are cached until invalidated without a TTL",
+			ttl:  0,
+			steps: []step{
+				{expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+				{advance: 24 * time.Hour, expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+				{invalidate: true, expectedGroupRequests: 2, expectedOpenAPIRequests: 2},
+			},
+		},
+		{
+			name: "invalidation restarts the TTL",
+			ttl:  time.Minute,
+			steps: []step{
+				{expectedGroupRequests: 1, expectedOpenAPIRequests: 1},
+				{advance: 50 * time.Second, invalidate: true, expectedGroupRequests: 2, expectedOpenAPIRequests: 2},
+				{advance: 50 * time.Second, expectedGroupRequests: 2, expectedOpenAPIRequests: 2},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			fakeClock := clocktesting.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+			delegate := &countingDiscovery{
+				FakeDiscovery: &fakediscovery.FakeDiscovery{
+					Fake: &clienttesting.Fake{
+						Resources: []*metav1.APIResourceList{{
+							GroupVersion: "example.com/v1",
+							APIResources: []metav1.APIResource{{Name: "things", Kind: "Thing"}},
+						}},
+					},
+				},
+			}
+
+			cache := newCachedDiscovery(delegate, testcase.ttl, fakeClock)
+
+			for i, s := range testcase.steps {
+				fakeClock.Step(s.advance)
+
+				if s.invalidate {
+					cache.Invalidate()
+				}
+
+				if _, _, err := cache.ServerGroupsAndResources(); err != nil {
+					t.Fatalf("Step %d: failed to discover resources: %v", i, err)
+				}
+
+				if _, err := cache.OpenAPISchema(); err != nil {
+					t.Fatalf("Step %d: failed to get OpenAPI schema: %v", i, err)
+				}
+
+				if delegate.groupRequests != s.expectedGroupRequests {
+					t.Errorf("Step %d: expected %d group discovery request(s), but got %d.", i, s.expectedGroupRequests, delegate.groupRequests)
+				}
+
+				if delegate.openAPIRequests != s.expectedOpenAPIRequests {
+					t.Errorf("Step %d: expected %d OpenAPI request(s), but got %d.", i, s.expectedOpenAPIRequests, delegate.openAPIRequests)
+				}
+			}
+		})
+	}
+}
+
+func TestCachedDiscoveryFindsNewGroupVersions(t *testing.T) {
+	fake := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{{
+				GroupVersion: "example.com/v1",
+				APIResources: []metav1.APIResource{{Name: "things", Kind: "Thing"}},
+			}},
+		},
+	}
+
+	cache := newCachedDiscovery(fake, 0, clocktesting.NewFakeClock(time.Now()))
+	if _, _, err := cache.ServerGroupsAndResources(); err != nil {
+		t.Fatalf("Failed to discover resources: %v", err)
+	}
+
+	// register a new version after the cache has been filled
+	fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+		GroupVersion: "example.com/v2",
+		APIResources: []metav1.APIResource{{Name: "things", Kind: "Thing"}},
+	})
+
+	resList, err := cache.ServerResourcesForGroupVersion("example.com/v2")
+	if err != nil {
+		t.Fatalf("Expected new group version to be found, but got: %v", err)
+	}
+
+	if resList.GroupVersion != "example.com/v2" {
+		t.Fatalf("Expected example.com/v2, but got %q.", resList.GroupVersion)
+	}
+}
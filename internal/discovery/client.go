@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/kcp-dev/kcp/pkg/crdpuller"
 
@@ -31,17 +33,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/endpoints/openapi"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 )
 
 type Client struct {
 	discoveryClient discovery.DiscoveryInterface
 	crdClient       apiextensionsv1client.ApiextensionsV1Interface
+
+	// cache is only set for cached clients
+	cache *cachedDiscovery
+
+	// subresources remembers the subresources found by the most recent discovery
+	// of each GVK, so that callers can look them up without another round-trip
+	subresourcesLock sync.Mutex
+	subresources     map[schema.GroupVersionKind][]string
 }
 
 func NewClient(config *rest.Config) (*Client, error) {
@@ -58,38 +68,64 @@ func NewClient(config *rest.Config) (*Client, error) {
 	return &Client{
 		discoveryClient: discoveryClient,
 		crdClient:       crdClient,
+		subresources:    map[schema.GroupVersionKind][]string{},
 	}, nil
 }
 
-func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (*apiextensionsv1.CustomResourceDefinition, error) {
+// NewCachedClient returns a client that keeps discovery results in memory for
+// the given TTL (0 means until invalidated). Callers should call Invalidate()
+// whenever the set of available APIs changes, for example when CRDs are created,
+// updated or deleted.
+func NewCachedClient(config *rest.Config, ttl time.Duration) (*Client, error) {
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	client.cache = newCachedDiscovery(client.discoveryClient, ttl, clock.RealClock{})
+	client.discoveryClient = client.cache
+
+	return client, nil
+}
+
+// Invalidate drops all cached discovery results. This is a no-op for uncached
+// clients.
+func (c *Client) Invalidate() {
+	if c.cache != nil {
+		c.cache.Invalidate()
+	}
+
+	c.subresourcesLock.Lock()
+	clear(c.subresources)
+	c.subresourcesLock.Unlock()
+}
+
+// RetrieveCRD returns a CRD for the given GVK, containing only the requested version
+// (which is marked as the storage version) and the given additional versions.
+func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind, additionalVersions ...string) (*apiextensionsv1.CustomResourceDefinition, error) {
 	// Most of this code follows the logic in kcp's crd-puller, but is slimmed down
-	// to extract a specific version, not necessarily the preferred version.
+	// to extract specific versions, not necessarily the preferred version.
+
+	versions := []string{gvk.Version}
+	for _, version := range additionalVersions {
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
 
 	////////////////////////////////////
 	// Resolve GVK into GVR, because we need the resource name to construct
 	// the full CRD name.
 
-	_, resourceLists, err := c.discoveryClient.ServerGroupsAndResources()
+	resourceLists, resource, err := c.discover(gvk)
 	if err != nil {
 		return nil, err
 	}
 
-	var resource *metav1.APIResource
-	allResourceNames := sets.New[string]()
-	for _, resList := range resourceLists {
-		for _, res := range resList.APIResources {
-			allResourceNames.Insert(res.Name)
-
-			// find the requested resource based on the Kind, but ensure that subresources
-			// are not misinterpreted as the main resource by checking for "/"
-			if resList.GroupVersion == gvk.GroupVersion().String() && res.Kind == gvk.Kind && !strings.Contains(res.Name, "/") {
-				resource = &res
-			}
-		}
-	}
-
-	if resource == nil {
-		return nil, fmt.Errorf("could not find %v in APIs", gvk)
+	// remember the subresources of all versions from this discovery result
+	for _, version := range versions {
+		versionGVK := gvk.GroupKind().WithVersion(version)
+		c.rememberSubresources(versionGVK, findSubresources(resourceLists, versionGVK, resource.Name))
 	}
 
 	////////////////////////////////////
@@ -104,26 +140,62 @@ func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (
 		crdName += "." + gvk.Group
 	}
 
+	// built-in APIs are never backed by CRDs, so there is no need to look for
+	// one (which would also require permissions to read CRDs)
+	if !IsBuiltInGroup(gvk.Group) {
+		crd, err := c.retrieveFromCRD(ctx, crdName, gvk, versions)
+		if err != nil {
+			return nil, err
+		}
+
+		if crd != nil {
+			return crd, nil
+		}
+	}
+
+	return c.retrieveFromOpenAPI(crdName, gvk, resource, resourceLists, versions)
+}
+
+// IsBuiltInGroup returns true if the given API group belongs to the APIs built into
+// Kubernetes, like the core group or "apps". These are never served by CRDs. API groups
+// of CRDs must always contain a dot.
+func IsBuiltInGroup(group string) bool {
+	return !strings.Contains(group, ".")
+}
+
+// retrieveFromCRD returns the stripped down CRD for the given GVK, or nil if the
+// resource is not backed by a CRD.
+func (c *Client) retrieveFromCRD(ctx context.Context, crdName string, gvk schema.GroupVersionKind, versions []string) (*apiextensionsv1.CustomResourceDefinition, error) {
 	crd, err := c.crdClient.CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		// any non-404 error is permanent
+		return nil, err
+	}
 
 	// Hooray, we found a CRD! There is so much goodness on a real CRD that instead
 	// of re-creating it later on based on the openapi schema, we take the original
 	// CRD and just strip it down to what we need.
-	if err == nil {
-		// remove all but the requested version
-		crd.Spec.Versions = slices.DeleteFunc(crd.Spec.Versions, func(ver apiextensionsv1.CustomResourceDefinitionVersion) bool {
-			return ver.Name != gvk.Version
-		})
 
-		if len(crd.Spec.Versions) == 0 {
-			return nil, fmt.Errorf("CRD %s does not contain version %s", crdName, gvk.Version)
+	// remove all but the requested versions, with the primary version first
+	crdVersions := []apiextensionsv1.CustomResourceDefinitionVersion{}
+	for _, version := range versions {
+		idx := slices.IndexFunc(crd.Spec.Versions, func(ver apiextensionsv1.CustomResourceDefinitionVersion) bool {
+			return ver.Name == version
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("CRD %s does not contain version %s", crdName, version)
 		}
 
-		crd.Spec.Versions[0].Served = true
-		crd.Spec.Versions[0].Storage = true
+		crdVersion := crd.Spec.Versions[idx]
+		crdVersion.Served = true
+		crdVersion.Storage = version == gvk.Version
 
 		if apihelpers.IsCRDConditionTrue(crd, apiextensionsv1.NonStructuralSchema) {
-			crd.Spec.Versions[0].Schema = &apiextensionsv1.CustomResourceValidation{
+			crdVersion.Schema = &apiextensionsv1.CustomResourceValidation{
 				OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
 					Type:                   "object",
 					XPreserveUnknownFields: ptr.To(true),
@@ -131,32 +203,34 @@ func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (
 			}
 		}
 
-		crd.APIVersion = apiextensionsv1.SchemeGroupVersion.Identifier()
-		crd.Kind = "CustomResourceDefinition"
+		crdVersions = append(crdVersions, crdVersion)
+	}
 
-		// cleanup object meta
-		oldMeta := crd.ObjectMeta
-		crd.ObjectMeta = metav1.ObjectMeta{
-			Name:        oldMeta.Name,
-			Annotations: filterAnnotations(oldMeta.Annotations),
-		}
+	crd.Spec.Versions = crdVersions
 
-		// There is only ever one version, so conversion rules do not make sense
-		// (and even if they did, the conversion webhook from the service cluster
-		// would not be available in kcp anyway).
-		crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
-			Strategy: apiextensionsv1.NoneConverter,
-		}
+	crd.APIVersion = apiextensionsv1.SchemeGroupVersion.Identifier()
+	crd.Kind = "CustomResourceDefinition"
 
-		return crd, nil
+	// cleanup object meta
+	oldMeta := crd.ObjectMeta
+	crd.ObjectMeta = metav1.ObjectMeta{
+		Name:        oldMeta.Name,
+		Annotations: filterAnnotations(oldMeta.Annotations),
 	}
 
-	// any non-404 error is permanent
-	if !apierrors.IsNotFound(err) {
-		return nil, err
+	// The conversion webhook from the service cluster would not be available in kcp
+	// anyway; when multiple versions are published, kcp will only change the
+	// apiVersion when converting between them.
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.NoneConverter,
 	}
 
-	// CRD not found, so fall back to using the OpenAPI schema
+	return crd, nil
+}
+
+// retrieveFromOpenAPI creates a CRD for the given GVK based on the OpenAPI schema,
+// which works for all APIs, including the ones built into Kubernetes.
+func (c *Client) retrieveFromOpenAPI(crdName string, gvk schema.GroupVersionKind, resource *metav1.APIResource, resourceLists []*metav1.APIResourceList, versions []string) (*apiextensionsv1.CustomResourceDefinition, error) {
 	openapiSchema, err := c.discoveryClient.OpenAPISchema()
 	if err != nil {
 		return nil, err
@@ -171,32 +245,19 @@ func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (
 		return nil, err
 	}
 
-	protoSchema := modelsByGKV[gvk]
-	if protoSchema == nil {
-		return nil, fmt.Errorf("no models for %v", gvk)
-	}
-
-	var schemaProps apiextensionsv1.JSONSchemaProps
-	errs := crdpuller.Convert(protoSchema, &schemaProps)
-	if len(errs) > 0 {
-		return nil, utilerrors.NewAggregate(errs)
-	}
+	crdVersions := []apiextensionsv1.CustomResourceDefinitionVersion{}
+	for _, version := range versions {
+		versionGVK := gvk.GroupKind().WithVersion(version)
 
-	hasSubResource := func(subResource string) bool {
-		return allResourceNames.Has(resource.Name + "/" + subResource)
-	}
+		subresources := findSubresources(resourceLists, versionGVK, resource.Name)
 
-	var statusSubResource *apiextensionsv1.CustomResourceSubresourceStatus
-	if hasSubResource("status") {
-		statusSubResource = &apiextensionsv1.CustomResourceSubresourceStatus{}
-	}
-
-	var scaleSubResource *apiextensionsv1.CustomResourceSubresourceScale
-	if hasSubResource("scale") {
-		scaleSubResource = &apiextensionsv1.CustomResourceSubresourceScale{
-			SpecReplicasPath:   ".spec.replicas",
-			StatusReplicasPath: ".status.replicas",
+		crdVersion, err := openAPIVersion(modelsByGKV, versionGVK, subresources)
+		if err != nil {
+			return nil, err
 		}
+
+		crdVersion.Storage = version == gvk.Version
+		crdVersions = append(crdVersions, *crdVersion)
 	}
 
 	scope := apiextensionsv1.ClusterScoped
@@ -213,22 +274,9 @@ func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (
 			Name: crdName,
 		},
 		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-			Group: gvk.Group,
-			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-				{
-					Name: gvk.Version,
-					Schema: &apiextensionsv1.CustomResourceValidation{
-						OpenAPIV3Schema: &schemaProps,
-					},
-					Subresources: &apiextensionsv1.CustomResourceSubresources{
-						Status: statusSubResource,
-						Scale:  scaleSubResource,
-					},
-					Served:  true,
-					Storage: true,
-				},
-			},
-			Scope: scope,
+			Group:    gvk.Group,
+			Versions: crdVersions,
+			Scope:    scope,
 			Names: apiextensionsv1.CustomResourceDefinitionNames{
 				Plural:     resource.Name,
 				Kind:       resource.Kind,
@@ -250,6 +298,150 @@ func (c *Client) RetrieveCRD(ctx context.Context, gvk schema.GroupVersionKind) (
 	return out, nil
 }
 
+// openAPIVersion creates a CRD version for the given GVK based on the OpenAPI schema
+// and the subresources found via discovery.
+func openAPIVersion(modelsByGKV openapi.ModelsByGKV, gvk schema.GroupVersionKind, subresources []string) (*apiextensionsv1.CustomResourceDefinitionVersion, error) {
+	protoSchema := modelsByGKV[gvk]
+	if protoSchema == nil {
+		return nil, fmt.Errorf("no models for %v", gvk)
+	}
+
+	var schemaProps apiextensionsv1.JSONSchemaProps
+	errs := crdpuller.Convert(protoSchema, &schemaProps)
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	var statusSubResource *apiextensionsv1.CustomResourceSubresourceStatus
+	if slices.Contains(subresources, "status") {
+		statusSubResource = &apiextensionsv1.CustomResourceSubresourceStatus{}
+	}
+
+	var scaleSubResource *apiextensionsv1.CustomResourceSubresourceScale
+	if slices.Contains(subresources, "scale") {
+		scaleSubResource = &apiextensionsv1.CustomResourceSubresourceScale{
+			SpecReplicasPath:   ".spec.replicas",
+			StatusReplicasPath: ".status.replicas",
+		}
+	}
+
+	return &apiextensionsv1.CustomResourceDefinitionVersion{
+		Name: gvk.Version,
+		Schema: &apiextensionsv1.CustomResourceValidation{
+			OpenAPIV3Schema: &schemaProps,
+		},
+		Subresources: &apiextensionsv1.CustomResourceSubresources{
+			Status: statusSubResource,
+			Scale:  scaleSubResource,
+		},
+		Served: true,
+	}, nil
+}
+
+// discover resolves the given GVK into its API resource and returns it together
+// with the discovery result it was found in.
+func (c *Client) discover(gvk schema.GroupVersionKind) ([]*metav1.APIResourceList, *metav1.APIResource, error) {
+	_, resourceLists, err := c.discoveryClient.ServerGroupsAndResources()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resource := findResource(resourceLists, gvk)
+
+	// cached discovery data might predate the resource, so try once more with fresh data
+	if resource == nil && c.cache != nil {
+		c.cache.Invalidate()
+
+		_, resourceLists, err = c.discoveryClient.ServerGroupsAndResources()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resource = findResource(resourceLists, gvk)
+	}
+
+	if resource == nil {
+		return nil, nil, fmt.Errorf("could not find %v in APIs", gvk)
+	}
+
+	return resourceLists, resource, nil
+}
+
+func findResource(resourceLists []*metav1.APIResourceList, gvk schema.GroupVersionKind) *metav1.APIResource {
+	var resource *metav1.APIResource
+	for _, resList := range resourceLists {
+		for _, res := range resList.APIResources {
+			// find the requested resource based on the Kind, but ensure that subresources
+			// are not misinterpreted as the main resource by checking for "/"
+			if resList.GroupVersion == gvk.GroupVersion().String() && res.Kind == gvk.Kind && !strings.Contains(res.Name, "/") {
+				resource = &res
+			}
+		}
+	}
+
+	return resource
+}
+
+// findSubresources returns the subresources (like "status" or "scale") of the given
+// resource, based on the "resource/subresource" entries in the discovery result.
+// This works for all kinds of APIs, regardless of whether they are backed by a CRD,
+// are built into Kubernetes or are served by an aggregated API server. Only the
+// subresources relevant for the Sync Agent are returned.
+func findSubresources(resourceLists []*metav1.APIResourceList, gvk schema.GroupVersionKind, resourceName string) []string {
+	subresources := []string{}
+	for _, resList := range resourceLists {
+		if resList.GroupVersion != gvk.GroupVersion().String() {
+			continue
+		}
+
+		for _, res := range resList.APIResources {
+			parent, subresource, found := strings.Cut(res.Name, "/")
+			if !found || parent != resourceName {
+				continue
+			}
+
+			if subresource == "status" || subresource == "scale" {
+				subresources = append(subresources, subresource)
+			}
+		}
+	}
+
+	slices.Sort(subresources)
+
+	return subresources
+}
+
+func (c *Client) rememberSubresources(gvk schema.GroupVersionKind, subresources []string) {
+	c.subresourcesLock.Lock()
+	defer c.subresourcesLock.Unlock()
+
+	c.subresources[gvk] = subresources
+}
+
+// RetrieveSubresources returns the subresources (like "status" or "scale") of the
+// resource identified by the given GVK. If the GVK has been discovered before, for
+// example by RetrieveCRD, that result is reused instead of querying the discovery
+// endpoints again.
+func (c *Client) RetrieveSubresources(gvk schema.GroupVersionKind) ([]string, error) {
+	c.subresourcesLock.Lock()
+	subresources, ok := c.subresources[gvk]
+	c.subresourcesLock.Unlock()
+
+	if ok {
+		return slices.Clone(subresources), nil
+	}
+
+	resourceLists, resource, err := c.discover(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	subresources = findSubresources(resourceLists, gvk, resource.Name)
+	c.rememberSubresources(gvk, subresources)
+
+	return slices.Clone(subresources), nil
+}
+
 func filterAnnotations(ann map[string]string) map[string]string {
 	allowlist := []string{
 		apiextensionsv1.KubeAPIApprovedAnnotation,
@@ -264,3 +456,22 @@ func filterAnnotations(ann map[string]string) map[string]string {
 
 	return out
 }
+
+// ConversionWebhook returns the conversion webhook of the CRD with the given name,
+// or nil if the CRD does not exist or does not use a conversion webhook.
+func (c *Client) ConversionWebhook(ctx context.Context, crdName string) (*apiextensionsv1.WebhookConversion, error) {
+	crd, err := c.crdClient.CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensionsv1.WebhookConverter {
+		return nil, nil
+	}
+
+	return crd.Spec.Conversion.Webhook, nil
+}
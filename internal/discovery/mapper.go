@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CachedResourceMapper wraps a RESTMapper and remembers the results of resource
+// lookups, so that repeated lookups do not hit the discovery endpoints. Failed
+// lookups are remembered as well, but only for a short time, after which the
+// underlying mapper is reset (if possible) and the lookup is retried.
+type CachedResourceMapper struct {
+	mapper      meta.RESTMapper
+	positiveTTL time.Duration
+	negativeTTL time.Duration
+
+	lock    sync.Mutex
+	entries map[schema.GroupVersionResource]resourceEntry
+}
+
+type resourceEntry struct {
+	resource schema.GroupVersionResource
+	err      error
+	expires  time.Time
+}
+
+// NewCachedResourceMapper returns a new cached mapper. Successful lookups are cached
+// for positiveTTL, failed lookups for negativeTTL.
+func NewCachedResourceMapper(mapper meta.RESTMapper, positiveTTL, negativeTTL time.Duration) *CachedResourceMapper {
+	return &CachedResourceMapper{
+		mapper:      mapper,
+		positiveTTL: positiveTTL,
+		negativeTTL: negativeTTL,
+		entries:     map[schema.GroupVersionResource]resourceEntry{},
+	}
+}
+
+// ResourceFor works like meta.RESTMapper.ResourceFor, but uses cached results if
+// they have not expired yet.
+func (m *CachedResourceMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+
+	entry, exists := m.entries[input]
+	if exists && now.Before(entry.expires) {
+		return entry.resource, entry.err
+	}
+
+	// a previously failed lookup might succeed now that new resources have been
+	// registered, so make sure the underlying mapper does not use stale discovery data
+	if exists && entry.err != nil {
+		if resettable, ok := m.mapper.(meta.ResettableRESTMapper); ok {
+			resettable.Reset()
+		}
+	}
+
+	resource, err := m.mapper.ResourceFor(input)
+
+	ttl := m.positiveTTL
+	if err != nil {
+		ttl = m.negativeTTL
+	}
+
+	m.entries[input] = resourceEntry{
+		resource: resource,
+		err:      err,
+		expires:  now.Add(ttl),
+	}
+
+	return resource, err
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events provides the event broadcaster used by all controllers of the Sync Agent.
+package events
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// burstSize is the number of events per object and reason that are emitted
+	// before rate limiting kicks in.
+	burstSize = 5
+	// refillQPS is the rate at which new events per object and reason are
+	// allowed after the burst has been used up (one event every 10 minutes).
+	refillQPS = 1. / 600.
+	// maxSimilarEvents is the number of similar events (same object and reason,
+	// but different messages) after which they are combined into a single event.
+	maxSimilarEvents = 3
+	// aggregationInterval is the time in seconds after which a recurring event is
+	// considered new again.
+	aggregationInterval = 3600
+)
+
+// NewBroadcaster returns an event broadcaster that is meant to be shared by all
+// controllers. Identical events are deduplicated into a single event with an
+// increasing count, similar events with varying messages are aggregated and
+// each combination of object and reason is rate limited individually, so that a
+// single recurring problem does not drown out all other events for an object.
+func NewBroadcaster() record.EventBroadcaster {
+	return record.NewBroadcaster(record.WithCorrelatorOptions(CorrelatorOptions()))
+}
+
+// CorrelatorOptions returns the options for deduplicating, aggregating and rate
+// limiting events. They are used by the broadcaster and for Events that are
+// recorded in kcp without a broadcaster.
+func CorrelatorOptions() record.CorrelatorOptions {
+	return record.CorrelatorOptions{
+		BurstSize:            burstSize,
+		QPS:                  refillQPS,
+		MaxEvents:            maxSimilarEvents,
+		MaxIntervalInSeconds: aggregationInterval,
+		SpamKeyFunc:          spamKeyByReason,
+	}
+}
+
+// spamKeyByReason works like the default spam key function in client-go, but also
+// takes the event's type and reason into account.
+func spamKeyByReason(event *corev1.Event) string {
+	return strings.Join([]string{
+		event.Source.Component,
+		event.Source.Host,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Namespace,
+		event.InvolvedObject.Name,
+		string(event.InvolvedObject.UID),
+		event.InvolvedObject.APIVersion,
+		event.Type,
+		event.Reason,
+	}, "")
+}
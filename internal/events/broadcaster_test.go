@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSpamKeyByReason(t *testing.T) {
+	newEvent := func(modify func(*corev1.Event)) *corev1.Event {
+		event := &corev1.Event{
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: "example.com/v1",
+				Kind:       "Thing",
+				Namespace:  "default",
+				Name:       "my-thing",
+				UID:        "1234",
+			},
+			Source:  corev1.EventSource{Component: "api-syncagent"},
+			Type:    corev1.EventTypeWarning,
+			Reason:  "SyncFailed",
+			Message: "first message",
+		}
+
+		if modify != nil {
+			modify(event)
+		}
+
+		return event
+	}
+
+	testcases := []struct {
+		name    string
+		modify  func(*corev1.Event)
+		sameKey bool
+	}{
+		{
+			name:    "different message",
+			modify:  func(e *corev1.Event) { e.Message = "second message" },
+			sameKey: true,
+		},
+		{
+			name:    "different reason",
+			modify:  func(e *corev1.Event) { e.Reason = "LimitExceeded" },
+			sameKey: false,
+		},
+		{
+			name:    "different type",
+			modify:  func(e *corev1.Event) { e.Type = corev1.EventTypeNormal },
+			sameKey: false,
+		},
+		{
+			name:    "different object",
+			modify:  func(e *corev1.Event) { e.InvolvedObject.Name = "other-thing" },
+			sameKey: false,
+		},
+		{
+			name:    "different object UID",
+			modify:  func(e *corev1.Event) { e.InvolvedObject.UID = "5678" },
+			sameKey: false,
+		},
+		{
+			name:    "different component",
+			modify:  func(e *corev1.Event) { e.Source.Component = "other-agent" },
+			sameKey: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			base := spamKeyByReason(newEvent(nil))
+			other := spamKeyByReason(newEvent(testcase.modify))
+
+			if sameKey := base == other; sameKey != testcase.sameKey {
+				t.Errorf("Expected same spam key to be %v, but got %q and %q.", testcase.sameKey, base, other)
+			}
+		})
+	}
+}
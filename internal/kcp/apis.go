@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// The Sync Agent reads and writes all apis.kcp.io objects exclusively as v1alpha1,
+// even if kcp serves (and stores) newer versions like v1alpha2. Instead of having
+// separate code paths per version, the agent relies on kcp converting between the
+// versions on every request:
+//
+//   - The APIExport's spec.latestResourceSchemas is converted into v1alpha2's
+//     spec.resources (and vice versa), so the agent never has to know the
+//     resource's group and name separately from the schema name.
+//   - Permission claims created by the agent have no verbs in v1alpha1 and are
+//     converted into claims for all verbs in v1alpha2.
+//   - Settings that cannot be expressed in v1alpha1, like verbs configured by an
+//     admin, are kept by kcp in annotations on the v1alpha1 representation.
+//
+// Because of the last point, the agent must only ever modify APIExports in place
+// and must never drop annotations or permission claims it does not own; otherwise
+// writing the v1alpha1 object back would discard these settings. When v1alpha1 is
+// not served anymore, CheckAPIsVersions makes the agent refuse to start.
+
+// ServedAPIsVersions returns the versions of the apis.kcp.io API group that are
+// served by kcp, in order of preference.
+func ServedAPIsVersions(client discovery.ServerGroupsInterface) ([]string, error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform discovery: %w", err)
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != kcpdevv1alpha1.SchemeGroupVersion.Group {
+			continue
+		}
+
+		versions := []string{}
+		for _, version := range group.Versions {
+			versions = append(versions, version.Version)
+		}
+
+		return versions, nil
+	}
+
+	return nil, nil
+}
+
+// CheckAPIsVersions ensures that the apis.kcp.io version used by the Sync Agent is
+// served. Newer kcp releases additionally serve newer versions and convert between
+// them, so the agent keeps working as long as its version is still served.
+func CheckAPIsVersions(served []string) error {
+	expected := kcpdevv1alpha1.SchemeGroupVersion
+
+	if len(served) == 0 {
+		return fmt.Errorf("kcp does not serve the %s API group", expected.Group)
+	}
+
+	if !slices.Contains(served, expected.Version) {
+		return fmt.Errorf("kcp does not serve %s anymore (served versions: %v), please upgrade the Sync Agent", expected, served)
+	}
+
+	return nil
+}
+
+// VirtualWorkspaceURL returns the URL of the virtual workspace for the given
+// APIExport, or an empty string if it is not yet known. Older kcp releases
+// publish the URL in the APIExport's status, newer releases only publish it in
+// the APIExportEndpointSlice of the same name, which is used as a fallback. The
+// reader should not be cached, as the Sync Agent might only be permitted to get
+// the slice.
+func VirtualWorkspaceURL(ctx context.Context, reader ctrlruntimeclient.Reader, apiExport *kcpdevv1alpha1.APIExport) (string, error) {
+	// the APIExport does not exist (anymore)
+	if apiExport.Name == "" {
+		return "", nil
+	}
+
+	//nolint:staticcheck
+	if urls := apiExport.Status.VirtualWorkspaces; len(urls) > 0 {
+		return urls[0].URL, nil
+	}
+
+	wsCtx := kontext.WithCluster(ctx, logicalcluster.From(apiExport))
+
+	slice := &kcpdevv1alpha1.APIExportEndpointSlice{}
+	if err := reader.Get(wsCtx, types.NamespacedName{Name: apiExport.Name}, slice); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to get APIExportEndpointSlice: %w", err)
+	}
+
+	for _, endpoint := range slice.Status.APIExportEndpoints {
+		if endpoint.URL != "" {
+			return endpoint.URL, nil
+		}
+	}
+
+	return "", nil
+}
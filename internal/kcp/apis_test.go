@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kcp
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestServedAPIsVersions(t *testing.T) {
+	client := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{GroupVersion: "apis.kcp.io/v1alpha2"},
+				{GroupVersion: "apis.kcp.io/v1alpha1"},
+				{GroupVersion: "tenancy.kcp.io/v1alpha1"},
+			},
+		},
+	}
+
+	versions, err := ServedAPIsVersions(client)
+	if err != nil {
+		t.Fatalf("Failed to determine versions: %v", err)
+	}
+
+	expected := []string{"v1alpha2", "v1alpha1"}
+	if !slices.Equal(versions, expected) {
+		t.Fatalf("Expected %v, but got %v.", expected, versions)
+	}
+}
+
+func TestCheckAPIsVersions(t *testing.T) {
+	testcases := []struct {
+		served []string
+		valid  bool
+	}{
+		{served: nil},
+		{served: []string{"v1alpha1"}, valid: true},
+		{served: []string{"v1alpha2", "v1alpha1"}, valid: true},
+		{served: []string{"v1alpha2"}},
+	}
+
+	for _, testcase := range testcases {
+		t.Run("", func(t *testing.T) {
+			err := CheckAPIsVersions(testcase.served)
+			if testcase.valid && err != nil {
+				t.Fatalf("Expected %v to be supported, but got: %v", testcase.served, err)
+			}
+
+			if !testcase.valid && err == nil {
+				t.Fatalf("Expected %v to not be supported.", testcase.served)
+			}
+		})
+	}
+}
+
+func TestVirtualWorkspaceURL(t *testing.T) {
+	apiExport := func(urls ...string) *kcpdevv1alpha1.APIExport {
+		export := &kcpdevv1alpha1.APIExport{}
+		export.Name = "example.com"
+
+		for _, url := range urls {
+			//nolint:staticcheck
+			export.Status.VirtualWorkspaces = append(export.Status.VirtualWorkspaces, kcpdevv1alpha1.VirtualWorkspace{URL: url})
+		}
+
+		return export
+	}
+
+	endpointSlice := &kcpdevv1alpha1.APIExportEndpointSlice{}
+	endpointSlice.Name = "example.com"
+	endpointSlice.Status.APIExportEndpoints = []kcpdevv1alpha1.APIExportEndpoint{{URL: "https://slice.example.com"}}
+
+	testcases := []struct {
+		name      string
+		apiExport *kcpdevv1alpha1.APIExport
+		objects   []ctrlruntimeclient.Object
+		expected  string
+	}{
+		{
+			name:      "URL in APIExport status",
+			apiExport: apiExport("https://status.example.com"),
+			objects:   []ctrlruntimeclient.Object{endpointSlice},
+			expected:  "https://status.example.com",
+		},
+		{
+			name:      "URL in APIExportEndpointSlice",
+			apiExport: apiExport(),
+			objects:   []ctrlruntimeclient.Object{endpointSlice},
+			expected:  "https://slice.example.com",
+		},
+		{
+			name:      "not ready yet",
+			apiExport: apiExport(),
+		},
+		{
+			name:      "APIExport does not exist",
+			apiExport: &kcpdevv1alpha1.APIExport{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := kcpdevv1alpha1.AddToScheme(scheme); err != nil {
+				t.Fatalf("Failed to register scheme: %v", err)
+			}
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).WithObjects(testcase.objects...).Build()
+
+			url, err := VirtualWorkspaceURL(context.Background(), client, testcase.apiExport)
+			if err != nil {
+				t.Fatalf("Failed to determine URL: %v", err)
+			}
+
+			if url != testcase.expected {
+				t.Fatalf("Expected %q, but got %q.", testcase.expected, url)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8s.io/client-go/rest"
+)
+
+// InMemoryPersister keeps the tokens refreshed by auth provider plugins (like
+// OIDC) in memory instead of writing them back into the kubeconfig file, which
+// is usually mounted read-only.
+type InMemoryPersister struct{}
+
+var _ rest.AuthProviderConfigPersister = InMemoryPersister{}
+
+// Persist implements rest.AuthProviderConfigPersister. The OIDC plugin only
+// uses refreshed tokens if they could be persisted, so this never fails.
+func (InMemoryPersister) Persist(map[string]string) error {
+	return nil
+}
+
+// AuthTracker observes the responses of a server and remembers whether the
+// server has rejected the client's credentials. This allows to distinguish
+// expired or revoked credentials from other errors, as client-go and the
+// controllers only see a generic "Unauthorized" error.
+type AuthTracker struct {
+	log *zap.SugaredLogger
+
+	lock    sync.RWMutex
+	failure string
+	since   time.Time
+}
+
+func NewAuthTracker(log *zap.SugaredLogger) *AuthTracker {
+	return &AuthTracker{
+		log: log,
+	}
+}
+
+// Wrap can be used with rest.Config.Wrap to track all requests made using
+// clients created from the config.
+func (t *AuthTracker) Wrap(rt http.RoundTripper) http.RoundTripper {
+	return &authTrackingRoundTripper{
+		tracker: t,
+		rt:      rt,
+	}
+}
+
+// Check returns an error while the server rejects the credentials. It can be
+// used as a readiness check.
+func (t *AuthTracker) Check(_ *http.Request) error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.failure == "" {
+		return nil
+	}
+
+	return fmt.Errorf("credentials have been rejected since %s: %s", t.since.Format(time.RFC3339), t.failure)
+}
+
+func (t *AuthTracker) observe(resp *http.Response) {
+	failure := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		failure = resp.Status
+	}
+
+	// fast path, nothing changed
+	t.lock.RLock()
+	unchanged := t.failure == failure
+	t.lock.RUnlock()
+
+	if unchanged {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.failure == failure {
+		return
+	}
+
+	if failure == "" {
+		t.log.Info("Credentials are accepted again")
+	} else {
+		t.log.Errorw("Credentials have been rejected, make sure they are valid and have not expired", "status", failure)
+		t.since = time.Now()
+	}
+
+	t.failure = failure
+}
+
+type authTrackingRoundTripper struct {
+	tracker *AuthTracker
+	rt      http.RoundTripper
+}
+
+func (rt *authTrackingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.rt.RoundTrip(req)
+	if err == nil {
+		rt.tracker.observe(resp)
+	}
+
+	return resp, err
+}
+
+func (rt *authTrackingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.rt
+}
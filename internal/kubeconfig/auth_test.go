@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestAuthTracker(t *testing.T) {
+	var status atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	tracker := NewAuthTracker(zap.NewNop().Sugar())
+	client := &http.Client{Transport: tracker.Wrap(http.DefaultTransport)}
+
+	testcases := []struct {
+		status      int
+		expectReady bool
+	}{
+		{status: http.StatusOK, expectReady: true},
+		{status: http.StatusUnauthorized, expectReady: false},
+		// still rejected
+		{status: http.StatusUnauthorized, expectReady: false},
+		// authenticated, but not authorized
+		{status: http.StatusForbidden, expectReady: true},
+		{status: http.StatusUnauthorized, expectReady: false},
+		// server errors do not affect the credentials
+		{status: http.StatusInternalServerError, expectReady: true},
+	}
+
+	for i, tt := range testcases {
+		status.Store(int32(tt.status))
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+		resp.Body.Close()
+
+		err = tracker.Check(nil)
+		if tt.expectReady && err != nil {
+			t.Fatalf("Expected to be ready after HTTP %d, but got: %v", tt.status, err)
+		}
+		if !tt.expectReady && err == nil {
+			t.Fatalf("Expected to not be ready after HTTP %d.", tt.status)
+		}
+	}
+}
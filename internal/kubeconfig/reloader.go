@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubeconfig allows to rotate the credentials of long-running clients
+// when their kubeconfig file changes, without having to recreate the clients.
+package kubeconfig
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+)
+
+// LoadFunc turns a kubeconfig file into a rest.Config.
+type LoadFunc func(filename string) (*rest.Config, error)
+
+// Reloader periodically re-reads a kubeconfig file. Once installed into a
+// rest.Config, all clients created from that config (and copies of it) send
+// their requests through the Reloader, which uses a transport built from the
+// most recent kubeconfig. This allows short-lived client certificates and tokens
+// to be rotated without restarting the Sync Agent.
+//
+// Changing the server address is not supported, as it is part of every client
+// created from the config; such changes are logged and ignored.
+type Reloader struct {
+	filename string
+	load     LoadFunc
+	interval time.Duration
+	log      *zap.SugaredLogger
+
+	lock      sync.RWMutex
+	host      string
+	checksum  [sha256.Size]byte
+	transport http.RoundTripper
+}
+
+// NewReloader returns a Reloader for the given kubeconfig file. The config must
+// have been loaded from the file and not yet been modified (e.g. wrapped).
+func NewReloader(log *zap.SugaredLogger, filename string, config *rest.Config, load LoadFunc, interval time.Duration) (*Reloader, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	return &Reloader{
+		filename:  filename,
+		load:      load,
+		interval:  interval,
+		log:       log.With("kubeconfig", filename),
+		host:      config.Host,
+		checksum:  sha256.Sum256(content),
+		transport: transport,
+	}, nil
+}
+
+// Install makes all clients created from the given config use the current
+// transport of the Reloader. It must be called before any other transport
+// wrappers are added to the config. Since the Reloader's transport takes care
+// of authentication, all credentials are removed from the config, as they
+// would otherwise take precedence over the rotated ones.
+func (r *Reloader) Install(config *rest.Config) {
+	config.BearerToken = ""
+	config.BearerTokenFile = ""
+	config.Username = ""
+	config.Password = ""
+	config.AuthProvider = nil
+	config.ExecProvider = nil
+	config.Impersonate = rest.ImpersonationConfig{}
+	config.CertFile = ""
+	config.CertData = nil
+	config.KeyFile = ""
+	config.KeyData = nil
+
+	config.Wrap(func(http.RoundTripper) http.RoundTripper {
+		return r
+	})
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Reloader) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.lock.RLock()
+	transport := r.transport
+	r.lock.RUnlock()
+
+	return transport.RoundTrip(req)
+}
+
+// Start implements manager.Runnable and checks the kubeconfig for changes in the
+// configured interval until the context is cancelled.
+func (r *Reloader) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			if _, err := r.Reload(); err != nil {
+				r.log.Errorw("Failed to reload kubeconfig, continuing to use the previous credentials", zap.Error(err))
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable; credentials must
+// be rotated regardless of whether this replica is the leader.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Reload re-reads the kubeconfig and swaps the transport if the file has changed.
+func (r *Reloader) Reload() (changed bool, err error) {
+	content, err := os.ReadFile(r.filename)
+	if err != nil {
+		return false, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+
+	checksum := sha256.Sum256(content)
+
+	r.lock.RLock()
+	unchanged := bytes.Equal(checksum[:], r.checksum[:])
+	r.lock.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	config, err := r.load(r.filename)
+	if err != nil {
+		return false, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	transport, err := rest.TransportFor(config)
+	if err != nil {
+		return false, fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// remember the checksum in any case, so the warning is not repeated
+	r.checksum = checksum
+
+	if config.Host != r.host {
+		r.log.Warnw("Server address in kubeconfig has changed, the Sync Agent must be restarted to use it", "current", r.host, "new", config.Host)
+		return false, nil
+	}
+
+	previous := r.transport
+	r.transport = transport
+
+	// make new requests use new connections (and thereby the new credentials);
+	// long-running requests like watches continue until they are closed
+	utilnet.CloseIdleConnectionsFor(previous)
+
+	r.log.Info("Reloaded kubeconfig")
+
+	return true, nil
+}
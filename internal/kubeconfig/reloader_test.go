@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func writeKubeconfig(t *testing.T, filename, server, token string) {
+	t.Helper()
+
+	content := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+users:
+- name: test
+  user:
+    token: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+`, server, token)
+
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+}
+
+func loadKubeconfig(filename string) (*rest.Config, error) {
+	return clientcmd.BuildConfigFromFlags("", filename)
+}
+
+func TestReloader(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, filename, server.URL, "first")
+
+	config, err := loadKubeconfig(filename)
+	if err != nil {
+		t.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+
+	reloader, err := NewReloader(zap.NewNop().Sugar(), filename, config, loadKubeconfig, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to create reloader: %v", err)
+	}
+
+	reloader.Install(config)
+
+	client, err := rest.HTTPClientFor(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	assertAuthorization := func(expected string) {
+		t.Helper()
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Failed to read response: %v", err)
+		}
+
+		if got := string(body); got != expected {
+			t.Fatalf("Expected Authorization header %q, got %q.", expected, got)
+		}
+	}
+
+	assertAuthorization("Bearer first")
+
+	// unchanged files are not reloaded
+	changed, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+
+	if changed {
+		t.Fatal("Expected unchanged kubeconfig to not be reloaded.")
+	}
+
+	// rotated credentials are used by the existing client
+	writeKubeconfig(t, filename, server.URL, "second")
+
+	changed, err = reloader.Reload()
+	if err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+
+	if !changed {
+		t.Fatal("Expected rotated kubeconfig to be reloaded.")
+	}
+
+	assertAuthorization("Bearer second")
+
+	// a different server cannot be used
+	writeKubeconfig(t, filename, "https://example.com", "third")
+
+	changed, err = reloader.Reload()
+	if err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+
+	if changed {
+		t.Fatal("Expected kubeconfig with a different server to be ignored.")
+	}
+
+	assertAuthorization("Bearer second")
+
+	// broken files keep the previous credentials
+	if err := os.WriteFile(filename, []byte("{{{"), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+
+	if _, err := reloader.Reload(); err == nil {
+		t.Fatal("Expected broken kubeconfig to return an error.")
+	}
+
+	assertAuthorization("Bearer second")
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "syncagent"
+
+	// ReasonPublishedResourceUpdated means a sync controller was restarted because
+	// its PublishedResource (or the profile it uses) has changed.
+	ReasonPublishedResourceUpdated = "pr-updated"
+	// ReasonPublishedResourceRemoved means a sync controller was stopped because its
+	// PublishedResource was deleted or does not match the agent's filter anymore.
+	ReasonPublishedResourceRemoved = "pr-removed"
+	// ReasonPublishedResourcePaused means a sync controller was stopped because its
+	// PublishedResource has been paused.
+	ReasonPublishedResourcePaused = "pr-paused"
+	// ReasonVirtualWorkspaceURLChanged means controllers were restarted because the
+	// APIExport's virtual workspace URL has changed.
+	ReasonVirtualWorkspaceURLChanged = "vw-url-changed"
+	// ReasonControllerFailed means a controller had stopped unexpectedly and was
+	// garbage collected.
+	ReasonControllerFailed = "controller-failed"
+	// ReasonShutdown means a controller was stopped because the Sync Agent is
+	// shutting down.
+	ReasonShutdown = "shutdown"
+)
+
+var (
+	// ControllerStops counts how often dynamically managed controllers were stopped
+	// by the syncmanager, partitioned by the controller type and the reason.
+	ControllerStops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "controller_stops_total",
+		Help:      "Number of times a dynamically managed controller has been stopped.",
+	}, []string{"controller", "reason"})
+
+	// ControllerStarts counts how often dynamically managed controllers were started.
+	ControllerStarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "controller_starts_total",
+		Help:      "Number of times a dynamically managed controller has been started.",
+	}, []string{"controller"})
+
+	// LastControllerStop records the time of the most recent controller stop for each reason.
+	LastControllerStop = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "controller_last_stop_timestamp_seconds",
+		Help:      "Unix timestamp of the most recent controller stop.",
+	}, []string{"controller", "reason"})
+
+	// VirtualWorkspaceRestarts counts how often the connection to the virtual
+	// workspace has been re-established.
+	VirtualWorkspaceRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "virtual_workspace_restarts_total",
+		Help:      "Number of times the virtual workspace cluster has been recreated.",
+	}, []string{"reason"})
+
+	// ObjectsTotal is the number of synced objects per PublishedResource and workspace
+	// for which readiness is evaluated.
+	ObjectsTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "objects",
+		Help:      "Number of synced objects with readiness evaluation.",
+	}, []string{"published_resource", "cluster"})
+
+	// ObjectsReady is the number of ready objects per PublishedResource and workspace.
+	ObjectsReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "objects_ready",
+		Help:      "Number of synced objects that are ready.",
+	}, []string{"published_resource", "cluster"})
+
+	// InitialSyncProgress is the percentage of pre-existing objects that have been
+	// processed during the initial sync of a workspace.
+	InitialSyncProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "initial_sync_progress_percent",
+		Help:      "Progress of the initial synchronization of a workspace in percent.",
+	}, []string{"published_resource", "cluster"})
+
+	// FilteredObjects counts how often objects were not synced because they did
+	// not match the PublishedResource's filter. Objects are only counted when they
+	// become excluded, not on every reconciliation.
+	FilteredObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "filtered_objects_total",
+		Help:      "Number of times an object became excluded from synchronization by a filter.",
+	}, []string{"published_resource"})
+
+	// SkippedObjects counts how often objects were not synced because their kcp
+	// workspace has been marked to be skipped.
+	SkippedObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "skipped_objects_total",
+		Help:      "Number of times an object was not synchronized because its workspace is skipped.",
+	}, []string{"published_resource"})
+
+	// LimitedObjects counts how often objects in kcp were not synced because the
+	// PublishedResource's object limits were reached.
+	LimitedObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "limited_objects_total",
+		Help:      "Number of times an object was not synchronized because an object limit was reached.",
+	}, []string{"published_resource", "limit"})
+
+	// ResyncedObjects counts how many objects were enqueued by full resyncs, i.e.
+	// after a PublishedResource changed or when the resync period has elapsed.
+	ResyncedObjects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "resynced_objects_total",
+		Help:      "Number of objects that were enqueued by full resyncs.",
+	}, []string{"published_resource"})
+
+	// OrphanedObjects is the number of local objects without an object in kcp that
+	// were found by the last orphan check.
+	OrphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "orphaned_objects",
+		Help:      "Number of local objects whose object in kcp does not exist anymore.",
+	}, []string{"published_resource"})
+
+	// DeletedOrphans counts how many orphaned local objects were deleted.
+	DeletedOrphans = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deleted_orphans_total",
+		Help:      "Number of orphaned local objects that were deleted.",
+	}, []string{"published_resource"})
+
+	// SyncLatency is the time between a change to an object in kcp and the change
+	// being fully synchronized to the service cluster.
+	SyncLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "sync_latency_seconds",
+		Help:      "Time between an object being changed in kcp and it being fully synchronized.",
+		Buckets:   []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"published_resource"})
+
+	// RunningControllers is the number of currently running sync controllers.
+	RunningControllers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sync_controllers_running",
+		Help:      "Number of currently running sync controllers.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ControllerStops,
+		ControllerStarts,
+		LastControllerStop,
+		VirtualWorkspaceRestarts,
+		RunningControllers,
+		ObjectsTotal,
+		ObjectsReady,
+		FilteredObjects,
+		SkippedObjects,
+		LimitedObjects,
+		InitialSyncProgress,
+		ResyncedObjects,
+		OrphanedObjects,
+		DeletedOrphans,
+		SyncLatency,
+	)
+}
+
+// RecordControllerStop increments the stop counter for the given controller type
+// and remembers the current time.
+func RecordControllerStop(controller, reason string) {
+	ControllerStops.WithLabelValues(controller, reason).Inc()
+	LastControllerStop.WithLabelValues(controller, reason).SetToCurrentTime()
+}
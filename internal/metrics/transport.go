@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DirectionKcp is used for requests sent to kcp.
+	DirectionKcp = "kcp"
+	// DirectionServiceCluster is used for requests sent to the service cluster.
+	DirectionServiceCluster = "service-cluster"
+
+	// minCompressionSize is the minimum request body size in bytes for which
+	// compression is used; smaller payloads are not worth the overhead.
+	minCompressionSize = 1024
+)
+
+var (
+	// RequestPayloadBytes measures the size of request bodies written to kcp and the
+	// service cluster, before compression.
+	RequestPayloadBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_payload_bytes",
+		Help:      "Size of request payloads sent to kcp or the service cluster.",
+		Buckets:   prometheus.ExponentialBuckets(256, 4, 8),
+	}, []string{"direction", "verb"})
+
+	// CompressedRequestPayloadBytes measures the size of compressed request bodies.
+	CompressedRequestPayloadBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_payload_compressed_bytes",
+		Help:      "Size of compressed request payloads sent to kcp or the service cluster.",
+		Buckets:   prometheus.ExponentialBuckets(256, 4, 8),
+	}, []string{"direction", "verb"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		RequestPayloadBytes,
+		CompressedRequestPayloadBytes,
+	)
+}
+
+// InstrumentTransport returns a function suitable for rest.Config.Wrap() that records
+// the payload size of all requests with a body. If compress is true, larger request
+// bodies are additionally gzip-compressed; this must only be enabled if the target
+// server supports compressed requests.
+func InstrumentTransport(direction string, compress bool) func(http.RoundTripper) http.RoundTripper {
+	return func(rt http.RoundTripper) http.RoundTripper {
+		return &instrumentedTransport{
+			delegate:  rt,
+			direction: direction,
+			compress:  compress,
+		}
+	}
+}
+
+type instrumentedTransport struct {
+	delegate  http.RoundTripper
+	direction string
+	compress  bool
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.delegate.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body.Close()
+
+	RequestPayloadBytes.WithLabelValues(t.direction, req.Method).Observe(float64(len(body)))
+
+	// the original request must not be modified
+	req = req.Clone(req.Context())
+
+	if t.compress && len(body) >= minCompressionSize && req.Header.Get("Content-Encoding") == "" {
+		compressed, err := gzipData(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+
+		CompressedRequestPayloadBytes.WithLabelValues(t.direction, req.Method).Observe(float64(len(compressed)))
+
+		body = compressed
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	req.ContentLength = int64(len(body))
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return t.delegate.RoundTrip(req)
+}
+
+func gzipData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
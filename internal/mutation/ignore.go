@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+// PreserveFields returns a copy of desired in which the values at the given paths
+// have been replaced with the values from current. Paths that do not exist in
+// current are removed from desired. This is used to keep ignored fields from ever
+// being overwritten when synchronizing objects. Paths use the same syntax as the
+// mutation rules, but can optionally start with a dot.
+func PreserveFields(desired, current any, paths []string) (any, error) {
+	if len(paths) == 0 {
+		return desired, nil
+	}
+
+	encodedDesired, err := json.Marshal(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to JSON encode value: %w", err)
+	}
+
+	encodedCurrent, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to JSON encode value: %w", err)
+	}
+
+	jsonData := string(encodedDesired)
+
+	for _, path := range paths {
+		path = strings.TrimPrefix(path, ".")
+
+		value := gjson.Get(string(encodedCurrent), path)
+		if value.Exists() {
+			jsonData, err = sjson.SetRaw(jsonData, path, value.Raw)
+		} else {
+			jsonData, err = sjson.Delete(jsonData, path)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to preserve %s: %w", path, err)
+		}
+	}
+
+	// decode numbers as int64 where possible, just like for any other unstructured object
+	var result any
+	if err := utiljson.Unmarshal([]byte(jsonData), &result); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutation
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreserveFields(t *testing.T) {
+	testcases := []struct {
+		name     string
+		desired  string
+		current  string
+		paths    []string
+		expected string
+	}{
+		{
+			name:     "no paths",
+			desired:  `{"spec":{"clusterIP":"10.0.0.1"}}`,
+			current:  `{"spec":{"clusterIP":"10.0.0.2"}}`,
+			expected: `{"spec":{"clusterIP":"10.0.0.1"}}`,
+		},
+		{
+			name:     "keep current value",
+			desired:  `{"spec":{"clusterIP":"10.0.0.1","port":80}}`,
+			current:  `{"spec":{"clusterIP":"10.0.0.2","port":443}}`,
+			paths:    []string{"spec.clusterIP"},
+			expected: `{"spec":{"clusterIP":"10.0.0.2","port":80}}`,
+		},
+		{
+			name:     "leading dot is allowed",
+			desired:  `{"spec":{"clusterIP":"10.0.0.1"}}`,
+			current:  `{"spec":{"clusterIP":"10.0.0.2"}}`,
+			paths:    []string{".spec.clusterIP"},
+			expected: `{"spec":{"clusterIP":"10.0.0.2"}}`,
+		},
+		{
+			name:     "remove field missing in current",
+			desired:  `{"spec":{"clusterIP":"10.0.0.1","port":80}}`,
+			current:  `{"spec":{"port":443}}`,
+			paths:    []string{"spec.clusterIP"},
+			expected: `{"spec":{"port":80}}`,
+		},
+		{
+			name:     "add field missing in desired",
+			desired:  `{"spec":{"port":80}}`,
+			current:  `{"spec":{"containers":[{"name":"app"},{"name":"sidecar"}]}}`,
+			paths:    []string{"spec.containers"},
+			expected: `{"spec":{"containers":[{"name":"app"},{"name":"sidecar"}],"port":80}}`,
+		},
+		{
+			name:     "array elements",
+			desired:  `{"spec":{"containers":[{"name":"app","image":"new"}]}}`,
+			current:  `{"spec":{"containers":[{"name":"app","image":"old"}]}}`,
+			paths:    []string{"spec.containers.0.image"},
+			expected: `{"spec":{"containers":[{"image":"old","name":"app"}]}}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var desired, current any
+			if err := json.Unmarshal([]byte(testcase.desired), &desired); err != nil {
+				t.Fatalf("Failed to decode desired data: %v", err)
+			}
+			if err := json.Unmarshal([]byte(testcase.current), &current); err != nil {
+				t.Fatalf("Failed to decode current data: %v", err)
+			}
+
+			result, err := PreserveFields(desired, current, testcase.paths)
+			if err != nil {
+				t.Fatalf("Function returned unexpected error: %v", err)
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				t.Fatalf("Failed to encode result: %v", err)
+			}
+
+			if string(encoded) != testcase.expected {
+				t.Errorf("Expected %s, but got %s.", testcase.expected, string(encoded))
+			}
+		})
+	}
+}
@@ -26,15 +26,28 @@ import (
 	"strings"
 
 	"github.com/Masterminds/sprig/v3"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func ApplyResourceMutations(value any, mutations []syncagentv1alpha1.ResourceMutation, ctx *TemplateMutationContext) (any, error) {
+	// conditions are always evaluated against the original source object,
+	// not against the intermediate results of previous mutations
+	source, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to JSON encode value: %w", err)
+	}
+
 	for _, mut := range mutations {
-		var err error
+		if !conditionMatches(string(source), mut.Condition) {
+			continue
+		}
+
 		value, err = ApplyResourceMutation(value, mut, ctx)
 		if err != nil {
 			return nil, err
@@ -67,6 +80,19 @@ func ApplyResourceMutation(value any, mut syncagentv1alpha1.ResourceMutation, ct
 	return result, nil
 }
 
+func conditionMatches(jsonData string, cond *syncagentv1alpha1.ResourceMutationCondition) bool {
+	if cond == nil {
+		return true
+	}
+
+	value := gjson.Get(jsonData, cond.Path)
+	if !value.Exists() {
+		return false
+	}
+
+	return cond.Value == "" || value.String() == cond.Value
+}
+
 func applyResourceMutationToJSON(jsonData string, mut syncagentv1alpha1.ResourceMutation, ctx *TemplateMutationContext) (string, error) {
 	switch {
 	case mut.Delete != nil:
@@ -75,11 +101,43 @@ func applyResourceMutationToJSON(jsonData string, mut syncagentv1alpha1.Resource
 		return applyResourceTemplateMutation(jsonData, *mut.Template, ctx)
 	case mut.Regex != nil:
 		return applyResourceRegexMutation(jsonData, *mut.Regex)
+	case len(mut.Patch) > 0:
+		return applyResourcePatchMutation(jsonData, mut.Patch)
+	case mut.Merge != nil:
+		return applyResourceMergeMutation(jsonData, *mut.Merge)
 	default:
-		return "", errors.New("must use either regex, template or delete mutation")
+		return "", errors.New("must use either regex, template, delete, patch or merge mutation")
 	}
 }
 
+func applyResourcePatchMutation(jsonData string, ops []syncagentv1alpha1.ResourcePatchOperation) (string, error) {
+	encoded, err := json.Marshal(ops)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON patch: %w", err)
+	}
+
+	patch, err := jsonpatch.DecodePatch(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid JSON patch: %w", err)
+	}
+
+	patched, err := patch.Apply([]byte(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to apply JSON patch: %w", err)
+	}
+
+	return string(patched), nil
+}
+
+func applyResourceMergeMutation(jsonData string, mergePatch runtime.RawExtension) (string, error) {
+	patched, err := jsonpatch.MergePatch([]byte(jsonData), mergePatch.Raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply merge patch: %w", err)
+	}
+
+	return string(patched), nil
+}
+
 func applyResourceDeleteMutation(jsonData string, mut syncagentv1alpha1.ResourceDeleteMutation) (string, error) {
 	jsonData, err := sjson.Delete(jsonData, mut.Path)
 	if err != nil {
@@ -124,6 +182,23 @@ type TemplateMutationContext struct {
 
 	LocalObject  map[string]any
 	RemoteObject map[string]any
+
+	// SourceObject and DestinationObject are the same objects as LocalObject and
+	// RemoteObject, but named after the direction of the mutation: when mutating
+	// the spec, the source is the remote object, when mutating the status, it is
+	// the local object. The destination object is nil if it does not exist yet.
+	SourceObject      map[string]any
+	DestinationObject map[string]any
+
+	// ClusterName is the logical cluster name of the kcp workspace the remote
+	// object lives in.
+	ClusterName string
+	// ClusterPath is the workspace path (e.g. "root:org:team"), which is only
+	// available if workspace paths are enabled for the PublishedResource.
+	ClusterPath string
+
+	// AgentName is the name of the Sync Agent performing the synchronization.
+	AgentName string
 }
 
 func applyResourceTemplateMutation(jsonData string, mut syncagentv1alpha1.ResourceTemplateMutation, ctx *TemplateMutationContext) (string, error) {
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestApplyResourceMutation(t *testing.T) {
@@ -164,6 +166,37 @@ func TestApplyResourceMutation(t *testing.T) {
 			},
 			expected: `{"spec":{"secretName":"FOO"}}`,
 		},
+		{
+			name:      "template: use workspace information",
+			inputData: `{"spec":{"url":"foo"}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Template: &syncagentv1alpha1.ResourceTemplateMutation{
+					Path:     "spec.url",
+					Template: `https://{{ .ClusterName }}.example.com/{{ .ClusterPath | replace ":" "/" }}/{{ .Value.String }}`,
+				},
+			},
+			ctx: &TemplateMutationContext{
+				ClusterName: "a1b2c3",
+				ClusterPath: "root:org:team",
+			},
+			expected: `{"spec":{"url":"https://a1b2c3.example.com/root/org/team/foo"}}`,
+		},
+		{
+			name:      "template: use source and destination objects and agent name",
+			inputData: `{"spec":{"owner":"foo"}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Template: &syncagentv1alpha1.ResourceTemplateMutation{
+					Path:     "spec.owner",
+					Template: `{{ .SourceObject.metadata.name }}-{{ .DestinationObject.metadata.name }}@{{ .AgentName }}`,
+				},
+			},
+			ctx: &TemplateMutationContext{
+				SourceObject:      map[string]any{"metadata": map[string]any{"name": "remote"}},
+				DestinationObject: map[string]any{"metadata": map[string]any{"name": "local"}},
+				AgentName:         "my-agent",
+			},
+			expected: `{"spec":{"owner":"remote-local@my-agent"}}`,
+		},
 
 		// delete
 
@@ -187,6 +220,42 @@ func TestApplyResourceMutation(t *testing.T) {
 			},
 			expected: `{"spec":[1,3]}`,
 		},
+
+		// patch
+
+		{
+			name:      "patch: can add and replace values",
+			inputData: `{"spec":{"replicas":1}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Patch: []syncagentv1alpha1.ResourcePatchOperation{
+					{Op: "replace", Path: "/spec/replicas", Value: &runtime.RawExtension{Raw: []byte(`3`)}},
+					{Op: "add", Path: "/spec/labels", Value: &runtime.RawExtension{Raw: []byte(`{"app":"foo"}`)}},
+				},
+			},
+			expected: `{"spec":{"labels":{"app":"foo"},"replicas":3}}`,
+		},
+		{
+			name:      "patch: can move and remove values",
+			inputData: `{"spec":{"old":"foo","unwanted":true}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Patch: []syncagentv1alpha1.ResourcePatchOperation{
+					{Op: "move", From: "/spec/old", Path: "/spec/new"},
+					{Op: "remove", Path: "/spec/unwanted"},
+				},
+			},
+			expected: `{"spec":{"new":"foo"}}`,
+		},
+
+		// merge
+
+		{
+			name:      "merge: can inject and remove sub-structures",
+			inputData: `{"spec":{"replicas":1,"unwanted":true}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Merge: &runtime.RawExtension{Raw: []byte(`{"spec":{"template":{"foo":"bar"},"unwanted":null}}`)},
+			},
+			expected: `{"spec":{"replicas":1,"template":{"foo":"bar"}}}`,
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -214,3 +283,127 @@ func TestApplyResourceMutation(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyResourceMutationsWithConditions(t *testing.T) {
+	premiumOnly := &syncagentv1alpha1.ResourceMutationCondition{Path: "spec.tier", Value: "premium"}
+
+	testcases := []struct {
+		name      string
+		inputData string
+		mutations []syncagentv1alpha1.ResourceMutation
+		expected  string
+	}{
+		{
+			name:      "matching condition applies mutation",
+			inputData: `{"spec":{"tier":"premium","replicas":1}}`,
+			mutations: []syncagentv1alpha1.ResourceMutation{{
+				Regex:     &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.replicas", Replacement: "3"},
+				Condition: premiumOnly,
+			}},
+			expected: `{"spec":{"replicas":"3","tier":"premium"}}`,
+		},
+		{
+			name:      "non-matching condition skips mutation",
+			inputData: `{"spec":{"tier":"basic","replicas":1}}`,
+			mutations: []syncagentv1alpha1.ResourceMutation{{
+				Regex:     &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.replicas", Replacement: "3"},
+				Condition: premiumOnly,
+			}},
+			expected: `{"spec":{"replicas":1,"tier":"basic"}}`,
+		},
+		{
+			name:      "condition without value only requires the field to exist",
+			inputData: `{"spec":{"replicas":1}}`,
+			mutations: []syncagentv1alpha1.ResourceMutation{{
+				Delete:    &syncagentv1alpha1.ResourceDeleteMutation{Path: "spec.replicas"},
+				Condition: &syncagentv1alpha1.ResourceMutationCondition{Path: "spec.tier"},
+			}},
+			expected: `{"spec":{"replicas":1}}`,
+		},
+		{
+			name:      "conditions are evaluated against the original object",
+			inputData: `{"spec":{"tier":"premium","replicas":1}}`,
+			mutations: []syncagentv1alpha1.ResourceMutation{
+				{
+					Delete: &syncagentv1alpha1.ResourceDeleteMutation{Path: "spec.tier"},
+				},
+				{
+					Regex:     &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.replicas", Replacement: "3"},
+					Condition: premiumOnly,
+				},
+			},
+			expected: `{"spec":{"replicas":"3"}}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var inputData any
+			if err := json.Unmarshal([]byte(testcase.inputData), &inputData); err != nil {
+				t.Fatalf("Failed to JSON encode input data: %v", err)
+			}
+
+			mutated, err := ApplyResourceMutations(inputData, testcase.mutations, nil)
+			if err != nil {
+				t.Fatalf("Function returned unexpected error: %v", err)
+			}
+
+			result, err := json.Marshal(mutated)
+			if err != nil {
+				t.Fatalf("Failed to JSON encode output: %v", err)
+			}
+
+			output := string(result)
+			if testcase.expected != output {
+				t.Errorf("Expected %q, but got %q.", testcase.expected, output)
+			}
+		})
+	}
+}
+
+func TestApplyResourceMutationFailures(t *testing.T) {
+	testcases := []struct {
+		name      string
+		inputData string
+		mutation  syncagentv1alpha1.ResourceMutation
+	}{
+		{
+			name:      "patch: failed test operation",
+			inputData: `{"spec":{"replicas":1}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Patch: []syncagentv1alpha1.ResourcePatchOperation{
+					{Op: "test", Path: "/spec/replicas", Value: &runtime.RawExtension{Raw: []byte(`2`)}},
+				},
+			},
+		},
+		{
+			name:      "patch: removing a non-existing path",
+			inputData: `{"spec":{"replicas":1}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Patch: []syncagentv1alpha1.ResourcePatchOperation{
+					{Op: "remove", Path: "/spec/missing"},
+				},
+			},
+		},
+		{
+			name:      "merge: invalid JSON",
+			inputData: `{"spec":{"replicas":1}}`,
+			mutation: syncagentv1alpha1.ResourceMutation{
+				Merge: &runtime.RawExtension{Raw: []byte(`{`)},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var inputData any
+			if err := json.Unmarshal([]byte(testcase.inputData), &inputData); err != nil {
+				t.Fatalf("Failed to JSON encode input data: %v", err)
+			}
+
+			if _, err := ApplyResourceMutation(inputData, testcase.mutation, nil); err == nil {
+				t.Fatal("Expected mutation to fail, but it succeeded.")
+			}
+		})
+	}
+}
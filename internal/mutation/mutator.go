@@ -19,47 +19,65 @@ package mutation
 import (
 	"fmt"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// Workspace describes the kcp workspace that the remote object of a sync
+// operation lives in.
+type Workspace struct {
+	ClusterName logicalcluster.Name
+	// Path is only known if workspace paths are enabled for the PublishedResource.
+	Path logicalcluster.Path
+}
+
 type Mutator interface {
 	// MutateSpec transform a remote object into a local one. On the first
 	// mutation, otherObj will be nil. MutateSpec can modify all fields
 	// except the status (i.e. "mutate spec" here means to mutate expected state,
 	// which can be more than just the spec).
-	MutateSpec(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	MutateSpec(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured, workspace Workspace) (*unstructured.Unstructured, error)
 	// MutateStatus transform a local object into a remote one. MutateStatus
 	// must only modify the status field.
-	MutateStatus(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	MutateStatus(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured, workspace Workspace) (*unstructured.Unstructured, error)
 }
 
 type mutator struct {
-	spec *syncagentv1alpha1.ResourceMutationSpec
+	spec      *syncagentv1alpha1.ResourceMutationSpec
+	agentName string
 }
 
 var _ Mutator = &mutator{}
 
 // NewMutator creates a new mutator, which will apply the mutation rules to a synced object, in
 // both directions. A nil spec is supported and will simply make the mutator not do anything.
-func NewMutator(spec *syncagentv1alpha1.ResourceMutationSpec) Mutator {
+// The agent name is made available to templates.
+func NewMutator(spec *syncagentv1alpha1.ResourceMutationSpec, agentName string) Mutator {
 	return &mutator{
-		spec: spec,
+		spec:      spec,
+		agentName: agentName,
 	}
 }
 
-func (m *mutator) MutateSpec(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (m *mutator) MutateSpec(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured, workspace Workspace) (*unstructured.Unstructured, error) {
 	if m.spec == nil || m.spec.Spec == nil {
 		return toMutate, nil
 	}
 
 	ctx := &TemplateMutationContext{
 		RemoteObject: toMutate.Object,
+		SourceObject: toMutate.Object,
+		ClusterName:  workspace.ClusterName.String(),
+		ClusterPath:  workspace.Path.String(),
+		AgentName:    m.agentName,
 	}
 
 	if otherObj != nil {
 		ctx.LocalObject = otherObj.Object
+		ctx.DestinationObject = otherObj.Object
 	}
 
 	mutatedObj, err := ApplyResourceMutations(toMutate.Object, m.spec.Spec, ctx)
@@ -77,17 +95,22 @@ func (m *mutator) MutateSpec(toMutate *unstructured.Unstructured, otherObj *unst
 	return toMutate, nil
 }
 
-func (m *mutator) MutateStatus(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (m *mutator) MutateStatus(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured, workspace Workspace) (*unstructured.Unstructured, error) {
 	if m.spec == nil || m.spec.Status == nil {
 		return toMutate, nil
 	}
 
 	ctx := &TemplateMutationContext{
-		LocalObject: toMutate.Object,
+		LocalObject:  toMutate.Object,
+		SourceObject: toMutate.Object,
+		ClusterName:  workspace.ClusterName.String(),
+		ClusterPath:  workspace.Path.String(),
+		AgentName:    m.agentName,
 	}
 
 	if otherObj != nil {
 		ctx.RemoteObject = otherObj.Object
+		ctx.DestinationObject = otherObj.Object
 	}
 
 	mutatedObj, err := ApplyResourceMutations(toMutate.Object, m.spec.Status, ctx)
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// refreshInterval is how often the ConfigMap is re-read at most.
+const refreshInterval = 30 * time.Second
+
+// ConfigMapPolicy is a set of CEL expressions, stored in a ConfigMap, that every
+// object must satisfy before the Sync Agent writes it on the service cluster.
+// Each key in the ConfigMap's data is the name of a policy and its value is a
+// CEL expression that has access to the object via the `object` variable and
+// must evaluate to true to allow the write.
+//
+// The ConfigMap is re-read periodically, so policies can be changed without
+// restarting the agent. If the ConfigMap cannot be loaded or a policy cannot be
+// compiled, all writes are rejected.
+type ConfigMapPolicy struct {
+	reader ctrlruntimeclient.Reader
+	key    types.NamespacedName
+	env    *cel.Env
+	now    func() time.Time
+
+	lock            sync.Mutex
+	loaded          time.Time
+	resourceVersion string
+	policies        []compiledPolicy
+	loadErr         error
+}
+
+type compiledPolicy struct {
+	name    string
+	program cel.Program
+}
+
+// NewConfigMapPolicy returns a new policy that is backed by the given ConfigMap.
+// The reader should not be cached, to not require a cluster-wide informer for
+// ConfigMaps.
+func NewConfigMapPolicy(reader ctrlruntimeclient.Reader, namespace, name string) (*ConfigMapPolicy, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	return &ConfigMapPolicy{
+		reader: reader,
+		key:    types.NamespacedName{Namespace: namespace, Name: name},
+		env:    env,
+		now:    time.Now,
+	}, nil
+}
+
+// BeforeWrite evaluates all policies and returns an error if any of them is
+// not satisfied by the object.
+func (p *ConfigMapPolicy) BeforeWrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	policies, err := p.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		out, _, err := policy.program.ContextEval(ctx, map[string]any{"object": obj.Object})
+		if err != nil {
+			return fmt.Errorf("failed to evaluate policy %q: %w", policy.name, err)
+		}
+
+		allowed, ok := out.Value().(bool)
+		if !ok {
+			return fmt.Errorf("policy %q did not evaluate to a boolean", policy.name)
+		}
+
+		if !allowed {
+			return fmt.Errorf("object %s rejected by policy %q", describe(obj), policy.name)
+		}
+	}
+
+	return nil
+}
+
+func (p *ConfigMapPolicy) load(ctx context.Context) ([]compiledPolicy, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.loaded.IsZero() && p.now().Sub(p.loaded) < refreshInterval {
+		return p.policies, p.loadErr
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := p.reader.Get(ctx, p.key, cm); err != nil {
+		// do not remember this error, so the next write will retry immediately
+		return nil, fmt.Errorf("failed to load write policies from ConfigMap %s: %w", p.key, err)
+	}
+
+	p.loaded = p.now()
+
+	if cm.ResourceVersion != "" && cm.ResourceVersion == p.resourceVersion {
+		return p.policies, p.loadErr
+	}
+
+	p.resourceVersion = cm.ResourceVersion
+	p.policies, p.loadErr = p.compile(cm.Data)
+
+	return p.policies, p.loadErr
+}
+
+func (p *ConfigMapPolicy) compile(data map[string]string) ([]compiledPolicy, error) {
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	policies := make([]compiledPolicy, 0, len(names))
+	for _, name := range names {
+		ast, issues := p.env.Compile(strings.TrimSpace(data[name]))
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("failed to compile policy %q: %w", name, issues.Err())
+		}
+
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("policy %q must evaluate to a boolean, but returns %v", name, ast.OutputType())
+		}
+
+		program, err := p.env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create program for policy %q: %w", name, err)
+		}
+
+		policies = append(policies, compiledPolicy{name: name, program: program})
+	}
+
+	return policies, nil
+}
+
+func describe(obj *unstructured.Unstructured) string {
+	name := obj.GetName()
+	if ns := obj.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+
+	return fmt.Sprintf("%s %s", obj.GetKind(), name)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newObject(labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Thing")
+	obj.SetName("my-thing")
+	obj.SetNamespace("default")
+	obj.SetLabels(labels)
+
+	return obj
+}
+
+func TestConfigMapPolicy(t *testing.T) {
+	testcases := []struct {
+		name      string
+		policies  map[string]string
+		object    *unstructured.Unstructured
+		expectErr bool
+	}{
+		{
+			name:     "no policies allow everything",
+			policies: map[string]string{},
+			object:   newObject(nil),
+		},
+		{
+			name: "satisfied policy",
+			policies: map[string]string{
+				"has-team": `has(object.metadata.labels) && "team" in object.metadata.labels`,
+			},
+			object: newObject(map[string]string{"team": "a"}),
+		},
+		{
+			name: "violated policy",
+			policies: map[string]string{
+				"has-team": `has(object.metadata.labels) && "team" in object.metadata.labels`,
+			},
+			object:    newObject(nil),
+			expectErr: true,
+		},
+		{
+			name: "any violated policy rejects",
+			policies: map[string]string{
+				"always":  `true`,
+				"is-pod":  `object.kind == "Pod"`,
+				"is-name": `object.metadata.name == "my-thing"`,
+			},
+			object:    newObject(nil),
+			expectErr: true,
+		},
+		{
+			name: "invalid policy rejects everything",
+			policies: map[string]string{
+				"broken": `object.kind ==`,
+			},
+			object:    newObject(nil),
+			expectErr: true,
+		},
+		{
+			name: "non-boolean policy rejects everything",
+			policies: map[string]string{
+				"string": `"yes"`,
+			},
+			object:    newObject(nil),
+			expectErr: true,
+		},
+		{
+			name: "evaluation errors reject",
+			policies: map[string]string{
+				"missing-field": `object.spec.replicas > 1`,
+			},
+			object:    newObject(nil),
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "policies", Namespace: "kcp-system"},
+				Data:       testcase.policies,
+			}
+
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(cm).Build()
+
+			policy, err := NewConfigMapPolicy(client, cm.Namespace, cm.Name)
+			if err != nil {
+				t.Fatalf("Failed to create policy: %v", err)
+			}
+
+			err = policy.BeforeWrite(context.Background(), testcase.object)
+			if testcase.expectErr && err == nil {
+				t.Fatal("Expected an error, but got none.")
+			}
+			if !testcase.expectErr && err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigMapPolicyReload(t *testing.T) {
+	ctx := context.Background()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies", Namespace: "kcp-system"},
+		Data: map[string]string{
+			"never": `false`,
+		},
+	}
+
+	client := fakectrlruntimeclient.NewClientBuilder().Build()
+
+	policy, err := NewConfigMapPolicy(client, cm.Namespace, cm.Name)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy.now = func() time.Time { return now }
+
+	if err := policy.BeforeWrite(ctx, newObject(nil)); err == nil {
+		t.Fatal("Expected an error when the ConfigMap does not exist, but got none.")
+	}
+
+	if err := client.Create(ctx, cm); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+
+	if err := policy.BeforeWrite(ctx, newObject(nil)); err == nil {
+		t.Fatal("Expected the policy to reject the object, but it did not.")
+	}
+
+	cm.Data["never"] = `true`
+	if err := client.Update(ctx, cm); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+
+	if err := policy.BeforeWrite(ctx, newObject(nil)); err == nil {
+		t.Fatal("Expected the cached policy to still reject the object, but it did not.")
+	}
+
+	now = now.Add(refreshInterval)
+
+	if err := policy.BeforeWrite(ctx, newObject(nil)); err != nil {
+		t.Fatalf("Expected the updated policy to allow the object, but got: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Resolve fetches the PublishedResourceProfile referenced by the given PublishedResource
+// and returns a copy of the PublishedResource with the profile applied. If the
+// PublishedResource does not reference a profile, it is returned as-is and the
+// returned profile is nil.
+func Resolve(ctx context.Context, client ctrlruntimeclient.Reader, pubRes *syncagentv1alpha1.PublishedResource) (*syncagentv1alpha1.PublishedResource, *syncagentv1alpha1.PublishedResourceProfile, error) {
+	if pubRes.Spec.Profile == "" {
+		return pubRes, nil, nil
+	}
+
+	profile := &syncagentv1alpha1.PublishedResourceProfile{}
+	if err := client.Get(ctx, types.NamespacedName{Name: pubRes.Spec.Profile}, profile); err != nil {
+		return nil, nil, fmt.Errorf("failed to get profile %q: %w", pubRes.Spec.Profile, err)
+	}
+
+	return Apply(pubRes, profile), profile, nil
+}
+
+// Apply returns a copy of the PublishedResource, with all fields that are not
+// configured on the PublishedResource itself filled in from the profile.
+func Apply(pubRes *syncagentv1alpha1.PublishedResource, profile *syncagentv1alpha1.PublishedResourceProfile) *syncagentv1alpha1.PublishedResource {
+	result := pubRes.DeepCopy()
+	if profile == nil {
+		return result
+	}
+
+	spec := profile.Spec.DeepCopy()
+
+	if result.Spec.Filter == nil {
+		result.Spec.Filter = spec.Filter
+	}
+
+	if result.Spec.Naming == nil {
+		result.Spec.Naming = spec.Naming
+	}
+
+	if result.Spec.Mutation == nil {
+		result.Spec.Mutation = spec.Mutation
+	}
+
+	result.Spec.EnableWorkspacePaths = result.Spec.EnableWorkspacePaths || spec.EnableWorkspacePaths
+
+	// related resources are merged by their identifier, with the ones on the
+	// PublishedResource taking precedence
+	for _, related := range spec.Related {
+		exists := slices.ContainsFunc(result.Spec.Related, func(rr syncagentv1alpha1.RelatedResourceSpec) bool {
+			return rr.Identifier == related.Identifier
+		})
+
+		if !exists {
+			result.Spec.Related = append(result.Spec.Related, related)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+)
+
+func TestApply(t *testing.T) {
+	profileNaming := &syncagentv1alpha1.ResourceNaming{Name: "$remoteName"}
+	profileMutation := &syncagentv1alpha1.ResourceMutationSpec{
+		Spec: []syncagentv1alpha1.ResourceMutation{{
+			Delete: &syncagentv1alpha1.ResourceDeleteMutation{Path: "spec.secret"},
+		}},
+	}
+
+	profile := &syncagentv1alpha1.PublishedResourceProfile{
+		Spec: syncagentv1alpha1.PublishedResourceProfileSpec{
+			Naming:               profileNaming,
+			Mutation:             profileMutation,
+			EnableWorkspacePaths: true,
+			Related: []syncagentv1alpha1.RelatedResourceSpec{
+				{Identifier: "credentials", Origin: "service", Kind: "Secret"},
+				{Identifier: "config", Origin: "service", Kind: "ConfigMap"},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		spec     syncagentv1alpha1.PublishedResourceSpec
+		profile  *syncagentv1alpha1.PublishedResourceProfile
+		expected syncagentv1alpha1.PublishedResourceSpec
+	}{
+		{
+			name: "no profile",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Naming: &syncagentv1alpha1.ResourceNaming{Name: "foo"},
+			},
+			profile: nil,
+			expected: syncagentv1alpha1.PublishedResourceSpec{
+				Naming: &syncagentv1alpha1.ResourceNaming{Name: "foo"},
+			},
+		},
+		{
+			name:    "empty PublishedResource inherits everything",
+			spec:    syncagentv1alpha1.PublishedResourceSpec{},
+			profile: profile,
+			expected: syncagentv1alpha1.PublishedResourceSpec{
+				Naming:               profileNaming,
+				Mutation:             profileMutation,
+				EnableWorkspacePaths: true,
+				Related:              profile.Spec.Related,
+			},
+		},
+		{
+			name: "PublishedResource overrides profile",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Naming: &syncagentv1alpha1.ResourceNaming{Name: "foo"},
+				Related: []syncagentv1alpha1.RelatedResourceSpec{
+					{Identifier: "credentials", Origin: "kcp", Kind: "Secret"},
+				},
+			},
+			profile: profile,
+			expected: syncagentv1alpha1.PublishedResourceSpec{
+				Naming:               &syncagentv1alpha1.ResourceNaming{Name: "foo"},
+				Mutation:             profileMutation,
+				EnableWorkspacePaths: true,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{
+					{Identifier: "credentials", Origin: "kcp", Kind: "Secret"},
+					{Identifier: "config", Origin: "service", Kind: "ConfigMap"},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := &syncagentv1alpha1.PublishedResource{
+				Spec: testcase.spec,
+			}
+
+			result := Apply(pubRes, testcase.profile)
+
+			if !diff.SemanticallyEqual(testcase.expected, result.Spec) {
+				t.Errorf("Did not get expected spec:\n%s", diff.ObjectDiff(testcase.expected, result.Spec))
+			}
+
+			if !diff.SemanticallyEqual(testcase.spec, pubRes.Spec) {
+				t.Errorf("Original PublishedResource was modified:\n%s", diff.ObjectDiff(testcase.spec, pubRes.Spec))
+			}
+		})
+	}
+}
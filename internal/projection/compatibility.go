@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"fmt"
+	"slices"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+)
+
+// SchemaIncompatibilities compares a new APIResourceSchema with an existing one
+// for the same resource and returns all changes that would break consumers of
+// the existing schema: changes to the kind or scope, versions that are no longer
+// served and fields that have been removed or have changed their type. New
+// versions and fields are always compatible.
+func SchemaIncompatibilities(existing, updated *kcpdevv1alpha1.APIResourceSchemaSpec) ([]string, error) {
+	problems := []string{}
+
+	if existing.Names.Kind != updated.Names.Kind {
+		problems = append(problems, fmt.Sprintf("kind changes from %s to %s", existing.Names.Kind, updated.Names.Kind))
+	}
+
+	if existing.Scope != updated.Scope {
+		problems = append(problems, fmt.Sprintf("scope changes from %s to %s", existing.Scope, updated.Scope))
+	}
+
+	for i := range existing.Versions {
+		existingVersion := &existing.Versions[i]
+		if !existingVersion.Served {
+			continue
+		}
+
+		idx := slices.IndexFunc(updated.Versions, func(v kcpdevv1alpha1.APIResourceVersion) bool {
+			return v.Name == existingVersion.Name && v.Served
+		})
+		if idx < 0 {
+			problems = append(problems, fmt.Sprintf("version %s is no longer served", existingVersion.Name))
+			continue
+		}
+
+		existingSchema, err := existingVersion.GetSchema()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse existing schema for version %s: %w", existingVersion.Name, err)
+		}
+
+		updatedSchema, err := updated.Versions[idx].GetSchema()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse new schema for version %s: %w", existingVersion.Name, err)
+		}
+
+		for _, problem := range compareSchemas(existingSchema, updatedSchema, "") {
+			problems = append(problems, fmt.Sprintf("version %s: %s", existingVersion.Name, problem))
+		}
+	}
+
+	return problems, nil
+}
+
+// compareSchemas recursively finds fields of the existing schema that are missing
+// or of a different type in the updated schema.
+func compareSchemas(existing, updated *apiextensionsv1.JSONSchemaProps, path string) []string {
+	if existing == nil || updated == nil {
+		return nil
+	}
+
+	if existing.Type != "" && updated.Type != "" && existing.Type != updated.Type {
+		return []string{fmt.Sprintf("field %s changes its type from %s to %s", displayPath(path), existing.Type, updated.Type)}
+	}
+
+	// fields nested in schemaless objects remain valid
+	if ptr.Deref(updated.XPreserveUnknownFields, false) && len(updated.Properties) == 0 {
+		return nil
+	}
+
+	problems := []string{}
+
+	names := make([]string, 0, len(existing.Properties))
+	for name := range existing.Properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		fieldPath := joinPath(path, name)
+		existingProperty := existing.Properties[name]
+
+		updatedProperty, exists := updated.Properties[name]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("field %s has been removed", fieldPath))
+			continue
+		}
+
+		problems = append(problems, compareSchemas(&existingProperty, &updatedProperty, fieldPath)...)
+	}
+
+	if existing.Items != nil && updated.Items != nil {
+		problems = append(problems, compareSchemas(existing.Items.Schema, updated.Items.Schema, path+"[]")...)
+	}
+
+	if existing.AdditionalProperties != nil && updated.AdditionalProperties != nil {
+		problems = append(problems, compareSchemas(existing.AdditionalProperties.Schema, updated.AdditionalProperties.Schema, path+"[*]")...)
+	}
+
+	return problems
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+
+	return path
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"slices"
+	"testing"
+
+	kcpdevv1alpha1 "github.com/kcp-dev/kcp/sdk/apis/apis/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newTestSchema(t *testing.T, versions map[string]*apiextensionsv1.JSONSchemaProps) *kcpdevv1alpha1.APIResourceSchemaSpec {
+	spec := &kcpdevv1alpha1.APIResourceSchemaSpec{
+		Group: "example.com",
+		Names: apiextensionsv1.CustomResourceDefinitionNames{
+			Kind:   "Thing",
+			Plural: "things",
+		},
+		Scope: apiextensionsv1.NamespaceScoped,
+	}
+
+	names := []string{}
+	for name := range versions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		version := kcpdevv1alpha1.APIResourceVersion{
+			Name:   name,
+			Served: true,
+		}
+
+		if err := version.SetSchema(versions[name]); err != nil {
+			t.Fatalf("Failed to set schema: %v", err)
+		}
+
+		spec.Versions = append(spec.Versions, version)
+	}
+
+	return spec
+}
+
+func thingSchema(specProperties map[string]apiextensionsv1.JSONSchemaProps) *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type:       "object",
+				Properties: specProperties,
+			},
+		},
+	}
+}
+
+func TestSchemaIncompatibilities(t *testing.T) {
+	baseSpec := map[string]apiextensionsv1.JSONSchemaProps{
+		"size": {Type: "string"},
+		"backends": {
+			Type: "array",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+				Schema: &apiextensionsv1.JSONSchemaProps{
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"host": {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		updated  func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec
+		expected []string
+	}{
+		{
+			name: "identical schemas are compatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": thingSchema(baseSpec)})
+			},
+			expected: []string{},
+		},
+		{
+			name: "new fields and versions are compatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				spec := map[string]apiextensionsv1.JSONSchemaProps{
+					"color": {Type: "string"},
+				}
+				for k, v := range baseSpec {
+					spec[k] = v
+				}
+
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{
+					"v1": thingSchema(spec),
+					"v2": thingSchema(spec),
+				})
+			},
+			expected: []string{},
+		},
+		{
+			name: "removed fields are incompatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": thingSchema(map[string]apiextensionsv1.JSONSchemaProps{
+					"backends": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+						},
+					},
+				})})
+			},
+			expected: []string{
+				"version v1: field spec.backends[].host has been removed",
+				"version v1: field spec.size has been removed",
+			},
+		},
+		{
+			name: "fields in schemaless objects are compatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"spec": {
+							Type:                   "object",
+							XPreserveUnknownFields: ptr.To(true),
+						},
+					},
+				}})
+			},
+			expected: []string{},
+		},
+		{
+			name: "changed types are incompatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				spec := map[string]apiextensionsv1.JSONSchemaProps{}
+				for k, v := range baseSpec {
+					spec[k] = v
+				}
+				spec["size"] = apiextensionsv1.JSONSchemaProps{Type: "integer"}
+
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": thingSchema(spec)})
+			},
+			expected: []string{
+				"version v1: field spec.size changes its type from string to integer",
+			},
+		},
+		{
+			name: "versions that are no longer served are incompatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				return newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v2": thingSchema(baseSpec)})
+			},
+			expected: []string{
+				"version v1 is no longer served",
+			},
+		},
+		{
+			name: "changing the scope is incompatible",
+			updated: func(t *testing.T) *kcpdevv1alpha1.APIResourceSchemaSpec {
+				schema := newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": thingSchema(baseSpec)})
+				schema.Scope = apiextensionsv1.ClusterScoped
+
+				return schema
+			},
+			expected: []string{
+				"scope changes from Namespaced to Cluster",
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			existing := newTestSchema(t, map[string]*apiextensionsv1.JSONSchemaProps{"v1": thingSchema(baseSpec)})
+
+			problems, err := SchemaIncompatibilities(existing, testcase.updated(t))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !slices.Equal(problems, testcase.expected) {
+				t.Fatalf("Expected %v, got %v.", testcase.expected, problems)
+			}
+		})
+	}
+}
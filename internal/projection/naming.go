@@ -17,53 +17,17 @@ limitations under the License.
 package projection
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	"github.com/kcp-dev/api-syncagent/internal/crypto"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/naming"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
-var DefaultNamingScheme = syncagentv1alpha1.ResourceNaming{
-	Namespace: syncagentv1alpha1.PlaceholderRemoteClusterName,
-	Name:      fmt.Sprintf("%s-%s", syncagentv1alpha1.PlaceholderRemoteNamespaceHash, syncagentv1alpha1.PlaceholderRemoteNameHash),
-}
-
-func GenerateLocalObjectName(pr *syncagentv1alpha1.PublishedResource, object metav1.Object, clusterName logicalcluster.Name) types.NamespacedName {
-	naming := pr.Spec.Naming
-	if naming == nil {
-		naming = &syncagentv1alpha1.ResourceNaming{}
-	}
-
-	replacer := strings.NewReplacer(
-		// order of elements is important here, "$fooHash" needs to be defined before "$foo"
-		syncagentv1alpha1.PlaceholderRemoteClusterName, clusterName.String(),
-		syncagentv1alpha1.PlaceholderRemoteNamespaceHash, crypto.ShortHash(object.GetNamespace()),
-		syncagentv1alpha1.PlaceholderRemoteNamespace, object.GetNamespace(),
-		syncagentv1alpha1.PlaceholderRemoteNameHash, crypto.ShortHash(object.GetName()),
-		syncagentv1alpha1.PlaceholderRemoteName, object.GetName(),
-	)
-
-	result := types.NamespacedName{}
-
-	pattern := naming.Namespace
-	if pattern == "" {
-		pattern = DefaultNamingScheme.Namespace
-	}
-
-	result.Namespace = replacer.Replace(pattern)
-
-	pattern = naming.Name
-	if pattern == "" {
-		pattern = DefaultNamingScheme.Name
-	}
-
-	result.Name = replacer.Replace(pattern)
-
-	return result
+// GenerateLocalObjectName returns the name and namespace for the local copy of
+// the given remote object, according to the PublishedResource's naming rules.
+func GenerateLocalObjectName(pr *syncagentv1alpha1.PublishedResource, object metav1.Object, clusterName logicalcluster.Name, workspacePath logicalcluster.Path) (types.NamespacedName, error) {
+	return naming.LocalObjectName(pr.Spec.Naming, clusterName, workspacePath, object)
 }
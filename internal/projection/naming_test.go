@@ -36,13 +36,22 @@ func createNewObject(name, namespace string) metav1.Object {
 	return obj
 }
 
+func createLabelledObject(name, namespace string, labels map[string]string) metav1.Object {
+	obj := createNewObject(name, namespace)
+	obj.SetLabels(labels)
+
+	return obj
+}
+
 func TestGenerateLocalObjectName(t *testing.T) {
 	testcases := []struct {
-		name         string
-		clusterName  string
-		remoteObject metav1.Object
-		namingConfig *syncagentv1alpha1.ResourceNaming
-		expected     types.NamespacedName
+		name          string
+		clusterName   string
+		workspacePath string
+		remoteObject  metav1.Object
+		namingConfig  *syncagentv1alpha1.ResourceNaming
+		expected      types.NamespacedName
+		expectErr     bool
 	}{
 		{
 			name:         "follow default naming rules",
@@ -86,6 +95,84 @@ func TestGenerateLocalObjectName(t *testing.T) {
 			namingConfig: &syncagentv1alpha1.ResourceNaming{Name: "foobar-$remoteName"},
 			expected:     types.NamespacedName{Namespace: "testcluster", Name: "foobar-objname"},
 		},
+		{
+			name:          "workspace path pattern",
+			clusterName:   "testcluster",
+			workspacePath: "root:customers:acme",
+			remoteObject:  createNewObject("objname", "objnamespace"),
+			namingConfig:  &syncagentv1alpha1.ResourceNaming{Namespace: "$remoteWorkspacePath", Name: "$remoteName"},
+			expected:      types.NamespacedName{Namespace: "root-customers-acme", Name: "objname"},
+		},
+		{
+			name:          "hashed workspace path pattern",
+			clusterName:   "testcluster",
+			workspacePath: "root:customers:acme",
+			remoteObject:  createNewObject("objname", "objnamespace"),
+			namingConfig:  &syncagentv1alpha1.ResourceNaming{Namespace: "ws-$remoteWorkspacePathHash", Name: "$remoteName"},
+			expected:      types.NamespacedName{Namespace: "ws-254e3b8a96d4746b2d3f", Name: "objname"},
+		},
+		{
+			name:         "longer SHA-256 hashes",
+			clusterName:  "testcluster",
+			remoteObject: createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{
+				Name: "$remoteNameHash",
+				Hash: &syncagentv1alpha1.NamingHash{Algorithm: syncagentv1alpha1.NamingHashSHA256, Length: 32},
+			},
+			expected: types.NamespacedName{Namespace: "testcluster", Name: "bbcf5b643a0f51a57beecfd7e9bb6db8"},
+		},
+		{
+			name:         "base36-encoded hashes",
+			clusterName:  "testcluster",
+			remoteObject: createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{
+				Name: "$remoteNameHash",
+				Hash: &syncagentv1alpha1.NamingHash{Encoding: syncagentv1alpha1.NamingHashBase36, Length: 16},
+			},
+			expected: types.NamespacedName{Namespace: "testcluster", Name: "g8on8xs8mkv97apt"},
+		},
+		{
+			name:         "name template with labels and functions",
+			clusterName:  "testcluster",
+			remoteObject: createLabelledObject("objname", "objnamespace", map[string]string{"team": "platform"}),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{NameTemplate: "{{ .Labels.team }}-{{ .RemoteName | sha1 | trunc 8 }}"},
+			expected:     types.NamespacedName{Namespace: "testcluster", Name: "platform-8b09d63c"},
+		},
+		{
+			name:          "namespace template with workspace path",
+			clusterName:   "testcluster",
+			workspacePath: "root:customers:acme",
+			remoteObject:  createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{
+				NamespaceTemplate: `{{ .WorkspacePath | replace ":" "-" }}-{{ .RemoteNamespace }}`,
+				Name:              "$remoteName",
+			},
+			expected: types.NamespacedName{Namespace: "root-customers-acme-objnamespace", Name: "objname"},
+		},
+		{
+			name:         "template with configured hash function",
+			clusterName:  "testcluster",
+			remoteObject: createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{
+				NameTemplate: "obj-{{ hash .RemoteName }}",
+				Hash:         &syncagentv1alpha1.NamingHash{Length: 10},
+			},
+			expected: types.NamespacedName{Namespace: "testcluster", Name: "obj-8b09d63c82"},
+		},
+		{
+			name:         "template with missing label",
+			clusterName:  "testcluster",
+			remoteObject: createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{NameTemplate: "{{ .Labels.team }}-{{ .RemoteName }}"},
+			expectErr:    true,
+		},
+		{
+			name:         "template with optional label",
+			clusterName:  "testcluster",
+			remoteObject: createNewObject("objname", "objnamespace"),
+			namingConfig: &syncagentv1alpha1.ResourceNaming{NameTemplate: `{{ index .Labels "team" | default "none" }}-{{ .RemoteName }}`},
+			expected:     types.NamespacedName{Namespace: "testcluster", Name: "none-objname"},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -96,7 +183,18 @@ func TestGenerateLocalObjectName(t *testing.T) {
 				},
 			}
 
-			generatedName := GenerateLocalObjectName(pubRes, testcase.remoteObject, logicalcluster.Name(testcase.clusterName))
+			generatedName, err := GenerateLocalObjectName(pubRes, testcase.remoteObject, logicalcluster.Name(testcase.clusterName), logicalcluster.NewPath(testcase.workspacePath))
+			if testcase.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, but got %q.", generatedName)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to generate name: %v", err)
+			}
 
 			if generatedName.String() != testcase.expected.String() {
 				t.Errorf("Expected %q, but got %q.", testcase.expected, generatedName)
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gobuffalo/flect"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// pluralOverrides contains the plural names for singular names that the
+// pluralization library would otherwise turn into unusual resource names, like
+// Latin plurals ("indices") that are not common in Kubernetes APIs.
+var pluralOverrides = map[string]string{
+	"endpoints": "endpoints",
+	"index":     "indexes",
+	"matrix":    "matrixes",
+	"person":    "persons",
+	"vertex":    "vertexes",
+}
+
+// SingularName returns the singular resource name for the given kind.
+func SingularName(kind string) string {
+	return strings.ToLower(kind)
+}
+
+// PluralName returns the plural resource name for the given kind, following the
+// same rules as controller-gen, so that projected resources are named just like
+// CRDs generated from Go types.
+func PluralName(kind string) string {
+	singular := SingularName(kind)
+
+	if plural, ok := pluralOverrides[singular]; ok {
+		return plural
+	}
+
+	return flect.Pluralize(singular)
+}
+
+// ValidateNames checks that the names of a projected resource can be used by
+// kcp. Since APIResourceSchemas are validated just like CRDs, invalid names would
+// otherwise only be rejected by kcp once the schema is created.
+func ValidateNames(names apiextensionsv1.CustomResourceDefinitionNames) error {
+	namesPath := field.NewPath("spec", "names")
+	allErrs := field.ErrorList{}
+
+	validateName := func(fldPath *field.Path, value string) {
+		for _, msg := range validation.IsDNS1035Label(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, msg))
+		}
+	}
+
+	validateName(namesPath.Child("plural"), names.Plural)
+
+	if names.Singular != "" {
+		validateName(namesPath.Child("singular"), names.Singular)
+	}
+
+	for i, shortName := range names.ShortNames {
+		validateName(namesPath.Child("shortNames").Index(i), shortName)
+	}
+
+	for i, category := range names.Categories {
+		validateName(namesPath.Child("categories").Index(i), category)
+	}
+
+	if len(allErrs) > 0 {
+		return fmt.Errorf("invalid resource names: %w", allErrs.ToAggregate())
+	}
+
+	return nil
+}
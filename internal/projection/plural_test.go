@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestPluralName(t *testing.T) {
+	testcases := []struct {
+		kind     string
+		expected string
+	}{
+		{kind: "Database", expected: "databases"},
+		{kind: "NetworkPolicy", expected: "networkpolicies"},
+		{kind: "Ingress", expected: "ingresses"},
+		{kind: "Endpoints", expected: "endpoints"},
+		{kind: "Index", expected: "indexes"},
+		{kind: "Proxy", expected: "proxies"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.kind, func(t *testing.T) {
+			if plural := PluralName(testcase.kind); plural != testcase.expected {
+				t.Errorf("Expected plural %q, got %q.", testcase.expected, plural)
+			}
+		})
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	testcases := []struct {
+		name    string
+		names   apiextensionsv1.CustomResourceDefinitionNames
+		invalid bool
+	}{
+		{
+			name: "valid names",
+			names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     "databases",
+				Singular:   "database",
+				ShortNames: []string{"db"},
+				Categories: []string{"all"},
+			},
+		},
+		{
+			name: "plural with dots",
+			names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "data.bases",
+			},
+			invalid: true,
+		},
+		{
+			name: "uppercase plural",
+			names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "Databases",
+			},
+			invalid: true,
+		},
+		{
+			name: "plural starting with a digit",
+			names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural: "3databases",
+			},
+			invalid: true,
+		},
+		{
+			name: "invalid short name",
+			names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:     "databases",
+				ShortNames: []string{"d_b"},
+			},
+			invalid: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := ValidateNames(testcase.names)
+			if testcase.invalid && err == nil {
+				t.Fatal("Expected names to be invalid, but got no error.")
+			}
+
+			if !testcase.invalid && err != nil {
+				t.Fatalf("Expected names to be valid, but got: %v", err)
+			}
+		})
+	}
+}
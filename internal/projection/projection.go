@@ -18,6 +18,7 @@ package projection
 
 import (
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/identity"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -59,3 +60,48 @@ func PublishedResourceProjectedGVK(pubRes *syncagentv1alpha1.PublishedResource)
 		Kind:    kind,
 	}
 }
+
+// PublishedResourceProjectedVersions returns a mapping from source versions to
+// projected versions for all versions published by the PublishedResource. The
+// primary version is always included.
+func PublishedResourceProjectedVersions(pubRes *syncagentv1alpha1.PublishedResource) map[string]string {
+	result := map[string]string{
+		pubRes.Spec.Resource.Version: PublishedResourceProjectedGVK(pubRes).Version,
+	}
+
+	for _, version := range pubRes.Spec.Resource.Versions {
+		projected := version.Name
+		if p := version.Projection; p != nil && p.Version != "" {
+			projected = p.Version
+		}
+
+		result[version.Name] = projected
+	}
+
+	return result
+}
+
+// RelatedResourceGVK returns the GVK of a related resource. For backwards
+// compatibility, an empty version defaults to "v1".
+func RelatedResourceGVK(relRes *syncagentv1alpha1.RelatedResourceSpec) schema.GroupVersionKind {
+	version := relRes.Version
+	if version == "" {
+		version = "v1"
+	}
+
+	return schema.GroupVersionKind{
+		Group:   relRes.APIGroup,
+		Version: version,
+		Kind:    relRes.Kind,
+	}
+}
+
+// PublishedResourceIdentity returns both the source and projected identity of
+// the PublishedResource.
+func PublishedResourceIdentity(pubRes *syncagentv1alpha1.PublishedResource) identity.Identity {
+	return identity.Identity{
+		PublishedResource: pubRes.Name,
+		Source:            PublishedResourceSourceGVK(pubRes),
+		Projected:         PublishedResourceProjectedGVK(pubRes),
+	}
+}
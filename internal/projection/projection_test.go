@@ -19,7 +19,11 @@ package projection
 import (
 	"testing"
 
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/identity"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -126,3 +130,136 @@ func TestPublishedResourceProjectedGVK(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishedResourceProjectedVersions(t *testing.T) {
+	testcases := []struct {
+		name       string
+		resource   syncagentv1alpha1.SourceResourceDescriptor
+		projection *syncagentv1alpha1.ResourceProjection
+		expected   map[string]string
+	}{
+		{
+			name:     "single version",
+			resource: syncagentv1alpha1.SourceResourceDescriptor{APIGroup: "example.corp", Version: "v1", Kind: "Database"},
+			expected: map[string]string{"v1": "v1"},
+		},
+		{
+			name:       "projected primary version",
+			resource:   syncagentv1alpha1.SourceResourceDescriptor{APIGroup: "example.corp", Version: "v1", Kind: "Database"},
+			projection: &syncagentv1alpha1.ResourceProjection{Version: "v2"},
+			expected:   map[string]string{"v1": "v2"},
+		},
+		{
+			name: "additional versions",
+			resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: "example.corp",
+				Version:  "v1",
+				Kind:     "Database",
+				Versions: []syncagentv1alpha1.SourceResourceVersion{
+					{Name: "v1alpha1"},
+					{Name: "v1beta1", Projection: &syncagentv1alpha1.ResourceVersionProjection{Version: "v1beta2"}},
+				},
+			},
+			projection: &syncagentv1alpha1.ResourceProjection{Version: "v2"},
+			expected:   map[string]string{"v1": "v2", "v1alpha1": "v1alpha1", "v1beta1": "v1beta2"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := &syncagentv1alpha1.PublishedResource{
+				Spec: syncagentv1alpha1.PublishedResourceSpec{
+					Resource:   testcase.resource,
+					Projection: testcase.projection,
+				},
+			}
+
+			versions := PublishedResourceProjectedVersions(pubRes)
+			if changes := diff.ObjectDiff(testcase.expected, versions); changes != "" {
+				t.Errorf("Did not get expected versions:\n\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestPublishedResourceIdentity(t *testing.T) {
+	testcases := []struct {
+		name       string
+		resource   syncagentv1alpha1.SourceResourceDescriptor
+		projection *syncagentv1alpha1.ResourceProjection
+	}{
+		{
+			name:     "no projection",
+			resource: syncagentv1alpha1.SourceResourceDescriptor{APIGroup: "example.corp", Version: "v1", Kind: "Database"},
+		},
+		{
+			name:       "projected kind and group",
+			resource:   syncagentv1alpha1.SourceResourceDescriptor{APIGroup: "example.corp", Version: "v1", Kind: "Database"},
+			projection: &syncagentv1alpha1.ResourceProjection{Group: "kcp.example.corp", Version: "v1beta1", Kind: "DB"},
+		},
+		{
+			name:     "core API group",
+			resource: syncagentv1alpha1.SourceResourceDescriptor{APIGroup: "", Version: "v1", Kind: "ConfigMap"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := &syncagentv1alpha1.PublishedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-pr"},
+				Spec: syncagentv1alpha1.PublishedResourceSpec{
+					Resource:   testcase.resource,
+					Projection: testcase.projection,
+				},
+			}
+
+			expected := PublishedResourceIdentity(pubRes)
+
+			parsed, err := identity.FromAnnotations(expected.Annotations())
+			if err != nil {
+				t.Fatalf("Failed to parse annotations: %v", err)
+			}
+
+			if parsed == nil || *parsed != expected {
+				t.Fatalf("Expected %+v, but got %+v.", expected, parsed)
+			}
+
+			if parsed.Projected != PublishedResourceProjectedGVK(pubRes) {
+				t.Errorf("Expected projected GVK %v, but got %v.", PublishedResourceProjectedGVK(pubRes), parsed.Projected)
+			}
+		})
+	}
+}
+
+func TestRelatedResourceGVK(t *testing.T) {
+	testcases := []struct {
+		name     string
+		related  syncagentv1alpha1.RelatedResourceSpec
+		expected schema.GroupVersionKind
+	}{
+		{
+			name:     "defaults to core/v1",
+			related:  syncagentv1alpha1.RelatedResourceSpec{Kind: "Secret"},
+			expected: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		},
+		{
+			name:     "explicit core version",
+			related:  syncagentv1alpha1.RelatedResourceSpec{Version: "v1", Kind: "PersistentVolumeClaim"},
+			expected: schema.GroupVersionKind{Version: "v1", Kind: "PersistentVolumeClaim"},
+		},
+		{
+			name:     "custom API group",
+			related:  syncagentv1alpha1.RelatedResourceSpec{APIGroup: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+			expected: schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			gvk := RelatedResourceGVK(&testcase.related)
+			if gvk != testcase.expected {
+				t.Errorf("Expected %v, but got %v.", testcase.expected, gvk)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// readOnlyRule is the CEL transition rule that prevents a field from being changed
+// once it has been set.
+const readOnlyRule = "self == oldSelf"
+
+// ProjectSchema modifies the schemas of all versions of the CRD according to the
+// schema projection. A path only needs to exist in at least one of the versions.
+func ProjectSchema(crd *apiextensionsv1.CustomResourceDefinition, spec *syncagentv1alpha1.SchemaProjection) error {
+	if spec == nil {
+		return nil
+	}
+
+	dropField := func(parent *apiextensionsv1.JSONSchemaProps, name string) {
+		delete(parent.Properties, name)
+		parent.Required = slices.DeleteFunc(slices.Clone(parent.Required), func(required string) bool {
+			return required == name
+		})
+	}
+
+	readOnlyField := func(parent *apiextensionsv1.JSONSchemaProps, name string) {
+		property := parent.Properties[name]
+		property.XValidations = append(property.XValidations, apiextensionsv1.ValidationRule{
+			Rule:    readOnlyRule,
+			Message: "field is read-only",
+		})
+		parent.Properties[name] = property
+	}
+
+	for _, path := range spec.Drop {
+		if err := modifySchemas(crd, path, dropField); err != nil {
+			return fmt.Errorf("failed to drop field: %w", err)
+		}
+	}
+
+	for _, path := range spec.ReadOnly {
+		if err := modifySchemas(crd, path, readOnlyField); err != nil {
+			return fmt.Errorf("failed to make field read-only: %w", err)
+		}
+	}
+
+	for _, def := range spec.Defaults {
+		value := apiextensionsv1.JSON{Raw: def.Value.Raw}
+
+		defaultField := func(parent *apiextensionsv1.JSONSchemaProps, name string) {
+			property := parent.Properties[name]
+			property.Default = &value
+
+			if def.Constant {
+				property.Enum = []apiextensionsv1.JSON{value}
+			}
+
+			parent.Properties[name] = property
+		}
+
+		if err := modifySchemas(crd, def.Path, defaultField); err != nil {
+			return fmt.Errorf("failed to set default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// propertyFunc modifies the property with the given name in the parent schema.
+type propertyFunc func(parent *apiextensionsv1.JSONSchemaProps, name string)
+
+func modifySchemas(crd *apiextensionsv1.CustomResourceDefinition, path string, fn propertyFunc) error {
+	segments := strings.Split(strings.TrimPrefix(path, "."), ".")
+	found := false
+
+	for _, version := range crd.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+
+		if modifyProperty(version.Schema.OpenAPIV3Schema, segments, fn) {
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("field %s does not exist in the schema", path)
+	}
+
+	return nil
+}
+
+func modifyProperty(schema *apiextensionsv1.JSONSchemaProps, segments []string, fn propertyFunc) bool {
+	// fields inside of lists are addressed like fields of objects
+	if schema.Items != nil && schema.Items.Schema != nil {
+		return modifyProperty(schema.Items.Schema, segments, fn)
+	}
+
+	property, exists := schema.Properties[segments[0]]
+	if !exists {
+		return false
+	}
+
+	if len(segments) == 1 {
+		fn(schema, segments[0])
+		return true
+	}
+
+	if !modifyProperty(&property, segments[1:], fn) {
+		return false
+	}
+
+	schema.Properties[segments[0]] = property
+
+	return true
+}
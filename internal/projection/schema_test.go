@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projection
+
+import (
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestCRD() *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name: "v1",
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type:     "object",
+								Required: []string{"size", "internalTuning"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"size":           {Type: "string"},
+									"internalTuning": {Type: "object"},
+									"backends": {
+										Type: "array",
+										Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+											Schema: &apiextensionsv1.JSONSchemaProps{
+												Type: "object",
+												Properties: map[string]apiextensionsv1.JSONSchemaProps{
+													"host":   {Type: "string"},
+													"weight": {Type: "integer"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestProjectSchema(t *testing.T) {
+	testcases := []struct {
+		name       string
+		projection *syncagentv1alpha1.SchemaProjection
+		expectErr  bool
+		check      func(t *testing.T, spec apiextensionsv1.JSONSchemaProps)
+	}{
+		{
+			name: "drop field",
+			projection: &syncagentv1alpha1.SchemaProjection{
+				Drop: []string{"spec.internalTuning"},
+			},
+			check: func(t *testing.T, spec apiextensionsv1.JSONSchemaProps) {
+				if _, exists := spec.Properties["internalTuning"]; exists {
+					t.Error("Expected internalTuning to be dropped.")
+				}
+
+				if len(spec.Required) != 1 || spec.Required[0] != "size" {
+					t.Errorf("Expected only size to be required, got %v.", spec.Required)
+				}
+			},
+		},
+		{
+			name: "drop field inside of a list",
+			projection: &syncagentv1alpha1.SchemaProjection{
+				Drop: []string{".spec.backends.weight"},
+			},
+			check: func(t *testing.T, spec apiextensionsv1.JSONSchemaProps) {
+				items := spec.Properties["backends"].Items.Schema
+				if _, exists := items.Properties["weight"]; exists {
+					t.Error("Expected weight to be dropped.")
+				}
+
+				if _, exists := items.Properties["host"]; !exists {
+					t.Error("Expected host to be kept.")
+				}
+			},
+		},
+		{
+			name: "read-only field",
+			projection: &syncagentv1alpha1.SchemaProjection{
+				ReadOnly: []string{"spec.size"},
+			},
+			check: func(t *testing.T, spec apiextensionsv1.JSONSchemaProps) {
+				rules := spec.Properties["size"].XValidations
+				if len(rules) != 1 || rules[0].Rule != readOnlyRule {
+					t.Errorf("Expected read-only validation rule, got %v.", rules)
+				}
+			},
+		},
+		{
+			name: "constant default",
+			projection: &syncagentv1alpha1.SchemaProjection{
+				Defaults: []syncagentv1alpha1.SchemaDefault{{
+					Path:     "spec.size",
+					Value:    runtime.RawExtension{Raw: []byte(`"small"`)},
+					Constant: true,
+				}},
+			},
+			check: func(t *testing.T, spec apiextensionsv1.JSONSchemaProps) {
+				size := spec.Properties["size"]
+				if size.Default == nil || string(size.Default.Raw) != `"small"` {
+					t.Errorf("Expected default value to be set, got %v.", size.Default)
+				}
+
+				if len(size.Enum) != 1 || string(size.Enum[0].Raw) != `"small"` {
+					t.Errorf("Expected field to be restricted to the default value, got %v.", size.Enum)
+				}
+			},
+		},
+		{
+			name: "unknown field",
+			projection: &syncagentv1alpha1.SchemaProjection{
+				Drop: []string{"spec.doesNotExist"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			crd := newTestCRD()
+
+			err := ProjectSchema(crd, testcase.projection)
+			if testcase.expectErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got none.")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			testcase.check(t, crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"])
+		})
+	}
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Evaluate determines whether the given object is ready according to the readiness
+// rules. An object without the configured condition or field is not ready.
+func Evaluate(readiness *syncagentv1alpha1.ResourceReadiness, obj *unstructured.Unstructured) (bool, error) {
+	switch {
+	case readiness.Condition != "":
+		conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		if err != nil {
+			return false, fmt.Errorf("failed to read conditions: %w", err)
+		}
+
+		for _, c := range conditions {
+			condition, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if condition["type"] == readiness.Condition {
+				return condition["status"] == "True", nil
+			}
+		}
+
+		return false, nil
+
+	case readiness.Path != "":
+		encoded, err := json.Marshal(obj.Object)
+		if err != nil {
+			return false, fmt.Errorf("failed to encode object: %w", err)
+		}
+
+		value := gjson.GetBytes(encoded, readiness.Path)
+
+		return value.Exists() && value.String() == readiness.Value, nil
+
+	default:
+		return false, errors.New("neither condition nor path configured")
+	}
+}
+
+// SetCondition returns a copy of the given status with a condition of the given type
+// reflecting the readiness. The last transition time is taken from the existing
+// condition in previousStatus, unless the condition's status changes.
+func SetCondition(status any, previousStatus any, conditionType string, ready bool) (map[string]any, error) {
+	reason := "NotReady"
+	if ready {
+		reason = "Ready"
+	}
+
+	return SetConditionWithReason(status, previousStatus, conditionType, ready, reason, "")
+}
+
+// SetConditionWithReason works like SetCondition, but allows to specify the reason
+// and message of the condition.
+func SetConditionWithReason(status any, previousStatus any, conditionType string, value bool, reason string, message string) (map[string]any, error) {
+	result := map[string]any{}
+	if status != nil {
+		statusMap, ok := runtime.DeepCopyJSONValue(status).(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("status is not an object, but %T", status)
+		}
+
+		result = statusMap
+	}
+
+	conditionStatus := string(metav1.ConditionFalse)
+	if value {
+		conditionStatus = string(metav1.ConditionTrue)
+	}
+
+	transitionTime := metav1.Now().UTC().Format(time.RFC3339)
+	if previous := findCondition(previousStatus, conditionType); previous != nil && previous["status"] == conditionStatus {
+		if t, ok := previous["lastTransitionTime"].(string); ok {
+			transitionTime = t
+		}
+	}
+
+	condition := map[string]any{
+		"type":               conditionType,
+		"status":             conditionStatus,
+		"reason":             reason,
+		"message":            message,
+		"lastTransitionTime": transitionTime,
+	}
+
+	conditions, _ := result["conditions"].([]any)
+	replaced := false
+	for i, c := range conditions {
+		if existing, ok := c.(map[string]any); ok && existing["type"] == conditionType {
+			conditions[i] = condition
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		conditions = append(conditions, condition)
+	}
+
+	result["conditions"] = conditions
+
+	return result, nil
+}
+
+// ConditionIsTrue returns true if the given status contains a condition of the
+// given type with status "True".
+func ConditionIsTrue(status any, conditionType string) bool {
+	condition := findCondition(status, conditionType)
+
+	return condition != nil && condition["status"] == string(metav1.ConditionTrue)
+}
+
+// PreserveCondition copies the condition of the given type from the previous status
+// into the status, unless the status already contains such a condition. This is
+// used to keep conditions that are managed by the Sync Agent when a status is
+// synchronized from another object.
+func PreserveCondition(status any, previousStatus any, conditionType string) (any, error) {
+	previous := findCondition(previousStatus, conditionType)
+	if previous == nil || findCondition(status, conditionType) != nil {
+		return status, nil
+	}
+
+	result := map[string]any{}
+	if status != nil {
+		statusMap, ok := runtime.DeepCopyJSONValue(status).(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("status is not an object, but %T", status)
+		}
+
+		result = statusMap
+	}
+
+	conditions, _ := result["conditions"].([]any)
+	result["conditions"] = append(conditions, runtime.DeepCopyJSONValue(previous))
+
+	return result, nil
+}
+
+func findCondition(status any, conditionType string) map[string]any {
+	statusMap, ok := status.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	conditions, _ := statusMap["conditions"].([]any)
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]any); ok && condition["type"] == conditionType {
+			return condition
+		}
+	}
+
+	return nil
+}
+
+// Tracker remembers the readiness of all objects of a PublishedResource and keeps
+// the per-workspace readiness metrics up-to-date.
+type Tracker struct {
+	lock              sync.Mutex
+	publishedResource string
+	// cluster name => object key => readiness
+	objects map[string]map[string]bool
+}
+
+func NewTracker(publishedResource string) *Tracker {
+	return &Tracker{
+		publishedResource: publishedResource,
+		objects:           map[string]map[string]bool{},
+	}
+}
+
+// Set records the readiness of a single object.
+func (t *Tracker) Set(clusterName string, key string, ready bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.objects[clusterName] == nil {
+		t.objects[clusterName] = map[string]bool{}
+	}
+
+	t.objects[clusterName][key] = ready
+	t.updateMetrics(clusterName)
+}
+
+// Forget removes an object, e.g. after it has been deleted.
+func (t *Tracker) Forget(clusterName string, key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, exists := t.objects[clusterName][key]; !exists {
+		return
+	}
+
+	delete(t.objects[clusterName], key)
+	t.updateMetrics(clusterName)
+
+	if len(t.objects[clusterName]) == 0 {
+		delete(t.objects, clusterName)
+		metrics.ObjectsTotal.DeleteLabelValues(t.publishedResource, clusterName)
+		metrics.ObjectsReady.DeleteLabelValues(t.publishedResource, clusterName)
+	}
+}
+
+func (t *Tracker) updateMetrics(clusterName string) {
+	ready := 0
+	for _, r := range t.objects[clusterName] {
+		if r {
+			ready++
+		}
+	}
+
+	metrics.ObjectsTotal.WithLabelValues(t.publishedResource, clusterName).Set(float64(len(t.objects[clusterName])))
+	metrics.ObjectsReady.WithLabelValues(t.publishedResource, clusterName).Set(float64(ready))
+}
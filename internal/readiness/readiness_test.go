@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"reflect"
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestEvaluate(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"phase": "Running",
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True"},
+				map[string]any{"type": "Degraded", "status": "False"},
+			},
+		},
+	}}
+
+	testcases := []struct {
+		name      string
+		readiness syncagentv1alpha1.ResourceReadiness
+		expected  bool
+	}{
+		{
+			name:      "condition is true",
+			readiness: syncagentv1alpha1.ResourceReadiness{Condition: "Ready"},
+			expected:  true,
+		},
+		{
+			name:      "condition is false",
+			readiness: syncagentv1alpha1.ResourceReadiness{Condition: "Degraded"},
+			expected:  false,
+		},
+		{
+			name:      "condition does not exist",
+			readiness: syncagentv1alpha1.ResourceReadiness{Condition: "Available"},
+			expected:  false,
+		},
+		{
+			name:      "field has expected value",
+			readiness: syncagentv1alpha1.ResourceReadiness{Path: "status.phase", Value: "Running"},
+			expected:  true,
+		},
+		{
+			name:      "field has different value",
+			readiness: syncagentv1alpha1.ResourceReadiness{Path: "status.phase", Value: "Succeeded"},
+			expected:  false,
+		},
+		{
+			name:      "field does not exist",
+			readiness: syncagentv1alpha1.ResourceReadiness{Path: "status.state", Value: ""},
+			expected:  false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ready, err := Evaluate(&testcase.readiness, obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if ready != testcase.expected {
+				t.Fatalf("Expected ready=%v, but got %v.", testcase.expected, ready)
+			}
+		})
+	}
+}
+
+func TestSetConditionKeepsTransitionTime(t *testing.T) {
+	previous := map[string]any{
+		"conditions": []any{
+			map[string]any{"type": "Ready", "status": "True", "lastTransitionTime": "2025-01-01T00:00:00Z"},
+		},
+	}
+
+	status, err := SetCondition(map[string]any{"phase": "Running"}, previous, "Ready", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	condition := findCondition(status, "Ready")
+	if condition == nil {
+		t.Fatal("Expected condition to be set.")
+	}
+
+	if condition["lastTransitionTime"] != "2025-01-01T00:00:00Z" {
+		t.Errorf("Expected transition time to be kept, but got %v.", condition["lastTransitionTime"])
+	}
+
+	if status["phase"] != "Running" {
+		t.Errorf("Expected other status fields to be kept, but got %v.", status)
+	}
+
+	status, err = SetCondition(nil, previous, "Ready", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	condition = findCondition(status, "Ready")
+	if condition["status"] != "False" || condition["lastTransitionTime"] == "2025-01-01T00:00:00Z" {
+		t.Errorf("Expected condition to transition, but got %v.", condition)
+	}
+}
+
+func TestPreserveCondition(t *testing.T) {
+	reverted := map[string]any{"type": "Reverted", "status": "True"}
+	ready := map[string]any{"type": "Ready", "status": "True"}
+
+	testcases := []struct {
+		name     string
+		status   any
+		previous any
+		expected any
+	}{
+		{
+			name:     "no previous condition",
+			status:   map[string]any{"phase": "Running"},
+			previous: map[string]any{"conditions": []any{ready}},
+			expected: map[string]any{"phase": "Running"},
+		},
+		{
+			name:     "condition is copied into empty status",
+			status:   nil,
+			previous: map[string]any{"conditions": []any{reverted}},
+			expected: map[string]any{"conditions": []any{reverted}},
+		},
+		{
+			name:     "condition is appended",
+			status:   map[string]any{"conditions": []any{ready}},
+			previous: map[string]any{"conditions": []any{reverted}},
+			expected: map[string]any{"conditions": []any{ready, reverted}},
+		},
+		{
+			name:     "existing condition is kept",
+			status:   map[string]any{"conditions": []any{map[string]any{"type": "Reverted", "status": "False"}}},
+			previous: map[string]any{"conditions": []any{reverted}},
+			expected: map[string]any{"conditions": []any{map[string]any{"type": "Reverted", "status": "False"}}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			status, err := PreserveCondition(testcase.status, testcase.previous, "Reverted")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(status, testcase.expected) {
+				t.Errorf("Expected %v, got %v.", testcase.expected, status)
+			}
+		})
+	}
+}
@@ -21,14 +21,17 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/kontext"
 )
 
 type Context struct {
-	clusterName   logicalcluster.Name
-	workspacePath logicalcluster.Path
-	local         context.Context
-	remote        context.Context
+	clusterName     logicalcluster.Name
+	workspacePath   logicalcluster.Path
+	namespaceLabels map[string]string
+	namespace       *corev1.Namespace
+	local           context.Context
+	remote          context.Context
 }
 
 func NewContext(local, remote context.Context) Context {
@@ -46,9 +49,37 @@ func NewContext(local, remote context.Context) Context {
 
 func (c *Context) WithWorkspacePath(path logicalcluster.Path) Context {
 	return Context{
-		clusterName:   c.clusterName,
-		workspacePath: path,
-		local:         c.local,
-		remote:        c.remote,
+		clusterName:     c.clusterName,
+		workspacePath:   path,
+		namespaceLabels: c.namespaceLabels,
+		namespace:       c.namespace,
+		local:           c.local,
+		remote:          c.remote,
+	}
+}
+
+// WithNamespaceLabels returns a copy of the context that will make the syncer
+// place the given labels on the local primary object.
+func (c *Context) WithNamespaceLabels(labels map[string]string) Context {
+	return Context{
+		clusterName:     c.clusterName,
+		workspacePath:   c.workspacePath,
+		namespaceLabels: labels,
+		namespace:       c.namespace,
+		local:           c.local,
+		remote:          c.remote,
+	}
+}
+
+// WithNamespace returns a copy of the context that contains the namespace in kcp that
+// the remote object resides in, so its metadata can be synced onto the local namespace.
+func (c *Context) WithNamespace(namespace *corev1.Namespace) Context {
+	return Context{
+		clusterName:     c.clusterName,
+		workspacePath:   c.workspacePath,
+		namespaceLabels: c.namespaceLabels,
+		namespace:       namespace,
+		local:           c.local,
+		remote:          c.remote,
 	}
 }
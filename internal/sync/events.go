@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/events"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// reasons for Events recorded on objects in kcp
+	reasonLocalObjectCreated = "LocalObjectCreated"
+	reasonLocalObjectAdopted = "LocalObjectAdopted"
+	reasonDeletionBlocked    = "DeletionBlocked"
+	reasonSyncFailed         = "SyncFailed"
+
+	reasonImmutableFieldChanged = "ImmutableFieldChanged"
+)
+
+// remoteEventRecorder records Events on objects in kcp. The Events are created in
+// the object's workspace and namespace, so that consumers can see them using
+// `kubectl describe`. Events for cluster-scoped objects are not recorded, as the
+// "default" namespace might not exist in the consumer's workspace. Like on the
+// service cluster, Events are deduplicated, aggregated and rate limited per object
+// and reason. Failing to record an Event never fails the synchronization. A nil
+// recorder ignores all Events.
+type remoteEventRecorder struct {
+	client     ctrlruntimeclient.Client
+	component  string
+	log        *zap.SugaredLogger
+	now        func() time.Time
+	correlator *record.EventCorrelator
+}
+
+func newRemoteEventRecorder(client ctrlruntimeclient.Client, component string, log *zap.SugaredLogger) *remoteEventRecorder {
+	return &remoteEventRecorder{
+		client:     client,
+		component:  component,
+		log:        log,
+		now:        time.Now,
+		correlator: record.NewEventCorrelatorWithOptions(events.CorrelatorOptions()),
+	}
+}
+
+// Event records an Event on the given object. The context must point to the
+// object's workspace.
+func (r *remoteEventRecorder) Event(ctx context.Context, obj *unstructured.Unstructured, eventType, reason, message string) {
+	if r == nil || obj == nil {
+		return
+	}
+
+	if obj.GetNamespace() == "" {
+		r.log.Debugw("Not recording Event in kcp for cluster-scoped object", "reason", reason)
+		return
+	}
+
+	now := metav1.NewTime(r.now())
+
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", obj.GetName(), now.UnixNano()),
+			Namespace: obj.GetNamespace(),
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      obj.GetAPIVersion(),
+			Kind:            obj.GetKind(),
+			Namespace:       obj.GetNamespace(),
+			Name:            obj.GetName(),
+			UID:             obj.GetUID(),
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Source:         corev1.EventSource{Component: r.component},
+	}
+
+	result, err := r.correlator.EventCorrelate(event)
+	if err != nil {
+		r.log.Debugw("Failed to correlate Event", "reason", reason, zap.Error(err))
+	}
+
+	if result.Skip {
+		return
+	}
+
+	if err := r.record(ctx, result); err != nil {
+		r.log.Debugw("Failed to record Event in kcp", "reason", reason, zap.Error(err))
+	}
+}
+
+// record creates the correlated Event or, if it is a repetition of an earlier
+// Event, patches the existing one, just like the broadcaster on the service cluster.
+func (r *remoteEventRecorder) record(ctx context.Context, result *record.EventCorrelateResult) error {
+	event := result.Event
+
+	if event.Count > 1 {
+		existing := &corev1.Event{}
+		existing.Name = event.Name
+		existing.Namespace = event.Namespace
+
+		err := r.client.Patch(ctx, existing, ctrlruntimeclient.RawPatch(types.StrategicMergePatchType, result.Patch))
+		if err == nil {
+			r.correlator.UpdateState(existing)
+			return nil
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		// the Event has been deleted in the meantime and has to be recreated
+		event = event.DeepCopy()
+		event.ResourceVersion = ""
+	}
+
+	if err := r.client.Create(ctx, event); err != nil {
+		return err
+	}
+
+	r.correlator.UpdateState(event)
+
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRemoteEventRecorder(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name           string
+		object         *unstructured.Unstructured
+		expectedEvents int
+	}{
+		{
+			name: "namespaced object",
+			object: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-thing", Namespace: "production"},
+			}),
+			expectedEvents: 1,
+		},
+		{
+			name: "cluster-scoped object",
+			object: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-thing"},
+			}),
+			expectedEvents: 0,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			client := fakectrlruntimeclient.NewClientBuilder().Build()
+
+			recorder := newRemoteEventRecorder(client, "my-agent", zap.NewNop().Sugar())
+			recorder.now = func() time.Time { return now }
+
+			recorder.Event(context.Background(), testcase.object, corev1.EventTypeNormal, reasonLocalObjectCreated, "Created object on the service cluster.")
+
+			events := &corev1.EventList{}
+			if err := client.List(context.Background(), events); err != nil {
+				t.Fatalf("Failed to list Events: %v", err)
+			}
+
+			if len(events.Items) != testcase.expectedEvents {
+				t.Fatalf("Expected %d Event(s), got %d.", testcase.expectedEvents, len(events.Items))
+			}
+
+			if testcase.expectedEvents == 0 {
+				return
+			}
+
+			event := events.Items[0]
+
+			if event.InvolvedObject.Name != testcase.object.GetName() || event.InvolvedObject.Kind != testcase.object.GetKind() {
+				t.Errorf("Event refers to wrong object: %+v", event.InvolvedObject)
+			}
+
+			if event.Reason != reasonLocalObjectCreated || event.Source.Component != "my-agent" {
+				t.Errorf("Event has unexpected reason %q or source %q.", event.Reason, event.Source.Component)
+			}
+		})
+	}
+}
+
+func TestRemoteEventRecorderCorrelation(t *testing.T) {
+	thing := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-thing", Namespace: "production", UID: "1234"},
+	})
+
+	testcases := []struct {
+		name           string
+		messages       []string
+		expectedEvents int
+		expectedCount  int32
+	}{
+		{
+			name:           "identical Events are deduplicated",
+			messages:       []string{"Sync failed.", "Sync failed.", "Sync failed."},
+			expectedEvents: 1,
+			expectedCount:  3,
+		},
+		{
+			name: "varying Events are rate limited",
+			messages: func() []string {
+				messages := []string{}
+				for i := range 20 {
+					messages = append(messages, fmt.Sprintf("Sync failed, attempt %d.", i))
+				}
+				return messages
+			}(),
+			// two individual Events, then similar Events are combined into one, which
+			// is only updated until the burst for the object and reason is used up
+			expectedEvents: 3,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fakectrlruntimeclient.NewClientBuilder().Build()
+
+			recorder := newRemoteEventRecorder(client, "my-agent", zap.NewNop().Sugar())
+
+			for i, message := range testcase.messages {
+				// every Event must get a unique name
+				now := time.Date(2025, 1, 1, 12, 0, i, 0, time.UTC)
+				recorder.now = func() time.Time { return now }
+
+				recorder.Event(ctx, thing, corev1.EventTypeWarning, reasonSyncFailed, message)
+			}
+
+			events := &corev1.EventList{}
+			if err := client.List(ctx, events); err != nil {
+				t.Fatalf("Failed to list Events: %v", err)
+			}
+
+			if len(events.Items) != testcase.expectedEvents {
+				t.Fatalf("Expected %d Event(s), got %d.", testcase.expectedEvents, len(events.Items))
+			}
+
+			if testcase.expectedCount > 0 && events.Items[0].Count != testcase.expectedCount {
+				t.Errorf("Expected Event count to be %d, got %d.", testcase.expectedCount, events.Items[0].Count)
+			}
+		})
+	}
+}
+
+func TestNilRemoteEventRecorder(t *testing.T) {
+	var recorder *remoteEventRecorder
+
+	// must not panic
+	recorder.Event(context.Background(), &unstructured.Unstructured{}, corev1.EventTypeWarning, reasonSyncFailed, "test")
+}
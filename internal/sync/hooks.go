@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WriteHook is called right before the Sync Agent writes an object on the
+// service cluster. Hooks can modify the object in-place or return an error to
+// reject the write altogether. This gives platform operators a last line of
+// defence that is independent of any policies on the kcp side.
+type WriteHook interface {
+	BeforeWrite(ctx context.Context, obj *unstructured.Unstructured) error
+}
+
+// WriteHookFunc turns a function into a WriteHook.
+type WriteHookFunc func(ctx context.Context, obj *unstructured.Unstructured) error
+
+func (f WriteHookFunc) BeforeWrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	return f(ctx, obj)
+}
+
+// WriteHooks runs a list of hooks in order, stopping at the first error.
+type WriteHooks []WriteHook
+
+func (h WriteHooks) BeforeWrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	for _, hook := range h {
+		if err := hook.BeforeWrite(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hookedClient runs a WriteHook before creating, updating or patching objects.
+// Subresources (like status) and deletions are not subject to hooks, and neither
+// are the Secrets used by the Sync Agent to store object states.
+type hookedClient struct {
+	ctrlruntimeclient.Client
+
+	hook WriteHook
+}
+
+// NewHookedClient wraps the client so that the hook is invoked for every write.
+// If the hook is nil, the client is returned as-is.
+func NewHookedClient(client ctrlruntimeclient.Client, hook WriteHook) ctrlruntimeclient.Client {
+	if hook == nil {
+		return client
+	}
+
+	return &hookedClient{
+		Client: client,
+		hook:   hook,
+	}
+}
+
+func (c *hookedClient) Create(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+	if err := c.runHook(ctx, obj); err != nil {
+		return err
+	}
+
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *hookedClient) Update(ctx context.Context, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.UpdateOption) error {
+	if err := c.runHook(ctx, obj); err != nil {
+		return err
+	}
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *hookedClient) Patch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch, opts ...ctrlruntimeclient.PatchOption) error {
+	if isStateObject(obj) {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	// for server-side apply, the object itself is the desired state
+	if patch.Type() == types.ApplyPatchType {
+		if err := c.runHook(ctx, obj); err != nil {
+			return err
+		}
+
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	hooked, err := c.hookPatch(ctx, obj, patch)
+	if err != nil {
+		return err
+	}
+
+	return c.Client.Patch(ctx, obj, hooked, opts...)
+}
+
+// hookPatch determines the object as it would look like after the patch was
+// applied, runs the hook on it and, if the hook made changes, returns a patch
+// that includes these changes.
+func (c *hookedClient) hookPatch(ctx context.Context, obj ctrlruntimeclient.Object, patch ctrlruntimeclient.Patch) (ctrlruntimeclient.Patch, error) {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute patch: %w", err)
+	}
+
+	current, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	var patched []byte
+
+	switch patch.Type() {
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(current, data)
+	case types.JSONPatchType:
+		var decoded jsonpatch.Patch
+		decoded, err = jsonpatch.DecodePatch(data)
+		if err == nil {
+			patched, err = decoded.Apply(current)
+		}
+	default:
+		return nil, fmt.Errorf("patch type %q is not supported when write hooks are configured", patch.Type())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch: %w", err)
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return nil, fmt.Errorf("failed to decode patched object: %w", err)
+	}
+
+	original := result.DeepCopy()
+
+	if err := c.hook.BeforeWrite(ctx, result); err != nil {
+		return nil, err
+	}
+
+	if equality.Semantic.DeepEqual(original, result) {
+		return ctrlruntimeclient.RawPatch(patch.Type(), data), nil
+	}
+
+	mutated, err := result.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode mutated object: %w", err)
+	}
+
+	// send the hook's changes along with the original patch; for merge patches,
+	// both can be combined, which retains things like the optimistic lock
+	hookChanges, err := jsonpatch.CreateMergePatch(patched, mutated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create patch: %w", err)
+	}
+
+	if patch.Type() == types.MergePatchType {
+		combined, err := jsonpatch.MergeMergePatches(data, hookChanges)
+		if err != nil {
+			return nil, fmt.Errorf("failed to combine patches: %w", err)
+		}
+
+		return ctrlruntimeclient.RawPatch(types.MergePatchType, combined), nil
+	}
+
+	// JSON patches cannot be combined with merge patches, so the entire change
+	// is expressed as a merge patch instead
+	fullPatch, err := jsonpatch.CreateMergePatch(current, mutated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create patch: %w", err)
+	}
+
+	return ctrlruntimeclient.RawPatch(types.MergePatchType, fullPatch), nil
+}
+
+// runHook invokes the hook on the given object, converting typed objects to
+// unstructured ones and back again if necessary.
+func (c *hookedClient) runHook(ctx context.Context, obj ctrlruntimeclient.Object) error {
+	if isStateObject(obj) {
+		return nil
+	}
+
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return c.hook.BeforeWrite(ctx, u)
+	}
+
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	u := &unstructured.Unstructured{Object: data}
+
+	// typed objects do not always have their TypeMeta set
+	if gvks, _, err := c.Scheme().ObjectKinds(obj); err == nil && len(gvks) > 0 {
+		u.SetGroupVersionKind(gvks[0])
+	}
+
+	if err := c.hook.BeforeWrite(ctx, u); err != nil {
+		return err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	return nil
+}
+
+// isStateObject returns true for the Secrets that contain object states or chunks
+// of them; these are internal to the Sync Agent and must never be rejected.
+func isStateObject(obj ctrlruntimeclient.Object) bool {
+	objLabels := obj.GetLabels()
+
+	return objLabels[state.LabelName] == state.LabelValue || objLabels[state.ChunkLabelName] == state.LabelValue
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testWriteHook rejects objects with a "forbidden" data key and labels all
+// other objects.
+var testWriteHook = WriteHookFunc(func(_ context.Context, obj *unstructured.Unstructured) error {
+	if _, exists, _ := unstructured.NestedString(obj.Object, "data", "forbidden"); exists {
+		return errors.New("forbidden")
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["hooked"] = "true"
+	obj.SetLabels(labels)
+
+	return nil
+})
+
+func newTestConfigMap(data map[string]string, labels map[string]string) *unstructured.Unstructured {
+	return newUnstructured(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Data: data,
+	})
+}
+
+func TestHookedClient(t *testing.T) {
+	testcases := []struct {
+		name          string
+		existing      *unstructured.Unstructured
+		write         func(ctx context.Context, client ctrlruntimeclient.Client) error
+		expectErr     bool
+		expectedLabel string
+	}{
+		{
+			name: "create is hooked",
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				return client.Create(ctx, newTestConfigMap(map[string]string{"foo": "bar"}, nil))
+			},
+			expectedLabel: "true",
+		},
+		{
+			name: "create can be rejected",
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				return client.Create(ctx, newTestConfigMap(map[string]string{"forbidden": "yes"}, nil))
+			},
+			expectErr: true,
+		},
+		{
+			name: "state objects are not hooked",
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				return client.Create(ctx, newTestConfigMap(map[string]string{"forbidden": "yes"}, map[string]string{state.LabelName: state.LabelValue}))
+			},
+			expectedLabel: "",
+		},
+		{
+			name: "typed objects are hooked",
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				return client.Create(ctx, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				})
+			},
+			expectedLabel: "true",
+		},
+		{
+			name:     "update is hooked",
+			existing: newTestConfigMap(nil, nil),
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				obj := newTestConfigMap(nil, nil)
+				if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(obj), obj); err != nil {
+					return err
+				}
+
+				return client.Update(ctx, obj)
+			},
+			expectedLabel: "true",
+		},
+		{
+			name:     "merge patch includes the hook's changes",
+			existing: newTestConfigMap(nil, nil),
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				obj := newTestConfigMap(nil, nil)
+				patch := ctrlruntimeclient.RawPatch(types.MergePatchType, []byte(`{"data":{"foo":"bar"}}`))
+
+				return client.Patch(ctx, obj, patch)
+			},
+			expectedLabel: "true",
+		},
+		{
+			name:     "merge patch can be rejected",
+			existing: newTestConfigMap(nil, nil),
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				obj := newTestConfigMap(nil, nil)
+				patch := ctrlruntimeclient.RawPatch(types.MergePatchType, []byte(`{"data":{"forbidden":"yes"}}`))
+
+				return client.Patch(ctx, obj, patch)
+			},
+			expectErr: true,
+		},
+		{
+			name:     "JSON patch includes the hook's changes",
+			existing: newTestConfigMap(map[string]string{"foo": "bar"}, nil),
+			write: func(ctx context.Context, client ctrlruntimeclient.Client) error {
+				obj := newTestConfigMap(map[string]string{"foo": "bar"}, nil)
+				patch := ctrlruntimeclient.RawPatch(types.JSONPatchType, []byte(`[{"op":"replace","path":"/data/foo","value":"baz"}]`))
+
+				return client.Patch(ctx, obj, patch)
+			},
+			expectedLabel: "true",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			builder := fakectrlruntimeclient.NewClientBuilder()
+			if testcase.existing != nil {
+				builder.WithObjects(testcase.existing)
+			}
+
+			client := NewHookedClient(builder.Build(), testWriteHook)
+
+			err := testcase.write(ctx, client)
+			if err != nil {
+				if !testcase.expectErr {
+					t.Fatalf("Expected no error, but got: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.expectErr {
+				t.Fatal("Expected an error, but got none.")
+			}
+
+			result := &corev1.ConfigMap{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test"}, result); err != nil {
+				t.Fatalf("Failed to get object: %v", err)
+			}
+
+			if label := result.Labels["hooked"]; label != testcase.expectedLabel {
+				t.Fatalf("Expected hooked label to be %q, but got %q.", testcase.expectedLabel, label)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NewImportedObject returns the object that has to be created in kcp to import the
+// given local object. Metadata managed by the Sync Agent or the API server as
+// well as the status are removed, as the object in kcp is a new object. For
+// cluster-scoped objects, the namespace must be empty.
+func NewImportedObject(localObj *unstructured.Unstructured, remoteGVK schema.GroupVersionKind, namespace string) (*unstructured.Unstructured, error) {
+	remoteObj := localObj.DeepCopy()
+	remoteObj.SetGroupVersionKind(remoteGVK)
+	remoteObj.SetNamespace(namespace)
+	remoteObj.SetDeletionTimestamp(nil)
+	remoteObj.SetDeletionGracePeriodSeconds(nil)
+	unstructured.RemoveNestedField(remoteObj.Object, "status")
+
+	if err := stripMetadata(remoteObj); err != nil {
+		return nil, fmt.Errorf("failed to strip metadata: %w", err)
+	}
+
+	labels := remoteObj.GetLabels()
+	delete(labels, agentNameLabel)
+	if err := setNestedMapOmitempty(remoteObj, labels, "metadata", "labels"); err != nil {
+		return nil, fmt.Errorf("failed to remove agent name label: %w", err)
+	}
+
+	return remoteObj, nil
+}
+
+// LinkLocalObject places the labels and annotations on the local object that tie it
+// to the given object in kcp, just as if the Sync Agent had created the local
+// object. The Sync Agent will then find and adopt the local object when it
+// processes the object in kcp.
+func LinkLocalObject(localObj, remoteObj *unstructured.Unstructured, clusterName logicalcluster.Name, workspacePath logicalcluster.Path, agentName string) {
+	key := newObjectKey(remoteObj, clusterName, workspacePath)
+
+	ensureLabels(localObj, key.DestinationLabels())
+	ensureLabels(localObj, map[string]string{agentNameLabel: agentName})
+	ensureAnnotations(localObj, key.Annotations())
+}
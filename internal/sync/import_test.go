@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestImportLocalObject(t *testing.T) {
+	localObj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Thing",
+		"metadata": map[string]any{
+			"name":              "my-thing",
+			"namespace":         "team-a",
+			"uid":               "1234",
+			"resourceVersion":   "42",
+			"generation":        int64(3),
+			"creationTimestamp": "2025-01-01T00:00:00Z",
+			"finalizers":        []any{"example.com/cleanup"},
+			"labels": map[string]any{
+				"app":          "thingy",
+				agentNameLabel: "other-agent",
+			},
+			"annotations": map[string]any{
+				"note": "keep me",
+				syncagentv1alpha1.PublishedResourceAnnotation: "things",
+			},
+		},
+		"spec": map[string]any{
+			"size": int64(12),
+		},
+		"status": map[string]any{
+			"phase": "Ready",
+		},
+	}}
+
+	remoteGVK := schema.GroupVersionKind{Group: "remote.example.corp", Version: "v1", Kind: "RemoteThing"}
+
+	remoteObj, err := NewImportedObject(localObj, remoteGVK, "default")
+	if err != nil {
+		t.Fatalf("Failed to create imported object: %v", err)
+	}
+
+	expected := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "remote.example.corp/v1",
+		"kind":       "RemoteThing",
+		"metadata": map[string]any{
+			"name":      "my-thing",
+			"namespace": "default",
+			"labels": map[string]any{
+				"app": "thingy",
+			},
+			"annotations": map[string]any{
+				"note": "keep me",
+			},
+		},
+		"spec": map[string]any{
+			"size": int64(12),
+		},
+	}}
+
+	if changes := diff.ObjectDiff(expected, remoteObj); changes != "" {
+		t.Errorf("Unexpected imported object:\n%s", changes)
+	}
+
+	// the original object must not have been modified
+	if localObj.GetNamespace() != "team-a" || localObj.GetUID() != "1234" {
+		t.Fatal("Local object was modified.")
+	}
+
+	LinkLocalObject(localObj, remoteObj, logicalcluster.Name("testcluster"), logicalcluster.NewPath("root:org"), "textor-the-doctor")
+
+	request := RemoteNameForLocalObject(localObj)
+	if request == nil {
+		t.Fatal("Linked local object does not point to its remote object.")
+	}
+
+	if request.ClusterName != "testcluster" || request.Namespace != "default" || request.Name != "my-thing" {
+		t.Errorf("Linked local object points to the wrong remote object: %+v", request)
+	}
+
+	if !OwnedBy(localObj, "textor-the-doctor") {
+		t.Error("Linked local object is not owned by the agent.")
+	}
+
+	if path := RemoteWorkspacePathForLocalObject(localObj); path.String() != "root:org" {
+		t.Errorf("Expected workspace path root:org, got %q.", path)
+	}
+
+	selector := newObjectKey(remoteObj, "testcluster", logicalcluster.None).Labels().AsSelector()
+	if !selector.Matches(labels.Set(localObj.GetLabels())) {
+		t.Error("Linked local object cannot be found by the syncer.")
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kcp-dev/api-syncagent/internal/metrics"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// latencyTracker measures how long it takes for changes to remote objects to be
+// fully synchronized. For this it remembers the generation of each remote object
+// that was last fully synchronized; once a newer generation has been synchronized,
+// the time since the object was last modified is recorded. Objects seen for the
+// first time are only measured if they were created after the tracker, so that
+// restarting the agent does not record the age of all existing objects.
+type latencyTracker struct {
+	lock              sync.Mutex
+	publishedResource string
+	started           time.Time
+	// object key => generation
+	generations map[string]int64
+	// observer is optionally called for every recorded latency
+	observer func(time.Duration)
+	now      func() time.Time
+}
+
+func newLatencyTracker(publishedResource string) *latencyTracker {
+	return &latencyTracker{
+		publishedResource: publishedResource,
+		started:           time.Now(),
+		generations:       map[string]int64{},
+		now:               time.Now,
+	}
+}
+
+// Synced records that the given remote object has been fully synchronized. A nil
+// tracker ignores all records.
+func (t *latencyTracker) Synced(key string, remoteObj *unstructured.Unstructured) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	generation := remoteObj.GetGeneration()
+	previous, known := t.generations[key]
+	t.generations[key] = generation
+
+	var changed time.Time
+
+	switch {
+	case known && previous != generation:
+		changed = lastModified(remoteObj)
+	case !known && remoteObj.GetCreationTimestamp().After(t.started):
+		changed = remoteObj.GetCreationTimestamp().Time
+	default:
+		return
+	}
+
+	// timestamps in kcp only have a precision of seconds
+	latency := max(t.now().Sub(changed), 0)
+
+	metrics.SyncLatency.WithLabelValues(t.publishedResource).Observe(latency.Seconds())
+
+	if t.observer != nil {
+		t.observer(latency)
+	}
+}
+
+// Forget removes an object, e.g. after it has been deleted.
+func (t *latencyTracker) Forget(key string) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.generations, key)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newObject := func(generation int64, created time.Time, modified time.Time) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetGeneration(generation)
+		obj.SetCreationTimestamp(metav1.NewTime(created))
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{
+			Manager: "kubectl",
+			Time:    &metav1.Time{Time: modified},
+		}})
+
+		return obj
+	}
+
+	testcases := []struct {
+		name     string
+		previous *unstructured.Unstructured
+		current  *unstructured.Unstructured
+		expected []time.Duration
+	}{
+		{
+			name:     "pre-existing objects are not measured",
+			current:  newObject(1, now.Add(-time.Hour), now.Add(-time.Hour)),
+			expected: nil,
+		},
+		{
+			name:     "new objects are measured from their creation",
+			current:  newObject(1, now.Add(-5*time.Second), now.Add(-5*time.Second)),
+			expected: []time.Duration{5 * time.Second},
+		},
+		{
+			name:     "unchanged generation is not measured again",
+			previous: newObject(1, now.Add(-time.Hour), now.Add(-time.Hour)),
+			current:  newObject(1, now.Add(-time.Hour), now.Add(-time.Minute)),
+			expected: nil,
+		},
+		{
+			name:     "new generations are measured from their last modification",
+			previous: newObject(1, now.Add(-time.Hour), now.Add(-time.Hour)),
+			current:  newObject(2, now.Add(-time.Hour), now.Add(-3*time.Second)),
+			expected: []time.Duration{3 * time.Second},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var recorded []time.Duration
+
+			tracker := newLatencyTracker("test")
+			tracker.started = now.Add(-time.Minute)
+			tracker.now = func() time.Time { return now }
+			tracker.observer = func(d time.Duration) { recorded = append(recorded, d) }
+
+			if testcase.previous != nil {
+				tracker.Synced("key", testcase.previous)
+				recorded = nil
+			}
+
+			tracker.Synced("key", testcase.current)
+
+			if len(recorded) != len(testcase.expected) {
+				t.Fatalf("Expected %v, but got %v.", testcase.expected, recorded)
+			}
+
+			for i := range recorded {
+				if recorded[i] != testcase.expected[i] {
+					t.Errorf("Expected %v, but got %v.", testcase.expected, recorded)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"sync"
+)
+
+// keyedMutex allows to serialize operations on individual keys, while operations
+// on different keys can happen concurrently.
+type keyedMutex struct {
+	lock  sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	sync.Mutex
+	// number of goroutines holding or waiting for this lock
+	users int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{
+		locks: map[string]*keyedMutexEntry{},
+	}
+}
+
+// Lock blocks until the lock for the given key is acquired and returns a function
+// to release it again.
+func (m *keyedMutex) Lock(key string) func() {
+	m.lock.Lock()
+	entry, exists := m.locks[key]
+	if !exists {
+		entry = &keyedMutexEntry{}
+		m.locks[key] = entry
+	}
+	entry.users++
+	m.lock.Unlock()
+
+	entry.Lock()
+
+	return func() {
+		entry.Unlock()
+
+		m.lock.Lock()
+		entry.users--
+		if entry.users == 0 {
+			delete(m.locks, key)
+		}
+		m.lock.Unlock()
+	}
+}
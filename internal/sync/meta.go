@@ -18,11 +18,12 @@ package sync
 
 import (
 	"context"
+	"maps"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 	"go.uber.org/zap"
 
-	"github.com/kcp-dev/api-syncagent/internal/crypto"
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -123,10 +124,6 @@ func (k objectKey) String() string {
 	return result
 }
 
-func (k objectKey) Key() string {
-	return crypto.Hash(k)
-}
-
 func (k objectKey) Labels() labels.Set {
 	// Name and namespace can be more than 63 characters long, so we must hash them
 	// to turn them into valid label values. The full, original value is kept as an annotation.
@@ -142,6 +139,15 @@ func (k objectKey) Labels() labels.Set {
 	return s
 }
 
+// DestinationLabels returns the labels that are placed on destination objects,
+// which in addition to the identifying labels includes the workspace path hash.
+func (k objectKey) DestinationLabels() labels.Set {
+	s := k.Labels()
+	maps.Copy(s, workspacePathLabels(k.WorkspacePath))
+
+	return s
+}
+
 func (k objectKey) Annotations() labels.Set {
 	s := labels.Set{
 		remoteObjectNameAnnotation: k.Name,
@@ -157,3 +163,13 @@ func (k objectKey) Annotations() labels.Set {
 
 	return s
 }
+
+// workspacePathLabels returns the label containing the hashed workspace path, if
+// the path is known.
+func workspacePathLabels(path logicalcluster.Path) labels.Set {
+	if path.Empty() {
+		return labels.Set{}
+	}
+
+	return labels.Set{remoteObjectWorkspacePathHashLabel: crypto.Hash(path.String())}
+}
@@ -21,8 +21,11 @@ import (
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func createNewObject(name, namespace string) metav1.Object {
@@ -78,3 +81,44 @@ func TestObjectKey(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectKeyDestinationLabels(t *testing.T) {
+	testcases := []struct {
+		name          string
+		workspacePath logicalcluster.Path
+		expected      labels.Set
+	}{
+		{
+			name:          "without workspace path",
+			workspacePath: logicalcluster.None,
+			expected: labels.Set{
+				remoteObjectClusterLabel:  "abc123",
+				remoteObjectNameHashLabel: crypto.Hash("test"),
+			},
+		},
+		{
+			name:          "with workspace path",
+			workspacePath: logicalcluster.NewPath("root:org:team"),
+			expected: labels.Set{
+				remoteObjectClusterLabel:           "abc123",
+				remoteObjectNameHashLabel:          crypto.Hash("test"),
+				remoteObjectWorkspacePathHashLabel: crypto.Hash("root:org:team"),
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			key := newObjectKey(createNewObject("test", ""), "abc123", testcase.workspacePath)
+
+			if destLabels := key.DestinationLabels(); !labels.Equals(destLabels, testcase.expected) {
+				t.Fatalf("Expected %v but got %v.", testcase.expected, destLabels)
+			}
+
+			// the workspace path must never be used to find objects
+			if _, exists := key.Labels()[remoteObjectWorkspacePathHashLabel]; exists {
+				t.Fatal("Identifying labels must not contain the workspace path.")
+			}
+		})
+	}
+}
@@ -21,6 +21,10 @@ import (
 	"maps"
 	"strings"
 
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -90,6 +94,8 @@ var unsyncableLabels = sets.New(
 	remoteObjectClusterLabel,
 	remoteObjectNamespaceHashLabel,
 	remoteObjectNameHashLabel,
+	remoteObjectWorkspacePathHashLabel,
+	syncagentv1alpha1.TargetClusterLabel,
 )
 
 // filterUnsyncableLabels removes all unwanted remote labels and returns a new label set.
@@ -113,6 +119,14 @@ var unsyncableAnnotations = sets.New(
 	remoteObjectNamespaceAnnotation,
 	remoteObjectNameAnnotation,
 	remoteObjectWorkspacePathAnnotation,
+	syncagentv1alpha1.PublishedResourceAnnotation,
+	syncagentv1alpha1.SourceGVKAnnotation,
+	syncagentv1alpha1.ProjectedGVKAnnotation,
+	syncagentv1alpha1.TargetNamespaceAnnotation,
+	syncagentv1alpha1.RelatedObjectsAnnotation,
+	syncagentv1alpha1.SyncErrorAnnotation,
+	syncagentv1alpha1.FailedRelatedResourcesAnnotation,
+	syncagentv1alpha1.PausedAnnotation,
 )
 
 // filterUnsyncableAnnotations removes all unwanted remote annotations and returns a new label set.
@@ -120,7 +134,7 @@ func filterUnsyncableAnnotations(original labels.Set) labels.Set {
 	filtered := filterLabels(original, unsyncableAnnotations)
 
 	maps.DeleteFunc(filtered, func(annotation string, _ string) bool {
-		return strings.HasPrefix(annotation, relatedObjectAnnotationPrefix)
+		return strings.HasPrefix(annotation, relatedObjectAnnotationPrefix) || strings.HasPrefix(annotation, syncagentv1alpha1.ProvenanceAnnotationPrefix)
 	})
 
 	return filtered
@@ -158,6 +172,18 @@ func RemoteNameForLocalObject(localObj ctrlruntimeclient.Object) *reconcile.Requ
 	}
 }
 
+// RemoteWorkspacePathForLocalObject returns the workspace path of the remote object
+// a local object originates from, if it was recorded by the Sync Agent.
+func RemoteWorkspacePathForLocalObject(localObj ctrlruntimeclient.Object) logicalcluster.Path {
+	return logicalcluster.NewPath(localObj.GetAnnotations()[remoteObjectWorkspacePathAnnotation])
+}
+
+// IsSynchronized returns true if the Sync Agent has started to synchronize the given
+// remote object, i.e. it has placed its cleanup finalizer on it.
+func IsSynchronized(remoteObj ctrlruntimeclient.Object) bool {
+	return hasFinalizer(remoteObj, deletionFinalizer)
+}
+
 // threeWayDiffMetadata is used when updating an object. Since the lastKnownState for any object
 // does not contain syncer-related metadata, this function determines whether labels/annotations are
 // missing by comparing the desired* sets with the current state on the destObj.
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"path"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type syncDirection int
+
+const (
+	// directionDown is used when syncing from kcp to the service cluster.
+	directionDown syncDirection = iota
+	// directionUp is used when syncing from the service cluster into kcp.
+	directionUp
+)
+
+// metadataPolicy restricts the labels and annotations that are synchronized in
+// one direction.
+type metadataPolicy struct {
+	labels      *syncagentv1alpha1.MetadataKeyFilter
+	annotations *syncagentv1alpha1.MetadataKeyFilter
+}
+
+// newMetadataPolicy returns nil if the policy does not restrict the given direction.
+func newMetadataPolicy(policy *syncagentv1alpha1.MetadataSyncPolicy, direction syncDirection) *metadataPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	result := &metadataPolicy{
+		labels:      directionFilter(policy.Labels, direction),
+		annotations: directionFilter(policy.Annotations, direction),
+	}
+
+	if result.labels == nil && result.annotations == nil {
+		return nil
+	}
+
+	return result
+}
+
+func directionFilter(filters *syncagentv1alpha1.MetadataDirectionFilters, direction syncDirection) *syncagentv1alpha1.MetadataKeyFilter {
+	if filters == nil {
+		return nil
+	}
+
+	if direction == directionUp {
+		return filters.Up
+	}
+
+	return filters.Down
+}
+
+// apply returns a copy of the object without the labels and annotations that must
+// not be synchronized. Sync-related metadata is kept, as it is never copied anyway
+// and might be required to identify the object.
+func (p *metadataPolicy) apply(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if p == nil {
+		return obj
+	}
+
+	obj = obj.DeepCopy()
+
+	if p.labels != nil {
+		syncable := filterUnsyncableLabels(obj.GetLabels())
+		obj.SetLabels(filterKeys(obj.GetLabels(), func(key string) bool {
+			_, ok := syncable[key]
+			return !ok || keyAllowed(p.labels, key)
+		}))
+	}
+
+	if p.annotations != nil {
+		syncable := filterUnsyncableAnnotations(obj.GetAnnotations())
+		obj.SetAnnotations(filterKeys(obj.GetAnnotations(), func(key string) bool {
+			_, ok := syncable[key]
+			return !ok || keyAllowed(p.annotations, key)
+		}))
+	}
+
+	return obj
+}
+
+func filterKeys(values map[string]string, keep func(key string) bool) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	result := map[string]string{}
+	for key, value := range values {
+		if keep(key) {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+func keyAllowed(filter *syncagentv1alpha1.MetadataKeyFilter, key string) bool {
+	if matchesAny(filter.Deny, key) {
+		return false
+	}
+
+	return len(filter.Allow) == 0 || matchesAny(filter.Allow, key)
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		// invalid patterns are rejected by the validation
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
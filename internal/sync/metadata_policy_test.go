@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMetadataPolicy(t *testing.T) {
+	newObject := func(labels, annotations map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetLabels(labels)
+		obj.SetAnnotations(annotations)
+
+		return obj
+	}
+
+	testcases := []struct {
+		name                string
+		policy              *syncagentv1alpha1.MetadataSyncPolicy
+		direction           syncDirection
+		labels              map[string]string
+		annotations         map[string]string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:                "no policy",
+			policy:              nil,
+			labels:              map[string]string{"a": "b"},
+			annotations:         map[string]string{"c": "d"},
+			expectedLabels:      map[string]string{"a": "b"},
+			expectedAnnotations: map[string]string{"c": "d"},
+		},
+		{
+			name: "policy for other direction",
+			policy: &syncagentv1alpha1.MetadataSyncPolicy{
+				Labels: &syncagentv1alpha1.MetadataDirectionFilters{
+					Up: &syncagentv1alpha1.MetadataKeyFilter{Deny: []string{"*"}},
+				},
+			},
+			direction:      directionDown,
+			labels:         map[string]string{"a": "b"},
+			expectedLabels: map[string]string{"a": "b"},
+		},
+		{
+			name: "allow list",
+			policy: &syncagentv1alpha1.MetadataSyncPolicy{
+				Labels: &syncagentv1alpha1.MetadataDirectionFilters{
+					Down: &syncagentv1alpha1.MetadataKeyFilter{Allow: []string{"example.com/*"}},
+				},
+			},
+			direction:           directionDown,
+			labels:              map[string]string{"example.com/team": "a", "other": "b"},
+			annotations:         map[string]string{"untouched": "c"},
+			expectedLabels:      map[string]string{"example.com/team": "a"},
+			expectedAnnotations: map[string]string{"untouched": "c"},
+		},
+		{
+			name: "deny takes precedence",
+			policy: &syncagentv1alpha1.MetadataSyncPolicy{
+				Annotations: &syncagentv1alpha1.MetadataDirectionFilters{
+					Up: &syncagentv1alpha1.MetadataKeyFilter{
+						Allow: []string{"example.com/*"},
+						Deny:  []string{"example.com/secret"},
+					},
+				},
+			},
+			direction:           directionUp,
+			annotations:         map[string]string{"example.com/team": "a", "example.com/secret": "b"},
+			expectedAnnotations: map[string]string{"example.com/team": "a"},
+		},
+		{
+			name: "sync-related metadata is kept",
+			policy: &syncagentv1alpha1.MetadataSyncPolicy{
+				Labels: &syncagentv1alpha1.MetadataDirectionFilters{
+					Down: &syncagentv1alpha1.MetadataKeyFilter{Deny: []string{"*"}},
+				},
+				Annotations: &syncagentv1alpha1.MetadataDirectionFilters{
+					Down: &syncagentv1alpha1.MetadataKeyFilter{Deny: []string{"*"}},
+				},
+			},
+			direction:           directionDown,
+			labels:              map[string]string{remoteObjectClusterLabel: "abc", "foo": "bar"},
+			annotations:         map[string]string{"kcp.io/cluster": "abc", "foo": "bar"},
+			expectedLabels:      map[string]string{remoteObjectClusterLabel: "abc"},
+			expectedAnnotations: map[string]string{"kcp.io/cluster": "abc"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			original := newObject(testcase.labels, testcase.annotations)
+			originalCopy := original.DeepCopy()
+
+			filtered := newMetadataPolicy(testcase.policy, testcase.direction).apply(original)
+
+			if !equality.Semantic.DeepEqual(original, originalCopy) {
+				t.Fatal("Original object was modified.")
+			}
+
+			if labels := filtered.GetLabels(); !equality.Semantic.DeepEqual(labels, testcase.expectedLabels) {
+				t.Errorf("Expected labels %v, but got %v.", testcase.expectedLabels, labels)
+			}
+
+			if annotations := filtered.GetAnnotations(); !equality.Semantic.DeepEqual(annotations, testcase.expectedAnnotations) {
+				t.Errorf("Expected annotations %v, but got %v.", testcase.expectedAnnotations, annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/api-syncagent/internal/projection"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/identity"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ProjectionLeftover is a local object whose object in kcp was synced using a
+// previously projected GVK.
+type ProjectionLeftover struct {
+	// LocalObject is the object on the service cluster.
+	LocalObject *unstructured.Unstructured
+	// Remote identifies the object in kcp.
+	Remote reconcile.Request
+}
+
+// FindProjectionLeftovers returns all local objects of the PublishedResource whose
+// objects in kcp still use the given previously projected GVK. Once the projection
+// has changed, kcp does not serve the previous GVK anymore, so the leftovers can only
+// be found using the identity annotations on the local objects.
+func FindProjectionLeftovers(ctx context.Context, localClient ctrlruntimeclient.Client, pubRes *syncagentv1alpha1.PublishedResource, previous schema.GroupVersionKind, agentName string) ([]ProjectionLeftover, error) {
+	localGVK := projection.PublishedResourceSourceGVK(pubRes)
+
+	objects := &unstructured.UnstructuredList{}
+	objects.SetGroupVersionKind(localGVK.GroupVersion().WithKind(localGVK.Kind + "List"))
+
+	if err := localClient.List(ctx, objects, ctrlruntimeclient.MatchingLabelsSelector{Selector: OwnedObjectsSelector(agentName)}); err != nil {
+		return nil, fmt.Errorf("failed to list local objects: %w", err)
+	}
+
+	leftovers := []ProjectionLeftover{}
+	for i := range objects.Items {
+		localObj := &objects.Items[i]
+
+		objIdentity, err := identity.FromAnnotations(localObj.GetAnnotations())
+		if err != nil || objIdentity == nil {
+			continue
+		}
+
+		if objIdentity.PublishedResource != pubRes.Name || objIdentity.Projected != previous {
+			continue
+		}
+
+		remote := RemoteNameForLocalObject(localObj)
+		if remote == nil {
+			continue
+		}
+
+		leftovers = append(leftovers, ProjectionLeftover{
+			LocalObject: localObj,
+			Remote:      *remote,
+		})
+	}
+
+	return leftovers, nil
+}
+
+// MigrateProjectionLeftover recreates the object in kcp of a leftover using the
+// current projected GVK, based on the local object. The states of the old object
+// are moved over to the new object and the local object is re-keyed, so that the
+// sync controller adopts both. If kcp still serves the previous GVK, the old object
+// is deleted. The context must point to the workspace the object lives in.
+func MigrateProjectionLeftover(ctx context.Context, localClient, remoteClient ctrlruntimeclient.Client, pubRes *syncagentv1alpha1.PublishedResource, leftover ProjectionLeftover, previous, current schema.GroupVersionKind, stateOptions StateOptions) error {
+	clusterName := logicalcluster.Name(leftover.Remote.ClusterName)
+
+	migrated, err := NewImportedObject(leftover.LocalObject, current, leftover.Remote.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to create object from local object: %w", err)
+	}
+	migrated.SetName(leftover.Remote.Name)
+
+	oldObj := &unstructured.Unstructured{}
+	oldObj.SetGroupVersionKind(previous)
+	oldObj.SetNamespace(leftover.Remote.Namespace)
+	oldObj.SetName(leftover.Remote.Name)
+
+	// move the states first, so that the sync controller finds them once the new object exists
+	opts := stateOptions.ForPublishedResource(pubRes)
+	stateCluster := syncSide{ctx: ctx, client: localClient}
+
+	oldStates := opts.backendFor(syncSide{clusterName: clusterName, object: oldObj}, stateCluster)
+	newStates := opts.backendFor(syncSide{clusterName: clusterName, object: migrated}, stateCluster)
+
+	if err := moveStates(ctx, oldStates, newStates); err != nil {
+		return fmt.Errorf("failed to move object states: %w", err)
+	}
+
+	if err := remoteClient.Create(ctx, migrated); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create migrated object: %w", err)
+	}
+
+	if err := deleteProjectionLeftover(ctx, remoteClient, oldObj); err != nil {
+		return err
+	}
+
+	// finally re-key the local object, so that it does not count as a leftover anymore
+	localObj := leftover.LocalObject.DeepCopy()
+	objIdentity := projection.PublishedResourceIdentity(pubRes)
+	objIdentity.Projected = current
+	ensureAnnotations(localObj, objIdentity.Annotations())
+
+	if err := localClient.Patch(ctx, localObj, ctrlruntimeclient.MergeFrom(leftover.LocalObject)); err != nil {
+		return fmt.Errorf("failed to update local object: %w", err)
+	}
+
+	return nil
+}
+
+// moveStates moves all states from one state object to another.
+func moveStates(ctx context.Context, from, to state.Backend) error {
+	keys, err := from.Keys(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		data, err := from.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if data != nil {
+			if err := to.Put(ctx, key, data); err != nil {
+				return err
+			}
+		}
+
+		if err := from.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteProjectionLeftover deletes the object in kcp that uses the previous GVK, if
+// kcp still serves it. The object is not handled by any sync controller anymore, so
+// our finalizer has to be removed manually.
+func deleteProjectionLeftover(ctx context.Context, client ctrlruntimeclient.Client, obj *unstructured.Unstructured) error {
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(obj), obj); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get old object: %w", err)
+	}
+
+	if finalizers := obj.GetFinalizers(); slices.Contains(finalizers, deletionFinalizer) {
+		oldObj := obj.DeepCopy()
+		obj.SetFinalizers(slices.DeleteFunc(finalizers, func(f string) bool {
+			return f == deletionFinalizer
+		}))
+
+		if err := client.Patch(ctx, obj, ctrlruntimeclient.MergeFrom(oldObj)); err != nil {
+			return fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+	}
+
+	if err := client.Delete(ctx, obj); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete old object: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/identity"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	previousProjection = schema.GroupVersionKind{Group: "remote.example.corp", Version: "v1alpha1", Kind: "OldThing"}
+	currentProjection  = schema.GroupVersionKind{Group: "remote.example.corp", Version: "v1alpha1", Kind: "NewThing"}
+)
+
+func newProjectionTestPublishedResource() *syncagentv1alpha1.PublishedResource {
+	return &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "publish-things",
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: dummyv1alpha1.GroupName,
+				Version:  "v1alpha1",
+				Kind:     "Thing",
+			},
+		},
+	}
+}
+
+// newSyncedLocalThing returns a local object as the Sync Agent would have created
+// it for a remote object that was projected using the given GVK.
+func newSyncedLocalThing(name string, pubResName string, projected schema.GroupVersionKind, agentName string) *unstructured.Unstructured {
+	localObj := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "synced-default",
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: "Colonel Mustard",
+		},
+	})
+
+	remoteObj := &unstructured.Unstructured{}
+	remoteObj.SetNamespace("default")
+	remoteObj.SetName(name)
+
+	LinkLocalObject(localObj, remoteObj, logicalcluster.Name("testcluster"), logicalcluster.None, agentName)
+	ensureAnnotations(localObj, identity.Identity{
+		PublishedResource: pubResName,
+		Source:            localObj.GroupVersionKind(),
+		Projected:         projected,
+	}.Annotations())
+
+	return localObj
+}
+
+func TestFindProjectionLeftovers(t *testing.T) {
+	pubRes := newProjectionTestPublishedResource()
+
+	testcases := []struct {
+		name     string
+		localObj *unstructured.Unstructured
+		expected bool
+	}{
+		{
+			name:     "object synced with the previous projection is a leftover",
+			localObj: newSyncedLocalThing("my-thing", pubRes.Name, previousProjection, "textor-the-doctor"),
+			expected: true,
+		},
+		{
+			name:     "object synced with the current projection is not a leftover",
+			localObj: newSyncedLocalThing("my-thing", pubRes.Name, currentProjection, "textor-the-doctor"),
+		},
+		{
+			name:     "object of another PublishedResource is ignored",
+			localObj: newSyncedLocalThing("my-thing", "other-things", previousProjection, "textor-the-doctor"),
+		},
+		{
+			name:     "object of another Sync Agent is ignored",
+			localObj: newSyncedLocalThing("my-thing", pubRes.Name, previousProjection, "other-agent"),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			localClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(testcase.localObj).Build()
+
+			leftovers, err := FindProjectionLeftovers(context.Background(), localClient, pubRes, previousProjection, "textor-the-doctor")
+			if err != nil {
+				t.Fatalf("Failed to find leftovers: %v", err)
+			}
+
+			if !testcase.expected {
+				if len(leftovers) > 0 {
+					t.Fatalf("Expected no leftovers, but got %d.", len(leftovers))
+				}
+
+				return
+			}
+
+			if len(leftovers) != 1 {
+				t.Fatalf("Expected exactly 1 leftover, but got %d.", len(leftovers))
+			}
+
+			remote := leftovers[0].Remote
+			if remote.ClusterName != "testcluster" || remote.Namespace != "default" || remote.Name != "my-thing" {
+				t.Fatalf("Leftover points to the wrong remote object: %+v", remote)
+			}
+		})
+	}
+}
+
+func TestMigrateProjectionLeftover(t *testing.T) {
+	testcases := []struct {
+		name string
+		// previousServed simulates kcp still serving the previous GVK
+		previousServed bool
+	}{
+		{
+			name: "previous GVK is not served anymore",
+		},
+		{
+			name:           "previous GVK is still served",
+			previousServed: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := newProjectionTestPublishedResource()
+			clusterName := logicalcluster.Name("testcluster")
+			stateOptions := StateOptions{Namespace: "kcp-system"}
+			ctx := context.Background()
+
+			localObj := newSyncedLocalThing("my-thing", pubRes.Name, previousProjection, "textor-the-doctor")
+
+			oldObj := &unstructured.Unstructured{}
+			oldObj.SetGroupVersionKind(previousProjection)
+			oldObj.SetNamespace("default")
+			oldObj.SetName("my-thing")
+			oldObj.SetFinalizers([]string{deletionFinalizer})
+
+			localClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(testScheme).WithObjects(localObj).Build()
+
+			remoteBuilder := fakectrlruntimeclient.NewClientBuilder()
+			if testcase.previousServed {
+				remoteBuilder.WithObjects(oldObj.DeepCopy())
+			}
+			remoteClient := remoteBuilder.Build()
+
+			// remember a state for the old object, as the sync controller would have
+			oldStateName := types.NamespacedName{Namespace: stateOptions.Namespace, Name: state.ObjectName(clusterName, oldObj)}
+			stateKey := state.Key(clusterName, oldObj)
+
+			if err := state.NewSecretBackend(localClient, oldStateName, nil, false).Put(ctx, stateKey, []byte(`{"spec":{}}`)); err != nil {
+				t.Fatalf("Failed to store state: %v", err)
+			}
+
+			leftovers, err := FindProjectionLeftovers(ctx, localClient, pubRes, previousProjection, "textor-the-doctor")
+			if err != nil {
+				t.Fatalf("Failed to find leftovers: %v", err)
+			}
+
+			if len(leftovers) != 1 {
+				t.Fatalf("Expected exactly 1 leftover, but got %d.", len(leftovers))
+			}
+
+			if err := MigrateProjectionLeftover(ctx, localClient, remoteClient, pubRes, leftovers[0], previousProjection, currentProjection, stateOptions); err != nil {
+				t.Fatalf("Failed to migrate leftover: %v", err)
+			}
+
+			// the object in kcp has been recreated using the new GVK
+			migrated := &unstructured.Unstructured{}
+			migrated.SetGroupVersionKind(currentProjection)
+
+			if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "my-thing"}, migrated); err != nil {
+				t.Fatalf("Failed to get migrated object: %v", err)
+			}
+
+			if username, _, _ := unstructured.NestedString(migrated.Object, "spec", "username"); username != "Colonel Mustard" {
+				t.Errorf("Expected spec to be preserved, but got username %q.", username)
+			}
+
+			if migrated.GetLabels()["foo"] != "bar" {
+				t.Errorf("Expected labels to be preserved, but got %v.", migrated.GetLabels())
+			}
+
+			if OwnedBy(migrated, "textor-the-doctor") {
+				t.Error("Expected migrated object to not carry the agent name label.")
+			}
+
+			// the old object is gone, if it was still served
+			if err := remoteClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(oldObj), oldObj.DeepCopy()); !apierrors.IsNotFound(err) {
+				t.Errorf("Expected old object to be gone, but got: %v", err)
+			}
+
+			// the states have been moved to the new object
+			if err := localClient.Get(ctx, oldStateName, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+				t.Errorf("Expected old state Secret to be gone, but got: %v", err)
+			}
+
+			newStateName := types.NamespacedName{Namespace: stateOptions.Namespace, Name: state.ObjectName(clusterName, migrated)}
+			data, err := state.NewSecretBackend(localClient, newStateName, nil, false).Get(ctx, stateKey)
+			if err != nil {
+				t.Fatalf("Failed to get moved state: %v", err)
+			}
+
+			if string(data) != `{"spec":{}}` {
+				t.Errorf("Expected state to be moved, but got %q.", string(data))
+			}
+
+			// the local object has been re-keyed and is not a leftover anymore
+			leftovers, err = FindProjectionLeftovers(ctx, localClient, pubRes, previousProjection, "textor-the-doctor")
+			if err != nil {
+				t.Fatalf("Failed to find leftovers: %v", err)
+			}
+
+			if len(leftovers) > 0 {
+				t.Errorf("Expected local object to be re-keyed, but it is still a leftover.")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"maps"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// namespaceMetadata describes the labels and annotations that the syncer manages on
+// the namespace of the destination object.
+type namespaceMetadata struct {
+	// the selected label/annotation names; names that have no value below are removed
+	labelNames      []string
+	annotationNames []string
+
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// newNamespaceMetadata returns the metadata of the source namespace that should be
+// synced onto the destination namespace, or nil if nothing is configured.
+func newNamespaceMetadata(settings *syncagentv1alpha1.NamespaceSync, sourceNamespace *corev1.Namespace) *namespaceMetadata {
+	if settings == nil || sourceNamespace == nil {
+		return nil
+	}
+
+	return &namespaceMetadata{
+		labelNames:      settings.Labels,
+		annotationNames: settings.Annotations,
+		labels:          sourceNamespace.Labels,
+		annotations:     sourceNamespace.Annotations,
+	}
+}
+
+// apply updates the labels and annotations on the given namespace and returns true
+// if anything has changed.
+func (m *namespaceMetadata) apply(ns *corev1.Namespace) bool {
+	if m == nil {
+		return false
+	}
+
+	labels, labelsChanged := syncSelectedKeys(ns.Labels, m.labels, m.labelNames)
+	annotations, annotationsChanged := syncSelectedKeys(ns.Annotations, m.annotations, m.annotationNames)
+
+	ns.Labels = labels
+	ns.Annotations = annotations
+
+	return labelsChanged || annotationsChanged
+}
+
+func syncSelectedKeys(current, desired map[string]string, keys []string) (map[string]string, bool) {
+	changed := false
+
+	for _, key := range keys {
+		currentValue, exists := current[key]
+		desiredValue, wanted := desired[key]
+
+		switch {
+		case wanted && (!exists || currentValue != desiredValue):
+			if current == nil {
+				current = map[string]string{}
+			}
+			current[key] = desiredValue
+			changed = true
+
+		case !wanted && exists:
+			delete(current, key)
+			changed = true
+		}
+	}
+
+	return current, changed
+}
+
+// labelWithWorkspace marks a namespace that is created on the service cluster with
+// the kcp workspace it is created for. Namespaces in kcp are left alone.
+func (s *objectSyncer) labelWithWorkspace(ns *corev1.Namespace, source, dest syncSide) {
+	if source.clusterName == "" || dest.clusterName != "" {
+		return
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+
+	ns.Labels[remoteObjectClusterLabel] = source.clusterName.String()
+	if s.agentName != "" {
+		ns.Labels[agentNameLabel] = s.agentName
+	}
+
+	if !source.workspacePath.Empty() {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+
+		ns.Annotations[remoteObjectWorkspacePathAnnotation] = source.workspacePath.String()
+		maps.Copy(ns.Labels, workspacePathLabels(source.workspacePath))
+	}
+
+	// remember the namespace in kcp, so the namespace can be cleaned up once it is gone
+	if source.object != nil && source.object.GetNamespace() != "" {
+		if ns.Annotations == nil {
+			ns.Annotations = map[string]string{}
+		}
+
+		ns.Annotations[remoteObjectNamespaceAnnotation] = source.object.GetNamespace()
+	}
+}
+
+// CreatedNamespacesSelector returns a label selector for all namespaces that the
+// given Sync Agent has created for objects from kcp.
+func CreatedNamespacesSelector(agentName string) labels.Selector {
+	agentReq, _ := labels.NewRequirement(agentNameLabel, selection.Equals, []string{agentName})
+	clusterReq, _ := labels.NewRequirement(remoteObjectClusterLabel, selection.Exists, nil)
+
+	return labels.NewSelector().Add(*agentReq, *clusterReq)
+}
+
+// NamespaceWorkspace returns the kcp workspace that a namespace on the service cluster
+// was created for. The cluster name is empty if the namespace was not created by the
+// Sync Agent, the path is empty if it was not known at the time.
+func NamespaceWorkspace(ns *corev1.Namespace) (logicalcluster.Name, logicalcluster.Path) {
+	clusterName := logicalcluster.Name(ns.Labels[remoteObjectClusterLabel])
+	path := logicalcluster.NewPath(ns.Annotations[remoteObjectWorkspacePathAnnotation])
+
+	return clusterName, path
+}
+
+// NamespaceSource returns the namespace in kcp that a namespace on the service cluster
+// was created for. It is empty if the namespace was not created by the Sync Agent or
+// if it was created by an older version of the Sync Agent.
+func NamespaceSource(ns *corev1.Namespace) string {
+	return ns.Annotations[remoteObjectNamespaceAnnotation]
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestNamespaceMetadata(t *testing.T) {
+	settings := &syncagentv1alpha1.NamespaceSync{
+		Labels:      []string{"team", "tier"},
+		Annotations: []string{"example.corp/owner"},
+	}
+
+	testcases := []struct {
+		name            string
+		sourceNamespace *corev1.Namespace
+		destNamespace   *corev1.Namespace
+		expected        *corev1.Namespace
+		expectedChanged bool
+	}{
+		{
+			name: "copy onto naked namespace",
+			sourceNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a", "unrelated": "x"},
+				Annotations: map[string]string{"example.corp/owner": "alice"},
+			}},
+			destNamespace: &corev1.Namespace{},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"team": "a"},
+				Annotations: map[string]string{"example.corp/owner": "alice"},
+			}},
+			expectedChanged: true,
+		},
+		{
+			name: "update changed values and keep unrelated metadata",
+			sourceNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "b"},
+			}},
+			destNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "a", "local": "yes"},
+			}},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "b", "local": "yes"},
+			}},
+			expectedChanged: true,
+		},
+		{
+			name:            "remove metadata that is gone in kcp",
+			sourceNamespace: &corev1.Namespace{},
+			destNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"tier": "gold", "local": "yes"},
+				Annotations: map[string]string{"example.corp/owner": "alice"},
+			}},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"local": "yes"},
+				Annotations: map[string]string{},
+			}},
+			expectedChanged: true,
+		},
+		{
+			name: "nothing to do",
+			sourceNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "a"},
+			}},
+			destNamespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "a"},
+			}},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"team": "a"},
+			}},
+			expectedChanged: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			metadata := newNamespaceMetadata(settings, testcase.sourceNamespace)
+
+			changed := metadata.apply(testcase.destNamespace)
+			if changed != testcase.expectedChanged {
+				t.Errorf("Expected changed=%v, but got %v.", testcase.expectedChanged, changed)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, testcase.destNamespace); changes != "" {
+				t.Errorf("Did not get expected namespace:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestLabelWithWorkspace(t *testing.T) {
+	syncer := &objectSyncer{agentName: "my-agent"}
+
+	testcases := []struct {
+		name          string
+		source        syncSide
+		dest          syncSide
+		expected      *corev1.Namespace
+		expectCreated bool
+	}{
+		{
+			name:   "namespace on the service cluster",
+			source: syncSide{clusterName: "12345"},
+			dest:   syncSide{},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Labels: map[string]string{
+					remoteObjectClusterLabel: "12345",
+					agentNameLabel:           "my-agent",
+				},
+			}},
+			expectCreated: true,
+		},
+		{
+			name:   "namespace on the service cluster with known workspace path",
+			source: syncSide{clusterName: "12345", workspacePath: logicalcluster.NewPath("root:org:team")},
+			dest:   syncSide{},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Labels: map[string]string{
+					remoteObjectClusterLabel:           "12345",
+					agentNameLabel:                     "my-agent",
+					remoteObjectWorkspacePathHashLabel: crypto.Hash("root:org:team"),
+				},
+				Annotations: map[string]string{
+					remoteObjectWorkspacePathAnnotation: "root:org:team",
+				},
+			}},
+			expectCreated: true,
+		},
+		{
+			name:   "namespace on the service cluster with known source namespace",
+			source: syncSide{clusterName: "12345", object: newUnstructured(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}})},
+			dest:   syncSide{},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+				Labels: map[string]string{
+					remoteObjectClusterLabel: "12345",
+					agentNameLabel:           "my-agent",
+				},
+				Annotations: map[string]string{
+					remoteObjectNamespaceAnnotation: "default",
+				},
+			}},
+			expectCreated: true,
+		},
+		{
+			name:   "namespace in kcp",
+			source: syncSide{},
+			dest:   syncSide{clusterName: "12345"},
+			expected: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			}},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+			syncer.labelWithWorkspace(ns, testcase.source, testcase.dest)
+
+			if changes := diff.ObjectDiff(testcase.expected, ns); changes != "" {
+				t.Fatalf("Did not get expected namespace:\n%s", changes)
+			}
+
+			created := CreatedNamespacesSelector("my-agent").Matches(labels.Set(ns.Labels))
+			if created != testcase.expectCreated {
+				t.Errorf("Expected selector to match=%v, but got %v.", testcase.expectCreated, created)
+			}
+
+			clusterName, path := NamespaceWorkspace(ns)
+			if clusterName != testcase.source.clusterName || path != testcase.source.workspacePath {
+				t.Errorf("Expected workspace %q (%q), but got %q (%q).", testcase.source.clusterName, testcase.source.workspacePath, clusterName, path)
+			}
+		})
+	}
+}
@@ -19,7 +19,10 @@ package sync
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -27,16 +30,25 @@ import (
 	"k8c.io/reconciler/pkg/equality"
 
 	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	"github.com/kcp-dev/api-syncagent/internal/readiness"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type objectCreatorFunc func(source *unstructured.Unstructured) *unstructured.Unstructured
 
+// statusDecoratorFunc can modify the status that is synced back from the destination
+// to the source object.
+type statusDecoratorFunc func(status any, source, dest *unstructured.Unstructured) (any, error)
+
 type objectSyncer struct {
 	// When set, the syncer will create a label on the destination object that contains
 	// this value; used to allow multiple agents syncing *the same* API from one
@@ -47,8 +59,17 @@ type objectSyncer struct {
 	destCreator objectCreatorFunc
 	// list of subresources in the resource type
 	subresources []string
+	// if set, replicas are synced using the scale subresource
+	scale *apiextensionsv1.CustomResourceSubresourceScale
 	// whether to enable status subresource back-syncing
 	syncStatusBack bool
+	// whether to copy the status subresource from the source to the destination
+	// object (mutually exclusive with syncStatusBack)
+	syncStatusForward bool
+	// optionally restricts the status fields that are synced back
+	statusFields []string
+	// optionally modifies the status before it is synced back
+	decorateStatus statusDecoratorFunc
 	// whether or not to add/expect a finalizer on the source
 	blockSourceDeletion bool
 	// whether or not to place sync-related metadata on the destination object
@@ -57,6 +78,33 @@ type objectSyncer struct {
 	mutator mutation.Mutator
 	// stateStore is capable of remembering the state of a Kubernetes object
 	stateStore ObjectStateStore
+	// when set, objects are updated using server-side apply with this field manager
+	// instead of merge patches computed from the stateStore
+	fieldManager string
+	// dot-separated paths to fields on the source object that must not change
+	// once the destination object exists
+	immutableFields []string
+	// dot-separated paths to fields whose changes on the destination object are
+	// copied back to the source object
+	bidirectionalFields []string
+	// optionally restricts the labels and annotations copied to the destination object
+	metadataPolicy *metadataPolicy
+	// paths to fields that are never overwritten on the destination object
+	ignoredFields []string
+	// optionally records Events about the destination object on the source object;
+	// only used for primary objects originating in kcp
+	sourceEvents *remoteEventRecorder
+	// additional labels to place on the destination object, e.g. labels
+	// propagated from the source object's namespace
+	extraLabels map[string]string
+	// additional sync-related annotations to place on the destination object
+	// (only if metadataOnDestination is enabled)
+	extraAnnotations map[string]string
+	// optional labels and annotations to keep in sync on the destination object's namespace
+	namespaceMetadata *namespaceMetadata
+	// when set, the destination object is annotated with the current time whenever
+	// it is created or changed
+	syncClock func() time.Time
 }
 
 type syncSide struct {
@@ -67,6 +115,22 @@ type syncSide struct {
 	object        *unstructured.Unstructured
 }
 
+// DestinationWriteError wraps errors returned by the API server when creating or
+// updating a destination object, for example because the object failed validation
+// or was rejected by an admission webhook. Errors about other objects (like the
+// state objects) are never wrapped.
+type DestinationWriteError struct {
+	Err error
+}
+
+func (e *DestinationWriteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DestinationWriteError) Unwrap() error {
+	return e.Err
+}
+
 func (s *objectSyncer) Sync(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
 	// handle deletion: if source object is in deletion, delete the destination object (the clone)
 	if source.object.GetDeletionTimestamp() != nil {
@@ -86,6 +150,40 @@ func (s *objectSyncer) Sync(log *zap.SugaredLogger, source, dest syncSide) (requ
 		}
 	}
 
+	// revert changes to immutable fields before they are propagated to the destination object
+	if len(s.immutableFields) > 0 && dest.object != nil {
+		reverted, err := s.revertImmutableFields(log, source)
+		if err != nil {
+			return false, fmt.Errorf("failed to enforce immutable fields: %w", err)
+		}
+
+		// the patch above would trigger a new reconciliation anyway
+		if reverted {
+			return true, nil
+		}
+	}
+
+	// copy changes to bidirectional fields back before they would be reverted
+	if len(s.bidirectionalFields) > 0 && dest.object != nil {
+		updated, err := s.syncBidirectionalFields(log, source, dest)
+		if err != nil {
+			return false, fmt.Errorf("failed to sync bidirectional fields: %w", err)
+		}
+
+		// the patch above would trigger a new reconciliation anyway
+		if updated {
+			return true, nil
+		}
+	}
+
+	// remember the accepted source object in its original form, so that the fields above
+	// can be compared to it without having to undo mutations
+	if len(s.immutableFields) > 0 || len(s.bidirectionalFields) > 0 {
+		if err := s.stateStore.PutOriginal(source.object, source.clusterName, s.subresources); err != nil {
+			return false, fmt.Errorf("failed to update original sync state: %w", err)
+		}
+	}
+
 	// Apply custom mutation rules; transform the source object into its mutated form, which
 	// then serves as the basis for the object content synchronization. Then transform the
 	// destination object's status.
@@ -94,6 +192,9 @@ func (s *objectSyncer) Sync(log *zap.SugaredLogger, source, dest syncSide) (requ
 		return false, fmt.Errorf("failed to apply mutations: %w", err)
 	}
 
+	// remove labels and annotations that must not be synchronized
+	source.object = s.metadataPolicy.apply(source.object)
+
 	// if no destination object exists yet, attempt to create it;
 	// note that the object _might_ exist, but we were not able to find it because of broken labels
 	if dest.object == nil {
@@ -116,11 +217,27 @@ func (s *objectSyncer) Sync(log *zap.SugaredLogger, source, dest syncSide) (requ
 		return false, nil
 	}
 
+	// keep the destination namespace's metadata up-to-date
+	if s.namespaceMetadata != nil {
+		if err := s.ensureNamespace(log, source, dest, dest.object.GetNamespace()); err != nil {
+			return false, fmt.Errorf("failed to update destination namespace: %w", err)
+		}
+	}
+
 	requeue, err = s.syncObjectContents(log, source, dest)
 	if err != nil {
 		return false, fmt.Errorf("failed to synchronize object state: %w", err)
 	}
 
+	if s.scale != nil {
+		scaled, err := s.syncReplicas(log, source, dest)
+		if err != nil {
+			return false, err
+		}
+
+		requeue = requeue || scaled
+	}
+
 	return requeue, nil
 }
 
@@ -137,7 +254,19 @@ func (s *objectSyncer) applyMutations(source, dest syncSide) (syncSide, syncSide
 		destObject = s.destCreator(source.object)
 	}
 
-	sourceObj, err := s.mutator.MutateSpec(source.object.DeepCopy(), destObject)
+	// Mutations always have access to the kcp workspace, regardless of the direction
+	// in which objects are synced; the local side never has a cluster name.
+	workspace := mutation.Workspace{
+		ClusterName: source.clusterName,
+		Path:        source.workspacePath,
+	}
+
+	if workspace.ClusterName == "" {
+		workspace.ClusterName = dest.clusterName
+		workspace.Path = dest.workspacePath
+	}
+
+	sourceObj, err := s.mutator.MutateSpec(source.object.DeepCopy(), destObject, workspace)
 	if err != nil {
 		return source, dest, fmt.Errorf("failed to apply spec mutation rules: %w", err)
 	}
@@ -149,7 +278,7 @@ func (s *objectSyncer) applyMutations(source, dest syncSide) (syncSide, syncSide
 	// (this is mostly only relevant for the primary object sync, which goes
 	// kcp->service cluster; related resources do not backsync the status subresource).
 	if dest.object != nil {
-		destObject, err = s.mutator.MutateStatus(dest.object.DeepCopy(), sourceObj)
+		destObject, err = s.mutator.MutateStatus(dest.object.DeepCopy(), sourceObj, workspace)
 		if err != nil {
 			return source, dest, fmt.Errorf("failed to apply status mutation rules: %w", err)
 		}
@@ -160,6 +289,207 @@ func (s *objectSyncer) applyMutations(source, dest syncSide) (syncSide, syncSide
 	return source, dest, nil
 }
 
+// revertImmutableFields compares the immutable fields of the source object with its
+// last known original (i.e. unmutated) state and resets any changed field to its
+// previous value. Returns true if the source object has been patched.
+func (s *objectSyncer) revertImmutableFields(log *zap.SugaredLogger, source syncSide) (bool, error) {
+	originalSourceState, err := s.stateStore.GetOriginal(source)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine last known original state: %w", err)
+	}
+
+	// without a known state, there is nothing to compare against
+	if originalSourceState == nil {
+		return false, nil
+	}
+
+	reverted := source.object.DeepCopy()
+	changedFields := []string{}
+
+	for _, path := range s.immutableFields {
+		fields := strings.Split(path, ".")
+
+		previous, previousFound, err := unstructured.NestedFieldNoCopy(originalSourceState.Object, fields...)
+		if err != nil {
+			return false, fmt.Errorf("failed to get %s from last known original state: %w", path, err)
+		}
+
+		current, currentFound, err := unstructured.NestedFieldNoCopy(source.object.Object, fields...)
+		if err != nil {
+			return false, fmt.Errorf("failed to get %s from source object: %w", path, err)
+		}
+
+		if previousFound == currentFound && equality.Semantic.DeepEqual(previous, current) {
+			continue
+		}
+
+		if previousFound {
+			err = unstructured.SetNestedField(reverted.Object, runtime.DeepCopyJSONValue(previous), fields...)
+		} else {
+			unstructured.RemoveNestedField(reverted.Object, fields...)
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("failed to revert %s: %w", path, err)
+		}
+
+		changedFields = append(changedFields, path)
+	}
+
+	if len(changedFields) == 0 {
+		return false, nil
+	}
+
+	log.Infow("Reverting changes to immutable fields…", "fields", changedFields)
+
+	if err := source.client.Patch(source.ctx, reverted, ctrlruntimeclient.MergeFrom(source.object)); err != nil {
+		return false, fmt.Errorf("failed to patch source object: %w", err)
+	}
+
+	message := fmt.Sprintf("Reverted changes to immutable fields: %s", strings.Join(changedFields, ", "))
+
+	// the revert itself has succeeded, failing to inform about it is not fatal
+	if err := s.setImmutableFieldsCondition(source, reverted, message); err != nil {
+		log.Warnw("Failed to set condition about reverted immutable fields", zap.Error(err))
+	}
+
+	s.sourceEvents.Event(source.ctx, source.object, corev1.EventTypeWarning, reasonImmutableFieldChanged, message)
+
+	return true, nil
+}
+
+// setImmutableFieldsCondition informs consumers about reverted changes using a
+// condition on the source object. Objects without status subresource are left alone.
+func (s *objectSyncer) setImmutableFieldsCondition(source syncSide, obj *unstructured.Unstructured, message string) error {
+	if !slices.Contains(s.subresources, "status") {
+		return nil
+	}
+
+	currentStatus := obj.Object["status"]
+
+	status, err := readiness.SetConditionWithReason(currentStatus, currentStatus, syncagentv1alpha1.ImmutableFieldsRevertedCondition, true, "Reverted", message)
+	if err != nil {
+		return fmt.Errorf("failed to set condition: %w", err)
+	}
+
+	updated := obj.DeepCopy()
+	updated.Object["status"] = status
+
+	if err := source.client.Status().Update(source.ctx, updated); err != nil {
+		return fmt.Errorf("failed to update source object status: %w", err)
+	}
+
+	return nil
+}
+
+// syncBidirectionalFields copies changes to the bidirectional fields on the destination
+// object back into the source object and updates the last known states accordingly, so
+// that the next sync does not revert them. Returns true if the source object has been
+// patched.
+func (s *objectSyncer) syncBidirectionalFields(log *zap.SugaredLogger, source, dest syncSide) (bool, error) {
+	lastKnownSourceState, err := s.stateStore.Get(source)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine last known state: %w", err)
+	}
+
+	originalSourceState, err := s.stateStore.GetOriginal(source)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine last known original state: %w", err)
+	}
+
+	// without known states, it is impossible to tell which side has changed a field
+	if lastKnownSourceState == nil || originalSourceState == nil {
+		return false, nil
+	}
+
+	updated := source.object.DeepCopy()
+
+	changedFields, err := mergeBidirectionalFields(updated, dest.object, lastKnownSourceState, originalSourceState, s.bidirectionalFields)
+	if err != nil {
+		return false, err
+	}
+
+	if len(changedFields) == 0 {
+		return false, nil
+	}
+
+	log.Infow("Copying changed bidirectional fields back…", "fields", changedFields)
+
+	if err := source.client.Patch(source.ctx, updated, ctrlruntimeclient.MergeFrom(source.object)); err != nil {
+		return false, fmt.Errorf("failed to patch source object: %w", err)
+	}
+
+	if err := s.stateStore.Put(lastKnownSourceState, source.clusterName, s.subresources); err != nil {
+		return true, fmt.Errorf("failed to update sync state: %w", err)
+	}
+
+	if err := s.stateStore.PutOriginal(originalSourceState, source.clusterName, s.subresources); err != nil {
+		return true, fmt.Errorf("failed to update original sync state: %w", err)
+	}
+
+	return true, nil
+}
+
+// mergeBidirectionalFields copies all bidirectional fields that have only changed on
+// the destination object into the source object and both its last known states. The
+// source object is compared to its original (unmutated) state, the destination object
+// to the last known (mutated) state. Fields that have changed on the source object are
+// left alone, as these changes still have to be synced to the destination. Returns the
+// paths of all copied fields.
+func mergeBidirectionalFields(source, dest, lastKnownSourceState, originalSourceState *unstructured.Unstructured, paths []string) ([]string, error) {
+	changedFields := []string{}
+
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+
+		current, currentFound, err := unstructured.NestedFieldNoCopy(source.Object, fields...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s from source object: %w", path, err)
+		}
+
+		original, originalFound, err := unstructured.NestedFieldNoCopy(originalSourceState.Object, fields...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s from last known original state: %w", path, err)
+		}
+
+		// the source has changed, so its value wins
+		if currentFound != originalFound || !equality.Semantic.DeepEqual(current, original) {
+			continue
+		}
+
+		previous, previousFound, err := unstructured.NestedFieldNoCopy(lastKnownSourceState.Object, fields...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s from last known state: %w", path, err)
+		}
+
+		desired, desiredFound, err := unstructured.NestedFieldNoCopy(dest.Object, fields...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s from destination object: %w", path, err)
+		}
+
+		// the destination has not changed since the last sync
+		if desiredFound == previousFound && equality.Semantic.DeepEqual(desired, previous) {
+			continue
+		}
+
+		for _, obj := range []*unstructured.Unstructured{source, lastKnownSourceState, originalSourceState} {
+			if desiredFound {
+				err = unstructured.SetNestedField(obj.Object, runtime.DeepCopyJSONValue(desired), fields...)
+			} else {
+				unstructured.RemoveNestedField(obj.Object, fields...)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to set %s: %w", path, err)
+			}
+		}
+
+		changedFields = append(changedFields, path)
+	}
+
+	return changedFields, nil
+}
+
 func (s *objectSyncer) syncObjectContents(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
 	// Sync the spec (or more generally, the desired state) from source to dest.
 	requeue, err = s.syncObjectSpec(log, source, dest)
@@ -167,11 +497,21 @@ func (s *objectSyncer) syncObjectContents(log *zap.SugaredLogger, source, dest s
 		return requeue, err
 	}
 
+	// For objects originating on the service cluster, the status is owned by the
+	// source as well and has to be copied to the destination.
+	if s.syncStatusForward {
+		return s.syncObjectStatusForward(log, source, dest)
+	}
+
 	// Sync the status back in the opposite direction, from dest to source.
 	return s.syncObjectStatus(log, source, dest)
 }
 
 func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
+	if s.fieldManager != "" {
+		return s.applyObjectSpec(log, source, dest)
+	}
+
 	// figure out the last known state
 	lastKnownSourceState, err := s.stateStore.Get(source)
 	if err != nil {
@@ -183,6 +523,10 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 		return false, fmt.Errorf("failed to strip metadata from source object: %w", err)
 	}
 
+	// treat additional labels as if they were part of the source object, so that
+	// changes to them are detected just like changes to the object itself
+	s.addExtraLabels(sourceObjCopy)
+
 	log = log.With("dest-object", newObjectKey(dest.object, dest.clusterName, logicalcluster.None))
 
 	// calculate the patch to go from the last known state to the current source object's state
@@ -204,7 +548,12 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 		// which we thankfully already fetched earlier.
 		if s.metadataOnDestination {
 			sourceKey := newObjectKey(source.object, source.clusterName, source.workspacePath)
-			threeWayDiffMetadata(sourceObjCopy, dest.object, sourceKey.Labels(), sourceKey.Annotations())
+			threeWayDiffMetadata(sourceObjCopy, dest.object, sourceKey.DestinationLabels(), s.destinationAnnotations(sourceKey))
+		}
+
+		// pretend that ignored fields never change, so they never end up in the patch
+		if err := s.preserveIgnoredFields(sourceObjCopy, lastKnownSourceState); err != nil {
+			return false, err
 		}
 
 		// now we can diff the two versions and create a patch
@@ -215,10 +564,15 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 
 		// only patch if the patch is not empty
 		if string(rawPatch) != "{}" {
+			rawPatch, err = s.stampSyncTimePatch(rawPatch)
+			if err != nil {
+				return false, err
+			}
+
 			log.Debugw("Patching destination object…", "patch", string(rawPatch))
 
 			if err := dest.client.Patch(dest.ctx, dest.object, ctrlruntimeclient.RawPatch(types.MergePatchType, rawPatch)); err != nil {
-				return false, fmt.Errorf("failed to patch destination object: %w", err)
+				return false, fmt.Errorf("failed to patch destination object: %w", &DestinationWriteError{Err: err})
 			}
 
 			requeue = true
@@ -227,6 +581,7 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 		// there is no last state available, we have to fall back to doing a stupid full update
 		sourceContent := source.object.UnstructuredContent()
 		destContent := dest.object.UnstructuredContent()
+		original := dest.object.DeepCopy()
 
 		// update things like spec and other top level elements
 		for key, data := range sourceContent {
@@ -235,16 +590,21 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 			}
 		}
 
+		if err := s.preserveIgnoredFields(dest.object, original); err != nil {
+			return false, err
+		}
+
 		// update selected metadata fields
 		ensureLabels(dest.object, filterUnsyncableLabels(sourceObjCopy.GetLabels()))
 		ensureAnnotations(dest.object, filterUnsyncableAnnotations(sourceObjCopy.GetAnnotations()))
+		s.stampSyncTime(dest.object)
 
 		// TODO: Check if anything has changed and skip the .Update() call if source and dest
 		// are identical w.r.t. the fields we have copied (spec, annotations, labels, ..).
 		log.Warn("Updating destination object because last-known-state is missing/invalid…")
 
 		if err := dest.client.Update(dest.ctx, dest.object); err != nil {
-			return false, fmt.Errorf("failed to update destination object: %w", err)
+			return false, fmt.Errorf("failed to update destination object: %w", &DestinationWriteError{Err: err})
 		}
 
 		requeue = true
@@ -261,6 +621,159 @@ func (s *objectSyncer) syncObjectSpec(log *zap.SugaredLogger, source, dest syncS
 	return requeue, nil
 }
 
+// applyObjectSpec is the server-side apply equivalent of syncObjectSpec. Since the
+// API server tracks which fields are owned by the Sync Agent, no last known state is
+// necessary to remove fields that have been removed from the source object.
+func (s *objectSyncer) applyObjectSpec(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
+	desired := s.desiredDestinationObject(source, dest.object)
+	resourceVersion := dest.object.GetResourceVersion()
+
+	// leave ignored fields to other field managers
+	if err := s.preserveIgnoredFields(desired, &unstructured.Unstructured{Object: map[string]any{}}); err != nil {
+		return false, err
+	}
+
+	log = log.With("dest-object", newObjectKey(dest.object, dest.clusterName, logicalcluster.None))
+	log.Debug("Applying destination object…")
+
+	if err := s.apply(dest.ctx, dest.client, desired); err != nil {
+		return false, fmt.Errorf("failed to apply destination object: %w", &DestinationWriteError{Err: err})
+	}
+
+	// a no-op apply does not change the resourceVersion
+	requeue = desired.GetResourceVersion() != resourceVersion
+
+	if requeue {
+		if err := s.recordSyncTime(dest.ctx, dest.client, desired); err != nil {
+			return true, err
+		}
+
+		if err := s.rememberState(source); err != nil {
+			return true, err
+		}
+	}
+
+	return requeue, nil
+}
+
+// desiredDestinationObject returns all fields the Sync Agent manages on the destination
+// object, based on the (already mutated) source object.
+func (s *objectSyncer) desiredDestinationObject(source syncSide, destObj *unstructured.Unstructured) *unstructured.Unstructured {
+	desired := &unstructured.Unstructured{Object: map[string]any{}}
+
+	for key, data := range source.object.UnstructuredContent() {
+		if !s.isIrrelevantTopLevelField(key) {
+			desired.Object[key] = runtime.DeepCopyJSONValue(data)
+		}
+	}
+
+	desired.SetAPIVersion(destObj.GetAPIVersion())
+	desired.SetKind(destObj.GetKind())
+	desired.SetName(destObj.GetName())
+	desired.SetNamespace(destObj.GetNamespace())
+
+	ensureLabels(desired, filterUnsyncableLabels(source.object.GetLabels()))
+	ensureAnnotations(desired, filterUnsyncableAnnotations(source.object.GetAnnotations()))
+	s.addExtraLabels(desired)
+
+	if s.metadataOnDestination {
+		sourceKey := newObjectKey(source.object, source.clusterName, source.workspacePath)
+		ensureLabels(desired, sourceKey.DestinationLabels())
+		ensureAnnotations(desired, s.destinationAnnotations(sourceKey))
+		s.labelWithAgent(desired)
+	}
+
+	return desired
+}
+
+// apply sends obj as a server-side apply patch, forcibly taking ownership of all
+// contained fields; obj is updated with the response from the API server.
+func (s *objectSyncer) apply(ctx context.Context, client ctrlruntimeclient.Client, obj *unstructured.Unstructured) error {
+	return client.Patch(ctx, obj, ctrlruntimeclient.Apply, ctrlruntimeclient.FieldOwner(s.fieldManager), ctrlruntimeclient.ForceOwnership)
+}
+
+// applyStatus is like apply, but for the status subresource.
+func (s *objectSyncer) applyStatus(ctx context.Context, client ctrlruntimeclient.Client, obj *unstructured.Unstructured, status any) error {
+	patch := &unstructured.Unstructured{Object: map[string]any{}}
+	patch.SetAPIVersion(obj.GetAPIVersion())
+	patch.SetKind(obj.GetKind())
+	patch.SetName(obj.GetName())
+	patch.SetNamespace(obj.GetNamespace())
+
+	if status != nil {
+		patch.Object["status"] = status
+	}
+
+	return client.Status().Patch(ctx, patch, ctrlruntimeclient.Apply, ctrlruntimeclient.FieldOwner(s.fieldManager), ctrlruntimeclient.ForceOwnership)
+}
+
+// rememberState stores the current source object state. With server-side apply, the
+// state is only needed to detect changes to immutable and bidirectional fields.
+func (s *objectSyncer) rememberState(source syncSide) error {
+	if s.fieldManager != "" && len(s.immutableFields) == 0 && len(s.bidirectionalFields) == 0 {
+		return nil
+	}
+
+	state := source.object.DeepCopy()
+	s.addExtraLabels(state)
+
+	if err := s.stateStore.Put(state, source.clusterName, s.subresources); err != nil {
+		return fmt.Errorf("failed to update sync state: %w", err)
+	}
+
+	return nil
+}
+
+// ignoredFields returns the paths to ignored fields from an optional mutation spec.
+func ignoredFields(spec *syncagentv1alpha1.ResourceMutationSpec) []string {
+	if spec == nil {
+		return nil
+	}
+
+	return spec.IgnoreFields
+}
+
+// preserveIgnoredFields replaces all ignored fields in desired with the values
+// from current, so that they are never changed on the destination object.
+func (s *objectSyncer) preserveIgnoredFields(desired, current *unstructured.Unstructured) error {
+	if len(s.ignoredFields) == 0 {
+		return nil
+	}
+
+	preserved, err := mutation.PreserveFields(desired.Object, current.Object, s.ignoredFields)
+	if err != nil {
+		return fmt.Errorf("failed to preserve ignored fields: %w", err)
+	}
+
+	obj, ok := preserved.(map[string]any)
+	if !ok {
+		return fmt.Errorf("preserving ignored fields did not yield an object, but %T", preserved)
+	}
+
+	desired.Object = obj
+
+	return nil
+}
+
+// preserveIgnoredStatusFields is like preserveIgnoredFields, but only for the status.
+func (s *objectSyncer) preserveIgnoredStatusFields(desired, current any) (any, error) {
+	if len(s.ignoredFields) == 0 {
+		return desired, nil
+	}
+
+	preserved, err := mutation.PreserveFields(map[string]any{"status": desired}, map[string]any{"status": current}, s.ignoredFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preserve ignored status fields: %w", err)
+	}
+
+	obj, ok := preserved.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("preserving ignored fields did not yield an object, but %T", preserved)
+	}
+
+	return obj["status"], nil
+}
+
 func (s *objectSyncer) syncObjectStatus(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
 	if !s.syncStatusBack {
 		return false, nil
@@ -272,12 +785,47 @@ func (s *objectSyncer) syncObjectStatus(log *zap.SugaredLogger, source, dest syn
 	sourceContent := source.object.UnstructuredContent()
 	destContent := dest.object.UnstructuredContent()
 
-	if !equality.Semantic.DeepEqual(sourceContent["status"], destContent["status"]) {
-		sourceContent["status"] = destContent["status"]
+	desiredStatus := destContent["status"]
+	if len(s.statusFields) > 0 {
+		desiredStatus, err = selectStatusFields(desiredStatus, s.statusFields)
+		if err != nil {
+			return false, fmt.Errorf("failed to select status fields: %w", err)
+		}
+	}
+
+	if s.decorateStatus != nil {
+		desiredStatus, err = s.decorateStatus(desiredStatus, source.object, dest.object)
+		if err != nil {
+			return false, fmt.Errorf("failed to determine source object status: %w", err)
+		}
+	}
+
+	// the condition about reverted immutable fields only exists on the source object
+	if len(s.immutableFields) > 0 {
+		desiredStatus, err = readiness.PreserveCondition(desiredStatus, sourceContent["status"], syncagentv1alpha1.ImmutableFieldsRevertedCondition)
+		if err != nil {
+			return false, fmt.Errorf("failed to preserve condition: %w", err)
+		}
+	}
 
-		log.Debug("Updating source object status…")
-		if err := source.client.Status().Update(source.ctx, source.object); err != nil {
-			return false, fmt.Errorf("failed to update source object status: %w", err)
+	desiredStatus, err = s.preserveIgnoredStatusFields(desiredStatus, sourceContent["status"])
+	if err != nil {
+		return false, err
+	}
+
+	if !equality.Semantic.DeepEqual(sourceContent["status"], desiredStatus) {
+		sourceContent["status"] = desiredStatus
+
+		if s.fieldManager != "" {
+			log.Debug("Applying source object status…")
+			if err := s.applyStatus(source.ctx, source.client, source.object, desiredStatus); err != nil {
+				return false, fmt.Errorf("failed to apply source object status: %w", err)
+			}
+		} else {
+			log.Debug("Updating source object status…")
+			if err := source.client.Status().Update(source.ctx, source.object); err != nil {
+				return false, fmt.Errorf("failed to update source object status: %w", err)
+			}
 		}
 	}
 
@@ -285,12 +833,48 @@ func (s *objectSyncer) syncObjectStatus(log *zap.SugaredLogger, source, dest syn
 	return false, nil
 }
 
+func (s *objectSyncer) syncObjectStatusForward(log *zap.SugaredLogger, source, dest syncSide) (requeue bool, err error) {
+	// without a status subresource, the status is synced like any other field
+	if !slices.Contains(s.subresources, "status") {
+		return false, nil
+	}
+
+	sourceContent := source.object.UnstructuredContent()
+	destContent := dest.object.UnstructuredContent()
+
+	desiredStatus, err := s.preserveIgnoredStatusFields(sourceContent["status"], destContent["status"])
+	if err != nil {
+		return false, err
+	}
+
+	if equality.Semantic.DeepEqual(desiredStatus, destContent["status"]) {
+		return false, nil
+	}
+
+	destContent["status"] = desiredStatus
+
+	if s.fieldManager != "" {
+		log.Debug("Applying destination object status…")
+		if err := s.applyStatus(dest.ctx, dest.client, dest.object, desiredStatus); err != nil {
+			return false, fmt.Errorf("failed to apply destination object status: %w", err)
+		}
+	} else {
+		log.Debug("Updating destination object status…")
+		if err := dest.client.Status().Update(dest.ctx, dest.object); err != nil {
+			return false, fmt.Errorf("failed to update destination object status: %w", err)
+		}
+	}
+
+	return false, nil
+}
+
 func (s *objectSyncer) ensureDestinationObject(log *zap.SugaredLogger, source, dest syncSide) error {
 	// create a copy of the source with GVK projected and renaming rules applied
 	destObj := s.destCreator(source.object)
+	s.addExtraLabels(destObj)
 
 	// make sure the target namespace on the destination cluster exists
-	if err := s.ensureNamespace(dest.ctx, log, dest.client, destObj.GetNamespace()); err != nil {
+	if err := s.ensureNamespace(log, source, dest, destObj.GetNamespace()); err != nil {
 		return fmt.Errorf("failed to ensure destination namespace: %w", err)
 	}
 
@@ -303,8 +887,8 @@ func (s *objectSyncer) ensureDestinationObject(log *zap.SugaredLogger, source, d
 	// remember the connection between the source and destination object
 	sourceObjKey := newObjectKey(source.object, source.clusterName, source.workspacePath)
 	if s.metadataOnDestination {
-		ensureLabels(destObj, sourceObjKey.Labels())
-		ensureAnnotations(destObj, sourceObjKey.Annotations())
+		ensureLabels(destObj, sourceObjKey.DestinationLabels())
+		ensureAnnotations(destObj, s.destinationAnnotations(sourceObjKey))
 
 		// remember what agent synced this object
 		s.labelWithAgent(destObj)
@@ -312,24 +896,40 @@ func (s *objectSyncer) ensureDestinationObject(log *zap.SugaredLogger, source, d
 
 	// finally, we can create the destination object
 	objectLog := log.With("dest-object", newObjectKey(destObj, dest.clusterName, logicalcluster.None))
-	objectLog.Debugw("Creating destination object…")
 
-	if err := dest.client.Create(dest.ctx, destObj); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return fmt.Errorf("failed to create destination object: %w", err)
+	if s.fieldManager != "" {
+		// applying also takes over existing, mislabelled destination objects
+		objectLog.Debugw("Applying destination object…")
+
+		applied := s.removeSubresources(destObj)
+		if err := s.apply(dest.ctx, dest.client, applied); err != nil {
+			return fmt.Errorf("failed to apply destination object: %w", &DestinationWriteError{Err: err})
 		}
 
-		if err := s.adoptExistingDestinationObject(objectLog, dest, destObj, sourceObjKey); err != nil {
-			return fmt.Errorf("failed to adopt destination object: %w", err)
+		if err := s.recordSyncTime(dest.ctx, dest.client, applied); err != nil {
+			return err
 		}
-	}
+	} else {
+		objectLog.Debugw("Creating destination object…")
+		s.stampSyncTime(destObj)
 
-	// remember the state of the object that we just created
-	if err := s.stateStore.Put(source.object, source.clusterName, s.subresources); err != nil {
-		return fmt.Errorf("failed to update sync state: %w", err)
+		if err := dest.client.Create(dest.ctx, destObj); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create destination object: %w", &DestinationWriteError{Err: err})
+			}
+
+			if err := s.adoptExistingDestinationObject(objectLog, dest, destObj, sourceObjKey); err != nil {
+				return fmt.Errorf("failed to adopt destination object: %w", err)
+			}
+
+			s.sourceEvents.Event(source.ctx, source.object, corev1.EventTypeNormal, reasonLocalObjectAdopted, "Adopted existing object on the service cluster.")
+		} else {
+			s.sourceEvents.Event(source.ctx, source.object, corev1.EventTypeNormal, reasonLocalObjectCreated, "Created object on the service cluster.")
+		}
 	}
 
-	return nil
+	// remember the state of the object that we just created
+	return s.rememberState(source)
 }
 
 func (s *objectSyncer) adoptExistingDestinationObject(log *zap.SugaredLogger, dest syncSide, existingDestObj *unstructured.Unstructured, sourceKey objectKey) error {
@@ -351,8 +951,8 @@ func (s *objectSyncer) adoptExistingDestinationObject(log *zap.SugaredLogger, de
 	// if we did not guarantee that destination objects never collide, this could in theory "take away"
 	// the destination object from another source object, which would then lead to the two source objects
 	// "fighting" about the one destination object.
-	ensureLabels(existingDestObj, sourceKey.Labels())
-	ensureAnnotations(existingDestObj, sourceKey.Annotations())
+	ensureLabels(existingDestObj, sourceKey.DestinationLabels())
+	ensureAnnotations(existingDestObj, s.destinationAnnotations(sourceKey))
 
 	s.labelWithAgent(existingDestObj)
 
@@ -363,7 +963,7 @@ func (s *objectSyncer) adoptExistingDestinationObject(log *zap.SugaredLogger, de
 	return nil
 }
 
-func (s *objectSyncer) ensureNamespace(ctx context.Context, log *zap.SugaredLogger, client ctrlruntimeclient.Client, namespace string) error {
+func (s *objectSyncer) ensureNamespace(log *zap.SugaredLogger, source, dest syncSide, namespace string) error {
 	// cluster-scoped objects do not need namespaces
 	if namespace == "" {
 		return nil
@@ -374,17 +974,28 @@ func (s *objectSyncer) ensureNamespace(ctx context.Context, log *zap.SugaredLogg
 	// is a race condition and we have to check for AlreadyExists later down the line, but that
 	// only occurs on cold caches. During normal operations this should be more efficient.
 	ns := &corev1.Namespace{}
-	if err := client.Get(ctx, types.NamespacedName{Name: namespace}, ns); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+	if err := dest.client.Get(dest.ctx, types.NamespacedName{Name: namespace}, ns); ctrlruntimeclient.IgnoreNotFound(err) != nil {
 		return fmt.Errorf("failed to check: %w", err)
 	}
 
 	if ns.Name == "" {
 		ns.Name = namespace
+		s.namespaceMetadata.apply(ns)
+		s.labelWithWorkspace(ns, source, dest)
 
 		log.Debugw("Creating namespace…", "namespace", namespace)
-		if err := client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		if err := dest.client.Create(dest.ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
 			return fmt.Errorf("failed to create: %w", err)
 		}
+
+		return nil
+	}
+
+	if s.namespaceMetadata.apply(ns) {
+		log.Debugw("Updating namespace metadata…", "namespace", namespace)
+		if err := dest.client.Update(dest.ctx, ns); err != nil {
+			return fmt.Errorf("failed to update: %w", err)
+		}
 	}
 
 	return nil
@@ -403,6 +1014,8 @@ func (s *objectSyncer) handleDeletion(log *zap.SugaredLogger, source, dest syncS
 			if err := dest.client.Delete(dest.ctx, dest.object); err != nil {
 				return false, fmt.Errorf("failed to delete destination object: %w", err)
 			}
+
+			s.sourceEvents.Event(source.ctx, source.object, corev1.EventTypeNormal, reasonDeletionBlocked, "Waiting for the object on the service cluster to be deleted.")
 		}
 
 		return true, nil
@@ -419,12 +1032,9 @@ func (s *objectSyncer) handleDeletion(log *zap.SugaredLogger, source, dest syncS
 		return true, nil
 	}
 
-	// For now we do not delete related resources; since after this step the destination object is
-	// gone already, the remaining syncer logic would fail if it attempts to sync relate objects.
-	// For the MVP it's fine to just leave related resources around, but in the future this behaviour
-	// might be configurable per PublishedResource, in which case this `return true` here would need
-	// to go away and the cleanup in general would need to be rethought a bit (maybe owner refs would
-	// be a good idea?).
+	// Related objects are cleaned up (if configured) by the ResourceSyncer before the destination
+	// object is deleted; since after this step the destination object is gone already, the remaining
+	// syncer logic would fail if it attempts to sync related objects.
 	return true, nil
 }
 
@@ -458,6 +1068,20 @@ func (s *objectSyncer) isIrrelevantTopLevelField(fieldName string) bool {
 	return fieldName == "kind" || fieldName == "apiVersion" || fieldName == "metadata" || slices.Contains(s.subresources, fieldName)
 }
 
+// destinationAnnotations returns all sync-related annotations for the destination object.
+func (s *objectSyncer) destinationAnnotations(sourceKey objectKey) labels.Set {
+	annotations := sourceKey.Annotations()
+	maps.Copy(annotations, s.extraAnnotations)
+
+	return annotations
+}
+
+func (s *objectSyncer) addExtraLabels(obj *unstructured.Unstructured) {
+	if len(s.extraLabels) > 0 {
+		ensureLabels(obj, s.extraLabels)
+	}
+}
+
 func (s *objectSyncer) labelWithAgent(obj *unstructured.Unstructured) {
 	if s.agentName != "" {
 		ensureLabels(obj, map[string]string{agentNameLabel: s.agentName})
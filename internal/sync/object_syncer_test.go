@@ -0,0 +1,490 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	"github.com/kcp-dev/api-syncagent/internal/readiness"
+	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDesiredDestinationObject(t *testing.T) {
+	source := syncSide{
+		clusterName: logicalcluster.Name("testcluster"),
+		object: &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "remote.example.corp/v1alpha1",
+			"kind":       "RemoteThing",
+			"metadata": map[string]any{
+				"name":            "my-test-thing",
+				"namespace":       "default",
+				"resourceVersion": "42",
+				"uid":             "abc",
+				"labels": map[string]any{
+					"app":                    "thing",
+					remoteObjectClusterLabel: "wrong",
+				},
+				"annotations": map[string]any{
+					"kcp.io/cluster": "testcluster",
+					"note":           "hello",
+				},
+			},
+			"spec": map[string]any{
+				"username": "Colonel Mustard",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		}},
+	}
+
+	destObj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "local.example.corp/v1alpha1",
+		"kind":       "Thing",
+		"metadata": map[string]any{
+			"name":            "renamed-thing",
+			"namespace":       "testcluster",
+			"resourceVersion": "7",
+		},
+	}}
+
+	sourceKey := newObjectKey(source.object, source.clusterName, source.workspacePath)
+
+	testcases := []struct {
+		name     string
+		syncer   objectSyncer
+		expected map[string]any
+	}{
+		{
+			name: "only source contents",
+			syncer: objectSyncer{
+				subresources: []string{"status"},
+			},
+			expected: map[string]any{
+				"apiVersion": "local.example.corp/v1alpha1",
+				"kind":       "Thing",
+				"metadata": map[string]any{
+					"name":      "renamed-thing",
+					"namespace": "testcluster",
+					"labels": map[string]any{
+						"app": "thing",
+					},
+					"annotations": map[string]any{
+						"note": "hello",
+					},
+				},
+				"spec": map[string]any{
+					"username": "Colonel Mustard",
+				},
+			},
+		},
+		{
+			name: "status without subresource is regular content",
+			syncer: objectSyncer{
+				extraLabels: map[string]string{"extra": "label"},
+			},
+			expected: map[string]any{
+				"apiVersion": "local.example.corp/v1alpha1",
+				"kind":       "Thing",
+				"metadata": map[string]any{
+					"name":      "renamed-thing",
+					"namespace": "testcluster",
+					"labels": map[string]any{
+						"app":   "thing",
+						"extra": "label",
+					},
+					"annotations": map[string]any{
+						"note": "hello",
+					},
+				},
+				"spec": map[string]any{
+					"username": "Colonel Mustard",
+				},
+				"status": map[string]any{
+					"phase": "Running",
+				},
+			},
+		},
+		{
+			name: "with sync metadata",
+			syncer: objectSyncer{
+				subresources:          []string{"status"},
+				agentName:             "textor-the-doctor",
+				metadataOnDestination: true,
+			},
+			expected: func() map[string]any {
+				labels := map[string]any{
+					"app":          "thing",
+					agentNameLabel: "textor-the-doctor",
+				}
+				for k, v := range sourceKey.Labels() {
+					labels[k] = v
+				}
+
+				annotations := map[string]any{
+					"note": "hello",
+				}
+				for k, v := range sourceKey.Annotations() {
+					annotations[k] = v
+				}
+
+				return map[string]any{
+					"apiVersion": "local.example.corp/v1alpha1",
+					"kind":       "Thing",
+					"metadata": map[string]any{
+						"name":        "renamed-thing",
+						"namespace":   "testcluster",
+						"labels":      labels,
+						"annotations": annotations,
+					},
+					"spec": map[string]any{
+						"username": "Colonel Mustard",
+					},
+				}
+			}(),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			desired := testcase.syncer.desiredDestinationObject(source, destObj)
+
+			if changes := diff.ObjectDiff(testcase.expected, desired.Object); changes != "" {
+				t.Errorf("Did not get expected object:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestAdoptExistingDestinationObject(t *testing.T) {
+	existing := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "testcluster-my-test-thing",
+		},
+	})
+
+	remoteObj := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+	}, withGroupKind("remote.example.corp", "RemoteThing"))
+
+	ctx := context.Background()
+	dest := syncSide{
+		ctx:    ctx,
+		client: buildFakeClient(existing),
+	}
+
+	syncer := objectSyncer{
+		agentName: "textor-the-doctor",
+		extraAnnotations: map[string]string{
+			syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+		},
+	}
+
+	sourceKey := newObjectKey(remoteObj, logicalcluster.Name("testcluster"), logicalcluster.None)
+	if err := syncer.adoptExistingDestinationObject(zap.NewNop().Sugar(), dest, existing.DeepCopy(), sourceKey); err != nil {
+		t.Fatalf("Failed to adopt object: %v", err)
+	}
+
+	adopted := existing.DeepCopy()
+	if err := dest.client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(adopted), adopted); err != nil {
+		t.Fatalf("Failed to get adopted object: %v", err)
+	}
+
+	annotations := adopted.GetAnnotations()
+	if annotations[remoteObjectNameAnnotation] != "my-test-thing" {
+		t.Errorf("Expected remote object name annotation, but got %v.", annotations)
+	}
+
+	if annotations[syncagentv1alpha1.PublishedResourceAnnotation] != "remote-things" {
+		t.Errorf("Expected extra annotations on the adopted object, but got %v.", annotations)
+	}
+
+	if !OwnedBy(adopted, "textor-the-doctor") {
+		t.Error("Expected adopted object to be owned by the agent.")
+	}
+}
+
+func TestMergeBidirectionalFields(t *testing.T) {
+	newObject := func(spec map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.corp/v1",
+			"kind":       "Thing",
+			"spec":       spec,
+		}}
+	}
+
+	testcases := []struct {
+		name      string
+		source    map[string]any
+		dest      map[string]any
+		lastKnown map[string]any
+		// original is the unmutated last known state, defaults to lastKnown
+		original      map[string]any
+		expectedSpec  map[string]any
+		expectedPaths []string
+	}{
+		{
+			name:          "unchanged field",
+			source:        map[string]any{"port": int64(80)},
+			dest:          map[string]any{"port": int64(80)},
+			lastKnown:     map[string]any{"port": int64(80)},
+			expectedSpec:  map[string]any{"port": int64(80)},
+			expectedPaths: []string{},
+		},
+		{
+			name:          "field changed on the destination",
+			source:        map[string]any{"port": int64(80), "name": "foo"},
+			dest:          map[string]any{"port": int64(30080), "name": "foo"},
+			lastKnown:     map[string]any{"port": int64(80), "name": "foo"},
+			expectedSpec:  map[string]any{"port": int64(30080), "name": "foo"},
+			expectedPaths: []string{"spec.port"},
+		},
+		{
+			name:          "field set on the destination",
+			source:        map[string]any{"name": "foo"},
+			dest:          map[string]any{"port": int64(30080), "name": "foo"},
+			lastKnown:     map[string]any{"name": "foo"},
+			expectedSpec:  map[string]any{"port": int64(30080), "name": "foo"},
+			expectedPaths: []string{"spec.port"},
+		},
+		{
+			name:          "field removed on the destination",
+			source:        map[string]any{"port": int64(80), "name": "foo"},
+			dest:          map[string]any{"name": "foo"},
+			lastKnown:     map[string]any{"port": int64(80), "name": "foo"},
+			expectedSpec:  map[string]any{"name": "foo"},
+			expectedPaths: []string{"spec.port"},
+		},
+		{
+			name:          "source changes take precedence",
+			source:        map[string]any{"port": int64(8080)},
+			dest:          map[string]any{"port": int64(30080)},
+			lastKnown:     map[string]any{"port": int64(80)},
+			expectedSpec:  map[string]any{"port": int64(8080)},
+			expectedPaths: []string{},
+		},
+		{
+			name:          "other fields are not copied",
+			source:        map[string]any{"port": int64(80), "name": "foo"},
+			dest:          map[string]any{"port": int64(80), "name": "bar"},
+			lastKnown:     map[string]any{"port": int64(80), "name": "foo"},
+			expectedSpec:  map[string]any{"port": int64(80), "name": "foo"},
+			expectedPaths: []string{},
+		},
+		{
+			name:          "mutated field is unchanged",
+			source:        map[string]any{"port": int64(80)},
+			dest:          map[string]any{"port": int64(8080)},
+			lastKnown:     map[string]any{"port": int64(8080)},
+			original:      map[string]any{"port": int64(80)},
+			expectedSpec:  map[string]any{"port": int64(80)},
+			expectedPaths: []string{},
+		},
+		{
+			name:          "mutated field changed on the destination",
+			source:        map[string]any{"port": int64(80)},
+			dest:          map[string]any{"port": int64(30080)},
+			lastKnown:     map[string]any{"port": int64(8080)},
+			original:      map[string]any{"port": int64(80)},
+			expectedSpec:  map[string]any{"port": int64(30080)},
+			expectedPaths: []string{"spec.port"},
+		},
+		{
+			name:          "mutated field changed on the source",
+			source:        map[string]any{"port": int64(90)},
+			dest:          map[string]any{"port": int64(30080)},
+			lastKnown:     map[string]any{"port": int64(8080)},
+			original:      map[string]any{"port": int64(80)},
+			expectedSpec:  map[string]any{"port": int64(90)},
+			expectedPaths: []string{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			source := newObject(testcase.source)
+			lastKnown := newObject(testcase.lastKnown)
+
+			original := newObject(testcase.lastKnown).DeepCopy()
+			if testcase.original != nil {
+				original = newObject(testcase.original)
+			}
+
+			paths, err := mergeBidirectionalFields(source, newObject(testcase.dest), lastKnown, original, []string{"spec.port"})
+			if err != nil {
+				t.Fatalf("Function returned unexpected error: %v", err)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expectedPaths, paths); changes != "" {
+				t.Errorf("Did not get expected paths:\n%s", changes)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expectedSpec, source.Object["spec"]); changes != "" {
+				t.Errorf("Did not get expected source object:\n%s", changes)
+			}
+
+			// the last known original state must match the source object and the last
+			// known state the destination, so that the next sync does not revert the
+			// copied fields
+			if changes := diff.ObjectDiff(source.Object, original.Object); len(paths) > 0 && changes != "" {
+				t.Errorf("Last known original state does not match source object:\n%s", changes)
+			}
+
+			if changes := diff.ObjectDiff(testcase.dest["port"], lastKnown.Object["spec"].(map[string]any)["port"]); len(paths) > 0 && changes != "" {
+				t.Errorf("Last known state does not match destination object:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestRevertImmutableFields(t *testing.T) {
+	newThing := func(username string) *unstructured.Unstructured {
+		return newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-thing", Namespace: "default"},
+			Spec:       dummyv1alpha1.ThingSpec{Username: username},
+		}, withGroupKind("remote.example.corp", "RemoteThing"))
+	}
+
+	testcases := []struct {
+		name             string
+		mutation         *syncagentv1alpha1.ResourceMutationSpec
+		sourceObject     *unstructured.Unstructured
+		lastKnownState   *unstructured.Unstructured
+		expectedReverted bool
+		expectedUsername string
+	}{
+		{
+			name:             "unchanged field is left alone",
+			sourceObject:     newThing("Colonel Mustard"),
+			lastKnownState:   newThing("Colonel Mustard"),
+			expectedReverted: false,
+			expectedUsername: "Colonel Mustard",
+		},
+		{
+			name:             "changed field is reverted",
+			sourceObject:     newThing("Miss Scarlet"),
+			lastKnownState:   newThing("Colonel Mustard"),
+			expectedReverted: true,
+			expectedUsername: "Colonel Mustard",
+		},
+		{
+			name:             "without a known original state, nothing is reverted",
+			sourceObject:     newThing("Miss Scarlet"),
+			expectedReverted: false,
+			expectedUsername: "Miss Scarlet",
+		},
+		{
+			name: "field rewritten by a mutation is compared in its original form",
+			mutation: &syncagentv1alpha1.ResourceMutationSpec{
+				Spec: []syncagentv1alpha1.ResourceMutation{{
+					Regex: &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.username", Pattern: "^mustard$", Replacement: "Colonel Mustard"},
+				}},
+			},
+			sourceObject:     newThing("mustard"),
+			lastKnownState:   newThing("mustard"),
+			expectedReverted: false,
+			expectedUsername: "mustard",
+		},
+		{
+			name: "field rewritten by a mutation is reverted to its original form",
+			mutation: &syncagentv1alpha1.ResourceMutationSpec{
+				Spec: []syncagentv1alpha1.ResourceMutation{{
+					Regex: &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.username", Pattern: "^mustard$", Replacement: "Colonel Mustard"},
+				}},
+			},
+			sourceObject:     newThing("Miss Scarlet"),
+			lastKnownState:   newThing("mustard"),
+			expectedReverted: true,
+			expectedUsername: "mustard",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			remoteClient := buildFakeClientWithStatus(testcase.sourceObject)
+
+			source := syncSide{
+				ctx:         ctx,
+				clusterName: logicalcluster.Name("testcluster"),
+				client:      remoteClient,
+				object:      testcase.sourceObject.DeepCopy(),
+			}
+
+			stateStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(source, syncSide{ctx: ctx, client: buildFakeClient()})
+			if testcase.lastKnownState != nil {
+				if err := stateStore.PutOriginal(testcase.lastKnownState, source.clusterName, []string{"status"}); err != nil {
+					t.Fatalf("Failed to prime state store: %v", err)
+				}
+			}
+
+			syncer := objectSyncer{
+				subresources:    []string{"status"},
+				immutableFields: []string{"spec.username"},
+				mutator:         mutation.NewMutator(testcase.mutation, ""),
+				stateStore:      stateStore,
+				destCreator:     func(obj *unstructured.Unstructured) *unstructured.Unstructured { return obj.DeepCopy() },
+				sourceEvents:    newRemoteEventRecorder(remoteClient, "my-agent", zap.NewNop().Sugar()),
+			}
+
+			reverted, err := syncer.revertImmutableFields(zap.NewNop().Sugar(), source)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if reverted != testcase.expectedReverted {
+				t.Fatalf("Expected reverted=%v, got %v.", testcase.expectedReverted, reverted)
+			}
+
+			final := testcase.sourceObject.DeepCopy()
+			if err := remoteClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(final), final); err != nil {
+				t.Fatalf("Failed to get source object: %v", err)
+			}
+
+			if username, _, _ := unstructured.NestedString(final.Object, "spec", "username"); username != testcase.expectedUsername {
+				t.Errorf("Expected username %q, got %q.", testcase.expectedUsername, username)
+			}
+
+			if hasCondition := readiness.ConditionIsTrue(final.Object["status"], syncagentv1alpha1.ImmutableFieldsRevertedCondition); hasCondition != testcase.expectedReverted {
+				t.Errorf("Expected condition to be present: %v, but got %v.", testcase.expectedReverted, hasCondition)
+			}
+
+			events := &corev1.EventList{}
+			if err := remoteClient.List(ctx, events); err != nil {
+				t.Fatalf("Failed to list Events: %v", err)
+			}
+
+			if recorded := len(events.Items) > 0; recorded != testcase.expectedReverted {
+				t.Errorf("Expected Event to be recorded: %v, but found %d Events.", testcase.expectedReverted, len(events.Items))
+			}
+		})
+	}
+}
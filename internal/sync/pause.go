@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/readiness"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// isPaused returns true if the given object (which can be nil) has been paused
+// using the paused annotation.
+func isPaused(obj *unstructured.Unstructured) bool {
+	return obj != nil && obj.GetAnnotations()[syncagentv1alpha1.PausedAnnotation] == "true"
+}
+
+// updatePausedCondition sets the paused condition on the object in kcp. When the
+// object is not paused, the condition is only updated if it exists already, so
+// that objects which were never paused are not modified. Objects without status
+// subresource are left alone.
+func (s *ResourceSyncer) updatePausedCondition(log *zap.SugaredLogger, ctx Context, remoteObj *unstructured.Unstructured, paused bool) (updated bool, err error) {
+	if remoteObj == nil || !slices.Contains(s.subresources, "status") {
+		return false, nil
+	}
+
+	currentStatus := remoteObj.Object["status"]
+	if !paused && !readiness.ConditionIsTrue(currentStatus, syncagentv1alpha1.PausedCondition) {
+		return false, nil
+	}
+
+	reason := "Resumed"
+	message := "The object is synchronized again."
+	if paused {
+		reason = "Paused"
+		message = fmt.Sprintf("Synchronization is paused via the %s annotation.", syncagentv1alpha1.PausedAnnotation)
+	}
+
+	status, err := readiness.SetConditionWithReason(currentStatus, currentStatus, syncagentv1alpha1.PausedCondition, paused, reason, message)
+	if err != nil {
+		return false, fmt.Errorf("failed to set condition: %w", err)
+	}
+
+	if equality.Semantic.DeepEqual(currentStatus, any(status)) {
+		return false, nil
+	}
+
+	log.Debugw("Updating paused condition", "paused", paused)
+
+	newObj := remoteObj.DeepCopy()
+	newObj.Object["status"] = status
+
+	if err := s.remoteClient.Status().Update(ctx.remote, newObj); err != nil {
+		return false, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return true, nil
+}
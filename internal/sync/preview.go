@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+// maxPreviewIterations limits how often the syncer is invoked during a preview; the
+// first synchronization of an object only takes a handful of steps.
+const maxPreviewIterations = 20
+
+// Preview synchronizes the given object from kcp into an empty, in-memory service
+// cluster and returns the resulting local object. This runs the same code as the
+// Sync Agent, so projection, naming and mutation rules are applied exactly like
+// they would be, but requires no cluster connectivity at all.
+func Preview(ctx context.Context, pubRes *syncagentv1alpha1.PublishedResource, localCRD *apiextensionsv1.CustomResourceDefinition, remoteObj *unstructured.Unstructured, clusterName logicalcluster.Name, workspacePath logicalcluster.Path) (*unstructured.Unstructured, error) {
+	if remoteObj.GetName() == "" {
+		return nil, errors.New("object has no name")
+	}
+
+	remoteObj = remoteObj.DeepCopy()
+	remoteObj.SetResourceVersion("")
+
+	localClient := fakectrlruntimeclient.NewClientBuilder().Build()
+	remoteClient := fakectrlruntimeclient.NewClientBuilder().WithObjects(remoteObj).Build()
+
+	stateOptions := StateOptions{
+		Namespace: "preview",
+		Backend:   state.BackendSecret,
+	}
+
+	syncer, err := NewResourceSyncer(zap.NewNop().Sugar(), localClient, remoteClient, pubRes, localCRD, nil, mutation.NewMutator(pubRes.Spec.Mutation, "preview"), &record.FakeRecorder{}, stateOptions, "preview")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create syncer: %w", err)
+	}
+
+	syncContext := NewContext(ctx, kontext.WithCluster(ctx, clusterName))
+	if !workspacePath.Empty() {
+		syncContext = syncContext.WithWorkspacePath(workspacePath)
+	}
+
+	for i := 0; ; i++ {
+		if i >= maxPreviewIterations {
+			return nil, fmt.Errorf("object was not synchronized after %d iterations", maxPreviewIterations)
+		}
+
+		requeue, err := syncer.Process(syncContext, remoteObj)
+		if err != nil {
+			return nil, err
+		}
+
+		if !requeue {
+			break
+		}
+
+		if err := remoteClient.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(remoteObj), remoteObj); err != nil {
+			return nil, fmt.Errorf("failed to get object: %w", err)
+		}
+	}
+
+	localObj, err := syncer.findLocalObject(syncContext, remoteObj)
+	if err != nil {
+		return nil, err
+	}
+
+	if localObj == nil {
+		return nil, errors.New("no local object was created")
+	}
+
+	// the in-memory cluster's resource version is meaningless
+	localObj.SetResourceVersion("")
+
+	return localObj, nil
+}
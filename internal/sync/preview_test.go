@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPreview(t *testing.T) {
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "remote-things",
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: dummyv1alpha1.GroupName,
+				Version:  dummyv1alpha1.GroupVersion,
+				Kind:     "Thing",
+			},
+			Projection: &syncagentv1alpha1.ResourceProjection{
+				Group: "remote.example.corp",
+				Kind:  "RemoteThing",
+			},
+			Naming: &syncagentv1alpha1.ResourceNaming{
+				Name: "$remoteClusterName-$remoteName",
+			},
+			Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+				Spec: []syncagentv1alpha1.ResourceMutation{{
+					Regex: &syncagentv1alpha1.ResourceRegexMutation{
+						Path:        "spec.username",
+						Pattern:     "Colonel",
+						Replacement: "Professor",
+					},
+				}},
+			},
+		},
+	}
+
+	remoteObj := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: "Colonel Mustard",
+		},
+	}, withGroupKind("remote.example.corp", "RemoteThing"))
+
+	localObj, err := Preview(context.Background(), pubRes, loadCRD("things"), remoteObj, logicalcluster.Name("testcluster"), logicalcluster.None)
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+
+	expected := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "testcluster-my-test-thing",
+			Labels: map[string]string{
+				agentNameLabel:            "preview",
+				remoteObjectClusterLabel:  "testcluster",
+				remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+			},
+			Annotations: map[string]string{
+				remoteObjectNameAnnotation:                    "my-test-thing",
+				syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+				syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+				syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+			},
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: "Professor Mustard",
+		},
+	})
+
+	if changes := diff.ObjectDiff(expected.Object, localObj.Object); changes != "" {
+		t.Fatalf("Did not get expected local object:\n%s", changes)
+	}
+}
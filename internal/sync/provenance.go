@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// provenanceAnnotations returns the provenance annotations for the local copy of
+// the given remote object. These only depend on the remote object and therefore
+// never change once the local object exists.
+func provenanceAnnotations(policy *syncagentv1alpha1.ProvenancePolicy, remoteObj *unstructured.Unstructured, workspacePath logicalcluster.Path) labels.Set {
+	annotations := labels.Set{}
+	if policy == nil {
+		return annotations
+	}
+
+	if policy.WorkspacePath && !workspacePath.Empty() {
+		annotations[syncagentv1alpha1.ProvenanceWorkspacePathAnnotation] = workspacePath.String()
+	}
+
+	if created := remoteObj.GetCreationTimestamp(); policy.CreationTimestamp && !created.IsZero() {
+		annotations[syncagentv1alpha1.ProvenanceCreatedAtAnnotation] = created.UTC().Format(time.RFC3339)
+	}
+
+	if policy.UserAnnotation != "" {
+		if user := remoteObj.GetAnnotations()[policy.UserAnnotation]; user != "" {
+			annotations[syncagentv1alpha1.ProvenanceCreatedByAnnotation] = user
+		}
+	}
+
+	return annotations
+}
+
+// syncTimestamp returns the value for the last-synced-at annotation.
+func (s *objectSyncer) syncTimestamp() string {
+	return s.syncClock().UTC().Format(time.RFC3339)
+}
+
+// stampSyncTime sets the last-synced-at annotation on an object that is about to
+// be created or updated, if enabled.
+func (s *objectSyncer) stampSyncTime(obj *unstructured.Unstructured) {
+	if s.syncClock != nil {
+		ensureAnnotations(obj, map[string]string{syncagentv1alpha1.ProvenanceLastSyncedAtAnnotation: s.syncTimestamp()})
+	}
+}
+
+// stampSyncTimePatch adds the last-synced-at annotation to a merge patch, if enabled.
+func (s *objectSyncer) stampSyncTimePatch(rawPatch []byte) ([]byte, error) {
+	if s.syncClock == nil {
+		return rawPatch, nil
+	}
+
+	patch := map[string]any{}
+	if err := json.Unmarshal(rawPatch, &patch); err != nil {
+		return nil, fmt.Errorf("failed to decode patch: %w", err)
+	}
+
+	// a patch that removes all annotations must not be turned into one that keeps them
+	if value, exists, _ := unstructured.NestedFieldNoCopy(patch, "metadata", "annotations"); exists && value == nil {
+		return rawPatch, nil
+	}
+
+	if err := unstructured.SetNestedField(patch, s.syncTimestamp(), "metadata", "annotations", syncagentv1alpha1.ProvenanceLastSyncedAtAnnotation); err != nil {
+		return nil, fmt.Errorf("failed to add sync timestamp to patch: %w", err)
+	}
+
+	return json.Marshal(patch)
+}
+
+// recordSyncTime updates the last-synced-at annotation on an existing object, if
+// enabled. This is used for server-side apply, where the annotation cannot be part
+// of the applied configuration, as every apply would then change the object.
+func (s *objectSyncer) recordSyncTime(ctx context.Context, client ctrlruntimeclient.Client, obj *unstructured.Unstructured) error {
+	if s.syncClock == nil {
+		return nil
+	}
+
+	patch, err := s.stampSyncTimePatch([]byte("{}"))
+	if err != nil {
+		return err
+	}
+
+	if err := client.Patch(ctx, obj, ctrlruntimeclient.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to update sync timestamp: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestProvenanceAnnotations(t *testing.T) {
+	remoteObj := &unstructured.Unstructured{}
+	remoteObj.SetName("test")
+	remoteObj.SetCreationTimestamp(metav1.NewTime(time.Date(2025, 3, 14, 15, 9, 26, 0, time.FixedZone("CET", 3600))))
+	remoteObj.SetAnnotations(map[string]string{"example.com/creator": "alice"})
+
+	path := logicalcluster.NewPath("root:org:team")
+
+	testcases := []struct {
+		name     string
+		policy   *syncagentv1alpha1.ProvenancePolicy
+		path     logicalcluster.Path
+		expected labels.Set
+	}{
+		{
+			name:     "no policy",
+			path:     path,
+			expected: labels.Set{},
+		},
+		{
+			name: "everything",
+			policy: &syncagentv1alpha1.ProvenancePolicy{
+				WorkspacePath:     true,
+				CreationTimestamp: true,
+				UserAnnotation:    "example.com/creator",
+				LastSyncTimestamp: true,
+			},
+			path: path,
+			expected: labels.Set{
+				syncagentv1alpha1.ProvenanceWorkspacePathAnnotation: "root:org:team",
+				syncagentv1alpha1.ProvenanceCreatedAtAnnotation:     "2025-03-14T14:09:26Z",
+				syncagentv1alpha1.ProvenanceCreatedByAnnotation:     "alice",
+			},
+		},
+		{
+			name: "unknown workspace path and missing user annotation",
+			policy: &syncagentv1alpha1.ProvenancePolicy{
+				WorkspacePath:  true,
+				UserAnnotation: "example.com/owner",
+			},
+			expected: labels.Set{},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			annotations := provenanceAnnotations(testcase.policy, remoteObj, testcase.path)
+
+			if !labels.Equals(annotations, testcase.expected) {
+				t.Fatalf("Expected %v, but got %v.", testcase.expected, annotations)
+			}
+		})
+	}
+}
+
+func TestStampSyncTimePatch(t *testing.T) {
+	clock := func() time.Time {
+		return time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	}
+
+	testcases := []struct {
+		name     string
+		clock    func() time.Time
+		patch    string
+		expected string
+	}{
+		{
+			name:     "disabled",
+			patch:    `{"spec":{"size":3}}`,
+			expected: `{"spec":{"size":3}}`,
+		},
+		{
+			name:     "patch without metadata",
+			clock:    clock,
+			patch:    `{"spec":{"size":3}}`,
+			expected: `{"metadata":{"annotations":{"provenance.syncagent.kcp.io/last-synced-at":"2025-03-14T15:09:26Z"}},"spec":{"size":3}}`,
+		},
+		{
+			name:     "patch with annotations",
+			clock:    clock,
+			patch:    `{"metadata":{"annotations":{"foo":"bar","baz":null}}}`,
+			expected: `{"metadata":{"annotations":{"baz":null,"foo":"bar","provenance.syncagent.kcp.io/last-synced-at":"2025-03-14T15:09:26Z"}}}`,
+		},
+		{
+			name:     "patch removing all annotations",
+			clock:    clock,
+			patch:    `{"metadata":{"annotations":null}}`,
+			expected: `{"metadata":{"annotations":null}}`,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			syncer := &objectSyncer{syncClock: testcase.clock}
+
+			patch, err := syncer.stampSyncTimePatch([]byte(testcase.patch))
+			if err != nil {
+				t.Fatalf("Failed to stamp patch: %v", err)
+			}
+
+			if string(patch) != testcase.expected {
+				t.Fatalf("Expected %s, but got %s.", testcase.expected, string(patch))
+			}
+		})
+	}
+}
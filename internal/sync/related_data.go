@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// dataTransformingMutator applies the data transformation of a related resource
+// after the regular mutation rules. Transformations only ever apply to the spec
+// direction, as ConfigMaps and Secrets have no status.
+type dataTransformingMutator struct {
+	mutation.Mutator
+
+	transform *syncagentv1alpha1.RelatedResourceDataTransformation
+}
+
+var _ mutation.Mutator = &dataTransformingMutator{}
+
+// newRelatedObjectMutator returns the mutator for the objects of a related resource.
+func newRelatedObjectMutator(relRes syncagentv1alpha1.RelatedResourceSpec, agentName string) mutation.Mutator {
+	mutator := mutation.NewMutator(relRes.Mutation, agentName)
+	if relRes.Transform == nil {
+		return mutator
+	}
+
+	return &dataTransformingMutator{
+		Mutator:   mutator,
+		transform: relRes.Transform,
+	}
+}
+
+func (m *dataTransformingMutator) MutateSpec(toMutate *unstructured.Unstructured, otherObj *unstructured.Unstructured, workspace mutation.Workspace) (*unstructured.Unstructured, error) {
+	mutated, err := m.Mutator.MutateSpec(toMutate, otherObj, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transformData(mutated, m.transform, workspace); err != nil {
+		return nil, fmt.Errorf("failed to transform data: %w", err)
+	}
+
+	return mutated, nil
+}
+
+// dataTemplateContext is the data available to templates of composed keys.
+type dataTemplateContext struct {
+	// Data is the original data of the object; Secret values are decoded.
+	Data map[string]string
+	// Object is the entire object before its data was transformed.
+	Object map[string]any
+	// ClusterName is the logical cluster name of the kcp workspace.
+	ClusterName string
+	// ClusterPath is the workspace path (e.g. "root:org:team"), which is only
+	// available if workspace paths are enabled for the PublishedResource.
+	ClusterPath string
+}
+
+func transformData(obj *unstructured.Unstructured, transform *syncagentv1alpha1.RelatedResourceDataTransformation, workspace mutation.Workspace) error {
+	groupKind := obj.GroupVersionKind().GroupKind()
+	if groupKind.Group != "" || (groupKind.Kind != "Secret" && groupKind.Kind != "ConfigMap") {
+		return fmt.Errorf("data transformations are not supported for %s", groupKind)
+	}
+
+	secret := groupKind.Kind == "Secret"
+
+	encoded, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return fmt.Errorf("failed to read data: %w", err)
+	}
+
+	data := make(map[string]string, len(encoded))
+	for key, value := range encoded {
+		if secret {
+			decoded, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return fmt.Errorf("failed to decode key %q: %w", key, err)
+			}
+
+			value = string(decoded)
+		}
+
+		data[key] = value
+	}
+
+	ctx := dataTemplateContext{
+		Data:        maps.Clone(data),
+		Object:      obj.DeepCopy().Object,
+		ClusterName: workspace.ClusterName.String(),
+		ClusterPath: workspace.Path.String(),
+	}
+
+	for _, rename := range transform.Rename {
+		if value, exists := data[rename.From]; exists {
+			delete(data, rename.From)
+			data[rename.To] = value
+		}
+	}
+
+	for _, key := range transform.Drop {
+		delete(data, key)
+	}
+
+	for _, compose := range transform.Compose {
+		value, err := renderDataTemplate(compose.Template, ctx)
+		if err != nil {
+			return fmt.Errorf("failed to compose key %q: %w", compose.Key, err)
+		}
+
+		data[compose.Key] = value
+	}
+
+	if len(data) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "data")
+		return nil
+	}
+
+	encoded = make(map[string]string, len(data))
+	for key, value := range data {
+		if secret {
+			value = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+
+		encoded[key] = value
+	}
+
+	return unstructured.SetNestedStringMap(obj.Object, encoded, "data")
+}
+
+// renderDataTemplate renders the value of a composed key. Unlike the templates
+// used to find related objects, the output is not trimmed, as whitespace can be
+// significant in credentials and certificates.
+func renderDataTemplate(tpl string, data dataTemplateContext) (string, error) {
+	funcs := sprig.TxtFuncMap()
+	funcs["join"] = strings.Join
+
+	parsed, err := template.New("data").Funcs(funcs).Option("missingkey=error").Parse(tpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", tpl, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tpl, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// retainsLocalObjects returns true if the PublishedResource's deletion policy
+// keeps local objects around after their objects in kcp are deleted.
+func (s *ResourceSyncer) retainsLocalObjects() bool {
+	switch s.pubRes.Spec.DeletionPolicy {
+	case syncagentv1alpha1.DeletionPolicyRetain, syncagentv1alpha1.DeletionPolicySnapshot:
+		return true
+	default:
+		return false
+	}
+}
+
+// retainLocalObject detaches the local object from the deleted remote object,
+// so that it is neither deleted nor considered for synchronization anymore. The
+// agent name label is kept, so that the object is still recognized as being
+// created by this agent. Once the local object is detached, the finalizer on the
+// remote object is removed so that kcp can finish the deletion.
+func (s *ResourceSyncer) retainLocalObject(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide) (requeue bool, err error) {
+	if s.pubRes.Spec.DeletionPolicy == syncagentv1alpha1.DeletionPolicySnapshot {
+		snapshot := remote.object.DeepCopy()
+		snapshot.SetDeletionTimestamp(nil)
+
+		// keep the status, as it is part of the final state of the object
+		if err := stateStore.Put(snapshot, remote.clusterName, nil); err != nil {
+			return false, fmt.Errorf("failed to store snapshot of remote object: %w", err)
+		}
+	}
+
+	original := local.object.DeepCopy()
+
+	labels := local.object.GetLabels()
+	delete(labels, remoteObjectClusterLabel)
+	delete(labels, remoteObjectNamespaceHashLabel)
+	delete(labels, remoteObjectNameHashLabel)
+	delete(labels, remoteObjectWorkspacePathHashLabel)
+	local.object.SetLabels(labels)
+
+	annotations := local.object.GetAnnotations()
+	delete(annotations, remoteObjectNamespaceAnnotation)
+	delete(annotations, remoteObjectNameAnnotation)
+	delete(annotations, remoteObjectWorkspacePathAnnotation)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[syncagentv1alpha1.RetainedFromAnnotation] = newObjectKey(remote.object, remote.clusterName, remote.workspacePath).String()
+	local.object.SetAnnotations(annotations)
+
+	log.Infow("Retaining local object", "policy", s.pubRes.Spec.DeletionPolicy)
+	if err := local.client.Patch(local.ctx, local.object, ctrlruntimeclient.MergeFrom(original)); err != nil {
+		return false, fmt.Errorf("failed to detach local object: %w", err)
+	}
+
+	if _, err := removeFinalizer(remote.ctx, log, remote.client, remote.object, deletionFinalizer); err != nil {
+		return false, fmt.Errorf("failed to remove cleanup finalizer from remote object: %w", err)
+	}
+
+	return true, nil
+}
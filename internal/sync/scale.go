@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// crdScaleSubresource returns the scale subresource defined for the given version
+// in the CRD, or nil if the version has no scale subresource.
+func crdScaleSubresource(crd *apiextensionsv1.CustomResourceDefinition, version string) *apiextensionsv1.CustomResourceSubresourceScale {
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Subresources != nil {
+			return v.Subresources.Scale
+		}
+	}
+
+	return nil
+}
+
+// defaultScaleSubresource returns the scale subresource assumed for APIs whose
+// scale subresource is only known from discovery, which does not include the paths.
+// This matches what the discovery client assumes when creating CRDs for such APIs.
+func defaultScaleSubresource() *apiextensionsv1.CustomResourceSubresourceScale {
+	return &apiextensionsv1.CustomResourceSubresourceScale{
+		SpecReplicasPath:   ".spec.replicas",
+		StatusReplicasPath: ".status.replicas",
+	}
+}
+
+// scalePath turns a JSON path from a scale subresource definition (e.g. ".spec.replicas")
+// into a list of fields.
+func scalePath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "."), ".")
+}
+
+// scaleSpecReplicasField returns the dot-separated path to the replicas field, in the
+// same syntax as ignored fields.
+func scaleSpecReplicasField(scale *apiextensionsv1.CustomResourceSubresourceScale) string {
+	return strings.Join(scalePath(scale.SpecReplicasPath), ".")
+}
+
+// scaleStatusFields returns the paths to the status fields that make up the status
+// of the scale subresource, relative to the status (as used by status projections).
+func scaleStatusFields(scale *apiextensionsv1.CustomResourceSubresourceScale) []string {
+	fields := []string{}
+
+	for _, path := range []string{scale.StatusReplicasPath, ptr.Deref(scale.LabelSelectorPath, "")} {
+		if relative, ok := strings.CutPrefix(path, ".status."); ok {
+			fields = append(fields, relative)
+		}
+	}
+
+	return fields
+}
+
+// getReplicas returns the replica count stored at the given path.
+func getReplicas(obj *unstructured.Unstructured, path string) (int64, bool, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, scalePath(path)...)
+	if err != nil || !found {
+		return 0, false, err
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return v, true, nil
+	case int:
+		return int64(v), true, nil
+	case float64:
+		return int64(v), true, nil
+	default:
+		return 0, false, fmt.Errorf("%s is not a number, but %T", path, value)
+	}
+}
+
+// syncReplicas copies the desired number of replicas from the source object to the
+// destination object using the scale subresource. Replicas are not synced as part
+// of the regular spec, so that service providers can grant the Sync Agent
+// permissions for the scale subresource only and to not interfere with local
+// autoscalers owning the field. Returns true if the destination object was scaled.
+func (s *objectSyncer) syncReplicas(log *zap.SugaredLogger, source, dest syncSide) (bool, error) {
+	sourceReplicas, found, err := getReplicas(source.object, s.scale.SpecReplicasPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine source replicas: %w", err)
+	}
+
+	// nothing to do if the replicas are defaulted on the destination side
+	if !found {
+		return false, nil
+	}
+
+	destReplicas, found, err := getReplicas(dest.object, s.scale.SpecReplicasPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine destination replicas: %w", err)
+	}
+
+	if found && sourceReplicas == destReplicas {
+		return false, nil
+	}
+
+	log.Debugw("Scaling destination object…", "replicas", sourceReplicas)
+
+	// the response is a Scale object, so it must not overwrite the destination object
+	scaled := dest.object.DeepCopy()
+	patch := ctrlruntimeclient.RawPatch(types.MergePatchType, fmt.Appendf(nil, `{"spec":{"replicas":%d}}`, sourceReplicas))
+
+	if err := dest.client.SubResource("scale").Patch(dest.ctx, scaled, patch); err != nil {
+		return false, fmt.Errorf("failed to scale destination object: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestScaleStatusFields(t *testing.T) {
+	testcases := []struct {
+		name     string
+		scale    *apiextensionsv1.CustomResourceSubresourceScale
+		expected []string
+	}{
+		{
+			name: "replicas only",
+			scale: &apiextensionsv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+			},
+			expected: []string{"replicas"},
+		},
+		{
+			name: "replicas and selector",
+			scale: &apiextensionsv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.scaling.replicas",
+				LabelSelectorPath:  ptr.To(".status.selector"),
+			},
+			expected: []string{"scaling.replicas", "selector"},
+		},
+		{
+			name: "selector outside of status",
+			scale: &apiextensionsv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+				LabelSelectorPath:  ptr.To(".spec.selector"),
+			},
+			expected: []string{"replicas"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			fields := scaleStatusFields(testcase.scale)
+			if !slices.Equal(fields, testcase.expected) {
+				t.Errorf("Expected %v, but got %v.", testcase.expected, fields)
+			}
+		})
+	}
+}
+
+func TestGetReplicas(t *testing.T) {
+	testcases := []struct {
+		name          string
+		object        map[string]any
+		expected      int64
+		expectedFound bool
+		expectErr     bool
+	}{
+		{
+			name:          "integer",
+			object:        map[string]any{"spec": map[string]any{"replicas": int64(3)}},
+			expected:      3,
+			expectedFound: true,
+		},
+		{
+			name:          "float",
+			object:        map[string]any{"spec": map[string]any{"replicas": float64(2)}},
+			expected:      2,
+			expectedFound: true,
+		},
+		{
+			name:          "missing",
+			object:        map[string]any{"spec": map[string]any{}},
+			expectedFound: false,
+		},
+		{
+			name:      "invalid",
+			object:    map[string]any{"spec": map[string]any{"replicas": "three"}},
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			replicas, found, err := getReplicas(&unstructured.Unstructured{Object: testcase.object}, ".spec.replicas")
+			if (err != nil) != testcase.expectErr {
+				t.Fatalf("Expected error=%v, but got %v.", testcase.expectErr, err)
+			}
+
+			if found != testcase.expectedFound || replicas != testcase.expected {
+				t.Errorf("Expected %d (found=%v), but got %d (found=%v).", testcase.expected, testcase.expectedFound, replicas, found)
+			}
+		})
+	}
+}
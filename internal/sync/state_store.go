@@ -17,14 +17,19 @@ limitations under the License.
 package sync
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/kcp-dev/logicalcluster/v3"
 
-	"github.com/kcp-dev/api-syncagent/internal/crypto"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
 
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +39,10 @@ import (
 type ObjectStateStore interface {
 	Get(source syncSide) (*unstructured.Unstructured, error)
 	Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error
+	// GetOriginal and PutOriginal work like Get and Put, but for the state of the
+	// source object before any mutations were applied.
+	GetOriginal(source syncSide) (*unstructured.Unstructured, error)
+	PutOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error
 }
 
 // objectStateStore is capable of creating/updating a target Kubernetes object
@@ -50,30 +59,165 @@ func newObjectStateStore(backend backend) ObjectStateStore {
 	}
 }
 
-func newKubernetesStateStoreCreator(namespace string) newObjectStateStoreFunc {
+// lockedStateStore serializes access to another state store, so that it can be
+// used by multiple goroutines. This is necessary because all states of a primary
+// object and its related objects are stored in the same object.
+type lockedStateStore struct {
+	lock  sync.Mutex
+	store ObjectStateStore
+}
+
+func newLockedStateStore(store ObjectStateStore) ObjectStateStore {
+	if _, ok := store.(*lockedStateStore); ok {
+		return store
+	}
+
+	return &lockedStateStore{store: store}
+}
+
+func (l *lockedStateStore) Get(source syncSide) (*unstructured.Unstructured, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.store.Get(source)
+}
+
+func (l *lockedStateStore) Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.store.Put(obj, clusterName, subresources)
+}
+
+func (l *lockedStateStore) GetOriginal(source syncSide) (*unstructured.Unstructured, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.store.GetOriginal(source)
+}
+
+func (l *lockedStateStore) PutOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.store.PutOriginal(obj, clusterName, subresources)
+}
+
+// StateOptions configure how and where the object states are stored.
+type StateOptions struct {
+	// Namespace is the namespace on the service cluster in which states are stored.
+	Namespace string
+	// Backend is the backend used to store states. Defaults to state.BackendSecret.
+	Backend state.BackendType
+	// PreviousBackend can be set to migrate states from another backend: states that
+	// are not found in Backend are read from PreviousBackend and removed from it once
+	// they have been written to Backend.
+	PreviousBackend state.BackendType
+}
+
+// ForPublishedResource returns the options to use for the given PublishedResource,
+// which can store its states in its own namespace.
+func (o StateOptions) ForPublishedResource(pubRes *syncagentv1alpha1.PublishedResource) StateOptions {
+	if pubRes.Spec.StateNamespace != "" {
+		o.Namespace = pubRes.Spec.StateNamespace
+	}
+
+	return o
+}
+
+// EnsureStateNamespace creates the namespace in which the states for the given
+// PublishedResource are stored, if the PublishedResource configures its own
+// namespace and it does not exist yet. The global state namespace is never created.
+func EnsureStateNamespace(ctx context.Context, client ctrlruntimeclient.Client, pubRes *syncagentv1alpha1.PublishedResource, agentName string) error {
+	name := pubRes.Spec.StateNamespace
+	if name == "" {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	err := client.Get(ctx, types.NamespacedName{Name: name}, ns)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	ns.Name = name
+	ns.Labels = map[string]string{
+		state.LabelName: state.LabelValue,
+		agentNameLabel:  agentName,
+	}
+	ns.Annotations = map[string]string{
+		syncagentv1alpha1.PublishedResourceAnnotation: pubRes.Name,
+	}
+
+	if err := client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+func newStateStoreCreator(opts StateOptions) newObjectStateStoreFunc {
 	return func(primaryObject, stateCluster syncSide) ObjectStateStore {
-		return newObjectStateStore(newKubernetesBackend(namespace, primaryObject, stateCluster))
+		return newObjectStateStore(newKeyedBackend(stateCluster.ctx, opts.backendFor(primaryObject, stateCluster)))
 	}
 }
 
+// backendFor returns the backend for the state object of the given primary object.
+func (o StateOptions) backendFor(primaryObject, stateCluster syncSide) state.Backend {
+	current := newStateBackend(o.Namespace, o.Backend, primaryObject, stateCluster)
+
+	// Secrets and compressed Secrets share the same objects, so no migration is necessary.
+	if o.PreviousBackend == "" || state.IsSecretBackend(o.PreviousBackend) == state.IsSecretBackend(o.Backend) {
+		return current
+	}
+
+	previous := newStateBackend(o.Namespace, o.PreviousBackend, primaryObject, stateCluster)
+
+	return state.NewMigratingBackend(current, previous)
+}
+
+func newStateBackend(namespace string, backendType state.BackendType, primaryObject, stateCluster syncSide) state.Backend {
+	name := types.NamespacedName{
+		Name:      stateObjectName(primaryObject),
+		Namespace: namespace,
+	}
+
+	return state.NewBackend(stateCluster.client, backendType, name, stateObjectLabels(primaryObject))
+}
+
 func (op *objectStateStore) Get(source syncSide) (*unstructured.Unstructured, error) {
 	data, err := op.backend.Get(source.object, source.clusterName)
 	if err != nil {
 		return nil, err
 	}
 
+	return decodeState(data), nil
+}
+
+func (op *objectStateStore) GetOriginal(source syncSide) (*unstructured.Unstructured, error) {
+	data, err := op.backend.GetOriginal(source.object, source.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeState(data), nil
+}
+
+// decodeState returns the stored state as an object, or nil if no (valid) state
+// is stored.
+func decodeState(data []byte) *unstructured.Unstructured {
 	lastKnown := &unstructured.Unstructured{}
 	if err := lastKnown.UnmarshalJSON(data); err != nil {
 		// if no last-known-state annotation exists or it's defective, the destination object is
 		// technically broken and we have to fall back to a full update
-		return nil, nil
+		return nil
 	}
 
-	return lastKnown, nil
+	return lastKnown
 }
 
 func (op *objectStateStore) Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error {
-	encoded, err := op.snapshotObject(obj, subresources)
+	encoded, err := snapshotObject(obj, subresources)
 	if err != nil {
 		return err
 	}
@@ -81,7 +225,28 @@ func (op *objectStateStore) Put(obj *unstructured.Unstructured, clusterName logi
 	return op.backend.Put(obj, clusterName, []byte(encoded))
 }
 
-func (op *objectStateStore) snapshotObject(obj *unstructured.Unstructured, subresources []string) (string, error) {
+func (op *objectStateStore) PutOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name, subresources []string) error {
+	encoded, err := snapshotObject(obj, subresources)
+	if err != nil {
+		return err
+	}
+
+	return op.backend.PutOriginal(obj, clusterName, []byte(encoded))
+}
+
+// SnapshotObject returns the given object the way a syncer for the given local CRD
+// version would store it as its last-known state, so that it can be compared to
+// the stored state.
+func SnapshotObject(obj *unstructured.Unstructured, localCRD *apiextensionsv1.CustomResourceDefinition, version string) (string, error) {
+	subresources, _ := crdSubresources(localCRD, version)
+	if subresources == nil {
+		return "", fmt.Errorf("CRD %s does not contain version %s", localCRD.Name, version)
+	}
+
+	return snapshotObject(obj, subresources)
+}
+
+func snapshotObject(obj *unstructured.Unstructured, subresources []string) (string, error) {
 	obj = obj.DeepCopy()
 	if err := stripMetadata(obj); err != nil {
 		return "", err
@@ -104,79 +269,60 @@ func (op *objectStateStore) snapshotObject(obj *unstructured.Unstructured, subre
 type backend interface {
 	Get(obj *unstructured.Unstructured, clusterName logicalcluster.Name) ([]byte, error)
 	Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, data []byte) error
+	GetOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name) ([]byte, error)
+	PutOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name, data []byte) error
+	Delete(obj *unstructured.Unstructured, clusterName logicalcluster.Name) error
 }
 
-type kubernetesBackend struct {
-	secretName   types.NamespacedName
-	labels       labels.Set
-	stateCluster syncSide
+// stateObjectName returns the name of the Secret or ObjectState that stores the
+// states for the given primary object and its related objects.
+func stateObjectName(primaryObject syncSide) string {
+	return state.ObjectName(primaryObject.clusterName, primaryObject.object)
 }
 
-func hashObject(obj *unstructured.Unstructured) string {
-	return crypto.ShortHash(map[string]any{
-		"apiVersion": obj.GetAPIVersion(),
-		"kind":       obj.GetKind(),
-		"namespace":  obj.GetNamespace(),
-		"name":       obj.GetName(),
-	})
-}
-
-func newKubernetesBackend(namespace string, primaryObject, stateCluster syncSide) *kubernetesBackend {
-	shortKeyHash := hashObject(primaryObject.object)
-
-	secretLabels := newObjectKey(primaryObject.object, primaryObject.clusterName, primaryObject.workspacePath).Labels()
-	secretLabels[objectStateLabelName] = objectStateLabelValue
+// stateObjectLabels returns the labels for the Secret or ObjectState that stores the
+// states for the given primary object.
+func stateObjectLabels(primaryObject syncSide) labels.Set {
+	stateLabels := newObjectKey(primaryObject.object, primaryObject.clusterName, primaryObject.workspacePath).Labels()
+	stateLabels[state.LabelName] = state.LabelValue
 
-	return &kubernetesBackend{
-		secretName: types.NamespacedName{
-			// trim hash down; 20 was chosen at random
-			Name:      fmt.Sprintf("obj-state-%s-%s", primaryObject.clusterName, shortKeyHash),
-			Namespace: namespace,
-		},
-		labels:       secretLabels,
-		stateCluster: stateCluster,
-	}
+	return stateLabels
 }
 
-func (b *kubernetesBackend) Get(obj *unstructured.Unstructured, clusterName logicalcluster.Name) ([]byte, error) {
-	secret := corev1.Secret{}
-	if err := b.stateCluster.client.Get(b.stateCluster.ctx, b.secretName, &secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
-		return nil, err
-	}
-
-	sourceKey := newObjectKey(obj, clusterName, logicalcluster.None).Key()
-	data, ok := secret.Data[sourceKey]
-	if !ok {
-		return nil, nil
-	}
-
-	return data, nil
+// keyedBackend stores the states of objects in a state.Backend, using the
+// same keys as all other tools that work with states.
+type keyedBackend struct {
+	ctx     context.Context
+	backend state.Backend
 }
 
-func (b *kubernetesBackend) Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, data []byte) error {
-	secret := corev1.Secret{}
-	if err := b.stateCluster.client.Get(b.stateCluster.ctx, b.secretName, &secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
-		return err
+func newKeyedBackend(ctx context.Context, backend state.Backend) *keyedBackend {
+	return &keyedBackend{
+		ctx:     ctx,
+		backend: backend,
 	}
+}
 
-	if secret.Data == nil {
-		secret.Data = map[string][]byte{}
-	}
+func (b *keyedBackend) Get(obj *unstructured.Unstructured, clusterName logicalcluster.Name) ([]byte, error) {
+	return b.backend.Get(b.ctx, state.Key(clusterName, obj))
+}
 
-	sourceKey := newObjectKey(obj, clusterName, logicalcluster.None).Key()
-	secret.Data[sourceKey] = data
-	secret.Labels = b.labels
+func (b *keyedBackend) Put(obj *unstructured.Unstructured, clusterName logicalcluster.Name, data []byte) error {
+	return b.backend.Put(b.ctx, state.Key(clusterName, obj), data)
+}
 
-	var err error
+func (b *keyedBackend) GetOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name) ([]byte, error) {
+	return b.backend.Get(b.ctx, state.OriginalKey(clusterName, obj))
+}
 
-	if secret.Namespace == "" {
-		secret.Name = b.secretName.Name
-		secret.Namespace = b.secretName.Namespace
+func (b *keyedBackend) PutOriginal(obj *unstructured.Unstructured, clusterName logicalcluster.Name, data []byte) error {
+	return b.backend.Put(b.ctx, state.OriginalKey(clusterName, obj), data)
+}
 
-		err = b.stateCluster.client.Create(b.stateCluster.ctx, &secret)
-	} else {
-		err = b.stateCluster.client.Update(b.stateCluster.ctx, &secret)
+func (b *keyedBackend) Delete(obj *unstructured.Unstructured, clusterName logicalcluster.Name) error {
+	if err := b.backend.Delete(b.ctx, state.OriginalKey(clusterName, obj)); err != nil {
+		return err
 	}
 
-	return err
+	return b.backend.Delete(b.ctx, state.Key(clusterName, obj))
 }
@@ -18,12 +18,23 @@ package sync
 
 import (
 	"context"
+	"encoding/base64"
+	"math/rand"
+	"strings"
 	"testing"
 
 	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestStateStoreBasics(t *testing.T) {
@@ -49,7 +60,7 @@ func TestStateStoreBasics(t *testing.T) {
 		client: serviceClusterClient,
 	}
 
-	storeCreator := newKubernetesStateStoreCreator(stateNamespace)
+	storeCreator := newStateStoreCreator(StateOptions{Namespace: stateNamespace})
 	store := storeCreator(primaryObjectSide, stateSide)
 
 	///////////////////////////////////////
@@ -192,3 +203,380 @@ func TestStateStoreBasics(t *testing.T) {
 	delete(thirdObject.Object, "status")
 	assertObjectsEqual(t, "RemoteThing", thirdObject, result)
 }
+
+func TestStateStoreDetectsConcurrentModifications(t *testing.T) {
+	primaryObject := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: "Miss Scarlet",
+		},
+	}, withKind("RemoteThing"))
+
+	serviceClusterClient := buildFakeClient()
+	ctx := context.Background()
+
+	primaryObjectSide := syncSide{
+		object: primaryObject,
+	}
+
+	stateSide := syncSide{
+		ctx:    ctx,
+		client: serviceClusterClient,
+	}
+
+	storeCreator := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})
+
+	// store an initial state
+	if err := storeCreator(primaryObjectSide, stateSide).Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	// start a new reconciliation, which reads the current state
+	store := storeCreator(primaryObjectSide, stateSide)
+	if _, err := store.Get(primaryObjectSide); err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+
+	// meanwhile, another reconciliation stores a newer state
+	updatedObject := primaryObject.DeepCopy()
+	updatedObject.Object["spec"] = map[string]any{"username": "Colonel Mustard"}
+
+	if err := storeCreator(primaryObjectSide, stateSide).Put(updatedObject, "", nil); err != nil {
+		t.Fatalf("Failed to store updated object: %v", err)
+	}
+
+	// the first reconciliation must not be able to overwrite the newer state
+	staleObject := primaryObject.DeepCopy()
+	staleObject.Object["spec"] = map[string]any{"username": "Professor Plum"}
+
+	err := store.Put(staleObject, "", nil)
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("Expected a conflict error, but got %v.", err)
+	}
+}
+
+func TestStateStoreMigratesBetweenBackends(t *testing.T) {
+	primaryObject := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: "Miss Scarlet",
+		},
+	}, withKind("RemoteThing"))
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register scheme: %v", err)
+	}
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register scheme: %v", err)
+	}
+
+	serviceClusterClient := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	primaryObjectSide := syncSide{
+		object: primaryObject,
+	}
+
+	stateSide := syncSide{
+		ctx:    ctx,
+		client: serviceClusterClient,
+	}
+
+	// store a state in a Secret
+	secretStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(primaryObjectSide, stateSide)
+	if err := secretStore.Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	// switch to ObjectStates and ensure the old state is still found
+	migratingOptions := StateOptions{
+		Namespace:       "kcp-system",
+		Backend:         state.BackendObjectState,
+		PreviousBackend: state.BackendSecret,
+	}
+
+	result, err := newStateStoreCreator(migratingOptions)(primaryObjectSide, stateSide).Get(primaryObjectSide)
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+	if result == nil {
+		t.Fatal("Could not retrieve state from previous backend.")
+	}
+
+	assertObjectsEqual(t, "RemoteThing", primaryObject, result)
+
+	// writing the state again must move it to the new backend
+	if err := newStateStoreCreator(migratingOptions)(primaryObjectSide, stateSide).Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	secrets := corev1.SecretList{}
+	if err := serviceClusterClient.List(ctx, &secrets); err != nil {
+		t.Fatalf("Failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 0 {
+		t.Fatalf("Expected state Secret to be removed, but found %d.", len(secrets.Items))
+	}
+
+	states := syncagentv1alpha1.ObjectStateList{}
+	if err := serviceClusterClient.List(ctx, &states); err != nil {
+		t.Fatalf("Failed to list object states: %v", err)
+	}
+	if len(states.Items) != 1 {
+		t.Fatalf("Expected exactly 1 ObjectState, got %d.", len(states.Items))
+	}
+
+	result, err = newStateStoreCreator(StateOptions{Namespace: "kcp-system", Backend: state.BackendObjectState})(primaryObjectSide, stateSide).Get(primaryObjectSide)
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+
+	assertObjectsEqual(t, "RemoteThing", primaryObject, result)
+}
+
+func TestStateStoreCompressesLargeStates(t *testing.T) {
+	primaryObject := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: strings.Repeat("Professor Plum ", state.CompressionThreshold/10),
+		},
+	}, withKind("RemoteThing"))
+
+	serviceClusterClient := buildFakeClient()
+	ctx := context.Background()
+
+	primaryObjectSide := syncSide{
+		object: primaryObject,
+	}
+
+	stateSide := syncSide{
+		ctx:    ctx,
+		client: serviceClusterClient,
+	}
+
+	store := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(primaryObjectSide, stateSide)
+
+	if err := store.Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: "kcp-system", Name: stateObjectName(primaryObjectSide)}
+	if err := serviceClusterClient.Get(ctx, key, secret); err != nil {
+		t.Fatalf("Failed to get state Secret: %v", err)
+	}
+
+	for _, data := range secret.Data {
+		if !state.IsCompressed(data) {
+			t.Fatal("Expected large state to be compressed.")
+		}
+
+		if len(data) > state.CompressionThreshold {
+			t.Fatalf("Expected compressed state to be smaller than %d bytes, but it is %d bytes.", state.CompressionThreshold, len(data))
+		}
+	}
+
+	result, err := store.Get(primaryObjectSide)
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+
+	assertObjectsEqual(t, "RemoteThing", primaryObject, result)
+
+	// storing the same state again must not update the Secret
+	if err := store.Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	unchanged := &corev1.Secret{}
+	if err := serviceClusterClient.Get(ctx, key, unchanged); err != nil {
+		t.Fatalf("Failed to get state Secret: %v", err)
+	}
+
+	if unchanged.ResourceVersion != secret.ResourceVersion {
+		t.Fatal("Expected unchanged state to not update the Secret.")
+	}
+}
+
+func TestStateStoreChunksHugeStates(t *testing.T) {
+	// random data does not compress well, so the state stays large even when compressed
+	random := make([]byte, 3*state.MaxChunkSize)
+	if _, err := rand.New(rand.NewSource(0)).Read(random); err != nil {
+		t.Fatalf("Failed to generate random data: %v", err)
+	}
+
+	primaryObject := newUnstructured(&dummyv1alpha1.Thing{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-test-thing",
+		},
+		Spec: dummyv1alpha1.ThingSpec{
+			Username: base64.StdEncoding.EncodeToString(random),
+		},
+	}, withKind("RemoteThing"))
+
+	serviceClusterClient := buildFakeClient()
+	ctx := context.Background()
+
+	primaryObjectSide := syncSide{
+		object: primaryObject,
+	}
+
+	stateSide := syncSide{
+		ctx:    ctx,
+		client: serviceClusterClient,
+	}
+
+	storeCreator := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})
+
+	countSecrets := func() int {
+		secrets := corev1.SecretList{}
+		if err := serviceClusterClient.List(ctx, &secrets); err != nil {
+			t.Fatalf("Failed to list secrets: %v", err)
+		}
+
+		return len(secrets.Items)
+	}
+
+	if err := storeCreator(primaryObjectSide, stateSide).Put(primaryObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	if count := countSecrets(); count < 3 {
+		t.Fatalf("Expected state to be split into multiple Secrets, but found %d Secret(s).", count)
+	}
+
+	// chunks must be distinguishable from states and be owned by the state Secret
+	states := corev1.SecretList{}
+	if err := serviceClusterClient.List(ctx, &states, ctrlruntimeclient.HasLabels{state.LabelName}); err != nil {
+		t.Fatalf("Failed to list states: %v", err)
+	}
+
+	if len(states.Items) != 1 {
+		t.Fatalf("Expected exactly one state Secret, but found %d.", len(states.Items))
+	}
+
+	chunks := corev1.SecretList{}
+	if err := serviceClusterClient.List(ctx, &chunks, ctrlruntimeclient.HasLabels{state.ChunkLabelName}); err != nil {
+		t.Fatalf("Failed to list chunks: %v", err)
+	}
+
+	if len(chunks.Items) != countSecrets()-1 {
+		t.Fatalf("Expected all but the state Secret to be labelled as chunks, but found %d chunk(s).", len(chunks.Items))
+	}
+
+	for _, chunk := range chunks.Items {
+		if owners := chunk.OwnerReferences; len(owners) != 1 || owners[0].Kind != "Secret" || owners[0].Name != states.Items[0].Name {
+			t.Errorf("Expected chunk %s to be owned by the state Secret, but got %+v.", chunk.Name, owners)
+		}
+	}
+
+	result, err := storeCreator(primaryObjectSide, stateSide).Get(primaryObjectSide)
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+
+	assertObjectsEqual(t, "RemoteThing", primaryObject, result)
+
+	// shrinking the state must remove the chunks
+	smallObject := primaryObject.DeepCopy()
+	smallObject.Object["spec"] = map[string]any{"username": "Mrs. White"}
+
+	if err := storeCreator(primaryObjectSide, stateSide).Put(smallObject, "", nil); err != nil {
+		t.Fatalf("Failed to store object: %v", err)
+	}
+
+	if count := countSecrets(); count != 1 {
+		t.Fatalf("Expected chunks to be removed, but found %d Secrets.", count)
+	}
+
+	result, err = storeCreator(primaryObjectSide, stateSide).Get(primaryObjectSide)
+	if err != nil {
+		t.Fatalf("Failed to get object: %v", err)
+	}
+
+	assertObjectsEqual(t, "RemoteThing", smallObject, result)
+}
+
+func TestEnsureStateNamespace(t *testing.T) {
+	existing := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-b-states",
+			Labels: map[string]string{"owner": "team-b"},
+		},
+	}
+
+	testcases := []struct {
+		name           string
+		stateNamespace string
+		expectedLabels map[string]string
+	}{
+		{
+			name:           "no namespace configured",
+			stateNamespace: "",
+		},
+		{
+			name:           "namespace is created",
+			stateNamespace: "team-a-states",
+			expectedLabels: map[string]string{
+				state.LabelName: state.LabelValue,
+				agentNameLabel:  "my-agent",
+			},
+		},
+		{
+			name:           "existing namespace is not modified",
+			stateNamespace: "team-b-states",
+			expectedLabels: map[string]string{"owner": "team-b"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(existing.DeepCopy()).Build()
+
+			pubRes := &syncagentv1alpha1.PublishedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-pr"},
+				Spec: syncagentv1alpha1.PublishedResourceSpec{
+					StateNamespace: testcase.stateNamespace,
+				},
+			}
+
+			if err := EnsureStateNamespace(ctx, client, pubRes, "my-agent"); err != nil {
+				t.Fatalf("Failed to ensure state namespace: %v", err)
+			}
+
+			namespaces := &corev1.NamespaceList{}
+			if err := client.List(ctx, namespaces); err != nil {
+				t.Fatalf("Failed to list namespaces: %v", err)
+			}
+
+			if testcase.stateNamespace == "" {
+				if len(namespaces.Items) != 1 {
+					t.Fatalf("Expected no namespace to be created, but found %d namespaces.", len(namespaces.Items))
+				}
+				return
+			}
+
+			ns := &corev1.Namespace{}
+			if err := client.Get(ctx, types.NamespacedName{Name: testcase.stateNamespace}, ns); err != nil {
+				t.Fatalf("Failed to get state namespace: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(ns.Labels, testcase.expectedLabels) {
+				t.Errorf("Expected labels %v, got %v.", testcase.expectedLabels, ns.Labels)
+			}
+
+			opts := StateOptions{Namespace: "global"}
+			if got := opts.ForPublishedResource(pubRes).Namespace; got != testcase.stateNamespace {
+				t.Errorf("Expected state options to use namespace %q, got %q.", testcase.stateNamespace, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// selectStatusFields returns a copy of the given status that only contains the fields
+// at the given dot-separated paths. Paths that do not exist in the status are ignored.
+// If no field is selected, nil is returned.
+func selectStatusFields(status any, fields []string) (any, error) {
+	statusMap, ok := status.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	result := map[string]any{}
+
+	for _, path := range fields {
+		fieldPath := strings.Split(path, ".")
+
+		value, found, err := unstructured.NestedFieldNoCopy(statusMap, fieldPath...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status field %s: %w", path, err)
+		}
+
+		if !found {
+			continue
+		}
+
+		if err := unstructured.SetNestedField(result, runtime.DeepCopyJSONValue(value), fieldPath...); err != nil {
+			return nil, fmt.Errorf("failed to set status field %s: %w", path, err)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	return result, nil
+}
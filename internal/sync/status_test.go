@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+)
+
+func TestSelectStatusFields(t *testing.T) {
+	status := map[string]any{
+		"phase": "Running",
+		"endpoint": map[string]any{
+			"host": "example.com",
+			"port": int64(443),
+		},
+		"nodeAllocations": []any{"node-a", "node-b"},
+	}
+
+	testcases := []struct {
+		name     string
+		status   any
+		fields   []string
+		expected any
+	}{
+		{
+			name:   "select top-level field",
+			status: status,
+			fields: []string{"phase"},
+			expected: map[string]any{
+				"phase": "Running",
+			},
+		},
+		{
+			name:   "select nested field",
+			status: status,
+			fields: []string{"phase", "endpoint.host"},
+			expected: map[string]any{
+				"phase": "Running",
+				"endpoint": map[string]any{
+					"host": "example.com",
+				},
+			},
+		},
+		{
+			name:     "missing fields are ignored",
+			status:   status,
+			fields:   []string{"doesnotexist"},
+			expected: nil,
+		},
+		{
+			name:     "no status",
+			status:   nil,
+			fields:   []string{"phase"},
+			expected: nil,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			selected, err := selectStatusFields(testcase.status, testcase.fields)
+			if err != nil {
+				t.Fatalf("Failed to select fields: %v", err)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, selected); changes != "" {
+				t.Errorf("Did not get expected status:\n%s", changes)
+			}
+		})
+	}
+}
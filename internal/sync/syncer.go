@@ -17,22 +17,39 @@ limitations under the License.
 package sync
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/kcp-dev/api-syncagent/internal/mutation"
 	"github.com/kcp-dev/api-syncagent/internal/projection"
+	"github.com/kcp-dev/api-syncagent/internal/readiness"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/naming"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type newObjectStateStoreFunc func(primaryObject, stateCluster syncSide) ObjectStateStore
 
+// SubresourceDiscoverer determines the subresources of an API via discovery. It is
+// used for APIs whose local CRD does not describe their subresources, like built-in
+// or aggregated APIs.
+type SubresourceDiscoverer interface {
+	RetrieveSubresources(gvk schema.GroupVersionKind) ([]string, error)
+}
+
 type ResourceSyncer struct {
 	log *zap.SugaredLogger
 
@@ -41,10 +58,26 @@ type ResourceSyncer struct {
 	pubRes       *syncagentv1alpha1.PublishedResource
 	localCRD     *apiextensionsv1.CustomResourceDefinition
 	subresources []string
+	// scale is only set if the CRD has a scale subresource
+	scale *apiextensionsv1.CustomResourceSubresourceScale
 
 	destDummy *unstructured.Unstructured
+	remoteGVK schema.GroupVersionKind
+
+	mutator  mutation.Mutator
+	recorder record.EventRecorder
+	// remoteEvents is only set if Events in kcp are enabled
+	remoteEvents *remoteEventRecorder
+
+	// readiness is only set if readiness rules are configured
+	readiness *readiness.Tracker
 
-	mutator mutation.Mutator
+	// latency measures how long it takes to synchronize changes
+	latency *latencyTracker
+
+	// objectLocks ensures that each remote object is only processed by one
+	// goroutine at a time
+	objectLocks *keyedMutex
 
 	agentName string
 
@@ -58,8 +91,10 @@ func NewResourceSyncer(
 	remoteClient ctrlruntimeclient.Client,
 	pubRes *syncagentv1alpha1.PublishedResource,
 	localCRD *apiextensionsv1.CustomResourceDefinition,
+	discoverer SubresourceDiscoverer,
 	mutator mutation.Mutator,
-	stateNamespace string,
+	recorder record.EventRecorder,
+	stateOptions StateOptions,
 	agentName string,
 ) (*ResourceSyncer, error) {
 	// create a dummy that represents the type used on the local service cluster
@@ -71,26 +106,19 @@ func NewResourceSyncer(
 	remoteGVK := projection.PublishedResourceProjectedGVK(pubRes)
 
 	// determine whether the CRD has a status subresource in the relevant version
-	subresources := []string{}
-	versionFound := false
-
-	for _, version := range localCRD.Spec.Versions {
-		if version.Name == pubRes.Spec.Resource.Version {
-			versionFound = true
-
-			if sr := version.Subresources; sr != nil {
-				if sr.Scale != nil {
-					subresources = append(subresources, "scale")
-				}
-				if sr.Status != nil {
-					subresources = append(subresources, "status")
-				}
-			}
-		}
+	subresources, scale, err := resolveSubresources(localCRD, discoverer, localGVK)
+	if err != nil {
+		return nil, err
 	}
 
-	if !versionFound {
-		return nil, fmt.Errorf("CRD %s does not define version %s requested by PublishedResource", pubRes.Spec.Resource.APIGroup, pubRes.Spec.Resource.Version)
+	var readinessTracker *readiness.Tracker
+	if pubRes.Spec.Readiness != nil {
+		readinessTracker = readiness.NewTracker(pubRes.Name)
+	}
+
+	var remoteEvents *remoteEventRecorder
+	if pubRes.Spec.EnableRemoteEvents {
+		remoteEvents = newRemoteEventRecorder(remoteClient, agentName, log)
 	}
 
 	return &ResourceSyncer{
@@ -100,13 +128,77 @@ func NewResourceSyncer(
 		pubRes:              pubRes,
 		localCRD:            localCRD,
 		subresources:        subresources,
+		scale:               scale,
 		destDummy:           localDummy,
+		remoteGVK:           remoteGVK,
 		mutator:             mutator,
+		recorder:            recorder,
+		remoteEvents:        remoteEvents,
+		readiness:           readinessTracker,
+		latency:             newLatencyTracker(pubRes.Name),
+		objectLocks:         newKeyedMutex(),
 		agentName:           agentName,
-		newObjectStateStore: newKubernetesStateStoreCreator(stateNamespace),
+		newObjectStateStore: newStateStoreCreator(stateOptions.ForPublishedResource(pubRes)),
 	}, nil
 }
 
+// resolveSubresources determines the subresources of the given GVK based on the
+// CRD. If the CRD does not describe the subresources of the version, the discoverer
+// (if given) is asked instead.
+func resolveSubresources(crd *apiextensionsv1.CustomResourceDefinition, discoverer SubresourceDiscoverer, gvk schema.GroupVersionKind) ([]string, *apiextensionsv1.CustomResourceSubresourceScale, error) {
+	subresources, described := crdSubresources(crd, gvk.Version)
+	if subresources == nil {
+		return nil, nil, fmt.Errorf("CRD %s does not define version %s requested by PublishedResource", gvk.Group, gvk.Version)
+	}
+
+	if described || discoverer == nil {
+		return subresources, crdScaleSubresource(crd, gvk.Version), nil
+	}
+
+	subresources, err := discoverer.RetrieveSubresources(gvk)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover subresources of %v: %w", gvk, err)
+	}
+
+	var scale *apiextensionsv1.CustomResourceSubresourceScale
+	if slices.Contains(subresources, "scale") {
+		scale = defaultScaleSubresource()
+	}
+
+	return subresources, scale, nil
+}
+
+// crdSubresources returns the subresources defined for the given version in the CRD.
+// For built-in and aggregated APIs, the CRD is synthesized by the discovery client,
+// which usually already determined the subresources using the discovery API. The
+// boolean is false if the CRD does not describe the subresources of the version,
+// in which case the returned list is nil if the version does not exist at all.
+func crdSubresources(crd *apiextensionsv1.CustomResourceDefinition, version string) ([]string, bool) {
+	for _, v := range crd.Spec.Versions {
+		if v.Name != version {
+			continue
+		}
+
+		subresources := []string{}
+
+		sr := v.Subresources
+		if sr == nil {
+			return subresources, false
+		}
+
+		if sr.Scale != nil {
+			subresources = append(subresources, "scale")
+		}
+		if sr.Status != nil {
+			subresources = append(subresources, "status")
+		}
+
+		return subresources, true
+	}
+
+	return nil, false
+}
+
 // Process is the primary entrypoint for object synchronization. This function will create/update
 // the local primary object (i.e. the copy of the remote object), sync any local status back to the
 // remote object and then also synchronize all related resources. It also handles object deletion
@@ -115,7 +207,19 @@ func NewResourceSyncer(
 // case, the caller should re-fetch the remote object and call Process() again (most likely in the
 // next reconciliation). Only when (false, nil) is returned is the entire process finished.
 func (s *ResourceSyncer) Process(ctx Context, remoteObj *unstructured.Unstructured) (requeue bool, err error) {
-	log := s.log.With("source-object", newObjectKey(remoteObj, ctx.clusterName, ctx.workspacePath))
+	remoteKey := newObjectKey(remoteObj, ctx.clusterName, ctx.workspacePath)
+	log := s.log.With("source-object", remoteKey)
+
+	// without the workspace path, the local object name cannot be determined reliably
+	if ctx.workspacePath.Empty() && naming.UsesWorkspacePath(s.pubRes.Spec.Naming) {
+		return false, errors.New("naming rules use the workspace path, but enableWorkspacePaths is not enabled")
+	}
+
+	// The controller's workqueue already never hands out the same object to multiple
+	// workers, but Process() can also be called from elsewhere, so guard against
+	// interleaved synchronizations that would store stale object states.
+	unlock := s.objectLocks.Lock(remoteKey.String())
+	defer unlock()
 
 	// find the local equivalent object in the local service cluster
 	localObj, err := s.findLocalObject(ctx, remoteObj)
@@ -123,6 +227,37 @@ func (s *ResourceSyncer) Process(ctx Context, remoteObj *unstructured.Unstructur
 		return false, fmt.Errorf("failed to find local equivalent: %w", err)
 	}
 
+	// paused objects are not synchronized at all, not even when being deleted
+	paused := isPaused(remoteObj) || isPaused(localObj)
+
+	updated, err := s.updatePausedCondition(log, ctx, remoteObj, paused)
+	if err != nil {
+		return false, fmt.Errorf("failed to update paused condition: %w", err)
+	}
+
+	if paused {
+		log.Debug("Object is paused, skipping")
+		return false, nil
+	}
+
+	// the status update will trigger a new reconciliation
+	if updated {
+		return true, nil
+	}
+
+	// Determine the local name up front, so that naming templates that cannot be
+	// evaluated (e.g. because of a missing label) fail the synchronization early;
+	// existing local objects are never renamed.
+	var localName types.NamespacedName
+	if localObj != nil {
+		localName = types.NamespacedName{Namespace: localObj.GetNamespace(), Name: localObj.GetName()}
+	} else {
+		localName, err = projection.GenerateLocalObjectName(s.pubRes, remoteObj, ctx.clusterName, ctx.workspacePath)
+		if err != nil {
+			return false, fmt.Errorf("failed to determine local object name: %w", err)
+		}
+	}
+
 	// Do not add local-object to the log here,
 	// instead each further function will fine tune the log context.
 
@@ -151,30 +286,82 @@ func (s *ResourceSyncer) Process(ctx Context, remoteObj *unstructured.Unstructur
 		// The primary object should be labelled with the agent name.
 		agentName:    s.agentName,
 		subresources: s.subresources,
+		// replicas are synced via the scale subresource, if available
+		scale: s.scale,
 		// use the projection and renaming rules configured in the PublishedResource
-		destCreator: s.createLocalObjectCreator(ctx),
+		destCreator: s.createLocalObjectCreator(localName),
 		// for the main resource, status subresource handling is enabled (this
 		// means _allowing_ status back-syncing, it still depends on whether the
 		// status subresource even exists whether an update happens)
 		syncStatusBack: true,
+		// only sync selected status fields back, if configured
+		statusFields: s.statusFields(),
+		// reflect the local object's readiness on the remote object, if configured
+		decorateStatus: s.readinessCondition(),
 		// perform cleanup on the service cluster side when the source object
 		// in kcp is deleted
 		blockSourceDeletion: true,
 		// use the configured mutations from the PublishedResource
 		mutator: s.mutator,
+		// copy changes to selected fields on the service cluster back into kcp
+		bidirectionalFields: s.pubRes.Spec.BidirectionalFields,
+		// only copy the allowed labels and annotations
+		metadataPolicy: newMetadataPolicy(s.pubRes.Spec.MetadataSync, directionDown),
+		// never overwrite ignored fields on the service cluster
+		ignoredFields: s.ignoredFields(),
+		// revert changes to immutable fields in kcp
+		immutableFields: s.pubRes.Spec.ImmutableFields,
+		// inform consumers about what happens to their object on the service cluster
+		sourceEvents: s.remoteEvents,
+		// copy selected labels from the namespace in kcp
+		extraLabels: ctx.namespaceLabels,
+		// optionally keep the local namespace's metadata in sync with kcp
+		namespaceMetadata: newNamespaceMetadata(s.pubRes.Spec.NamespaceSync, ctx.namespace),
+		// record the source and projected identity of the resource, plus its provenance if configured
+		extraAnnotations: s.destinationAnnotations(ctx, remoteObj),
+		// record when the local object was last changed, if configured
+		syncClock: s.syncClock(),
 		// make sure the syncer can remember the current state of any object
 		stateStore: stateStore,
+		// use server-side apply, if configured
+		fieldManager: s.fieldManager(),
 		// For the main resource, we need to store metadata on the destination copy
 		// (i.e. on the service cluster), so that the original and copy are linked
 		// together and can be found.
 		metadataOnDestination: true,
 	}
 
+	// deleted objects do not need to be measured anymore
+	if remoteObj.GetDeletionTimestamp() != nil {
+		s.latency.Forget(remoteKey.String())
+	}
+
+	// Depending on the deletion policy, the local object (and its related objects)
+	// is kept and only detached from the remote object.
+	if remoteObj.GetDeletionTimestamp() != nil && localObj != nil && s.retainsLocalObjects() {
+		return s.retainLocalObject(log, stateStore, sourceSide, destSide)
+	}
+
+	// Related objects have to be cleaned up before the local primary object is deleted,
+	// as resolving them requires both primary objects.
+	if remoteObj.GetDeletionTimestamp() != nil && localObj != nil {
+		requeue, err := s.cleanupRelatedResources(log, sourceSide, destSide)
+		if err != nil {
+			return false, err
+		}
+
+		if requeue {
+			return true, nil
+		}
+	}
+
 	requeue, err = syncer.Sync(log, sourceSide, destSide)
 	if err != nil {
 		return false, err
 	}
 
+	s.trackReadiness(log, ctx, remoteObj, destSide.object)
+
 	// the patch above would trigger a new reconciliation anyway
 	if requeue {
 		return true, nil
@@ -187,7 +374,138 @@ func (s *ResourceSyncer) Process(ctx Context, remoteObj *unstructured.Unstructur
 	// it modifies the state of the world, otherwise the objects in
 	// source/dest.object might be ouf date.
 
-	return s.processRelatedResources(log, stateStore, sourceSide, destSide)
+	requeue, err = s.processRelatedResources(log, stateStore, sourceSide, destSide)
+	if err == nil && !requeue {
+		s.latency.Synced(remoteKey.String(), remoteObj)
+	}
+
+	return requeue, err
+}
+
+// RecordSyncError records an Event about a failed synchronization on the given
+// remote object, if Events in kcp are enabled.
+func (s *ResourceSyncer) RecordSyncError(ctx Context, remoteObj *unstructured.Unstructured, message string) {
+	s.remoteEvents.Event(ctx.remote, remoteObj, corev1.EventTypeWarning, reasonSyncFailed, message)
+}
+
+// SetLatencyObserver sets a function that is called with the latency of every
+// change that has been fully synchronized.
+func (s *ResourceSyncer) SetLatencyObserver(observer func(time.Duration)) {
+	if s.latency != nil {
+		s.latency.observer = observer
+	}
+}
+
+// fieldManager returns the field manager used for server-side apply, or an empty
+// string if objects are updated using merge patches.
+func (s *ResourceSyncer) fieldManager() string {
+	if settings := s.pubRes.Spec.Sync; settings == nil || settings.Strategy != syncagentv1alpha1.SyncStrategyServerSideApply {
+		return ""
+	}
+
+	return "api-syncagent/" + s.agentName
+}
+
+// relatedObjectWorkers returns the number of related objects of a single primary
+// object that are synchronized in parallel.
+func (s *ResourceSyncer) relatedObjectWorkers() int {
+	if settings := s.pubRes.Spec.Sync; settings != nil && settings.MaxConcurrentRelatedObjects > 0 {
+		return settings.MaxConcurrentRelatedObjects
+	}
+
+	return 1
+}
+
+// destinationAnnotations returns the static sync-related annotations for the local
+// copy of the remote object.
+func (s *ResourceSyncer) destinationAnnotations(ctx Context, remoteObj *unstructured.Unstructured) map[string]string {
+	annotations := projection.PublishedResourceIdentity(s.pubRes).Annotations()
+	maps.Copy(annotations, provenanceAnnotations(s.pubRes.Spec.Provenance, remoteObj, ctx.workspacePath))
+
+	return annotations
+}
+
+// syncClock returns the clock for the last-synced-at annotation, or nil if the
+// annotation is not enabled.
+func (s *ResourceSyncer) syncClock() func() time.Time {
+	if policy := s.pubRes.Spec.Provenance; policy == nil || !policy.LastSyncTimestamp {
+		return nil
+	}
+
+	return time.Now
+}
+
+func (s *ResourceSyncer) statusFields() []string {
+	if s.pubRes.Spec.StatusProjection == nil {
+		return nil
+	}
+
+	fields := s.pubRes.Spec.StatusProjection.Fields
+
+	// the scale subresource in kcp relies on these status fields
+	if s.scale != nil {
+		fields = append(slices.Clone(fields), scaleStatusFields(s.scale)...)
+	}
+
+	return fields
+}
+
+// ignoredFields returns the fields that are not synced as part of the regular spec
+// synchronization for the primary object.
+func (s *ResourceSyncer) ignoredFields() []string {
+	fields := ignoredFields(s.pubRes.Spec.Mutation)
+
+	// replicas are synced using the scale subresource
+	if s.scale != nil {
+		fields = append(slices.Clone(fields), scaleSpecReplicasField(s.scale))
+	}
+
+	// fields that are not published in kcp must keep their values on the service cluster
+	if schemaProjection := s.pubRes.Spec.SchemaProjection; schemaProjection != nil {
+		fields = append(slices.Clone(fields), schemaProjection.Drop...)
+	}
+
+	return fields
+}
+
+// trackReadiness evaluates the readiness of the local object and updates the metrics.
+func (s *ResourceSyncer) trackReadiness(log *zap.SugaredLogger, ctx Context, remoteObj, localObj *unstructured.Unstructured) {
+	if s.readiness == nil {
+		return
+	}
+
+	key := ctrlruntimeclient.ObjectKeyFromObject(remoteObj).String()
+
+	if remoteObj.GetDeletionTimestamp() != nil || localObj == nil {
+		s.readiness.Forget(ctx.clusterName.String(), key)
+		return
+	}
+
+	ready, err := readiness.Evaluate(s.pubRes.Spec.Readiness, localObj)
+	if err != nil {
+		log.Warnw("Failed to evaluate readiness", zap.Error(err))
+		return
+	}
+
+	s.readiness.Set(ctx.clusterName.String(), key, ready)
+}
+
+// readinessCondition returns a status decorator that sets the configured readiness
+// condition on the remote object, or nil if no such condition is configured.
+func (s *ResourceSyncer) readinessCondition() statusDecoratorFunc {
+	spec := s.pubRes.Spec.Readiness
+	if spec == nil || spec.RemoteCondition == "" {
+		return nil
+	}
+
+	return func(status any, remoteObj, localObj *unstructured.Unstructured) (any, error) {
+		ready, err := readiness.Evaluate(spec, localObj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate readiness: %w", err)
+		}
+
+		return readiness.SetCondition(status, remoteObj.Object["status"], spec.RemoteCondition, ready)
+	}
 }
 
 func (s *ResourceSyncer) findLocalObject(ctx Context, remoteObj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
@@ -214,7 +532,7 @@ func (s *ResourceSyncer) findLocalObject(ctx Context, remoteObj *unstructured.Un
 	}
 }
 
-func (s *ResourceSyncer) createLocalObjectCreator(ctx Context) objectCreatorFunc {
+func (s *ResourceSyncer) createLocalObjectCreator(mappedName types.NamespacedName) objectCreatorFunc {
 	return func(remoteObj *unstructured.Unstructured) *unstructured.Unstructured {
 		// map from the remote API into the actual, local API group
 		destObj := remoteObj.DeepCopy()
@@ -224,8 +542,6 @@ func (s *ResourceSyncer) createLocalObjectCreator(ctx Context) objectCreatorFunc
 		destScope := syncagentv1alpha1.ResourceScope(s.localCRD.Spec.Scope)
 
 		// map namespace/name
-		mappedName := projection.GenerateLocalObjectName(s.pubRes, remoteObj, ctx.clusterName)
-
 		switch destScope {
 		case syncagentv1alpha1.ClusterScoped:
 			destObj.SetNamespace("")
@@ -17,40 +17,172 @@ limitations under the License.
 package sync
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"slices"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
+	"github.com/kcp-dev/logicalcluster/v3"
 	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
-	"github.com/kcp-dev/api-syncagent/internal/mutation"
+	"github.com/kcp-dev/api-syncagent/internal/projection"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/related"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (s *ResourceSyncer) processRelatedResources(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide) (requeue bool, err error) {
-	for _, relatedResource := range s.pubRes.Spec.Related {
-		requeue, err := s.processRelatedResource(log.With("identifier", relatedResource.Identifier), stateStore, remote, local, relatedResource)
+	// the primary objects can never be related objects of themselves
+	ancestors := sets.New(relatedObjectKey(remote, remote.object), relatedObjectKey(local, local.object))
+
+	references, requeue, err := s.processRelatedResourceList(log, stateStore, remote, local, s.pubRes.Spec.Related, ancestors)
+
+	// let the consumer know which related resources could not be synced; this
+	// does not affect the related resources that were synced successfully
+	var (
+		failed []string
+		rrErr  *relatedResourcesError
+	)
+
+	if errors.As(err, &rrErr) {
+		failed = rrErr.identifiers
+	}
+
+	if updateErr := s.updateFailedRelatedResources(log, remote, failed); updateErr != nil {
+		return false, utilerrors.NewAggregate([]error{err, updateErr})
+	}
+
+	if err != nil || requeue {
+		return requeue, err
+	}
+
+	// now that all related objects were successfully synced, we can remember their details
+	// on the main object
+	return s.updateRelatedObjectReferences(log, remote, references)
+}
+
+// relatedResourcesError is returned when one or more related resources could not
+// be synchronized. A failing related resource does not prevent the others from
+// being synchronized; the failed ones are retried with the usual backoff when the
+// primary object is requeued.
+type relatedResourcesError struct {
+	identifiers []string
+	errs        []error
+}
+
+func (e *relatedResourcesError) Error() string {
+	return utilerrors.NewAggregate(e.errs).Error()
+}
+
+func (e *relatedResourcesError) Unwrap() []error {
+	return e.errs
+}
+
+// processRelatedResourceList synchronizes the given related resources, which are related
+// to the remote and local objects. Ancestors contains the keys of all objects that are
+// currently being synchronized further up in the chain of nested related resources.
+// If any related resource fails, the others are still processed and a
+// relatedResourcesError is returned.
+func (s *ResourceSyncer) processRelatedResourceList(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, relatedResources []syncagentv1alpha1.RelatedResourceSpec, ancestors sets.Set[string]) (refs []related.ObjectReference, requeue bool, err error) {
+	references := []related.ObjectReference{}
+	failed := &relatedResourcesError{}
+
+	for _, relatedResource := range relatedResources {
+		refs, req, err := s.processRelatedResource(log.With("identifier", relatedResource.Identifier), stateStore, remote, local, relatedResource, ancestors)
 		if err != nil {
-			return false, fmt.Errorf("failed to process related resource %s: %w", relatedResource.Identifier, err)
+			failed.identifiers = append(failed.identifiers, relatedResource.Identifier)
+			failed.errs = append(failed.errs, fmt.Errorf("failed to process related resource %s: %w", relatedResource.Identifier, err))
+			continue
 		}
 
-		if requeue {
-			return true, nil
-		}
+		requeue = requeue || req
+		references = append(references, refs...)
+	}
+
+	if len(failed.errs) > 0 {
+		return nil, false, failed
+	}
+
+	if requeue {
+		return nil, true, nil
+	}
+
+	return references, false, nil
+}
+
+// updateFailedRelatedResources records the identifiers of the related resources that
+// could not be synchronized on the remote object, or removes the annotation once
+// all related resources have been synchronized.
+func (s *ResourceSyncer) updateFailedRelatedResources(log *zap.SugaredLogger, remote syncSide, identifiers []string) error {
+	value := strings.Join(identifiers, ",")
+	if remote.object.GetAnnotations()[syncagentv1alpha1.FailedRelatedResourcesAnnotation] == value {
+		return nil
+	}
+
+	oldState := remote.object.DeepCopy()
+
+	annotations := remote.object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if value == "" {
+		delete(annotations, syncagentv1alpha1.FailedRelatedResourcesAnnotation)
+	} else {
+		annotations[syncagentv1alpha1.FailedRelatedResourcesAnnotation] = value
+	}
+
+	remote.object.SetAnnotations(annotations)
+
+	log.Debugw("Updating failed related resources in main object…", "failed", value)
+	if err := remote.client.Patch(remote.ctx, remote.object, ctrlruntimeclient.MergeFrom(oldState)); err != nil {
+		return fmt.Errorf("failed to update failed related resources in remote object: %w", err)
+	}
+
+	return nil
+}
+
+// relatedObjectKey uniquely identifies an object on one side of the synchronization.
+func relatedObjectKey(side syncSide, obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s|%s|%s", side.clusterName, obj.GroupVersionKind().GroupKind(), ctrlruntimeclient.ObjectKeyFromObject(obj))
+}
+
+// nestedRelatedResourceSides returns the remote and local sides to use when resolving the
+// nested related resources of a related object. If the related object does not exist on
+// the destination side yet, found is false.
+func nestedRelatedResourceSides(relRes syncagentv1alpha1.RelatedResourceSpec, origin, dest syncSide, resolved resolvedObject) (remote, local syncSide, found bool, err error) {
+	destObject := &unstructured.Unstructured{}
+	destObject.SetGroupVersionKind(projection.RelatedResourceGVK(&relRes))
+
+	if err := dest.client.Get(dest.ctx, resolved.destination, destObject); err != nil {
+		return remote, local, false, ctrlruntimeclient.IgnoreNotFound(err)
 	}
 
-	return false, nil
+	origin.object = resolved.original
+	dest.object = destObject
+
+	if relRes.Origin == "service" {
+		return dest, origin, true, nil
+	}
+
+	return origin, dest, true, nil
 }
 
 type relatedObjectAnnotation struct {
@@ -60,32 +192,59 @@ type relatedObjectAnnotation struct {
 	Kind       string `json:"kind"`
 }
 
-func (s *ResourceSyncer) processRelatedResource(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, relRes syncagentv1alpha1.RelatedResourceSpec) (requeue bool, err error) {
-	// decide what direction to sync (local->remote vs. remote->local)
-	var (
-		origin syncSide
-		dest   syncSide
-	)
+// relatedResourceSides decides what direction to sync (local->remote vs. remote->local).
+func relatedResourceSides(relRes syncagentv1alpha1.RelatedResourceSpec, remote, local syncSide) (origin, dest syncSide) {
+	if relRes.Origin == "service" {
+		return local, remote
+	}
 
+	return remote, local
+}
+
+// relatedResourceDirection returns the sync direction for a related resource.
+func relatedResourceDirection(relRes syncagentv1alpha1.RelatedResourceSpec) syncDirection {
 	if relRes.Origin == "service" {
-		origin = local
-		dest = remote
-	} else {
-		origin = remote
-		dest = local
+		return directionUp
+	}
+
+	return directionDown
+}
+
+// processRelatedResource synchronizes all objects of a single related resource. For
+// related objects that were synced into kcp, references are returned.
+func (s *ResourceSyncer) processRelatedResource(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, ancestors sets.Set[string]) (refs []related.ObjectReference, requeue bool, err error) {
+	if relRes.Origin == "both" {
+		return s.processBidirectionalRelatedResource(log, stateStore, remote, local, relRes, ancestors)
 	}
 
+	origin, dest := relatedResourceSides(relRes, remote, local)
+
 	// find the all objects on the origin side that match the given criteria
 	resolvedObjects, err := resolveRelatedResourceObjects(origin, dest, relRes)
 	if err != nil {
-		return false, fmt.Errorf("failed to get resolve origin objects: %w", err)
+		return nil, false, fmt.Errorf("failed to get resolve origin objects: %w", err)
 	}
 
+	return s.syncRelatedObjects(log, stateStore, remote, local, relRes, relRes.Origin, resolvedObjects, ancestors)
+}
+
+// syncRelatedObjects synchronizes the given resolved objects from the origin to the
+// destination side. The origin can differ from the origin configured in the related
+// resource if the related resource is bidirectional.
+func (s *ResourceSyncer) syncRelatedObjects(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, originName string, resolvedObjects []resolvedObject, ancestors sets.Set[string]) (refs []related.ObjectReference, requeue bool, err error) {
 	// no objects were found yet, that's okay
 	if len(resolvedObjects) == 0 {
-		return false, nil
+		return nil, false, nil
 	}
 
+	// From here on, the related resource behaves as if it was configured with
+	// the effective origin. Settings that only make sense for a single, fixed
+	// origin (like finalizers) are still based on the configured origin.
+	configured := relRes
+	relRes.Origin = originName
+
+	origin, dest := relatedResourceSides(relRes, remote, local)
+
 	slices.SortStableFunc(resolvedObjects, func(a, b resolvedObject) int {
 		aKey := ctrlruntimeclient.ObjectKeyFromObject(a.original).String()
 		bKey := ctrlruntimeclient.ObjectKeyFromObject(b.original).String()
@@ -93,106 +252,457 @@ func (s *ResourceSyncer) processRelatedResource(log *zap.SugaredLogger, stateSto
 		return strings.Compare(aKey, bKey)
 	})
 
+	relatedGVK := projection.RelatedResourceGVK(&relRes)
+
 	// Synchronize objects the same way the parent object was synchronized.
-	for idx, resolved := range resolvedObjects {
-		destObject := &unstructured.Unstructured{}
-		destObject.SetAPIVersion("v1") // we only support ConfigMaps and Secrets, both are in core/v1
-		destObject.SetKind(relRes.Kind)
+	workers := s.relatedObjectWorkers()
+	if workers == 1 || len(resolvedObjects) == 1 {
+		for _, resolved := range resolvedObjects {
+			objRefs, req, err := s.syncRelatedObject(log, stateStore, remote, local, configured, relRes, origin, dest, relatedGVK, resolved, ancestors)
+			if err != nil {
+				return nil, false, err
+			}
+
+			refs = append(refs, objRefs...)
+			requeue = requeue || req
+		}
+
+		return refs, requeue, nil
+	}
+
+	// all states of a primary object are kept together, so the store must not be
+	// used concurrently
+	stateStore = newLockedStateStore(stateStore)
+
+	results := make([]relatedObjectResult, len(resolvedObjects))
+
+	group := errgroup.Group{}
+	group.SetLimit(workers)
+
+	for i, resolved := range resolvedObjects {
+		group.Go(func() error {
+			objRefs, req, err := s.syncRelatedObject(log, stateStore, remote, local, configured, relRes, origin, dest, relatedGVK, resolved, ancestors)
+			results[i] = relatedObjectResult{refs: objRefs, requeue: req}
+
+			return err
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, false, err
+	}
+
+	// keep the references in the same order as if they were synchronized sequentially
+	for _, result := range results {
+		refs = append(refs, result.refs...)
+		requeue = requeue || result.requeue
+	}
+
+	return refs, requeue, nil
+}
+
+// relatedObjectResult is the outcome of synchronizing a single related object.
+type relatedObjectResult struct {
+	refs    []related.ObjectReference
+	requeue bool
+}
+
+// syncRelatedObject synchronizes a single resolved object of a related resource
+// from the origin to the destination side, followed by its nested related resources.
+// relRes has the effective origin, configured the origin configured by the user.
+func (s *ResourceSyncer) syncRelatedObject(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, configured, relRes syncagentv1alpha1.RelatedResourceSpec, origin, dest syncSide, relatedGVK schema.GroupVersionKind, resolved resolvedObject, ancestors sets.Set[string]) (refs []related.ObjectReference, requeue bool, err error) {
+	// nested related resources might lead back to an object further up the chain
+	originKey := relatedObjectKey(origin, resolved.original)
+	if ancestors.Has(originKey) {
+		log.Debugw("Skipping related object that is already part of the chain of related objects", "object", originKey)
+		return nil, false, nil
+	}
+
+	destObject := &unstructured.Unstructured{}
+	destObject.SetGroupVersionKind(relatedGVK)
+
+	if err := dest.client.Get(dest.ctx, resolved.destination, destObject); err != nil {
+		destObject = nil
+	}
+
+	sourceSide := syncSide{
+		ctx:           origin.ctx,
+		clusterName:   origin.clusterName,
+		workspacePath: origin.workspacePath,
+		client:        origin.client,
+		object:        resolved.original,
+	}
+
+	destSide := syncSide{
+		ctx:           dest.ctx,
+		clusterName:   dest.clusterName,
+		workspacePath: dest.workspacePath,
+		client:        dest.client,
+		object:        destObject,
+	}
+
+	syncer := objectSyncer{
+		// Related objects within kcp are not labelled with the agent name because it's unnecessary.
+		// agentName: "",
+		// use the same state store as we used for the main resource, to keep everything contained
+		// in one place, on the service cluster side
+		stateStore: stateStore,
+		// use server-side apply, if configured
+		fieldManager: s.fieldManager(),
+		// how to create a new destination object
+		destCreator: func(source *unstructured.Unstructured) *unstructured.Unstructured {
+			dest := source.DeepCopy()
+			dest.SetName(resolved.destination.Name)
+			dest.SetNamespace(resolved.destination.Namespace)
+
+			return dest
+		},
+		// related objects are always synced as a whole; for kinds with a status
+		// subresource, the status is simply not persisted when patching the
+		// main resource
+		subresources: nil,
+		// only sync the status back if the object originates in kcp,
+		// as the service side should never have to rely on new status infos coming
+		// from the kcp side
+		syncStatusBack: configured.Origin == "kcp",
+		// if the origin is on the remote side, we want to add a finalizer to make
+		// sure we can clean up properly
+		blockSourceDeletion: configured.Origin == "kcp",
+		// apply mutation rules and data transformations configured for the related resource
+		mutator:       newRelatedObjectMutator(relRes, s.agentName),
+		ignoredFields: ignoredFields(relRes.Mutation),
+		// the PublishedResource's metadata policy also applies to related objects
+		metadataPolicy: newMetadataPolicy(s.pubRes.Spec.MetadataSync, relatedResourceDirection(relRes)),
+		// we never want to store sync-related metadata inside kcp
+		metadataOnDestination: false,
+	}
+
+	req, err := syncer.Sync(log, sourceSide, destSide)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to sync related object: %w", err)
+	}
+
+	// allow to select related objects on the service cluster by workspace path,
+	// regardless of which side they originate from
+	if !remote.workspacePath.Empty() {
+		localKey := resolved.destination
+		if relRes.Origin == "service" {
+			localKey = types.NamespacedName{Namespace: resolved.original.GetNamespace(), Name: resolved.original.GetName()}
+		}
 
-		if err = dest.client.Get(dest.ctx, resolved.destination, destObject); err != nil {
-			destObject = nil
+		updated, err := ensureWorkspacePathMetadata(log, local, relatedGVK, localKey, remote.workspacePath)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to set workspace path on related object: %w", err)
 		}
 
-		sourceSide := syncSide{
-			ctx:         origin.ctx,
-			clusterName: origin.clusterName,
-			client:      origin.client,
-			object:      resolved.original,
+		req = req || updated
+	}
+
+	// link the related object to its primary object, if configured
+	if relRes.SetOwnerReference {
+		primary := local
+		if relRes.Origin == "service" {
+			primary = remote
 		}
 
-		destSide := syncSide{
-			ctx:         dest.ctx,
-			clusterName: dest.clusterName,
-			client:      dest.client,
-			object:      destObject,
+		updated, err := ensureOwnerReference(log, dest, primary.object, relatedGVK, resolved.destination)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to set owner reference on related object: %w", err)
 		}
 
-		syncer := objectSyncer{
-			// Related objects within kcp are not labelled with the agent name because it's unnecessary.
-			// agentName: "",
-			// use the same state store as we used for the main resource, to keep everything contained
-			// in one place, on the service cluster side
-			stateStore: stateStore,
-			// how to create a new destination object
-			destCreator: func(source *unstructured.Unstructured) *unstructured.Unstructured {
-				dest := source.DeepCopy()
-				dest.SetName(resolved.destination.Name)
-				dest.SetNamespace(resolved.destination.Namespace)
+		req = req || updated
+	}
 
-				return dest
-			},
-			// ConfigMaps and Secrets have no subresources
-			subresources: nil,
-			// only sync the status back if the object originates in kcp,
-			// as the service side should never have to rely on new status infos coming
-			// from the kcp side
-			syncStatusBack: relRes.Origin == "kcp",
-			// if the origin is on the remote side, we want to add a finalizer to make
-			// sure we can clean up properly
-			blockSourceDeletion: relRes.Origin == "kcp",
-			// apply mutation rules configured for the related resource
-			mutator: mutation.NewMutator(relRes.Mutation),
-			// we never want to store sync-related metadata inside kcp
-			metadataOnDestination: false,
-		}
-
-		req, err := syncer.Sync(log, sourceSide, destSide)
+	// Updating a related object should not immediately trigger a requeue, but
+	// the caller only requeues after all related objects are done. This is purely
+	// to not perform too many unnecessary requeues.
+	requeue = req
+
+	// remember the related object, so the user can find it
+	if relRes.Origin == "service" {
+		refs = append(refs, related.ObjectReference{
+			Identifier: relRes.Identifier,
+			APIVersion: relatedGVK.GroupVersion().String(),
+			Kind:       relatedGVK.Kind,
+			Namespace:  resolved.destination.Namespace,
+			Name:       resolved.destination.Name,
+		})
+	}
+
+	// nested related resources can only be resolved once this related object
+	// is up-to-date on both sides
+	if len(relRes.Related) > 0 && !req {
+		nestedRemote, nestedLocal, found, err := nestedRelatedResourceSides(relRes, origin, dest, resolved)
 		if err != nil {
-			return false, fmt.Errorf("failed to sync related object: %w", err)
+			return nil, false, fmt.Errorf("failed to get related object: %w", err)
 		}
 
-		// Updating a related object should not immediately trigger a requeue,
-		// but only after all related objects are done. This is purely to not perform
-		// too many unnecessary requeues.
-		requeue = requeue || req
+		if found {
+			nestedRefs, nestedRequeue, err := s.processRelatedResourceList(log, stateStore, nestedRemote, nestedLocal, relRes.Related, ancestors.Clone().Insert(originKey))
+			if err != nil {
+				return nil, false, err
+			}
 
-		// now that the related object was successfully synced, we can remember its details on the
-		// main object
-		if relRes.Origin == "service" {
-			// TODO: Improve this logic, the added index is just a hack until we find a better solution
-			// to let the user know about the related object (this annotation is not relevant for the
-			// syncing logic, it's purely for the end-user).
-			annotation := fmt.Sprintf("%s%s.%d", relatedObjectAnnotationPrefix, relRes.Identifier, idx)
+			refs = append(refs, nestedRefs...)
+			requeue = requeue || nestedRequeue
+		}
+	}
+
+	return refs, requeue, nil
+}
+
+// ensureOwnerReference adds an owner reference pointing to the owner to the related
+// object on the given side. Other owner references are left untouched.
+func ensureOwnerReference(log *zap.SugaredLogger, side syncSide, owner *unstructured.Unstructured, gvk schema.GroupVersionKind, key types.NamespacedName) (updated bool, err error) {
+	// namespaced owners cannot own objects in other namespaces
+	if owner.GetNamespace() != "" && owner.GetNamespace() != key.Namespace {
+		log.Warnw("Cannot set owner reference on related object in a different namespace than its primary object.", "related", key)
+		return false, nil
+	}
+
+	// the related object might not have been created yet
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := side.client.Get(side.ctx, key, obj); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return false, nil
+		}
+	}
+
+	oldState := obj.DeepCopy()
+
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: owner.GetAPIVersion(),
+		Kind:       owner.GetKind(),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+	}))
+
+	log.Debugw("Setting owner reference on related object…", "related", key)
+
+	patch := ctrlruntimeclient.MergeFromWithOptions(oldState, ctrlruntimeclient.MergeFromWithOptimisticLock{})
+	if err := side.client.Patch(side.ctx, obj, patch); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ensureWorkspacePathMetadata annotates and labels the local related object with
+// the workspace path of its primary object in kcp.
+func ensureWorkspacePathMetadata(log *zap.SugaredLogger, side syncSide, gvk schema.GroupVersionKind, key types.NamespacedName, path logicalcluster.Path) (updated bool, err error) {
+	// the related object might not have been created yet
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := side.client.Get(side.ctx, key, obj); err != nil {
+		return false, ctrlruntimeclient.IgnoreNotFound(err)
+	}
+
+	desiredLabels := workspacePathLabels(path)
+	desiredAnnotations := map[string]string{remoteObjectWorkspacePathAnnotation: path.String()}
+
+	if labels.SelectorFromSet(desiredLabels).Matches(labels.Set(obj.GetLabels())) && obj.GetAnnotations()[remoteObjectWorkspacePathAnnotation] == path.String() {
+		return false, nil
+	}
+
+	oldState := obj.DeepCopy()
+
+	ensureLabels(obj, desiredLabels)
+	ensureAnnotations(obj, desiredAnnotations)
+
+	log.Debugw("Setting workspace path on related object…", "related", key)
+
+	if err := side.client.Patch(side.ctx, obj, ctrlruntimeclient.MergeFrom(oldState)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// updateRelatedObjectReferences records the related objects that were synced into kcp on
+// the remote primary object, so that consumers can discover them (these annotations are
+// not relevant for the syncing logic, they are purely for the end-user).
+func (s *ResourceSyncer) updateRelatedObjectReferences(log *zap.SugaredLogger, remote syncSide, refs []related.ObjectReference) (requeue bool, err error) {
+	mode := s.pubRes.Spec.RelatedObjectReferences
+	if mode == "" {
+		mode = syncagentv1alpha1.RelatedObjectReferencesBoth
+	}
+
+	if mode == syncagentv1alpha1.RelatedObjectReferencesNone {
+		return false, nil
+	}
+
+	oldState := remote.object.DeepCopy()
+
+	annotations := remote.object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	changed := false
+
+	if mode == syncagentv1alpha1.RelatedObjectReferencesStructured || mode == syncagentv1alpha1.RelatedObjectReferencesBoth {
+		existing, exists := annotations[syncagentv1alpha1.RelatedObjectsAnnotation]
+
+		if len(refs) == 0 {
+			if exists {
+				delete(annotations, syncagentv1alpha1.RelatedObjectsAnnotation)
+				changed = true
+			}
+		} else {
+			value, err := related.Encode(refs)
+			if err != nil {
+				return false, err
+			}
+
+			if existing != value {
+				annotations[syncagentv1alpha1.RelatedObjectsAnnotation] = value
+				changed = true
+			}
+		}
+	}
+
+	if mode == syncagentv1alpha1.RelatedObjectReferencesLegacy || mode == syncagentv1alpha1.RelatedObjectReferencesBoth {
+		indexes := map[string]int{}
+
+		for _, ref := range refs {
+			annotation := fmt.Sprintf("%s%s.%d", relatedObjectAnnotationPrefix, ref.Identifier, indexes[ref.Identifier])
+			indexes[ref.Identifier]++
 
 			value, err := json.Marshal(relatedObjectAnnotation{
-				Namespace:  resolved.destination.Namespace,
-				Name:       resolved.destination.Name,
-				APIVersion: "v1", // we only support ConfigMaps and Secrets
-				Kind:       relRes.Kind,
+				Namespace:  ref.Namespace,
+				Name:       ref.Name,
+				APIVersion: ref.APIVersion,
+				Kind:       ref.Kind,
 			})
 			if err != nil {
 				return false, fmt.Errorf("failed to encode related object annotation: %w", err)
 			}
 
-			annotations := remote.object.GetAnnotations()
-			existing := annotations[annotation]
+			if annotations[annotation] != string(value) {
+				annotations[annotation] = string(value)
+				changed = true
+			}
+		}
+	}
 
-			if existing != string(value) {
-				oldState := remote.object.DeepCopy()
+	if !changed {
+		return false, nil
+	}
 
-				annotations[annotation] = string(value)
-				remote.object.SetAnnotations(annotations)
+	remote.object.SetAnnotations(annotations)
+
+	log.Debug("Remembering related objects in main object…")
+	if err := remote.client.Patch(remote.ctx, remote.object, ctrlruntimeclient.MergeFrom(oldState)); err != nil {
+		return false, fmt.Errorf("failed to update related data in remote object: %w", err)
+	}
+
+	// requeue (since this updated the main object, we do actually want to
+	// requeue immediately because successive patches would fail anyway)
+	return true, nil
+}
+
+// cleanupRelatedResources deletes all related objects on the destination side whose
+// related resource is configured with the Delete policy. This must happen before the
+// local primary object is deleted, as both primary objects are required to resolve
+// the related objects.
+func (s *ResourceSyncer) cleanupRelatedResources(log *zap.SugaredLogger, remote, local syncSide) (requeue bool, err error) {
+	return s.cleanupRelatedResourceList(log, remote, local, s.pubRes.Spec.Related)
+}
+
+func (s *ResourceSyncer) cleanupRelatedResourceList(log *zap.SugaredLogger, remote, local syncSide, relatedResources []syncagentv1alpha1.RelatedResourceSpec) (requeue bool, err error) {
+	for _, relatedResource := range relatedResources {
+		if !requiresCleanup(relatedResource) {
+			continue
+		}
+
+		req, err := s.cleanupRelatedResource(log.With("identifier", relatedResource.Identifier), remote, local, relatedResource)
+		if err != nil {
+			return false, fmt.Errorf("failed to clean up related resource %s: %w", relatedResource.Identifier, err)
+		}
+
+		requeue = requeue || req
+	}
+
+	return requeue, nil
+}
+
+// requiresCleanup returns true if the related resource or any of its nested related
+// resources uses the Delete policy.
+func requiresCleanup(relRes syncagentv1alpha1.RelatedResourceSpec) bool {
+	return relRes.DeletionPolicy == syncagentv1alpha1.RelatedResourceDeletionPolicyDelete || slices.ContainsFunc(relRes.Related, requiresCleanup)
+}
+
+func (s *ResourceSyncer) cleanupRelatedResource(log *zap.SugaredLogger, remote, local syncSide, relRes syncagentv1alpha1.RelatedResourceSpec) (requeue bool, err error) {
+	origin, dest := relatedResourceSides(relRes, remote, local)
+
+	resolvedObjects, err := resolveRelatedResourceObjects(origin, dest, relRes)
+	if err != nil {
+		return false, fmt.Errorf("failed to get resolve origin objects: %w", err)
+	}
+
+	relatedGVK := projection.RelatedResourceGVK(&relRes)
+
+	for _, resolved := range resolvedObjects {
+		// nested related objects must be cleaned up first, as they can only be resolved
+		// as long as this related object exists on both sides
+		if len(relRes.Related) > 0 {
+			nestedRemote, nestedLocal, found, err := nestedRelatedResourceSides(relRes, origin, dest, resolved)
+			if err != nil {
+				return false, fmt.Errorf("failed to get related object: %w", err)
+			}
 
-				log.Debug("Remembering related object in main object…")
-				if err := remote.client.Patch(remote.ctx, remote.object, ctrlruntimeclient.MergeFrom(oldState)); err != nil {
-					return false, fmt.Errorf("failed to update related data in remote object: %w", err)
+			if found {
+				req, err := s.cleanupRelatedResourceList(log, nestedRemote, nestedLocal, relRes.Related)
+				if err != nil {
+					return false, err
 				}
 
-				// requeue (since this updated the main object, we do actually want to
-				// requeue immediately because successive patches would fail anyway)
-				return true, nil
+				if req {
+					requeue = true
+					continue
+				}
 			}
 		}
+
+		if relRes.DeletionPolicy != syncagentv1alpha1.RelatedResourceDeletionPolicyDelete {
+			continue
+		}
+
+		// related objects originating in kcp are protected by a finalizer, which can be
+		// released now since they will not be synced anymore
+		if relRes.Origin == "kcp" {
+			if _, err := removeFinalizer(origin.ctx, log, origin.client, resolved.original, deletionFinalizer); err != nil {
+				return false, fmt.Errorf("failed to remove cleanup finalizer from origin object: %w", err)
+			}
+		}
+
+		destObject := &unstructured.Unstructured{}
+		destObject.SetGroupVersionKind(relatedGVK)
+
+		if err := dest.client.Get(dest.ctx, resolved.destination, destObject); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return false, fmt.Errorf("failed to get destination object: %w", err)
+		}
+
+		if destObject.GetDeletionTimestamp() == nil {
+			log.Debugw("Deleting related object…", "dest-object", newObjectKey(destObject, dest.clusterName, logicalcluster.None))
+			if err := dest.client.Delete(dest.ctx, destObject); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete related object: %w", err)
+			}
+		}
+
+		// wait for the object to be gone
+		requeue = true
 	}
 
 	return requeue, nil
@@ -250,27 +760,7 @@ func resolveRelatedResourceObjects(relatedOrigin, relatedDest syncSide, relRes s
 func resolveRelatedResourceOriginNamespaces(relatedOrigin, relatedDest syncSide, spec syncagentv1alpha1.RelatedResourceObjectSpec) (map[string]string, error) {
 	switch {
 	case spec.Reference != nil:
-		originNamespace, err := resolveObjectReference(relatedOrigin.object, *spec.Reference)
-		if err != nil {
-			return nil, err
-		}
-
-		if originNamespace == "" {
-			return nil, nil
-		}
-
-		destNamespace, err := resolveObjectReference(relatedDest.object, *spec.Reference)
-		if err != nil {
-			return nil, err
-		}
-
-		if destNamespace == "" {
-			return nil, nil
-		}
-
-		return map[string]string{
-			originNamespace: destNamespace,
-		}, nil
+		return resolveObjectReferencePairs(relatedOrigin.object, relatedDest.object, *spec.Reference)
 
 	case spec.Selector != nil:
 		namespaces := &corev1.NamespaceList{}
@@ -322,23 +812,28 @@ func resolveRelatedResourceOriginNamespaces(relatedOrigin, relatedDest syncSide,
 }
 
 func resolveRelatedResourceObjectsInNamespaces(relatedOrigin, relatedDest syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, spec syncagentv1alpha1.RelatedResourceObjectSpec, namespaceMap map[string]string) ([]resolvedObject, error) {
+	// label selectors can match many objects in many namespaces, which are all
+	// fetched at once
+	if spec.Selector != nil {
+		return resolveSelectedObjects(relatedOrigin, relatedDest, relRes, *spec.Selector, namespaceMap)
+	}
+
+	// references and templates yield the same names in every namespace
+	nameMap, err := resolveRelatedResourceObjectNames(relatedOrigin, relatedDest, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find objects on origin side: %w", err)
+	}
+
 	result := []resolvedObject{}
 
 	for originNamespace, destNamespace := range namespaceMap {
-		nameMap, err := resolveRelatedResourceObjectsInNamespace(relatedOrigin, relatedDest, relRes, spec, originNamespace)
-		if err != nil {
-			return nil, fmt.Errorf("failed to find objects on origin side: %w", err)
-		}
-
 		for originName, destName := range nameMap {
 			originObj := &unstructured.Unstructured{}
-			originObj.SetAPIVersion("v1") // we only support ConfigMaps and Secrets, both are in core/v1
-			originObj.SetKind(relRes.Kind)
+			originObj.SetGroupVersionKind(projection.RelatedResourceGVK(&relRes))
 
 			err = relatedOrigin.client.Get(relatedOrigin.ctx, types.NamespacedName{Name: originName, Namespace: originNamespace}, originObj)
 			if err != nil {
-				// this should rarely happen, only if an object was deleted in between the .List() call
-				// above and the .Get() call here.
+				// the referenced object does not exist (yet)
 				if apierrors.IsNotFound(err) {
 					continue
 				}
@@ -359,63 +854,68 @@ func resolveRelatedResourceObjectsInNamespaces(relatedOrigin, relatedDest syncSi
 	return result, nil
 }
 
-func resolveRelatedResourceObjectsInNamespace(relatedOrigin, relatedDest syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, spec syncagentv1alpha1.RelatedResourceObjectSpec, namespace string) (map[string]string, error) {
-	switch {
-	case spec.Reference != nil:
-		originName, err := resolveObjectReference(relatedOrigin.object, *spec.Reference)
-		if err != nil {
-			return nil, err
-		}
+// resolveSelectedObjects finds all objects matching the label selector in the given
+// origin namespaces. Instead of listing each namespace individually, a single List
+// call across all namespaces is used if more than one namespace is involved. The
+// listed objects are used as-is, so no additional requests are necessary.
+func resolveSelectedObjects(relatedOrigin, relatedDest syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, spec syncagentv1alpha1.RelatedResourceObjectSelector, namespaceMap map[string]string) ([]resolvedObject, error) {
+	relatedGVK := projection.RelatedResourceGVK(&relRes)
 
-		if originName == "" {
-			return nil, nil
-		}
+	originObjects := &unstructured.UnstructuredList{}
+	originObjects.SetAPIVersion(relatedGVK.GroupVersion().String())
+	originObjects.SetKind(relatedGVK.Kind + "List")
 
-		destName, err := resolveObjectReference(relatedDest.object, *spec.Reference)
-		if err != nil {
-			return nil, err
-		}
+	selector, err := metav1.LabelSelectorAsSelector(&spec.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector configured: %w", err)
+	}
 
-		if destName == "" {
-			return nil, nil
-		}
+	opts := &ctrlruntimeclient.ListOptions{
+		LabelSelector: selector,
+	}
 
-		return map[string]string{
-			originName: destName,
-		}, nil
+	if len(namespaceMap) == 1 {
+		for namespace := range namespaceMap {
+			opts.Namespace = namespace
+		}
+	}
 
-	case spec.Selector != nil:
-		originObjects := &unstructured.UnstructuredList{}
-		originObjects.SetAPIVersion("v1") // we only support ConfigMaps and Secrets, both are in core/v1
-		originObjects.SetKind(relRes.Kind)
+	if err := relatedOrigin.client.List(relatedOrigin.ctx, originObjects, opts); err != nil {
+		return nil, fmt.Errorf("failed to select origin objects based on label selector: %w", err)
+	}
 
-		selector, err := metav1.LabelSelectorAsSelector(&spec.Selector.LabelSelector)
-		if err != nil {
-			return nil, fmt.Errorf("invalid selector configured: %w", err)
-		}
+	result := []resolvedObject{}
+	for i := range originObjects.Items {
+		originObj := &originObjects.Items[i]
 
-		opts := &ctrlruntimeclient.ListOptions{
-			LabelSelector: selector,
-			Namespace:     namespace,
+		destNamespace, ok := namespaceMap[originObj.GetNamespace()]
+		if !ok {
+			continue
 		}
 
-		if err := relatedOrigin.client.List(relatedOrigin.ctx, originObjects, opts); err != nil {
-			return nil, fmt.Errorf("failed to select origin objects based on label selector: %w", err)
+		destinationName, err := applyRewrites(relatedOrigin, relatedDest, originObj.GetName(), spec.Rewrite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite origin name: %w", err)
 		}
 
-		nameMap := map[string]string{}
-		for _, originObject := range originObjects.Items {
-			name := originObject.GetName()
+		originObj.SetGroupVersionKind(relatedGVK)
 
-			destinationName, err := applyRewrites(relatedOrigin, relatedDest, name, spec.Selector.Rewrite)
-			if err != nil {
-				return nil, fmt.Errorf("failed to rewrite origin name: %w", err)
-			}
+		result = append(result, resolvedObject{
+			original: originObj,
+			destination: types.NamespacedName{
+				Namespace: destNamespace,
+				Name:      destinationName,
+			},
+		})
+	}
 
-			nameMap[name] = destinationName
-		}
+	return result, nil
+}
 
-		return nameMap, nil
+func resolveRelatedResourceObjectNames(relatedOrigin, relatedDest syncSide, spec syncagentv1alpha1.RelatedResourceObjectSpec) (map[string]string, error) {
+	switch {
+	case spec.Reference != nil:
+		return resolveObjectReferencePairs(relatedOrigin.object, relatedDest.object, *spec.Reference)
 
 	case spec.Template != nil:
 		originValue, destValue, err := applyTemplateBothSides(relatedOrigin, relatedDest, *spec.Template)
@@ -436,34 +936,76 @@ func resolveRelatedResourceObjectsInNamespace(relatedOrigin, relatedDest syncSid
 	}
 }
 
-func resolveObjectReference(object *unstructured.Unstructured, ref syncagentv1alpha1.RelatedResourceObjectReference) (string, error) {
+// resolveObjectReferencePairs evaluates the reference on the origin and destination
+// objects and pairs up the resulting values. List-valued references (e.g.
+// "status.secretRefs[*].name") yield one pair per element, matched by their
+// index; pairs with an empty value on either side are skipped.
+func resolveObjectReferencePairs(origin, dest *unstructured.Unstructured, ref syncagentv1alpha1.RelatedResourceObjectReference) (map[string]string, error) {
+	originValues, err := resolveObjectReference(origin, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	destValues, err := resolveObjectReference(dest, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(originValues) != len(destValues) {
+		return nil, fmt.Errorf("%s yields %d value(s) on the origin side, but %d on the destination side", ref.Path, len(originValues), len(destValues))
+	}
+
+	result := map[string]string{}
+	for i, originValue := range originValues {
+		if originValue != "" && destValues[i] != "" {
+			result[originValue] = destValues[i]
+		}
+	}
+
+	return result, nil
+}
+
+func resolveObjectReference(object *unstructured.Unstructured, ref syncagentv1alpha1.RelatedResourceObjectReference) ([]string, error) {
 	data, err := object.MarshalJSON()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	return resolveReference(data, ref)
 }
 
-func resolveReference(jsonData []byte, ref syncagentv1alpha1.RelatedResourceObjectReference) (string, error) {
-	gval := gjson.Get(string(jsonData), ref.Path)
+func resolveReference(jsonData []byte, ref syncagentv1alpha1.RelatedResourceObjectReference) ([]string, error) {
+	// support the JSONPath-style wildcard in addition to gjson's own syntax
+	path := strings.ReplaceAll(ref.Path, "[*]", ".#")
+
+	gval := gjson.Get(string(jsonData), path)
 	if !gval.Exists() {
-		return "", fmt.Errorf("cannot find %s in document", ref.Path)
+		return nil, fmt.Errorf("cannot find %s in document", ref.Path)
 	}
 
-	// this does apply some coalescing, like turning numbers into strings
-	strVal := gval.String()
+	values := []gjson.Result{gval}
+	if gval.IsArray() {
+		values = gval.Array()
+	}
 
-	if re := ref.Regex; re != nil {
-		var err error
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		// this does apply some coalescing, like turning numbers into strings
+		strVal := value.String()
 
-		strVal, err = applyRegularExpression(strVal, *re)
-		if err != nil {
-			return "", err
+		if re := ref.Regex; re != nil {
+			var err error
+
+			strVal, err = applyRegularExpression(strVal, *re)
+			if err != nil {
+				return nil, err
+			}
 		}
+
+		result = append(result, strVal)
 	}
 
-	return strVal, nil
+	return result, nil
 }
 
 func applyRewrites(relatedOrigin, relatedDest syncSide, value string, rewrite syncagentv1alpha1.RelatedResourceSelectorRewrite) (string, error) {
@@ -490,10 +1032,85 @@ func applyRegularExpression(value string, re syncagentv1alpha1.RegularExpression
 	return expr.ReplaceAllString(value, re.Replacement), nil
 }
 
+// relatedObjectTemplateContext is the data available to templates used to find
+// related objects.
+type relatedObjectTemplateContext struct {
+	// Value is the value found on the origin side; this is only set when
+	// rewriting the names of selected objects.
+	Value string
+	// Object is the primary object on the side the template is evaluated for.
+	Object map[string]any
+	// LocalObject is the primary object on the service cluster.
+	LocalObject map[string]any
+	// RemoteObject is the primary object in kcp.
+	RemoteObject map[string]any
+	// ClusterName is the logical cluster name of the kcp workspace.
+	ClusterName string
+	// ClusterPath is the workspace path (e.g. "root:org:team"), which is only
+	// available if workspace paths are enabled for the PublishedResource.
+	ClusterPath string
+}
+
+func newRelatedObjectTemplateContext(relatedOrigin, relatedDest syncSide, side syncSide, value string) relatedObjectTemplateContext {
+	// the local side never has a cluster name
+	local, remote := relatedOrigin, relatedDest
+	if local.clusterName != "" {
+		local, remote = remote, local
+	}
+
+	return relatedObjectTemplateContext{
+		Value:        value,
+		Object:       unstructuredContent(side.object),
+		LocalObject:  unstructuredContent(local.object),
+		RemoteObject: unstructuredContent(remote.object),
+		ClusterName:  string(remote.clusterName),
+		ClusterPath:  remote.workspacePath.String(),
+	}
+}
+
+func unstructuredContent(obj *unstructured.Unstructured) map[string]any {
+	if obj == nil {
+		return nil
+	}
+
+	return obj.UnstructuredContent()
+}
+
+// applyTemplate is used to rewrite the value found on the origin side, so the
+// template is evaluated for the destination side.
 func applyTemplate(relatedOrigin, relatedDest syncSide, tpl syncagentv1alpha1.TemplateExpression, value string) (string, error) {
-	return "", errors.New("not yet implemented")
+	return renderTemplate(tpl, newRelatedObjectTemplateContext(relatedOrigin, relatedDest, relatedDest, value))
 }
 
+// applyTemplateBothSides evaluates the template once for each side, similar to
+// how references are resolved in both the origin and destination primary objects.
 func applyTemplateBothSides(relatedOrigin, relatedDest syncSide, tpl syncagentv1alpha1.TemplateExpression) (originValue, destValue string, err error) {
-	return "", "", errors.New("not yet implemented")
+	originValue, err = renderTemplate(tpl, newRelatedObjectTemplateContext(relatedOrigin, relatedDest, relatedOrigin, ""))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to evaluate template on origin side: %w", err)
+	}
+
+	destValue, err = renderTemplate(tpl, newRelatedObjectTemplateContext(relatedOrigin, relatedDest, relatedDest, ""))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to evaluate template on destination side: %w", err)
+	}
+
+	return originValue, destValue, nil
+}
+
+func renderTemplate(tpl syncagentv1alpha1.TemplateExpression, data relatedObjectTemplateContext) (string, error) {
+	funcs := sprig.TxtFuncMap()
+	funcs["join"] = strings.Join
+
+	parsed, err := template.New("related").Funcs(funcs).Option("missingkey=error").Parse(tpl.Template)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", tpl.Template, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tpl.Template, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
 }
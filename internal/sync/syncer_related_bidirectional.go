@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/related"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// processBidirectionalRelatedResource synchronizes the objects of a related resource
+// with origin "both". Objects are resolved on both sides; objects that exist only on
+// one side are synchronized to the other side, for objects that exist on both sides,
+// the conflict policy decides the direction.
+func (s *ResourceSyncer) processBidirectionalRelatedResource(log *zap.SugaredLogger, stateStore ObjectStateStore, remote, local syncSide, relRes syncagentv1alpha1.RelatedResourceSpec, ancestors sets.Set[string]) (refs []related.ObjectReference, requeue bool, err error) {
+	kcpRes := relRes
+	kcpRes.Origin = "kcp"
+
+	fromKcp, err := resolveRelatedResourceObjects(remote, local, kcpRes)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve objects in kcp: %w", err)
+	}
+
+	serviceRes := relRes
+	serviceRes.Origin = "service"
+
+	fromService, err := resolveRelatedResourceObjects(local, remote, serviceRes)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve objects on the service cluster: %w", err)
+	}
+
+	kcpObjects, serviceObjects := splitBidirectionalObjects(fromKcp, fromService, relRes.ConflictPolicy)
+
+	kcpRefs, kcpRequeue, err := s.syncRelatedObjects(log, stateStore, remote, local, relRes, "kcp", kcpObjects, ancestors)
+	if err != nil {
+		return nil, false, err
+	}
+
+	serviceRefs, serviceRequeue, err := s.syncRelatedObjects(log, stateStore, remote, local, relRes, "service", serviceObjects, ancestors)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return append(kcpRefs, serviceRefs...), kcpRequeue || serviceRequeue, nil
+}
+
+// splitBidirectionalObjects decides for each object whether it has to be synced from
+// kcp to the service cluster or the other way around. fromKcp and fromService are the
+// objects resolved in kcp and on the service cluster, respectively.
+func splitBidirectionalObjects(fromKcp, fromService []resolvedObject, policy syncagentv1alpha1.RelatedResourceConflictPolicy) (kcpObjects, serviceObjects []resolvedObject) {
+	// index the service cluster objects by the name of their counterpart in kcp
+	serviceByKcpKey := map[types.NamespacedName]resolvedObject{}
+	for _, obj := range fromService {
+		serviceByKcpKey[obj.destination] = obj
+	}
+
+	for _, kcpObj := range fromKcp {
+		key := ctrlruntimeclient.ObjectKeyFromObject(kcpObj.original)
+
+		serviceObj, exists := serviceByKcpKey[key]
+		if !exists {
+			kcpObjects = append(kcpObjects, kcpObj)
+			continue
+		}
+
+		delete(serviceByKcpKey, key)
+
+		if serviceWins(policy, kcpObj.original, serviceObj.original) {
+			serviceObjects = append(serviceObjects, serviceObj)
+		} else {
+			kcpObjects = append(kcpObjects, kcpObj)
+		}
+	}
+
+	// everything left only exists on the service cluster
+	for _, obj := range fromService {
+		if _, exists := serviceByKcpKey[obj.destination]; exists {
+			serviceObjects = append(serviceObjects, obj)
+		}
+	}
+
+	return kcpObjects, serviceObjects
+}
+
+// serviceWins returns true if the service cluster's copy of a related object should
+// be used instead of the copy in kcp.
+func serviceWins(policy syncagentv1alpha1.RelatedResourceConflictPolicy, kcpObj, serviceObj *unstructured.Unstructured) bool {
+	switch policy {
+	case syncagentv1alpha1.RelatedResourceConflictPolicyServiceWins:
+		return true
+	case syncagentv1alpha1.RelatedResourceConflictPolicyNewestWins:
+		return lastModified(serviceObj).After(lastModified(kcpObj))
+	default:
+		return false
+	}
+}
+
+// lastModified returns the most recent time an object was modified, based on its
+// managed fields. If no managed fields exist, the creation timestamp is used.
+func lastModified(obj *unstructured.Unstructured) time.Time {
+	result := obj.GetCreationTimestamp().Time
+
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(result) {
+			result = entry.Time.Time
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,1025 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	"github.com/kcp-dev/api-syncagent/internal/test/diff"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/related"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestApplyTemplateBothSides(t *testing.T) {
+	remote := syncSide{
+		clusterName:   logicalcluster.Name("testcluster"),
+		workspacePath: logicalcluster.NewPath("root:org:team"),
+		object: &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "my-thing"},
+			"spec":     map[string]any{"secretName": "remote-secret"},
+		}},
+	}
+
+	local := syncSide{
+		object: &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "hashed-name"},
+			"spec":     map[string]any{"secretName": "local-secret"},
+		}},
+	}
+
+	testcases := []struct {
+		name           string
+		origin         syncSide
+		dest           syncSide
+		template       string
+		expectedOrigin string
+		expectedDest   string
+		expectErr      bool
+	}{
+		{
+			name:           "static value",
+			origin:         local,
+			dest:           remote,
+			template:       "credentials",
+			expectedOrigin: "credentials",
+			expectedDest:   "credentials",
+		},
+		{
+			name:           "use primary object of each side",
+			origin:         local,
+			dest:           remote,
+			template:       "{{ .Object.spec.secretName }}",
+			expectedOrigin: "local-secret",
+			expectedDest:   "remote-secret",
+		},
+		{
+			name:           "explicitly use remote object",
+			origin:         remote,
+			dest:           local,
+			template:       "{{ .RemoteObject.metadata.name }}-creds",
+			expectedOrigin: "my-thing-creds",
+			expectedDest:   "my-thing-creds",
+		},
+		{
+			name:           "use workspace information and helper functions",
+			origin:         local,
+			dest:           remote,
+			template:       `{{ .ClusterName | upper }}-{{ .ClusterPath | replace ":" "-" }}`,
+			expectedOrigin: "TESTCLUSTER-root-org-team",
+			expectedDest:   "TESTCLUSTER-root-org-team",
+		},
+		{
+			name:      "missing fields are errors",
+			origin:    local,
+			dest:      remote,
+			template:  "{{ .Object.status.secretName }}",
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			tpl := syncagentv1alpha1.TemplateExpression{Template: testcase.template}
+
+			originValue, destValue, err := applyTemplateBothSides(testcase.origin, testcase.dest, tpl)
+			if err != nil {
+				if !testcase.expectErr {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.expectErr {
+				t.Fatal("Expected error, but got none.")
+			}
+
+			if originValue != testcase.expectedOrigin {
+				t.Errorf("Expected origin value %q, but got %q.", testcase.expectedOrigin, originValue)
+			}
+
+			if destValue != testcase.expectedDest {
+				t.Errorf("Expected destination value %q, but got %q.", testcase.expectedDest, destValue)
+			}
+		})
+	}
+}
+
+func TestApplyTemplateRewrite(t *testing.T) {
+	origin := syncSide{
+		object: &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "hashed-name"},
+		}},
+	}
+
+	dest := syncSide{
+		clusterName: logicalcluster.Name("testcluster"),
+		object: &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "my-thing"},
+		}},
+	}
+
+	tpl := syncagentv1alpha1.TemplateExpression{Template: "{{ .Object.metadata.name }}-{{ .Value | trimPrefix \"tls-\" }}"}
+
+	value, err := applyTemplate(origin, dest, tpl, "tls-certificate")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "my-thing-certificate"
+	if value != expected {
+		t.Errorf("Expected %q, but got %q.", expected, value)
+	}
+}
+
+func TestResolveObjectReferencePairs(t *testing.T) {
+	newObject := func(secretRefs ...string) *unstructured.Unstructured {
+		refs := []any{}
+		for _, ref := range secretRefs {
+			refs = append(refs, map[string]any{"name": ref})
+		}
+
+		return &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "my-thing"},
+			"spec":     map[string]any{"secretName": "credentials"},
+			"status":   map[string]any{"secretRefs": refs},
+		}}
+	}
+
+	testcases := []struct {
+		name      string
+		origin    *unstructured.Unstructured
+		dest      *unstructured.Unstructured
+		reference syncagentv1alpha1.RelatedResourceObjectReference
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:      "single value",
+			origin:    newObject(),
+			dest:      newObject(),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+			expected:  map[string]string{"credentials": "credentials"},
+		},
+		{
+			name:      "list values are paired by index",
+			origin:    newObject("local-a", "local-b"),
+			dest:      newObject("remote-a", "remote-b"),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "status.secretRefs[*].name"},
+			expected:  map[string]string{"local-a": "remote-a", "local-b": "remote-b"},
+		},
+		{
+			name:      "gjson syntax for lists",
+			origin:    newObject("local-a"),
+			dest:      newObject("remote-a"),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "status.secretRefs.#.name"},
+			expected:  map[string]string{"local-a": "remote-a"},
+		},
+		{
+			name:   "regex is applied to each element",
+			origin: newObject("a", "b"),
+			dest:   newObject("a", "b"),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{
+				Path:  "status.secretRefs[*].name",
+				Regex: &syncagentv1alpha1.RegularExpression{Pattern: "^(.+)$", Replacement: "tls-$1"},
+			},
+			expected: map[string]string{"tls-a": "tls-a", "tls-b": "tls-b"},
+		},
+		{
+			name:      "empty values are skipped",
+			origin:    newObject("local-a", ""),
+			dest:      newObject("remote-a", "remote-b"),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "status.secretRefs[*].name"},
+			expected:  map[string]string{"local-a": "remote-a"},
+		},
+		{
+			name:      "empty lists yield nothing",
+			origin:    newObject(),
+			dest:      newObject(),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "status.secretRefs[*].name"},
+			expected:  map[string]string{},
+		},
+		{
+			name:      "mismatching lengths are errors",
+			origin:    newObject("local-a", "local-b"),
+			dest:      newObject("remote-a"),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "status.secretRefs[*].name"},
+			expectErr: true,
+		},
+		{
+			name:      "missing fields are errors",
+			origin:    newObject(),
+			dest:      newObject(),
+			reference: syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.other"},
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pairs, err := resolveObjectReferencePairs(testcase.origin, testcase.dest, testcase.reference)
+			if err != nil {
+				if !testcase.expectErr {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if testcase.expectErr {
+				t.Fatal("Expected error, but got none.")
+			}
+
+			if !maps.Equal(pairs, testcase.expected) {
+				t.Errorf("Expected %v, but got %v.", testcase.expected, pairs)
+			}
+		})
+	}
+}
+
+func TestCleanupRelatedResources(t *testing.T) {
+	newPrimary := func(namespace string, secretName string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "my-thing", "namespace": namespace},
+			"spec":     map[string]any{"secretName": secretName},
+		}}
+	}
+
+	newSecret := func(namespace string, name string, finalizers ...string) *unstructured.Unstructured {
+		return newUnstructured(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  namespace,
+				Finalizers: finalizers,
+			},
+		})
+	}
+
+	testcases := []struct {
+		name                string
+		origin              string
+		deletionPolicy      syncagentv1alpha1.RelatedResourceDeletionPolicy
+		expectLocalSecret   bool
+		expectRemoteSecret  bool
+		expectRemoteCleanup bool
+	}{
+		{
+			name:               "orphan by default",
+			origin:             "service",
+			expectLocalSecret:  true,
+			expectRemoteSecret: true,
+		},
+		{
+			name:               "delete copy in kcp",
+			origin:             "service",
+			deletionPolicy:     syncagentv1alpha1.RelatedResourceDeletionPolicyDelete,
+			expectLocalSecret:  true,
+			expectRemoteSecret: false,
+		},
+		{
+			name:                "delete copy on service cluster and release kcp object",
+			origin:              "kcp",
+			deletionPolicy:      syncagentv1alpha1.RelatedResourceDeletionPolicyDelete,
+			expectLocalSecret:   false,
+			expectRemoteSecret:  true,
+			expectRemoteCleanup: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			remoteSecretFinalizers := []string{}
+			if testcase.origin == "kcp" {
+				remoteSecretFinalizers = append(remoteSecretFinalizers, deletionFinalizer)
+			}
+
+			localClient := buildFakeClient(newSecret("local-ns", "local-secret"))
+			remoteClient := buildFakeClient(newSecret("remote-ns", "remote-secret", remoteSecretFinalizers...))
+
+			remote := syncSide{
+				ctx:         ctx,
+				clusterName: logicalcluster.Name("testcluster"),
+				client:      remoteClient,
+				object:      newPrimary("remote-ns", "remote-secret"),
+			}
+
+			local := syncSide{
+				ctx:    ctx,
+				client: localClient,
+				object: newPrimary("local-ns", "local-secret"),
+			}
+
+			syncer := &ResourceSyncer{
+				pubRes: &syncagentv1alpha1.PublishedResource{
+					Spec: syncagentv1alpha1.PublishedResourceSpec{
+						Related: []syncagentv1alpha1.RelatedResourceSpec{{
+							Identifier:     "credentials",
+							Origin:         testcase.origin,
+							Kind:           "Secret",
+							DeletionPolicy: testcase.deletionPolicy,
+							Object: syncagentv1alpha1.RelatedResourceObject{
+								RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+									Reference: &syncagentv1alpha1.RelatedResourceObjectReference{
+										Path: "spec.secretName",
+									},
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			// process until nothing is left to do
+			for range 3 {
+				requeue, err := syncer.cleanupRelatedResources(zap.NewNop().Sugar(), remote, local)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				if !requeue {
+					break
+				}
+			}
+
+			localSecret := &corev1.Secret{}
+			err := localClient.Get(ctx, types.NamespacedName{Namespace: "local-ns", Name: "local-secret"}, localSecret)
+			if exists := !apierrors.IsNotFound(err); exists != testcase.expectLocalSecret {
+				t.Errorf("Expected local Secret to exist=%v, but got err=%v.", testcase.expectLocalSecret, err)
+			}
+
+			remoteSecret := &corev1.Secret{}
+			err = remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "remote-secret"}, remoteSecret)
+			if exists := !apierrors.IsNotFound(err); exists != testcase.expectRemoteSecret {
+				t.Errorf("Expected remote Secret to exist=%v, but got err=%v.", testcase.expectRemoteSecret, err)
+			}
+
+			if testcase.expectRemoteCleanup && len(remoteSecret.Finalizers) > 0 {
+				t.Errorf("Expected cleanup finalizer to be removed from remote Secret, but got %v.", remoteSecret.Finalizers)
+			}
+		})
+	}
+}
+
+func TestUpdateRelatedObjectReferences(t *testing.T) {
+	refs := []related.ObjectReference{
+		{Identifier: "credentials", APIVersion: "v1", Kind: "Secret", Namespace: "remote-ns", Name: "secret-a"},
+		{Identifier: "credentials", APIVersion: "v1", Kind: "Secret", Namespace: "remote-ns", Name: "secret-b"},
+	}
+
+	structured := `[{"identifier":"credentials","apiVersion":"v1","kind":"Secret","namespace":"remote-ns","name":"secret-a"},{"identifier":"credentials","apiVersion":"v1","kind":"Secret","namespace":"remote-ns","name":"secret-b"}]`
+	legacyA := `{"namespace":"remote-ns","name":"secret-a","apiVersion":"v1","kind":"Secret"}`
+	legacyB := `{"namespace":"remote-ns","name":"secret-b","apiVersion":"v1","kind":"Secret"}`
+
+	testcases := []struct {
+		name          string
+		mode          syncagentv1alpha1.RelatedObjectReferencesMode
+		refs          []related.ObjectReference
+		existing      map[string]string
+		expected      map[string]string
+		expectRequeue bool
+	}{
+		{
+			name: "both by default",
+			refs: refs,
+			expected: map[string]string{
+				syncagentv1alpha1.RelatedObjectsAnnotation:      structured,
+				relatedObjectAnnotationPrefix + "credentials.0": legacyA,
+				relatedObjectAnnotationPrefix + "credentials.1": legacyB,
+			},
+			expectRequeue: true,
+		},
+		{
+			name: "structured only",
+			mode: syncagentv1alpha1.RelatedObjectReferencesStructured,
+			refs: refs,
+			expected: map[string]string{
+				syncagentv1alpha1.RelatedObjectsAnnotation: structured,
+			},
+			expectRequeue: true,
+		},
+		{
+			name: "legacy only",
+			mode: syncagentv1alpha1.RelatedObjectReferencesLegacy,
+			refs: refs,
+			expected: map[string]string{
+				relatedObjectAnnotationPrefix + "credentials.0": legacyA,
+				relatedObjectAnnotationPrefix + "credentials.1": legacyB,
+			},
+			expectRequeue: true,
+		},
+		{
+			name:          "disabled",
+			mode:          syncagentv1alpha1.RelatedObjectReferencesNone,
+			refs:          refs,
+			expected:      nil,
+			expectRequeue: false,
+		},
+		{
+			name: "up-to-date",
+			mode: syncagentv1alpha1.RelatedObjectReferencesStructured,
+			refs: refs,
+			existing: map[string]string{
+				syncagentv1alpha1.RelatedObjectsAnnotation: structured,
+			},
+			expected: map[string]string{
+				syncagentv1alpha1.RelatedObjectsAnnotation: structured,
+			},
+			expectRequeue: false,
+		},
+		{
+			name: "remove structured list when objects are gone",
+			mode: syncagentv1alpha1.RelatedObjectReferencesStructured,
+			existing: map[string]string{
+				syncagentv1alpha1.RelatedObjectsAnnotation: structured,
+			},
+			expected:      nil,
+			expectRequeue: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			primary := newUnstructured(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-thing",
+					Namespace:   "remote-ns",
+					Annotations: testcase.existing,
+				},
+			})
+
+			remoteClient := buildFakeClient(primary)
+
+			remote := syncSide{
+				ctx:    ctx,
+				client: remoteClient,
+				object: primary.DeepCopy(),
+			}
+
+			syncer := &ResourceSyncer{
+				pubRes: &syncagentv1alpha1.PublishedResource{
+					Spec: syncagentv1alpha1.PublishedResourceSpec{
+						RelatedObjectReferences: testcase.mode,
+					},
+				},
+			}
+
+			requeue, err := syncer.updateRelatedObjectReferences(zap.NewNop().Sugar(), remote, testcase.refs)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if requeue != testcase.expectRequeue {
+				t.Errorf("Expected requeue=%v, but got %v.", testcase.expectRequeue, requeue)
+			}
+
+			current := &corev1.ConfigMap{}
+			if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "my-thing"}, current); err != nil {
+				t.Fatalf("Failed to get primary object: %v", err)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, current.Annotations); changes != "" {
+				t.Errorf("Did not get expected annotations:\n%s", changes)
+			}
+		})
+	}
+}
+
+func TestEnsureOwnerReference(t *testing.T) {
+	newOwner := func(namespace string) *unstructured.Unstructured {
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion("example.com/v1")
+		owner.SetKind("Thing")
+		owner.SetName("my-thing")
+		owner.SetNamespace(namespace)
+		owner.SetUID("owner-uid")
+
+		return owner
+	}
+
+	otherRef := metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}
+	ownerRef := metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Thing", Name: "my-thing", UID: "owner-uid"}
+
+	testcases := []struct {
+		name          string
+		owner         *unstructured.Unstructured
+		existing      []metav1.OwnerReference
+		expected      []metav1.OwnerReference
+		expectUpdated bool
+	}{
+		{
+			name:          "add owner reference",
+			owner:         newOwner("ns"),
+			expected:      []metav1.OwnerReference{ownerRef},
+			expectUpdated: true,
+		},
+		{
+			name:          "keep other owner references",
+			owner:         newOwner("ns"),
+			existing:      []metav1.OwnerReference{otherRef},
+			expected:      []metav1.OwnerReference{otherRef, ownerRef},
+			expectUpdated: true,
+		},
+		{
+			name:     "owner reference already exists",
+			owner:    newOwner("ns"),
+			existing: []metav1.OwnerReference{ownerRef},
+			expected: []metav1.OwnerReference{ownerRef},
+		},
+		{
+			name:          "cluster-scoped owner",
+			owner:         newOwner(""),
+			expected:      []metav1.OwnerReference{ownerRef},
+			expectUpdated: true,
+		},
+		{
+			name:     "owner in other namespace",
+			owner:    newOwner("other-ns"),
+			expected: nil,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			client := buildFakeClient(newUnstructured(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "my-secret",
+					Namespace:       "ns",
+					OwnerReferences: testcase.existing,
+				},
+			}))
+
+			side := syncSide{ctx: ctx, client: client}
+			key := types.NamespacedName{Namespace: "ns", Name: "my-secret"}
+			gvk := corev1.SchemeGroupVersion.WithKind("Secret")
+
+			updated, err := ensureOwnerReference(zap.NewNop().Sugar(), side, testcase.owner, gvk, key)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if updated != testcase.expectUpdated {
+				t.Errorf("Expected updated=%v, but got %v.", testcase.expectUpdated, updated)
+			}
+
+			secret := &corev1.Secret{}
+			if err := client.Get(ctx, key, secret); err != nil {
+				t.Fatalf("Failed to get Secret: %v", err)
+			}
+
+			if changes := diff.ObjectDiff(testcase.expected, secret.OwnerReferences); changes != "" {
+				t.Errorf("Did not get expected owner references:\n%s", changes)
+			}
+		})
+	}
+
+	t.Run("related object does not exist yet", func(t *testing.T) {
+		side := syncSide{ctx: context.Background(), client: buildFakeClient()}
+		key := types.NamespacedName{Namespace: "ns", Name: "my-secret"}
+		gvk := corev1.SchemeGroupVersion.WithKind("Secret")
+
+		updated, err := ensureOwnerReference(zap.NewNop().Sugar(), side, newOwner("ns"), gvk, key)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if updated {
+			t.Error("Expected no update for a missing related object.")
+		}
+	})
+}
+
+func TestNestedRelatedResources(t *testing.T) {
+	ctx := context.Background()
+
+	newConfigMap := func(namespace, name string, data map[string]string) *unstructured.Unstructured {
+		return newUnstructured(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		})
+	}
+
+	remotePrimary := newConfigMap("remote-ns", "my-thing", map[string]string{"configMapName": "remote-config"})
+	localPrimary := newConfigMap("local-ns", "my-thing", map[string]string{"configMapName": "local-config"})
+
+	remoteClient := buildFakeClient(remotePrimary)
+	localClient := buildFakeClient(
+		localPrimary,
+		newConfigMap("local-ns", "local-config", map[string]string{"secretName": "my-secret"}),
+		newUnstructured(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "local-ns"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		}),
+	)
+
+	remote := syncSide{
+		ctx:         ctx,
+		clusterName: logicalcluster.Name("testcluster"),
+		client:      remoteClient,
+		object:      remotePrimary.DeepCopy(),
+	}
+
+	local := syncSide{
+		ctx:    ctx,
+		client: localClient,
+		object: localPrimary.DeepCopy(),
+	}
+
+	newReference := func(path string) syncagentv1alpha1.RelatedResourceObject {
+		return syncagentv1alpha1.RelatedResourceObject{
+			RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+				Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: path},
+			},
+		}
+	}
+
+	syncer := &ResourceSyncer{
+		pubRes: &syncagentv1alpha1.PublishedResource{
+			Spec: syncagentv1alpha1.PublishedResourceSpec{
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "config",
+					Origin:     "service",
+					Kind:       "ConfigMap",
+					Object:     newReference("data.configMapName"),
+					Related: []syncagentv1alpha1.RelatedResourceSpec{{
+						Identifier: "credentials",
+						Origin:     "service",
+						Kind:       "Secret",
+						Object:     newReference("data.secretName"),
+					}},
+				}},
+			},
+		},
+	}
+
+	stateStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(remote, local)
+
+	// process until nothing is left to do
+	for range 5 {
+		requeue, err := syncer.processRelatedResources(zap.NewNop().Sugar(), stateStore, remote, local)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !requeue {
+			break
+		}
+	}
+
+	remoteConfig := &corev1.ConfigMap{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "remote-config"}, remoteConfig); err != nil {
+		t.Fatalf("Failed to get related ConfigMap in kcp: %v", err)
+	}
+
+	remoteSecret := &corev1.Secret{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "my-secret"}, remoteSecret); err != nil {
+		t.Fatalf("Failed to get nested related Secret in kcp: %v", err)
+	}
+
+	if password := string(remoteSecret.Data["password"]); password != "hunter2" {
+		t.Errorf("Expected nested related Secret to be synced, but got password %q.", password)
+	}
+}
+
+func TestRelatedResourceFailureIsolation(t *testing.T) {
+	ctx := context.Background()
+
+	newConfigMap := func(namespace, name string, data map[string]string) *unstructured.Unstructured {
+		return newUnstructured(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		})
+	}
+
+	remotePrimary := newConfigMap("remote-ns", "my-thing", map[string]string{"configMapName": "my-config"})
+	localPrimary := newConfigMap("local-ns", "my-thing", map[string]string{"configMapName": "my-config"})
+
+	remoteClient := buildFakeClient(remotePrimary)
+	localClient := buildFakeClient(
+		localPrimary,
+		newConfigMap("local-ns", "my-config", map[string]string{"hello": "world"}),
+	)
+
+	remote := syncSide{
+		ctx:         ctx,
+		clusterName: logicalcluster.Name("testcluster"),
+		client:      remoteClient,
+		object:      remotePrimary.DeepCopy(),
+	}
+
+	local := syncSide{
+		ctx:    ctx,
+		client: localClient,
+		object: localPrimary.DeepCopy(),
+	}
+
+	newRelatedResource := func(identifier, pattern string) syncagentv1alpha1.RelatedResourceSpec {
+		return syncagentv1alpha1.RelatedResourceSpec{
+			Identifier: identifier,
+			Origin:     "service",
+			Kind:       "ConfigMap",
+			Object: syncagentv1alpha1.RelatedResourceObject{
+				RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+					Reference: &syncagentv1alpha1.RelatedResourceObjectReference{
+						Path:  "data.configMapName",
+						Regex: &syncagentv1alpha1.RegularExpression{Pattern: pattern, Replacement: "$0"},
+					},
+				},
+			},
+		}
+	}
+
+	// the invalid regular expression makes the first related resource fail
+	syncer := &ResourceSyncer{
+		pubRes: &syncagentv1alpha1.PublishedResource{
+			Spec: syncagentv1alpha1.PublishedResourceSpec{
+				Related: []syncagentv1alpha1.RelatedResourceSpec{
+					newRelatedResource("broken", "("),
+					newRelatedResource("config", ".*"),
+				},
+			},
+		},
+	}
+
+	stateStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(remote, local)
+
+	var err error
+	for range 5 {
+		_, err = syncer.processRelatedResources(zap.NewNop().Sugar(), stateStore, remote, local)
+	}
+
+	if err == nil {
+		t.Fatal("Expected an error for the broken related resource, but got none.")
+	}
+
+	remoteConfig := &corev1.ConfigMap{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "my-config"}, remoteConfig); err != nil {
+		t.Fatalf("Expected working related resource to be synced despite the broken one: %v", err)
+	}
+
+	if failed := remote.object.GetAnnotations()[syncagentv1alpha1.FailedRelatedResourcesAnnotation]; failed != "broken" {
+		t.Fatalf("Expected failed related resources annotation to be %q, got %q.", "broken", failed)
+	}
+
+	// once the related resource has been fixed, the annotation must be removed
+	syncer.pubRes.Spec.Related[0] = newRelatedResource("broken", ".*")
+
+	for range 5 {
+		if _, err := syncer.processRelatedResources(zap.NewNop().Sugar(), stateStore, remote, local); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if _, exists := remote.object.GetAnnotations()[syncagentv1alpha1.FailedRelatedResourcesAnnotation]; exists {
+		t.Fatal("Expected failed related resources annotation to be removed.")
+	}
+}
+
+func TestParallelRelatedObjects(t *testing.T) {
+	ctx := context.Background()
+
+	newConfigMap := func(namespace, name string, labels map[string]string, data map[string]string) *unstructured.Unstructured {
+		return newUnstructured(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Data:       data,
+		})
+	}
+
+	remotePrimary := newConfigMap("remote-ns", "my-thing", nil, nil)
+	localPrimary := newConfigMap("local-ns", "my-thing", nil, nil)
+
+	selected := map[string]string{"app": "my-thing"}
+	names := []string{"config-a", "config-b", "config-c", "config-d", "config-e"}
+
+	localObjects := []*unstructured.Unstructured{
+		localPrimary,
+		newConfigMap("local-ns", "unrelated", map[string]string{"app": "other"}, nil),
+	}
+	for _, name := range names {
+		localObjects = append(localObjects, newConfigMap("local-ns", name, selected, map[string]string{"name": name}))
+	}
+
+	remoteClient := buildFakeClient(remotePrimary)
+	localClient := buildFakeClient(localObjects...)
+
+	remote := syncSide{
+		ctx:         ctx,
+		clusterName: logicalcluster.Name("testcluster"),
+		client:      remoteClient,
+		object:      remotePrimary.DeepCopy(),
+	}
+
+	local := syncSide{
+		ctx:    ctx,
+		client: localClient,
+		object: localPrimary.DeepCopy(),
+	}
+
+	syncer := &ResourceSyncer{
+		pubRes: &syncagentv1alpha1.PublishedResource{
+			Spec: syncagentv1alpha1.PublishedResourceSpec{
+				Sync: &syncagentv1alpha1.SyncSettings{
+					MaxConcurrentRelatedObjects: 3,
+				},
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "configs",
+					Origin:     "service",
+					Kind:       "ConfigMap",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Selector: &syncagentv1alpha1.RelatedResourceObjectSelector{
+								LabelSelector: metav1.LabelSelector{MatchLabels: selected},
+								Rewrite: syncagentv1alpha1.RelatedResourceSelectorRewrite{
+									Regex: &syncagentv1alpha1.RegularExpression{Pattern: ".*", Replacement: "$0"},
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}
+
+	stateStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(remote, local)
+
+	// process until nothing is left to do
+	for range 5 {
+		requeue, err := syncer.processRelatedResources(zap.NewNop().Sugar(), stateStore, remote, local)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !requeue {
+			break
+		}
+	}
+
+	for _, name := range names {
+		remoteConfig := &corev1.ConfigMap{}
+		if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: name}, remoteConfig); err != nil {
+			t.Fatalf("Failed to get related ConfigMap %q in kcp: %v", name, err)
+		}
+
+		if remoteConfig.Data["name"] != name {
+			t.Errorf("Expected ConfigMap %q to contain its own data, but got %v.", name, remoteConfig.Data)
+		}
+	}
+
+	unrelated := &corev1.ConfigMap{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: "remote-ns", Name: "unrelated"}, unrelated); err == nil {
+		t.Error("Expected unselected ConfigMap not to be synced.")
+	}
+}
+
+func TestBidirectionalRelatedResources(t *testing.T) {
+	newSecret := func(namespace, password string) *unstructured.Unstructured {
+		return newUnstructured(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: namespace},
+			Data:       map[string][]byte{"password": []byte(password)},
+		})
+	}
+
+	testcases := []struct {
+		name             string
+		remoteSecret     *unstructured.Unstructured
+		localSecret      *unstructured.Unstructured
+		policy           syncagentv1alpha1.RelatedResourceConflictPolicy
+		expectedPassword string
+	}{
+		{
+			name:             "object only exists in kcp",
+			remoteSecret:     newSecret("remote-ns", "from-kcp"),
+			expectedPassword: "from-kcp",
+		},
+		{
+			name:             "object only exists on the service cluster",
+			localSecret:      newSecret("local-ns", "from-service"),
+			expectedPassword: "from-service",
+		},
+		{
+			name:             "kcp wins by default",
+			remoteSecret:     newSecret("remote-ns", "from-kcp"),
+			localSecret:      newSecret("local-ns", "from-service"),
+			expectedPassword: "from-kcp",
+		},
+		{
+			name:             "service cluster wins",
+			remoteSecret:     newSecret("remote-ns", "from-kcp"),
+			localSecret:      newSecret("local-ns", "from-service"),
+			policy:           syncagentv1alpha1.RelatedResourceConflictPolicyServiceWins,
+			expectedPassword: "from-service",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			remotePrimary := newUnstructured(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-thing", Namespace: "remote-ns"},
+				Data:       map[string]string{"secretName": "credentials"},
+			})
+
+			localPrimary := newUnstructured(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-thing", Namespace: "local-ns"},
+				Data:       map[string]string{"secretName": "credentials"},
+			})
+
+			remoteObjects := []*unstructured.Unstructured{remotePrimary}
+			if testcase.remoteSecret != nil {
+				remoteObjects = append(remoteObjects, testcase.remoteSecret)
+			}
+
+			localObjects := []*unstructured.Unstructured{localPrimary}
+			if testcase.localSecret != nil {
+				localObjects = append(localObjects, testcase.localSecret)
+			}
+
+			remote := syncSide{
+				ctx:         ctx,
+				clusterName: logicalcluster.Name("testcluster"),
+				client:      buildFakeClient(remoteObjects...),
+				object:      remotePrimary.DeepCopy(),
+			}
+
+			local := syncSide{
+				ctx:    ctx,
+				client: buildFakeClient(localObjects...),
+				object: localPrimary.DeepCopy(),
+			}
+
+			syncer := &ResourceSyncer{
+				pubRes: &syncagentv1alpha1.PublishedResource{
+					Spec: syncagentv1alpha1.PublishedResourceSpec{
+						Related: []syncagentv1alpha1.RelatedResourceSpec{{
+							Identifier:     "credentials",
+							Origin:         "both",
+							ConflictPolicy: testcase.policy,
+							Kind:           "Secret",
+							Object: syncagentv1alpha1.RelatedResourceObject{
+								RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+									Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "data.secretName"},
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			stateStore := newStateStoreCreator(StateOptions{Namespace: "kcp-system"})(remote, local)
+
+			// process until nothing is left to do
+			for range 5 {
+				requeue, err := syncer.processRelatedResources(zap.NewNop().Sugar(), stateStore, remote, local)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				if !requeue {
+					break
+				}
+			}
+
+			for _, side := range []syncSide{remote, local} {
+				secret := &corev1.Secret{}
+				if err := side.client.Get(ctx, types.NamespacedName{Namespace: side.object.GetNamespace(), Name: "credentials"}, secret); err != nil {
+					t.Fatalf("Failed to get Secret in %q: %v", side.object.GetNamespace(), err)
+				}
+
+				if password := string(secret.Data["password"]); password != testcase.expectedPassword {
+					t.Errorf("Expected Secret in %q to have password %q, but got %q.", side.object.GetNamespace(), testcase.expectedPassword, password)
+				}
+			}
+		})
+	}
+}
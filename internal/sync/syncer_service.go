@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TargetForServiceObject returns the request for a local object of a PublishedResource
+// with origin "service", i.e. the local object's key and the logical cluster it is
+// published into. If the object has no target cluster, but still carries the cleanup
+// finalizer, a request without cluster name is returned, so that the finalizer can be
+// removed. Otherwise nil is returned.
+func TargetForServiceObject(localObj ctrlruntimeclient.Object) *reconcile.Request {
+	clusterName := localObj.GetLabels()[syncagentv1alpha1.TargetClusterLabel]
+
+	if clusterName == "" && !hasFinalizer(localObj, deletionFinalizer) {
+		return nil
+	}
+
+	return &reconcile.Request{
+		ClusterName:    clusterName,
+		NamespacedName: ctrlruntimeclient.ObjectKeyFromObject(localObj),
+	}
+}
+
+// ProcessLocal is the counterpart to Process for PublishedResources with origin "service".
+// It publishes the given local object into the kcp workspace given in the context,
+// including its status, and removes the copy in kcp when the local object is deleted.
+// Like Process, it returns true if the caller should requeue the object.
+func (s *ResourceSyncer) ProcessLocal(ctx Context, localObj *unstructured.Unstructured) (requeue bool, err error) {
+	localKey := newObjectKey(localObj, "", logicalcluster.None)
+	log := s.log.With("source-object", localKey, "cluster", ctx.clusterName)
+
+	unlock := s.objectLocks.Lock(string(ctx.clusterName) + ">" + localKey.String())
+	defer unlock()
+
+	remoteKey, err := s.remoteNameForServiceObject(localObj)
+	if err != nil {
+		return false, err
+	}
+
+	// find the copy in kcp
+	remoteObj := &unstructured.Unstructured{}
+	remoteObj.SetGroupVersionKind(s.remoteGVK)
+
+	if err := s.remoteClient.Get(ctx.remote, remoteKey, remoteObj); err != nil {
+		if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to get remote object: %w", err)
+		}
+
+		remoteObj = nil
+	}
+
+	// paused objects are not synchronized at all, not even when being deleted
+	paused := isPaused(remoteObj) || isPaused(localObj)
+
+	updated, err := s.updatePausedCondition(log, ctx, remoteObj, paused)
+	if err != nil {
+		return false, fmt.Errorf("failed to update paused condition: %w", err)
+	}
+
+	if paused {
+		log.Debug("Object is paused, skipping")
+		return false, nil
+	}
+
+	// the status update will trigger a new reconciliation
+	if updated {
+		return true, nil
+	}
+
+	sourceSide := syncSide{
+		ctx:    ctx.local,
+		client: s.localClient,
+		object: localObj,
+	}
+
+	destSide := syncSide{
+		ctx:           ctx.remote,
+		clusterName:   ctx.clusterName,
+		workspacePath: ctx.workspacePath,
+		client:        s.remoteClient,
+		object:        remoteObj,
+	}
+
+	// The state is kept on the service cluster, like for every other object; the
+	// cluster name is only used to keep the states for different workspaces apart.
+	statePrimary := sourceSide
+	statePrimary.clusterName = ctx.clusterName
+
+	syncer := objectSyncer{
+		// objects in kcp are not labelled with the agent name
+		// agentName: "",
+		subresources: s.subresources,
+		destCreator: func(source *unstructured.Unstructured) *unstructured.Unstructured {
+			dest := source.DeepCopy()
+			dest.SetGroupVersionKind(s.remoteGVK)
+			dest.SetNamespace(remoteKey.Namespace)
+			dest.SetName(remoteKey.Name)
+
+			return dest
+		},
+		// the status is owned by the service cluster and copied into kcp
+		syncStatusForward: true,
+		// make sure the copy in kcp is removed when the local object is deleted
+		blockSourceDeletion: true,
+		// use the configured mutations from the PublishedResource
+		mutator:        s.mutator,
+		ignoredFields:  ignoredFields(s.pubRes.Spec.Mutation),
+		metadataPolicy: newMetadataPolicy(s.pubRes.Spec.MetadataSync, directionUp),
+		// make sure the syncer can remember the current state of any object
+		stateStore: s.newObjectStateStore(statePrimary, sourceSide),
+		// use server-side apply, if configured
+		fieldManager: s.fieldManager(),
+		// we never want to store sync-related metadata inside kcp
+		metadataOnDestination: false,
+	}
+
+	return syncer.Sync(log, sourceSide, destSide)
+}
+
+// ReleaseLocal removes the cleanup finalizer from a local object that is not published
+// into any workspace anymore (because its target cluster label was removed).
+func (s *ResourceSyncer) ReleaseLocal(ctx context.Context, localObj *unstructured.Unstructured) error {
+	log := s.log.With("source-object", newObjectKey(localObj, "", logicalcluster.None))
+
+	if _, err := removeFinalizer(ctx, log, s.localClient, localObj, deletionFinalizer); err != nil {
+		return fmt.Errorf("failed to remove cleanup finalizer: %w", err)
+	}
+
+	return nil
+}
+
+// remoteNameForServiceObject determines the name of the copy in kcp for an object
+// originating on the service cluster. The name is never changed, the namespace can
+// be overridden using an annotation.
+func (s *ResourceSyncer) remoteNameForServiceObject(localObj *unstructured.Unstructured) (types.NamespacedName, error) {
+	key := types.NamespacedName{Name: localObj.GetName()}
+
+	if s.remoteScope() == syncagentv1alpha1.ClusterScoped {
+		return key, nil
+	}
+
+	key.Namespace = localObj.GetAnnotations()[syncagentv1alpha1.TargetNamespaceAnnotation]
+	if key.Namespace == "" {
+		key.Namespace = localObj.GetNamespace()
+	}
+
+	if key.Namespace == "" {
+		return key, errors.New("object is cluster-scoped, but the resource is namespaced in kcp; the target namespace annotation is required")
+	}
+
+	return key, nil
+}
+
+func (s *ResourceSyncer) remoteScope() syncagentv1alpha1.ResourceScope {
+	if projection := s.pubRes.Spec.Projection; projection != nil && projection.Scope != "" {
+		return projection.Scope
+	}
+
+	return syncagentv1alpha1.ResourceScope(s.localCRD.Spec.Scope)
+}
+
+func hasFinalizer(obj ctrlruntimeclient.Object, finalizer string) bool {
+	return slices.Contains(obj.GetFinalizers(), finalizer)
+}
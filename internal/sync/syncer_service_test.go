@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+	"go.uber.org/zap"
+
+	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/kontext"
+)
+
+func TestSyncerProcessingServiceObject(t *testing.T) {
+	clusterName := logicalcluster.Name("testcluster")
+
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inventory-things",
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: dummyv1alpha1.GroupName,
+				Version:  dummyv1alpha1.GroupVersion,
+				Kind:     "ThingWithStatusSubresource",
+			},
+			Projection: &syncagentv1alpha1.ResourceProjection{
+				Kind: "RemoteThing",
+			},
+			Origin: syncagentv1alpha1.PublishedResourceOriginService,
+		},
+	}
+
+	remoteTemplate := newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{}, withKind("RemoteThing"))
+
+	testcases := []struct {
+		name                 string
+		localObject          *unstructured.Unstructured
+		remoteObject         *unstructured.Unstructured
+		expectedLocalObject  *unstructured.Unstructured
+		expectedRemoteObject *unstructured.Unstructured
+	}{
+		{
+			name: "new local object is published into kcp",
+			localObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Labels: map[string]string{
+						syncagentv1alpha1.TargetClusterLabel: clusterName.String(),
+						"app":                                "inventory",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+				Status: dummyv1alpha1.ThingStatus{
+					CurrentVersion: "v1",
+				},
+			}),
+			expectedLocalObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Labels: map[string]string{
+						syncagentv1alpha1.TargetClusterLabel: clusterName.String(),
+						"app":                                "inventory",
+					},
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+				Status: dummyv1alpha1.ThingStatus{
+					CurrentVersion: "v1",
+				},
+			}),
+			expectedRemoteObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Labels: map[string]string{
+						"app": "inventory",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+				Status: dummyv1alpha1.ThingStatus{
+					CurrentVersion: "v1",
+				},
+			}, withKind("RemoteThing")),
+		},
+		{
+			name: "deleted local object is removed from kcp",
+			localObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "my-test-thing",
+					DeletionTimestamp: &nonEmptyTime,
+					Labels: map[string]string{
+						syncagentv1alpha1.TargetClusterLabel: clusterName.String(),
+					},
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			remoteObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}, withKind("RemoteThing")),
+			expectedLocalObject:  nil,
+			expectedRemoteObject: nil,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			localClient := buildFakeClientWithStatus(testcase.localObject)
+
+			remoteBuilder := fakectrlruntimeclient.NewClientBuilder().WithStatusSubresource(remoteTemplate)
+			if testcase.remoteObject != nil {
+				remoteBuilder.WithObjects(testcase.remoteObject)
+			}
+			remoteClient := remoteBuilder.Build()
+
+			syncer, err := NewResourceSyncer(
+				zap.NewNop().Sugar(),
+				localClient,
+				remoteClient,
+				pubRes,
+				loadCRD("thingwithstatussubresources"),
+				nil,
+				nil,
+				record.NewFakeRecorder(10),
+				StateOptions{Namespace: "kcp-system"},
+				"textor-the-doctor",
+			)
+			if err != nil {
+				t.Fatalf("Failed to create syncer: %v", err)
+			}
+
+			localCtx := context.Background()
+			remoteCtx := kontext.WithCluster(localCtx, clusterName)
+			ctx := NewContext(localCtx, remoteCtx)
+
+			target := testcase.localObject.DeepCopy()
+
+			for i := 0; true; i++ {
+				if i > 20 {
+					t.Fatalf("Detected potential infinite loop, stopping after %d requeues.", i)
+				}
+
+				requeue, err := syncer.ProcessLocal(ctx, target)
+				if err != nil {
+					t.Fatalf("Processing failed: %v", err)
+				}
+
+				if !requeue {
+					break
+				}
+
+				if err := localClient.Get(localCtx, ctrlruntimeclient.ObjectKeyFromObject(target), target); err != nil {
+					if apierrors.IsNotFound(err) {
+						break
+					}
+
+					t.Fatalf("Failed to get updated local object: %v", err)
+				}
+			}
+
+			finalLocalObject, err := getFinalObjectVersion(localCtx, localClient, testcase.localObject)
+			if err != nil {
+				t.Fatalf("Failed to get final local object: %v", err)
+			}
+
+			finalRemoteObject, err := getFinalObjectVersion(remoteCtx, remoteClient, testcase.remoteObject, testcase.expectedRemoteObject)
+			if err != nil {
+				t.Fatalf("Failed to get final remote object: %v", err)
+			}
+
+			assertObjectsEqual(t, "local", testcase.expectedLocalObject, finalLocalObject)
+			assertObjectsEqual(t, "remote", testcase.expectedRemoteObject, finalRemoteObject)
+		})
+	}
+}
@@ -19,8 +19,10 @@ package sync
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/kcp-dev/logicalcluster/v3"
@@ -29,13 +31,17 @@ import (
 	dummyv1alpha1 "github.com/kcp-dev/api-syncagent/internal/sync/apis/dummy/v1alpha1"
 	"github.com/kcp-dev/api-syncagent/internal/test/diff"
 	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/state"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/kontext"
@@ -82,6 +88,132 @@ func loadCRD(filename string) *apiextensionsv1.CustomResourceDefinition {
 	return crd
 }
 
+type fakeDiscoverer struct {
+	subresources []string
+	err          error
+	calls        int
+}
+
+func (d *fakeDiscoverer) RetrieveSubresources(gvk schema.GroupVersionKind) ([]string, error) {
+	d.calls++
+	return d.subresources, d.err
+}
+
+func TestNewResourceSyncerSubresources(t *testing.T) {
+	withoutSubresources := loadCRD("things")
+	withoutSubresources.Spec.Versions[0].Subresources = nil
+
+	withoutVersion := loadCRD("things")
+	withoutVersion.Spec.Versions[0].Name = "v2"
+
+	testcases := []struct {
+		name                 string
+		localCRD             *apiextensionsv1.CustomResourceDefinition
+		discoverer           *fakeDiscoverer
+		expectedSubresources []string
+		expectedScale        *apiextensionsv1.CustomResourceSubresourceScale
+		expectedCalls        int
+		expectErr            bool
+	}{
+		{
+			name:                 "subresources described by the CRD do not require discovery",
+			localCRD:             loadCRD("things"),
+			discoverer:           &fakeDiscoverer{subresources: []string{"scale", "status"}},
+			expectedSubresources: []string{"status"},
+		},
+		{
+			name:                 "CRD without subresources and without discovery",
+			localCRD:             withoutSubresources,
+			expectedSubresources: []string{},
+		},
+		{
+			name:                 "subresources missing from the CRD fall back to discovery",
+			localCRD:             withoutSubresources,
+			discoverer:           &fakeDiscoverer{subresources: []string{"scale", "status"}},
+			expectedSubresources: []string{"scale", "status"},
+			expectedScale:        defaultScaleSubresource(),
+			expectedCalls:        1,
+		},
+		{
+			name:       "version missing from the CRD is an error even with discovery",
+			localCRD:   withoutVersion,
+			discoverer: &fakeDiscoverer{subresources: []string{"status"}},
+			expectErr:  true,
+		},
+		{
+			name:      "version missing from the CRD without discovery",
+			localCRD:  withoutVersion,
+			expectErr: true,
+		},
+		{
+			name:          "discovery errors are returned",
+			localCRD:      withoutSubresources,
+			discoverer:    &fakeDiscoverer{err: errors.New("no such API")},
+			expectedCalls: 1,
+			expectErr:     true,
+		},
+	}
+
+	pubRes := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "things",
+		},
+		Spec: syncagentv1alpha1.PublishedResourceSpec{
+			Resource: syncagentv1alpha1.SourceResourceDescriptor{
+				APIGroup: dummyv1alpha1.GroupName,
+				Version:  dummyv1alpha1.GroupVersion,
+				Kind:     "Thing",
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			// avoid passing a typed nil pointer as the interface
+			var discoverer SubresourceDiscoverer
+			if testcase.discoverer != nil {
+				discoverer = testcase.discoverer
+			}
+
+			syncer, err := NewResourceSyncer(
+				zap.NewNop().Sugar(),
+				buildFakeClient(),
+				buildFakeClient(),
+				pubRes,
+				testcase.localCRD,
+				discoverer,
+				nil,
+				record.NewFakeRecorder(10),
+				StateOptions{Namespace: "kcp-system"},
+				"textor-the-doctor",
+			)
+
+			if testcase.discoverer != nil && testcase.discoverer.calls != testcase.expectedCalls {
+				t.Errorf("Expected %d discovery calls, but got %d.", testcase.expectedCalls, testcase.discoverer.calls)
+			}
+
+			if testcase.expectErr {
+				if err == nil {
+					t.Fatal("Expected an error, but got none.")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to create syncer: %v", err)
+			}
+
+			if !slices.Equal(syncer.subresources, testcase.expectedSubresources) {
+				t.Errorf("Expected subresources %v, but got %v.", testcase.expectedSubresources, syncer.subresources)
+			}
+
+			if !equality.Semantic.DeepEqual(syncer.scale, testcase.expectedScale) {
+				t.Errorf("Expected scale subresource %+v, but got %+v.", testcase.expectedScale, syncer.scale)
+			}
+		})
+	}
+}
+
 func withKind(kind string) func(*unstructured.Unstructured) {
 	return func(u *unstructured.Unstructured) {
 		u.SetKind(kind)
@@ -123,22 +255,28 @@ func newUnstructured(obj runtime.Object, modifiers ...func(*unstructured.Unstruc
 
 func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 	type testcase struct {
-		name                 string
-		localCRD             *apiextensionsv1.CustomResourceDefinition
-		pubRes               *syncagentv1alpha1.PublishedResource
-		remoteObject         *unstructured.Unstructured
-		localObject          *unstructured.Unstructured
-		existingState        string
-		performRequeues      bool
-		expectedRemoteObject *unstructured.Unstructured
-		expectedLocalObject  *unstructured.Unstructured
-		expectedState        string
-		customVerification   func(t *testing.T, requeue bool, processErr error, finalRemoteObject *unstructured.Unstructured, finalLocalObject *unstructured.Unstructured, testcase testcase)
+		name          string
+		localCRD      *apiextensionsv1.CustomResourceDefinition
+		pubRes        *syncagentv1alpha1.PublishedResource
+		remoteObject  *unstructured.Unstructured
+		localObject   *unstructured.Unstructured
+		existingState string
+		// existingOriginalState is the state of the remote object before mutations
+		existingOriginalState string
+		namespaceLabels       map[string]string
+		performRequeues       bool
+		expectedRemoteObject  *unstructured.Unstructured
+		expectedLocalObject   *unstructured.Unstructured
+		expectedState         string
+		customVerification    func(t *testing.T, requeue bool, processErr error, finalRemoteObject *unstructured.Unstructured, finalLocalObject *unstructured.Unstructured, testcase testcase)
 	}
 
 	clusterName := logicalcluster.Name("testcluster")
 
 	remoteThingPR := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "remote-things",
+		},
 		Spec: syncagentv1alpha1.PublishedResourceSpec{
 			Resource: syncagentv1alpha1.SourceResourceDescriptor{
 				APIGroup: dummyv1alpha1.GroupName,
@@ -156,6 +294,15 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 		},
 	}
 
+	immutableUsernamePR := remoteThingPR.DeepCopy()
+	immutableUsernamePR.Spec.ImmutableFields = []string{"spec.username"}
+
+	retainingPR := remoteThingPR.DeepCopy()
+	retainingPR.Spec.DeletionPolicy = syncagentv1alpha1.DeletionPolicyRetain
+
+	snapshottingPR := remoteThingPR.DeepCopy()
+	snapshottingPR.Spec.DeletionPolicy = syncagentv1alpha1.DeletionPolicySnapshot
+
 	testcases := []testcase{
 
 		/////////////////////////////////////////////////////////////////////////////////
@@ -186,7 +333,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -215,7 +365,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -264,7 +417,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -323,7 +479,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -361,7 +520,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -390,7 +552,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -402,6 +567,155 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 
 		/////////////////////////////////////////////////////////////////////////////////
 
+		{
+			name:            "changes to immutable fields should be reverted",
+			localCRD:        loadCRD("things"),
+			pubRes:          immutableUsernamePR,
+			performRequeues: true,
+
+			remoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Miss Scarlet",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			localObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			existingState:         `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+			existingOriginalState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+
+			expectedRemoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			expectedLocalObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			expectedState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+		},
+
+		/////////////////////////////////////////////////////////////////////////////////
+
+		{
+			name:            "labels from the remote namespace should be placed on the local object",
+			localCRD:        loadCRD("things"),
+			pubRes:          remoteThingPR,
+			performRequeues: true,
+			namespaceLabels: map[string]string{"cost-center": "12345"},
+
+			remoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			localObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			existingState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+
+			expectedRemoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			expectedLocalObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+						"cost-center":             "12345",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			expectedState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"labels":{"cost-center":"12345"},"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+		},
+
+		/////////////////////////////////////////////////////////////////////////////////
+
 		{
 			name:            "a broken last-state should be fixed automatically",
 			localCRD:        loadCRD("things"),
@@ -428,7 +742,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -457,7 +774,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -499,8 +819,11 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "testcluster-my-test-thing",
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
-						"existing-annotation":      "annotation-value",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+						"existing-annotation":                         "annotation-value",
 					},
 					Labels: map[string]string{
 						agentNameLabel:            "textor-the-doctor",
@@ -540,9 +863,12 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "testcluster-my-test-thing",
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
-						"existing-annotation":      "new-annotation-value",
-						"new-annotation":           "hei-verden",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
+						"existing-annotation":                         "new-annotation-value",
+						"new-annotation":                              "hei-verden",
 					},
 					Labels: map[string]string{
 						agentNameLabel:            "textor-the-doctor",
@@ -609,7 +935,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "testcluster-my-test-thing",
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 					Labels: map[string]string{
 						agentNameLabel:            "textor-the-doctor",
@@ -653,7 +982,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -683,7 +1015,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -730,7 +1065,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -764,7 +1102,10 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "Thing.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.remote.example.corp",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -816,6 +1157,148 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 
 		/////////////////////////////////////////////////////////////////////////////////
 
+		{
+			name:            "the Retain deletion policy detaches the local object instead of deleting it",
+			localCRD:        loadCRD("things"),
+			pubRes:          retainingPR,
+			performRequeues: false,
+
+			remoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+						"prevent-object-from-disappearing",
+					},
+					DeletionTimestamp: &nonEmptyTime,
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Miss Scarlet",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			localObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			existingState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+
+			expectedRemoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						"prevent-object-from-disappearing",
+					},
+					DeletionTimestamp: &nonEmptyTime,
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Miss Scarlet",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			expectedLocalObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel: "textor-the-doctor",
+					},
+					Annotations: map[string]string{
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.RetainedFromAnnotation:      "testcluster|my-test-thing",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					// the object is detached and not updated anymore
+					Username: "Colonel Mustard",
+				},
+			}),
+			expectedState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+		},
+
+		/////////////////////////////////////////////////////////////////////////////////
+
+		{
+			name:            "the Snapshot deletion policy also stores the final remote state",
+			localCRD:        loadCRD("things"),
+			pubRes:          snapshottingPR,
+			performRequeues: false,
+
+			remoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+						"prevent-object-from-disappearing",
+					},
+					DeletionTimestamp: &nonEmptyTime,
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Miss Scarlet",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			localObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+			}),
+			existingState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+
+			expectedRemoteObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						"prevent-object-from-disappearing",
+					},
+					DeletionTimestamp: &nonEmptyTime,
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Miss Scarlet",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			expectedLocalObject: newUnstructured(&dummyv1alpha1.Thing{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel: "textor-the-doctor",
+					},
+					Annotations: map[string]string{
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.RetainedFromAnnotation:      "testcluster|my-test-thing",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					// the object is detached and not updated anymore
+					Username: "Colonel Mustard",
+				},
+			}),
+			expectedState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Miss Scarlet"}}`,
+		},
+
+		/////////////////////////////////////////////////////////////////////////////////
+
 		{
 			name:            "do not attempt to update a local object that is in deletion",
 			localCRD:        loadCRD("things"),
@@ -900,7 +1383,9 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 				testcase.pubRes,
 				testcase.localCRD,
 				nil,
-				stateNamespace,
+				nil,
+				record.NewFakeRecorder(10),
+				StateOptions{Namespace: stateNamespace},
 				"textor-the-doctor",
 			)
 			if err != nil {
@@ -911,17 +1396,26 @@ func TestSyncerProcessingSingleResourceWithoutStatus(t *testing.T) {
 			remoteCtx := kontext.WithCluster(localCtx, clusterName)
 			ctx := NewContext(localCtx, remoteCtx)
 
+			if testcase.namespaceLabels != nil {
+				ctx = ctx.WithNamespaceLabels(testcase.namespaceLabels)
+			}
+
 			// setup a custom state backend that we can prime
-			var backend *kubernetesBackend
+			var backend backend
 			syncer.newObjectStateStore = func(primaryObject, stateCluster syncSide) ObjectStateStore {
 				// .Process() is called multiple times, but we want the state to persist between reconciles.
 				if backend == nil {
-					backend = newKubernetesBackend(stateNamespace, primaryObject, stateCluster)
+					backend = newKeyedBackend(stateCluster.ctx, newStateBackend(stateNamespace, state.BackendSecret, primaryObject, stateCluster))
 					if testcase.existingState != "" {
 						if err := backend.Put(testcase.remoteObject, clusterName, []byte(testcase.existingState)); err != nil {
 							t.Fatalf("Failed to prime state store: %v", err)
 						}
 					}
+					if testcase.existingOriginalState != "" {
+						if err := backend.PutOriginal(testcase.remoteObject, clusterName, []byte(testcase.existingOriginalState)); err != nil {
+							t.Fatalf("Failed to prime state store: %v", err)
+						}
+					}
 				}
 
 				return &objectStateStore{
@@ -1017,6 +1511,9 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 	clusterName := logicalcluster.Name("testcluster")
 
 	remoteThingPR := &syncagentv1alpha1.PublishedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "remote-things",
+		},
 		Spec: syncagentv1alpha1.PublishedResourceSpec{
 			Resource: syncagentv1alpha1.SourceResourceDescriptor{
 				APIGroup: dummyv1alpha1.GroupName,
@@ -1066,7 +1563,10 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "ThingWithStatusSubresource.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.dummy.example.com",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -1101,7 +1601,10 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "ThingWithStatusSubresource.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.dummy.example.com",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -1142,7 +1645,10 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "ThingWithStatusSubresource.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.dummy.example.com",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -1177,7 +1683,10 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
 					},
 					Annotations: map[string]string{
-						remoteObjectNameAnnotation: "my-test-thing",
+						remoteObjectNameAnnotation:                    "my-test-thing",
+						syncagentv1alpha1.PublishedResourceAnnotation: "remote-things",
+						syncagentv1alpha1.SourceGVKAnnotation:         "ThingWithStatusSubresource.v1alpha1.dummy.example.com",
+						syncagentv1alpha1.ProjectedGVKAnnotation:      "RemoteThing.v1alpha1.dummy.example.com",
 					},
 				},
 				Spec: dummyv1alpha1.ThingSpec{
@@ -1189,6 +1698,74 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 			}),
 			expectedState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
 		},
+		/////////////////////////////////////////////////////////////////////////////////
+
+		{
+			name:            "paused objects are not synchronized, but get a condition",
+			localCRD:        loadCRD("thingwithstatussubresources"),
+			pubRes:          remoteThingPR,
+			performRequeues: true,
+
+			remoteObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-test-thing",
+					Finalizers: []string{
+						deletionFinalizer,
+					},
+					Annotations: map[string]string{
+						syncagentv1alpha1.PausedAnnotation: "true",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Professor Plum",
+				},
+			}, withGroupKind("remote.example.corp", "RemoteThing")),
+			localObject: newUnstructured(&dummyv1alpha1.ThingWithStatusSubresource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testcluster-my-test-thing",
+					Labels: map[string]string{
+						agentNameLabel:            "textor-the-doctor",
+						remoteObjectClusterLabel:  "testcluster",
+						remoteObjectNameHashLabel: "c346c8ceb5d104cc783d09b95e8ea7032c190948",
+					},
+					Annotations: map[string]string{
+						remoteObjectNameAnnotation: "my-test-thing",
+					},
+				},
+				Spec: dummyv1alpha1.ThingSpec{
+					Username: "Colonel Mustard",
+				},
+				Status: dummyv1alpha1.ThingStatus{
+					CurrentVersion: "v1",
+				},
+			}),
+			existingState: `{"apiVersion":"remote.example.corp/v1alpha1","kind":"RemoteThing","metadata":{"name":"my-test-thing"},"spec":{"username":"Colonel Mustard"}}`,
+
+			customVerification: func(t *testing.T, requeue bool, processErr error, finalRemoteObject *unstructured.Unstructured, finalLocalObject *unstructured.Unstructured, testcase testcase) {
+				if processErr != nil {
+					t.Fatalf("Processing failed: %v", processErr)
+				}
+
+				if requeue {
+					t.Fatal("Expected no requeue for paused object.")
+				}
+
+				username, _, _ := unstructured.NestedString(finalLocalObject.Object, "spec", "username")
+				if username != "Colonel Mustard" {
+					t.Fatalf("Expected local object to remain unchanged, but username is %q.", username)
+				}
+
+				conditions, _, _ := unstructured.NestedSlice(finalRemoteObject.Object, "status", "conditions")
+				if len(conditions) != 1 {
+					t.Fatalf("Expected exactly one condition on the remote object, got %v.", conditions)
+				}
+
+				condition := conditions[0].(map[string]any)
+				if condition["type"] != syncagentv1alpha1.PausedCondition || condition["status"] != "True" {
+					t.Fatalf("Expected paused condition to be true, got %v.", condition)
+				}
+			},
+		},
 	}
 
 	const stateNamespace = "kcp-system"
@@ -1206,7 +1783,9 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 				testcase.pubRes,
 				testcase.localCRD,
 				nil,
-				stateNamespace,
+				nil,
+				record.NewFakeRecorder(10),
+				StateOptions{Namespace: stateNamespace},
 				"textor-the-doctor",
 			)
 			if err != nil {
@@ -1218,11 +1797,11 @@ func TestSyncerProcessingSingleResourceWithStatus(t *testing.T) {
 			ctx := NewContext(localCtx, remoteCtx)
 
 			// setup a custom state backend that we can prime
-			var backend *kubernetesBackend
+			var backend backend
 			syncer.newObjectStateStore = func(primaryObject, stateCluster syncSide) ObjectStateStore {
 				// .Process() is called multiple times, but we want the state to persist between reconciles.
 				if backend == nil {
-					backend = newKubernetesBackend(stateNamespace, primaryObject, stateCluster)
+					backend = newKeyedBackend(stateCluster.ctx, newStateBackend(stateNamespace, state.BackendSecret, primaryObject, stateCluster))
 					if testcase.existingState != "" {
 						if err := backend.Put(testcase.remoteObject, clusterName, []byte(testcase.existingState)); err != nil {
 							t.Fatalf("Failed to prime state store: %v", err)
@@ -16,7 +16,10 @@ limitations under the License.
 
 package sync
 
-import ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 const (
 	// deletionFinalizer is the finalizer put on remote objects to prevent
@@ -36,17 +39,15 @@ const (
 
 	remoteObjectWorkspacePathAnnotation = "syncagent.kcp.io/remote-object-workspace-path"
 
+	// remoteObjectWorkspacePathHashLabel contains a hash of the workspace path (if
+	// enabled), so that local objects can be selected per workspace path. Unlike the
+	// other labels, it is not used to find local objects, as workspace paths can change.
+	remoteObjectWorkspacePathHashLabel = "syncagent.kcp.io/remote-object-workspace-path-hash"
+
 	// agentNameLabel contains the Sync Agent's name and is used to allow multiple Sync Agents
 	// on the same service cluster, syncing *the same* API to different kcp's.
 	agentNameLabel = "syncagent.kcp.io/agent-name"
 
-	// objectStateLabelName is put on object state Secrets to allow for easier mass deletions
-	// if ever necessary.
-	objectStateLabelName = "syncagent.kcp.io/object-state"
-
-	// objectStateLabelValue is the value of the objectStateLabelName label.
-	objectStateLabelValue = "true"
-
 	// relatedObjectAnnotationPrefix is the prefix for the annotation that is placed on
 	// objects in the kcp workspaces, informing the user about the existence of a related
 	// object. The identifier of the related object is appended to this to form the
@@ -58,3 +59,9 @@ const (
 func OwnedBy(obj ctrlruntimeclient.Object, agentName string) bool {
 	return obj.GetLabels()[agentNameLabel] == agentName
 }
+
+// OwnedObjectsSelector returns a label selector for all objects on the service
+// cluster that are managed by the given Sync Agent.
+func OwnedObjectsSelector(agentName string) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{agentNameLabel: agentName})
+}
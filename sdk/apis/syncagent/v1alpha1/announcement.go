@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.cluster"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Announcement allows service providers to create an object of a published kind
+// in a consumer's kcp workspace, for example to provide a default instance. The
+// object is created exactly once; afterwards it is synced like any other object
+// created by the consumer.
+type Announcement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AnnouncementSpec `json:"spec"`
+
+	// Status contains information about the created object.
+	Status AnnouncementStatus `json:"status,omitempty"`
+}
+
+// AnnouncementSpec describes which object should be created in which workspace.
+type AnnouncementSpec struct {
+	// PublishedResource is the name of the PublishedResource whose kind the object
+	// has. The object is created using the projected GVK of the PublishedResource.
+	PublishedResource string `json:"publishedResource"`
+
+	// Cluster is the logical cluster name of the kcp workspace in which the object
+	// should be created.
+	Cluster string `json:"cluster"`
+
+	// Object is the object to create. Its apiVersion and kind can be omitted and
+	// will be set according to the PublishedResource. The object's metadata must
+	// contain at least a name.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Object runtime.RawExtension `json:"object"`
+}
+
+// AnnouncementPhase describes the state of an Announcement.
+type AnnouncementPhase string
+
+const (
+	// AnnouncementPhasePending means the object has not yet been created.
+	AnnouncementPhasePending AnnouncementPhase = "Pending"
+	// AnnouncementPhaseCreated means the object has been created in kcp.
+	AnnouncementPhaseCreated AnnouncementPhase = "Created"
+	// AnnouncementPhaseDeleted means the object had been created, but was since
+	// deleted in kcp. The Sync Agent will not recreate it.
+	AnnouncementPhaseDeleted AnnouncementPhase = "Deleted"
+	// AnnouncementPhaseFailed means the object could not be created.
+	AnnouncementPhaseFailed AnnouncementPhase = "Failed"
+)
+
+// AnnouncementStatus keeps track of the object created in kcp.
+type AnnouncementStatus struct {
+	Phase AnnouncementPhase `json:"phase,omitempty"`
+
+	// Message contains details in case the object could not be created.
+	Message string `json:"message,omitempty"`
+
+	// RemoteUID is the UID of the object created in kcp. It is used to detect
+	// whether the object has been deleted since.
+	RemoteUID types.UID `json:"remoteUID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AnnouncementList contains a list of Announcements.
+type AnnouncementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Announcement `json:"items"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+
+// ObjectState stores the last known state of a synchronized object and its related
+// objects. It is an alternative to storing the state in Secrets and is only used if the
+// Sync Agent is started with --state-backend=objectstate. The data is always compressed.
+type ObjectState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Data contains the gzip-compressed states, keyed by a hash of the object's identity.
+	Data map[string][]byte `json:"data,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectStateList contains a list of ObjectStates.
+type ObjectStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectState `json:"items"`
+}
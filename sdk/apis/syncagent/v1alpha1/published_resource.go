@@ -18,14 +18,17 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
-	PlaceholderRemoteClusterName   = "$remoteClusterName"
-	PlaceholderRemoteNamespace     = "$remoteNamespace"
-	PlaceholderRemoteNamespaceHash = "$remoteNamespaceHash"
-	PlaceholderRemoteName          = "$remoteName"
-	PlaceholderRemoteNameHash      = "$remoteNameHash"
+	PlaceholderRemoteClusterName       = "$remoteClusterName"
+	PlaceholderRemoteWorkspacePath     = "$remoteWorkspacePath"
+	PlaceholderRemoteWorkspacePathHash = "$remoteWorkspacePathHash"
+	PlaceholderRemoteNamespace         = "$remoteNamespace"
+	PlaceholderRemoteNamespaceHash     = "$remoteNamespaceHash"
+	PlaceholderRemoteName              = "$remoteName"
+	PlaceholderRemoteNameHash          = "$remoteNameHash"
 )
 
 // +genclient
@@ -33,6 +36,10 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Schema",type="string",JSONPath=".status.resourceSchemaName"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Synced",type="integer",JSONPath=".status.statistics.syncedObjects"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // PublishedResource describes how an API type (usually defined by a CRD)
 // on the service cluster should be exposed in kcp workspaces. Besides
@@ -56,6 +63,20 @@ type PublishedResourceSpec struct {
 	// that should be exposed in kcp workspaces. All fields have to be specified.
 	Resource SourceResourceDescriptor `json:"resource"`
 
+	// Origin configures on which side objects of this resource are created. By default,
+	// objects originate in kcp and are synced down to the service cluster ("kcp"). If set
+	// to "service", objects are created on the service cluster and are published into
+	// the kcp workspace named by their syncagent.kcp.io/target-cluster label.
+	// Defaults to "kcp".
+	// +kubebuilder:validation:Enum=kcp;service
+	Origin PublishedResourceOrigin `json:"origin,omitempty"`
+
+	// Paused stops the synchronization of all objects of this resource, for example
+	// during maintenance windows of the service cluster. The APIResourceSchema and
+	// the APIExport are not affected, so consumers can still use the API in kcp,
+	// their objects are simply not synchronized until the resource is unpaused.
+	Paused bool `json:"paused,omitempty"`
+
 	// If specified, the filter will be applied to the resources in a workspace
 	// and allow restricting which of them will be handled by the Sync Agent.
 	Filter *ResourceFilter `json:"filter,omitempty"`
@@ -75,6 +96,12 @@ type PublishedResourceSpec struct {
 	// service cluster side.
 	EnableWorkspacePaths bool `json:"enableWorkspacePaths,omitempty"`
 
+	// EnableRemoteEvents toggles whether the Sync Agent records Events on the objects
+	// in kcp when their copies on the service cluster are created, adopted or deleted
+	// and when the synchronization fails. This requires an additional permission claim
+	// for Events.
+	EnableRemoteEvents bool `json:"enableRemoteEvents,omitempty"`
+
 	// Projection is used to change the GVK of a published resource within kcp.
 	// This can be used to hide implementation details and provide a customized API
 	// experience to the user.
@@ -83,14 +110,393 @@ type PublishedResourceSpec struct {
 	// resource namespaced or vice-versa.
 	Projection *ResourceProjection `json:"projection,omitempty"`
 
+	// ProjectionChangePolicy controls what happens to objects in kcp that still use
+	// the previous GVK after the projection of this PublishedResource has been changed.
+	// Defaults to "Leave".
+	// +kubebuilder:validation:Enum=Leave;Warn;Migrate
+	ProjectionChangePolicy ProjectionChangePolicy `json:"projectionChangePolicy,omitempty"`
+
+	// SchemaProjection can be used to hide, protect or default individual fields in the
+	// schema that is published in kcp, while the CRD on the service cluster remains unchanged.
+	SchemaProjection *SchemaProjection `json:"schemaProjection,omitempty"`
+
 	// Mutation allows to configure "rewrite rules" to modify the objects in both
 	// directions during the synchronization.
 	Mutation *ResourceMutationSpec `json:"mutation,omitempty"`
 
+	// StatusProjection can be used to only copy selected fields of the status from
+	// the service cluster back into kcp. If not set, the entire status is copied.
+	StatusProjection *StatusProjection `json:"statusProjection,omitempty"`
+
+	// ImmutableFields is a list of dot-separated paths (e.g. "spec.region") to fields
+	// that must not change in kcp once an object has been synced. Changes to these
+	// fields are not propagated to the service cluster, but reverted instead.
+	// Fields listed here should not be modified by spec mutations.
+	ImmutableFields []string `json:"immutableFields,omitempty"`
+
+	// BidirectionalFields is a list of dot-separated paths (e.g. "spec.port") to fields
+	// that may be changed on the service cluster, for example by an operator allocating
+	// resources. Such changes are copied back into kcp instead of being reverted by the
+	// next sync. Changes made in kcp still take precedence. Fields listed here should
+	// not be modified by spec mutations.
+	BidirectionalFields []string `json:"bidirectionalFields,omitempty"`
+
+	// NamespaceLabels configures which labels of the namespace in kcp that an object
+	// resides in are copied onto the local object. Changes to the namespace's labels
+	// are propagated as well. This only has an effect for namespaced resources.
+	NamespaceLabels []NamespaceLabelMapping `json:"namespaceLabels,omitempty"`
+
+	// NamespaceSync configures which labels and annotations of the namespace in kcp
+	// that an object resides in are copied onto the namespace on the service cluster
+	// that the local object is placed in. Changes are propagated as well.
+	NamespaceSync *NamespaceSync `json:"namespaceSync,omitempty"`
+
+	// MetadataSync restricts which labels and annotations are copied between kcp and
+	// the service cluster. Sync-related metadata is never copied, regardless of this
+	// policy. If not set, all other labels and annotations are copied.
+	MetadataSync *MetadataSyncPolicy `json:"metadataSync,omitempty"`
+
+	// Provenance configures annotations on local objects that record where and when
+	// their counterparts in kcp were created, e.g. for auditing purposes. Provenance
+	// annotations are only placed on primary objects originating in kcp.
+	Provenance *ProvenancePolicy `json:"provenance,omitempty"`
+
+	// StateNamespace overrides the namespace on the service cluster in which the last
+	// known states of the synchronized objects are stored (the agent's --state-namespace
+	// by default). The namespace is created if it does not exist. Changing this field
+	// does not migrate existing states.
+	StateNamespace string `json:"stateNamespace,omitempty"`
+
+	// Readiness configures how the Sync Agent determines whether a local object is
+	// ready. The readiness is exposed as metrics and can optionally be reflected
+	// as a condition on the object in kcp.
+	Readiness *ResourceReadiness `json:"readiness,omitempty"`
+
+	// InitialSync can be used to throttle the first synchronization of workspaces
+	// with many pre-existing objects. If not set, all objects of a workspace are
+	// processed as soon as the Sync Agent sees them.
+	InitialSync *InitialSyncSettings `json:"initialSync,omitempty"`
+
+	// Sync can be used to tune how many objects are synchronized in parallel and how
+	// fast the Sync Agent sends requests to kcp for this resource, so that resources with
+	// many objects do not starve others. It also configures how objects are updated.
+	Sync *SyncSettings `json:"sync,omitempty"`
+
+	// Limits restrict how many objects are synchronized, so that a single consumer
+	// cannot exhaust the resources of the service cluster. Objects that would exceed
+	// a limit are not synchronized until other objects have been deleted.
+	Limits *ObjectLimits `json:"limits,omitempty"`
+
+	// Orphans enables periodically checking for local objects whose object in kcp has
+	// disappeared without the Sync Agent noticing, e.g. because it was force-deleted
+	// while the agent was not running. If not set, orphans are not detected.
+	Orphans *OrphanSettings `json:"orphans,omitempty"`
+
+	// Related configures additional objects that are synchronized alongside the
+	// primary object. Each related resource needs a unique identifier.
+	// +listType=map
+	// +listMapKey=identifier
 	Related []RelatedResourceSpec `json:"related,omitempty"`
+
+	// RelatedObjectReferences configures how objects in kcp reference the related
+	// objects that were synchronized into kcp from the service cluster. Defaults to
+	// "Both".
+	RelatedObjectReferences RelatedObjectReferencesMode `json:"relatedObjectReferences,omitempty"`
+
+	// SchemaUpdatePolicy controls what happens when the schema of the local CRD changes
+	// after an APIResourceSchema has been created for it. With "Manual" (the default),
+	// the first schema is kept until the PublishedResource is changed in a way that
+	// leads to a new APIResourceSchema name, and the drift is only reported in the
+	// SchemaUpToDate condition. With "Recreate", a new APIResourceSchema is created
+	// whenever the schema changes and replaces the previous one in the APIExport.
+	SchemaUpdatePolicy SchemaUpdatePolicy `json:"schemaUpdatePolicy,omitempty"`
+
+	// DeletionPolicy controls what happens to the object on the service cluster when
+	// its object in kcp is deleted. With "Delete" (the default), the local object is
+	// deleted as well. With "Retain", the local object is detached from kcp and kept.
+	// "Snapshot" works like "Retain", but additionally stores the final state of the
+	// object in kcp (including its status) as the object's state. Only supported for
+	// objects originating in kcp.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Profile is the name of an optional PublishedResourceProfile. Settings from the
+	// profile are used as defaults and can be overridden by configuring the same
+	// fields on this PublishedResource.
+	Profile string `json:"profile,omitempty"`
+
+	// Unpublish can be set to stop synchronizing this resource without deleting the
+	// PublishedResource. The progress is reported in the status. Deleting the
+	// PublishedResource implies unpublishing it.
+	Unpublish bool `json:"unpublish,omitempty"`
+}
+
+// PublishedResourceOrigin describes on which side the primary objects of a
+// PublishedResource are created.
+type PublishedResourceOrigin string
+
+const (
+	// PublishedResourceOriginKcp means objects are created in kcp workspaces and are
+	// synced down to the service cluster.
+	PublishedResourceOriginKcp PublishedResourceOrigin = "kcp"
+	// PublishedResourceOriginService means objects are created on the service cluster
+	// and are published into kcp workspaces.
+	PublishedResourceOriginService PublishedResourceOrigin = "service"
+)
+
+// RelatedObjectReferencesMode describes how related objects are referenced on the
+// primary object in kcp.
+// +kubebuilder:validation:Enum=Structured;Legacy;Both;None
+type RelatedObjectReferencesMode string
+
+const (
+	// RelatedObjectReferencesStructured maintains a JSON list of all related objects in
+	// the RelatedObjectsAnnotation.
+	RelatedObjectReferencesStructured RelatedObjectReferencesMode = "Structured"
+	// RelatedObjectReferencesLegacy maintains one annotation per related object, named
+	// "related-resources.syncagent.kcp.io/<identifier>.<index>".
+	//
+	// Deprecated: Use RelatedObjectReferencesStructured instead.
+	RelatedObjectReferencesLegacy RelatedObjectReferencesMode = "Legacy"
+	// RelatedObjectReferencesBoth maintains both the structured and legacy annotations.
+	RelatedObjectReferencesBoth RelatedObjectReferencesMode = "Both"
+	// RelatedObjectReferencesNone disables references to related objects.
+	RelatedObjectReferencesNone RelatedObjectReferencesMode = "None"
+)
+
+// SchemaUpdatePolicy describes how changes to the schema of a local CRD are
+// published in kcp.
+// +kubebuilder:validation:Enum=Manual;Recreate
+type SchemaUpdatePolicy string
+
+const (
+	// SchemaUpdateManual keeps the first APIResourceSchema that was created for a
+	// CRD and only reports later changes to its schema.
+	SchemaUpdateManual SchemaUpdatePolicy = "Manual"
+	// SchemaUpdateRecreate creates a new APIResourceSchema whenever the schema of
+	// the CRD changes and swaps it into the APIExport.
+	SchemaUpdateRecreate SchemaUpdatePolicy = "Recreate"
+)
+
+// DeletionPolicy describes what happens to local objects when their objects in kcp
+// are deleted.
+// +kubebuilder:validation:Enum=Delete;Retain;Snapshot
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the local object (and its related objects).
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain detaches the local object from kcp and keeps it.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicySnapshot retains the local object and stores the final state
+	// of the object in kcp in the state store.
+	DeletionPolicySnapshot DeletionPolicy = "Snapshot"
+)
+
+// StatusProjection restricts which status fields are synced back into kcp.
+type StatusProjection struct {
+	// Fields is a list of dot-separated paths relative to the status (e.g. "phase"
+	// or "endpoint.host") that are copied back into kcp. All other status fields are
+	// omitted. Status mutations are applied before the fields are selected.
+	// +kubebuilder:validation:MinItems=1
+	Fields []string `json:"fields"`
+}
+
+// NamespaceLabelMapping describes how a label from a namespace in kcp is copied onto
+// local objects.
+type NamespaceLabelMapping struct {
+	// Label is the name of the label on the namespace in kcp.
+	Label string `json:"label"`
+	// TargetLabel is the name of the label on the local object. If left empty, the
+	// same name as the source label is used.
+	TargetLabel string `json:"targetLabel,omitempty"`
+}
+
+// NamespaceSync selects the metadata of namespaces in kcp that is copied onto the
+// corresponding namespaces on the service cluster.
+type NamespaceSync struct {
+	// Labels is the list of label names to copy.
+	Labels []string `json:"labels,omitempty"`
+	// Annotations is the list of annotation names to copy.
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// MetadataSyncPolicy configures the synchronization of labels and annotations.
+type MetadataSyncPolicy struct {
+	// Labels filters the labels of synchronized objects.
+	Labels *MetadataDirectionFilters `json:"labels,omitempty"`
+	// Annotations filters the annotations of synchronized objects.
+	Annotations *MetadataDirectionFilters `json:"annotations,omitempty"`
+}
+
+// ProvenancePolicy selects the provenance annotations placed on local objects. See
+// the ProvenanceAnnotationPrefix for the names of the annotations.
+type ProvenancePolicy struct {
+	// WorkspacePath records the path of the kcp workspace the object originates from.
+	// This requires enableWorkspacePaths to be enabled.
+	WorkspacePath bool `json:"workspacePath,omitempty"`
+	// CreationTimestamp records when the object was created in kcp.
+	CreationTimestamp bool `json:"creationTimestamp,omitempty"`
+	// UserAnnotation is the name of an annotation on the object in kcp that contains
+	// the identity of the user who created it, for example set by an admission
+	// webhook. Its value is copied into the provenance annotation; objects without
+	// this annotation do not get a user annotation.
+	UserAnnotation string `json:"userAnnotation,omitempty"`
+	// LastSyncTimestamp records when the Sync Agent last changed the local object.
+	// Reconciliations that do not change the local object do not update the timestamp.
+	LastSyncTimestamp bool `json:"lastSyncTimestamp,omitempty"`
+}
+
+// MetadataDirectionFilters configures filters for each direction of the synchronization.
+type MetadataDirectionFilters struct {
+	// Down filters the keys copied from kcp to the service cluster.
+	Down *MetadataKeyFilter `json:"down,omitempty"`
+	// Up filters the keys copied from the service cluster into kcp.
+	Up *MetadataKeyFilter `json:"up,omitempty"`
+}
+
+// MetadataKeyFilter selects label or annotation keys using glob patterns like
+// "internal.corp/*" (see Go's path.Match for the syntax).
+type MetadataKeyFilter struct {
+	// Allow restricts the synchronization to keys matching any of these patterns.
+	// If empty, all keys are allowed.
+	Allow []string `json:"allow,omitempty"`
+	// Deny prevents keys matching any of these patterns from being synchronized.
+	// Deny takes precedence over Allow.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// ResourceReadiness describes how to determine whether a local object is ready.
+// Either a condition or a path can be configured.
+// +kubebuilder:validation:XValidation:rule="has(self.condition) != has(self.path)",message="exactly one of condition or path must be set"
+type ResourceReadiness struct {
+	// Condition is the type of a condition in the object's status.conditions that
+	// must have the status "True" for the object to be considered ready.
+	Condition string `json:"condition,omitempty"`
+
+	// Path is a path in gjson syntax (e.g. "status.phase") to a field whose value
+	// must equal Value for the object to be considered ready.
+	Path string `json:"path,omitempty"`
+
+	// Value is the expected value of the field at Path.
+	Value string `json:"value,omitempty"`
+
+	// RemoteCondition is the type of a condition that the Sync Agent will set on
+	// the object in kcp to reflect the readiness. This requires the published
+	// resource to have a status subresource. If empty, no condition is set.
+	RemoteCondition string `json:"remoteCondition,omitempty"`
+}
+
+// InitialSyncSettings configure how pre-existing objects in a workspace are processed
+// when the workspace is synchronized for the first time.
+type InitialSyncSettings struct {
+	// ChunkSize is the number of objects that are listed and processed at once.
+	// +kubebuilder:validation:Minimum=1
+	ChunkSize int64 `json:"chunkSize"`
+
+	// Interval is the time to wait between two chunks. Defaults to 1s.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// SyncSettings configure the sync controller of a single PublishedResource.
+type SyncSettings struct {
+	// MaxConcurrentReconciles is the number of objects that are synchronized in
+	// parallel. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+
+	// MaxConcurrentRelatedObjects is the number of related objects of a single primary
+	// object that are synchronized in parallel. Higher values reduce the time it takes
+	// to synchronize primary objects with many related objects. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentRelatedObjects int `json:"maxConcurrentRelatedObjects,omitempty"`
+
+	// RequeueBackoff configures how quickly objects whose synchronization failed are
+	// retried.
+	RequeueBackoff *RequeueBackoff `json:"requeueBackoff,omitempty"`
+
+	// QPS is the maximum number of write requests per second that the Sync Agent sends
+	// to kcp for this resource. Reads are served from a cache and are not limited. If
+	// not set, requests are not limited beyond the agent's global limits.
+	// +kubebuilder:validation:Minimum=1
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum number of write requests that can be sent at once, on top of
+	// the QPS. Defaults to the QPS.
+	// +kubebuilder:validation:Minimum=1
+	Burst int32 `json:"burst,omitempty"`
+
+	// Strategy configures how existing objects are updated. Defaults to "MergePatch".
+	Strategy SyncStrategy `json:"strategy,omitempty"`
+
+	// ResyncPeriod is the interval in which all objects in kcp are enqueued again,
+	// so that every object converges to the current configuration within a bounded
+	// time, even if events were missed. Regardless of this setting, all objects are
+	// enqueued whenever the PublishedResource changes. If not set, objects are not
+	// periodically resynced.
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+}
+
+// SyncStrategy describes how the Sync Agent updates existing objects.
+// +kubebuilder:validation:Enum=MergePatch;ServerSideApply
+type SyncStrategy string
+
+const (
+	// SyncStrategyMergePatch computes JSON merge patches based on the last known
+	// state of each object, which is kept in the state store.
+	SyncStrategyMergePatch SyncStrategy = "MergePatch"
+	// SyncStrategyServerSideApply uses server-side apply with the field manager
+	// "api-syncagent/<agent name>". Fields owned by other managers are tracked by
+	// the API server and the state store is only used for immutable fields.
+	SyncStrategyServerSideApply SyncStrategy = "ServerSideApply"
+)
+
+// OrphanSettings configure how local objects without an object in kcp are handled.
+type OrphanSettings struct {
+	// Policy decides what happens to orphaned local objects. Defaults to "Report".
+	Policy OrphanPolicy `json:"policy,omitempty"`
+
+	// Interval is how often the Sync Agent checks for orphaned objects. The first
+	// check happens when the sync controller starts. Defaults to 1h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// OrphanPolicy describes how orphaned local objects are handled.
+// +kubebuilder:validation:Enum=Report;Delete
+type OrphanPolicy string
+
+const (
+	// OrphanPolicyReport only records Events and metrics for orphaned objects.
+	OrphanPolicyReport OrphanPolicy = "Report"
+	// OrphanPolicyDelete deletes orphaned objects on the service cluster.
+	OrphanPolicyDelete OrphanPolicy = "Delete"
+)
+
+// ObjectLimits configure the maximum number of synchronized objects.
+type ObjectLimits struct {
+	// MaxObjectsPerWorkspace is the maximum number of objects that are synchronized
+	// from a single kcp workspace.
+	// +kubebuilder:validation:Minimum=1
+	MaxObjectsPerWorkspace int64 `json:"maxObjectsPerWorkspace,omitempty"`
+
+	// MaxObjects is the maximum number of objects that are synchronized from all
+	// workspaces combined.
+	// +kubebuilder:validation:Minimum=1
+	MaxObjects int64 `json:"maxObjects,omitempty"`
+}
+
+// RequeueBackoff configures the exponential backoff for retrying failed synchronizations.
+type RequeueBackoff struct {
+	// Initial is the delay after the first failure; the delay doubles with every
+	// further failure. Defaults to 5ms.
+	Initial *metav1.Duration `json:"initial,omitempty"`
+
+	// Max is the maximum delay between two attempts. Defaults to 1000s.
+	Max *metav1.Duration `json:"max,omitempty"`
 }
 
 // ResourceNaming describes how the names for local objects should be formed.
+// +kubebuilder:validation:XValidation:rule="!(has(self.name) && has(self.nameTemplate))",message="name and nameTemplate are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!(has(self.__namespace__) && has(self.namespaceTemplate))",message="namespace and namespaceTemplate are mutually exclusive"
 type ResourceNaming struct {
 	// The name field allows to control the name the local objects created by the Sync Agent.
 	// If left empty, "$remoteNamespaceHash-$remoteNameHash" is assumed. This guarantees unique
@@ -98,14 +504,18 @@ type ResourceNaming struct {
 	// (the default unless configured otherwise).
 	// This is a string with placeholders. The following placeholders can be used:
 	//
-	//   - $remoteClusterName   -- the kcp workspace's cluster name (e.g. "1084s8ceexsehjm2")
-	//   - $remoteNamespace     -- the original namespace used by the consumer inside the kcp
-	//                             workspace (if targetNamespace is left empty, it's equivalent
-	//                             to setting "$remote_ns")
-	//   - $remoteNamespaceHash -- first 20 hex characters of the SHA-1 hash of $remoteNamespace
-	//   - $remoteName          -- the original name of the object inside the kcp workspace
-	//                             (rarely used to construct local namespace names)
-	//   - $remoteNameHash      -- first 20 hex characters of the SHA-1 hash of $remoteName
+	//   - $remoteClusterName       -- the kcp workspace's cluster name (e.g. "1084s8ceexsehjm2")
+	//   - $remoteWorkspacePath     -- the kcp workspace's path with colons replaced by dashes
+	//                                 (e.g. "root-customers-acme"); requires enableWorkspacePaths
+	//   - $remoteWorkspacePathHash -- first 20 hex characters of the SHA-1 hash of the workspace
+	//                                 path; requires enableWorkspacePaths
+	//   - $remoteNamespace         -- the original namespace used by the consumer inside the kcp
+	//                                 workspace (if targetNamespace is left empty, it's equivalent
+	//                                 to setting "$remote_ns")
+	//   - $remoteNamespaceHash     -- first 20 hex characters of the SHA-1 hash of $remoteNamespace
+	//   - $remoteName              -- the original name of the object inside the kcp workspace
+	//                                 (rarely used to construct local namespace names)
+	//   - $remoteNameHash          -- first 20 hex characters of the SHA-1 hash of $remoteName
 	//
 	Name string `json:"name,omitempty"`
 
@@ -113,32 +523,123 @@ type ResourceNaming struct {
 	// be created. If left empty, "$remoteClusterName" is assumed.
 	// This is a string with placeholders. The following placeholders can be used:
 	//
-	//   - $remoteClusterName   -- the kcp workspace's cluster name (e.g. "1084s8ceexsehjm2")
-	//   - $remoteNamespace     -- the original namespace used by the consumer inside the kcp
-	//                             workspace (if targetNamespace is left empty, it's equivalent
-	//                             to setting "$remote_ns")
-	//   - $remoteNamespaceHash -- first 20 hex characters of the SHA-1 hash of $remoteNamespace
-	//   - $remoteName          -- the original name of the object inside the kcp workspace
-	//                             (rarely used to construct local namespace names)
-	//   - $remoteNameHash      -- first 20 hex characters of the SHA-1 hash of $remoteName
+	//   - $remoteClusterName       -- the kcp workspace's cluster name (e.g. "1084s8ceexsehjm2")
+	//   - $remoteWorkspacePath     -- the kcp workspace's path with colons replaced by dashes
+	//                                 (e.g. "root-customers-acme"); requires enableWorkspacePaths
+	//   - $remoteWorkspacePathHash -- first 20 hex characters of the SHA-1 hash of the workspace
+	//                                 path; requires enableWorkspacePaths
+	//   - $remoteNamespace         -- the original namespace used by the consumer inside the kcp
+	//                                 workspace (if targetNamespace is left empty, it's equivalent
+	//                                 to setting "$remote_ns")
+	//   - $remoteNamespaceHash     -- first 20 hex characters of the SHA-1 hash of $remoteNamespace
+	//   - $remoteName              -- the original name of the object inside the kcp workspace
+	//                                 (rarely used to construct local namespace names)
+	//   - $remoteNameHash          -- first 20 hex characters of the SHA-1 hash of $remoteName
 	//
 	Namespace string `json:"namespace,omitempty"`
+
+	// NameTemplate is a Go template that is used instead of Name to form the names of
+	// local objects. The template has access to .ClusterName, .WorkspacePath (requires
+	// enableWorkspacePaths), .RemoteNamespace, .RemoteName, .Labels and .Annotations of
+	// the object in kcp, the Sprig functions and the functions sha1, sha256 and hash
+	// (using the settings in Hash), e.g. `{{ .Labels.team }}-{{ .RemoteName | sha1 | trunc 8 }}`.
+	// Accessing a missing label or annotation is an error, use `{{ index .Labels "team" }}`
+	// for optional ones.
+	NameTemplate string `json:"nameTemplate,omitempty"`
+
+	// NamespaceTemplate is a Go template that is used instead of Namespace to form the
+	// namespaces of local objects. It has access to the same data as NameTemplate.
+	NamespaceTemplate string `json:"namespaceTemplate,omitempty"`
+
+	// Hash configures how the hash placeholders ($remoteNameHash, $remoteNamespaceHash and
+	// $remoteWorkspacePathHash) are computed. If not set, the first 20 hex characters of the
+	// SHA-1 hash are used. Changing this only affects local objects created afterwards.
+	Hash *NamingHash `json:"hash,omitempty"`
 }
 
+// NamingHash configures the hashes used in local object names.
+type NamingHash struct {
+	// Algorithm is the hash algorithm to use. Defaults to "sha1".
+	Algorithm NamingHashAlgorithm `json:"algorithm,omitempty"`
+
+	// Encoding is how the hash sum is turned into a string. Base36 produces shorter
+	// strings for the same amount of entropy. Defaults to "hex".
+	Encoding NamingHashEncoding `json:"encoding,omitempty"`
+
+	// Length is the number of characters of the encoded hash to use. It must not
+	// exceed the length of the full encoded hash (40 for SHA-1 and 64 for SHA-256
+	// in hex, 31 and 50 in base36). Defaults to 20.
+	// +kubebuilder:validation:Minimum=8
+	Length int `json:"length,omitempty"`
+}
+
+// NamingHashAlgorithm is a hash algorithm for local object names.
+// +kubebuilder:validation:Enum=sha1;sha256
+type NamingHashAlgorithm string
+
+const (
+	NamingHashSHA1   NamingHashAlgorithm = "sha1"
+	NamingHashSHA256 NamingHashAlgorithm = "sha256"
+)
+
+// NamingHashEncoding is the encoding of hashes in local object names.
+// +kubebuilder:validation:Enum=hex;base36
+type NamingHashEncoding string
+
+const (
+	NamingHashHex    NamingHashEncoding = "hex"
+	NamingHashBase36 NamingHashEncoding = "base36"
+)
+
 // ResourceMutationSpec allows to configure "rewrite rules" to modify the objects in both
 // directions during the synchronization.
 type ResourceMutationSpec struct {
 	Spec   []ResourceMutation `json:"spec,omitempty"`
 	Status []ResourceMutation `json:"status,omitempty"`
+
+	// IgnoreFields is a list of paths (e.g. "spec.clusterIP", using the same syntax
+	// as the mutation rules) to fields that are never overwritten on the destination
+	// object, in either direction. Unlike delete mutations, the destination keeps its
+	// own values for these fields. They are only copied when the destination object
+	// is first created.
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
 }
 
+// ResourceMutation is a single rewrite rule. Exactly one of the options must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.delete), has(self.regex), has(self.template), has(self.patch), has(self.merge)].filter(x, x).size() == 1",message="exactly one of delete, regex, template, patch or merge must be set"
 type ResourceMutation struct {
 	// Must use exactly one of these options, never more, never fewer.
-	// TODO: Add validation code for this somewhere.
 
 	Delete   *ResourceDeleteMutation   `json:"delete,omitempty"`
 	Regex    *ResourceRegexMutation    `json:"regex,omitempty"`
 	Template *ResourceTemplateMutation `json:"template,omitempty"`
+
+	// Patch is a JSON Patch (RFC 6902) that is applied to the entire object, so
+	// paths start at the object's root (e.g. "/spec/replicas").
+	Patch []ResourcePatchOperation `json:"patch,omitempty"`
+
+	// Merge is a JSON merge patch (RFC 7386) that is merged into the entire object.
+	// This can be used to inject or overwrite whole sub-structures at once; null
+	// values remove fields.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Merge *runtime.RawExtension `json:"merge,omitempty"`
+
+	// Condition can be used to only apply this mutation if the source object
+	// matches. If not set, the mutation is always applied.
+	Condition *ResourceMutationCondition `json:"condition,omitempty"`
+}
+
+// ResourceMutationCondition is a predicate on the source object (i.e. the object
+// before any mutation was applied to it).
+type ResourceMutationCondition struct {
+	// Path is a path in gjson syntax (e.g. "spec.tier") to a field in the source
+	// object. If the field does not exist, the condition does not match.
+	Path string `json:"path"`
+
+	// Value is the value that the field at Path must have for the condition to
+	// match. If empty, the condition matches as long as the field exists.
+	Value string `json:"value,omitempty"`
 }
 
 type ResourceDeleteMutation struct {
@@ -158,6 +659,24 @@ type ResourceTemplateMutation struct {
 	Template string `json:"template"`
 }
 
+// ResourcePatchOperation is a single JSON Patch operation.
+type ResourcePatchOperation struct {
+	// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+	Op string `json:"op"`
+
+	// Path is a JSON Pointer (e.g. "/spec/template/metadata/labels/app").
+	Path string `json:"path"`
+
+	// From is the source location for "move" and "copy" operations.
+	From string `json:"from,omitempty"`
+
+	// Value is used by "add", "replace" and "test" operations and can be any
+	// JSON value.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value *runtime.RawExtension `json:"value,omitempty"`
+}
+
 type RelatedResourceSpec struct {
 	// Identifier is a unique name for this related resource. The name must be unique within one
 	// PublishedResource and is the key by which consumers (end users) can identify and consume the
@@ -165,10 +684,27 @@ type RelatedResourceSpec struct {
 	// The identifier must be an alphanumeric string.
 	Identifier string `json:"identifier"`
 
-	// "service" or "kcp"
+	// "service", "kcp" or "both". Related objects with origin "both" can be created on
+	// either side and are synchronized in whichever direction is necessary; if an
+	// object exists on both sides, the ConflictPolicy decides which side wins.
+	// +kubebuilder:validation:Enum=service;kcp;both
 	Origin string `json:"origin"`
 
-	// ConfigMap or Secret
+	// ConflictPolicy decides which side's copy of a related object is used when the
+	// object exists on both sides. This can only be configured for related resources
+	// with origin "both" and defaults to "kcpWins".
+	// +kubebuilder:validation:Enum=kcpWins;serviceWins;newestWins
+	ConflictPolicy RelatedResourceConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// APIGroup is the API group of the related resource, for example "cert-manager.io".
+	// If not specified, the core API group is assumed.
+	APIGroup string `json:"apiGroup,omitempty"`
+
+	// Version is the API version of the related resource, for example "v1beta1".
+	// If not specified, "v1" is assumed.
+	Version string `json:"version,omitempty"`
+
+	// Kind is the resource Kind of the related resource, for example "Secret" or "Certificate".
 	Kind string `json:"kind"`
 
 	// Object describes how the related resource can be found on the origin side
@@ -178,8 +714,95 @@ type RelatedResourceSpec struct {
 	// Mutation configures optional transformation rules for the related resource.
 	// Status mutations are only performed when the related resource originates in kcp.
 	Mutation *ResourceMutationSpec `json:"mutation,omitempty"`
+
+	// Transform reshapes the data of related ConfigMaps and Secrets, so that consumers
+	// receive them in the form their applications expect. It is applied after the
+	// mutation rules and can only be configured for ConfigMaps and Secrets.
+	Transform *RelatedResourceDataTransformation `json:"transform,omitempty"`
+
+	// DeletionPolicy controls what happens to the related objects on the destination
+	// side when the primary object is deleted. Defaults to "Orphan".
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy RelatedResourceDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// SetOwnerReference makes the Sync Agent add an owner reference to each related object on
+	// the destination side, pointing to the primary object on the same side. This enables
+	// Kubernetes' garbage collection to delete related objects together with their primary
+	// object, regardless of the deletion policy. Owner references can only be set if the
+	// primary object is cluster-scoped or lives in the same namespace as the related object.
+	SetOwnerReference bool `json:"setOwnerReference,omitempty"`
+
+	// Related configures nested related resources, i.e. objects that are related to
+	// the objects of this related resource rather than to the primary object (e.g. the
+	// Secret of a Certificate that is itself related to the primary object). Nested
+	// related resources are resolved using the related objects on both sides in place
+	// of the primary objects. Identifiers must be unique across all levels.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Related []RelatedResourceSpec `json:"related,omitempty"`
+}
+
+// RelatedResourceDataTransformation describes how the keys in the data of a related
+// ConfigMap or Secret are rewritten. All templates are evaluated against the original
+// data, after which keys are renamed, dropped and finally the composed keys are set.
+// Secret values are decoded before being made available to templates and encoded
+// again afterwards.
+type RelatedResourceDataTransformation struct {
+	// Rename moves the value of a key to a new key (e.g. "password" to "DB_PASSWORD").
+	// Renaming a key that does not exist is not an error.
+	Rename []RelatedResourceKeyRename `json:"rename,omitempty"`
+
+	// Drop is a list of keys that are removed from the data.
+	Drop []string `json:"drop,omitempty"`
+
+	// Compose creates new keys whose values are rendered from Go templates. Existing
+	// keys with the same name are overwritten.
+	Compose []RelatedResourceComposedKey `json:"compose,omitempty"`
+}
+
+// RelatedResourceKeyRename renames a single key.
+type RelatedResourceKeyRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RelatedResourceComposedKey describes a key whose value is rendered from a template.
+// The template has access to `.Data` (the original data with decoded values),
+// `.Object` (the entire original object), `.ClusterName` and `.ClusterPath` (only
+// available if workspace paths are enabled for the PublishedResource).
+type RelatedResourceComposedKey struct {
+	Key      string `json:"key"`
+	Template string `json:"template"`
 }
 
+// RelatedResourceDeletionPolicy describes how related objects are handled when
+// their primary object is deleted.
+type RelatedResourceDeletionPolicy string
+
+const (
+	// RelatedResourceDeletionPolicyOrphan leaves related objects untouched.
+	RelatedResourceDeletionPolicyOrphan RelatedResourceDeletionPolicy = "Orphan"
+	// RelatedResourceDeletionPolicyDelete deletes the related objects on the destination
+	// side before the primary object is cleaned up on the service cluster.
+	RelatedResourceDeletionPolicyDelete RelatedResourceDeletionPolicy = "Delete"
+)
+
+// RelatedResourceConflictPolicy describes how related objects with origin "both"
+// are synchronized when they exist on both sides.
+type RelatedResourceConflictPolicy string
+
+const (
+	// RelatedResourceConflictPolicyKcpWins always synchronizes the object from kcp
+	// to the service cluster.
+	RelatedResourceConflictPolicyKcpWins RelatedResourceConflictPolicy = "kcpWins"
+	// RelatedResourceConflictPolicyServiceWins always synchronizes the object from
+	// the service cluster into kcp.
+	RelatedResourceConflictPolicyServiceWins RelatedResourceConflictPolicy = "serviceWins"
+	// RelatedResourceConflictPolicyNewestWins synchronizes the object from the side
+	// where it was most recently modified.
+	RelatedResourceConflictPolicyNewestWins RelatedResourceConflictPolicy = "newestWins"
+)
+
 // RelatedResourceSource configures how the related resource can be found on the origin side
 // and where it is to supposed to be created on the destination side.
 type RelatedResourceObject struct {
@@ -194,6 +817,7 @@ type RelatedResourceObject struct {
 
 // RelatedResourceObjectSpec configures different ways an object can be located.
 // All fields are mutually exclusive.
+// +kubebuilder:validation:XValidation:rule="[has(self.selector), has(self.reference), has(self.template)].filter(x, x).size() == 1",message="exactly one of selector, reference or template must be set"
 type RelatedResourceObjectSpec struct {
 	// Selector is a label selector that is useful if no reference is in the
 	// main resource (i.e. if the related object links back to its parent, instead
@@ -212,7 +836,9 @@ type RelatedResourceObjectSpec struct {
 type RelatedResourceObjectReference struct {
 	// Path is a simplified JSONPath expression like "metadata.name". A reference
 	// must always select at least _something_ in the object, even if the value
-	// is discarded by the regular expression.
+	// is discarded by the regular expression. Paths selecting lists, like
+	// "status.secretRefs[*].name", yield one value per element; the values from
+	// the origin and destination objects are paired up by their index.
 	Path string `json:"path"`
 	// Regex is a Go regular expression that is optionally applied to the selected
 	// value from the path.
@@ -231,6 +857,7 @@ type RelatedResourceObjectSelector struct {
 	Rewrite RelatedResourceSelectorRewrite `json:"rewrite"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.regex) != has(self.template)",message="exactly one of regex or template must be set"
 type RelatedResourceSelectorRewrite struct {
 	// Regex is a Go regular expression that is optionally applied to the selected
 	// value from the path.
@@ -263,10 +890,30 @@ type TemplateExpression struct {
 type SourceResourceDescriptor struct {
 	// The API group of a resource, for example "storage.initroid.com".
 	APIGroup string `json:"apiGroup"`
-	// The API version, for example "v1beta1".
+	// The API version, for example "v1beta1". This version is used for synchronizing
+	// objects and is the storage version in kcp.
 	Version string `json:"version"`
 	// The resource Kind, for example "Database".
 	Kind string `json:"kind"`
+	// Versions optionally lists additional API versions of the resource that should
+	// be published as well. Objects are always synchronized using the primary version,
+	// kcp only changes the apiVersion when converting between versions, so all versions
+	// must be compatible with each other.
+	Versions []SourceResourceVersion `json:"versions,omitempty"`
+}
+
+// SourceResourceVersion describes an additional API version of a published resource.
+type SourceResourceVersion struct {
+	// Name is the API version on the service cluster, for example "v1alpha1".
+	Name string `json:"name"`
+	// Projection optionally overrides how this version is named in kcp.
+	Projection *ResourceVersionProjection `json:"projection,omitempty"`
+}
+
+// ResourceVersionProjection describes how an additional version is projected into kcp.
+type ResourceVersionProjection struct {
+	// Version is the name of the version in kcp.
+	Version string `json:"version,omitempty"`
 }
 
 // ResourceScope is an enum defining the different scopes available to a custom resource.
@@ -291,9 +938,9 @@ type ResourceProjection struct {
 	Scope ResourceScope `json:"scope,omitempty"`
 	// The resource Kind, for example "Database". Setting this field will also overwrite
 	// the singular name by lowercasing the resource kind. In addition, if this is set,
-	// the plural name will also be updated by taking the lowercased kind name and appending
-	// an "s". If this would yield an undesirable name, use the plural field to explicitly
-	// give the plural name.
+	// the plural name will also be updated by pluralizing the lowercased kind name using
+	// the same rules as controller-gen (e.g. "policy" becomes "policies"). If this would
+	// yield an undesirable name, use the plural field to explicitly give the plural name.
 	Kind string `json:"kind,omitempty"`
 	// When overwriting the Kind, it can be necessary to also override the plural name in
 	// case of more complex pluralization rules.
@@ -309,17 +956,196 @@ type ResourceProjection struct {
 	Categories []string `json:"categories"` // not omitempty because we need to distinguish between [] and nil
 }
 
+// SchemaProjection describes how the schema of a resource is modified before it's
+// published in kcp. Paths are dot-separated (e.g. "spec.internalTuning"); fields
+// inside of lists are addressed like fields of objects.
+type SchemaProjection struct {
+	// Drop is a list of paths to fields that are removed from the published schema
+	// entirely. Consumers cannot see or set these fields, and the Sync Agent never
+	// changes their values on the service cluster.
+	Drop []string `json:"drop,omitempty"`
+	// ReadOnly is a list of paths to fields that remain in the published schema, but
+	// cannot be changed by consumers once they have been set. Status fields cannot be
+	// made read-only, as they are already managed by the Sync Agent.
+	ReadOnly []string `json:"readOnly,omitempty"`
+	// Defaults are injected into the published schema, so that kcp sets them on
+	// objects that consumers create. This complements mutations, which only affect
+	// the copies on the service cluster.
+	Defaults []SchemaDefault `json:"defaults,omitempty"`
+	// Annotations are added to the APIResourceSchema in kcp, e.g. to provide
+	// additional metadata for tools that work with the published APIs.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SchemaDefault describes a default value for a field in the published schema.
+type SchemaDefault struct {
+	// Path is the dot-separated path to the field (e.g. "spec.tier").
+	Path string `json:"path"`
+	// Value is the default value and must match the field's type.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value runtime.RawExtension `json:"value"`
+	// Constant additionally restricts the field to the default value, so that
+	// consumers cannot set any other value.
+	Constant bool `json:"constant,omitempty"`
+}
+
+// ProjectionChangePolicy describes how leftover objects in kcp are handled after
+// the projected GVK of a PublishedResource has changed.
+type ProjectionChangePolicy string
+
+const (
+	// ProjectionChangePolicyLeave leaves old objects untouched, they are only
+	// reported in the PublishedResource's status.
+	ProjectionChangePolicyLeave ProjectionChangePolicy = "Leave"
+	// ProjectionChangePolicyWarn is like Leave, but additionally emits warning
+	// events on the PublishedResource as long as old objects exist.
+	ProjectionChangePolicyWarn ProjectionChangePolicy = "Warn"
+	// ProjectionChangePolicyMigrate recreates old objects using the new GVK,
+	// based on their local copies, and deletes the old objects if possible.
+	ProjectionChangePolicyMigrate ProjectionChangePolicy = "Migrate"
+)
+
 // ResourceFilter can be used to limit what resources should be included in an operation.
 type ResourceFilter struct {
 	// When given, the namespace filter will be applied to a resource's namespace.
 	Namespace *metav1.LabelSelector `json:"namespace,omitempty"`
+	// When given, the namespace name filter will be applied to the name of a
+	// resource's namespace in kcp. Cluster-scoped resources are not affected.
+	NamespaceNames *NamespaceNameFilter `json:"namespaceNames,omitempty"`
 	// When given, the resource filter will be applied to a resource itself.
 	Resource *metav1.LabelSelector `json:"resource,omitempty"`
+	// Expression is a CEL expression that must evaluate to true for a resource to be
+	// synchronized. It has access to the resource in kcp via the `object` variable
+	// and to its namespace via `namespaceObject` (null for cluster-scoped resources).
+	Expression string `json:"expression,omitempty"`
+	// AnnotateExcluded makes the Sync Agent place an annotation on objects in kcp
+	// that are excluded by this filter, so that consumers can tell why an object is
+	// not being processed. The annotation is removed once the object matches again.
+	AnnotateExcluded bool `json:"annotateExcluded,omitempty"`
+}
+
+// NamespaceNameFilter selects namespaces by their names. Both lists can contain
+// exact names and glob patterns like "prod-*".
+type NamespaceNameFilter struct {
+	// Include is a list of namespace names or patterns. If given, a namespace must
+	// match at least one of them.
+	Include []string `json:"include,omitempty"`
+	// Exclude is a list of namespace names or patterns. Namespaces matching any of
+	// them are excluded, even if they are also included.
+	Exclude []string `json:"exclude,omitempty"`
 }
 
 // PublishedResourceStatus stores status information about a published resource.
 type PublishedResourceStatus struct {
 	ResourceSchemaName string `json:"resourceSchemaName,omitempty"`
+
+	// ProjectedGVK is the GVK under which the resource is currently published in kcp.
+	ProjectedGVK *GroupVersionKind `json:"projectedGVK,omitempty"`
+
+	// ProjectionLeftovers lists previous GVKs of this PublishedResource for which
+	// objects still exist in kcp workspaces.
+	ProjectionLeftovers []ProjectionLeftover `json:"projectionLeftovers,omitempty"`
+
+	// Unpublish is set while the resource is being unpublished and reports what
+	// still remains in kcp.
+	Unpublish *UnpublishStatus `json:"unpublish,omitempty"`
+
+	// Conditions contain the latest observations of the PublishedResource's state.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Statistics contains counters about the synchronization of objects. They are
+	// reset whenever the Sync Agent restarts.
+	Statistics *SyncStatistics `json:"statistics,omitempty"`
+}
+
+const (
+	// ConditionReady is true if the resource is fully published and objects are
+	// being synchronized, i.e. all other conditions are true.
+	ConditionReady = "Ready"
+
+	// ConditionSchemaCreated is true once the APIResourceSchema for the resource
+	// has been created in kcp.
+	ConditionSchemaCreated = "SchemaCreated"
+
+	// ConditionExportUpdated is true once the APIResourceSchema has been added to
+	// the APIExport.
+	ConditionExportUpdated = "ExportUpdated"
+
+	// ConditionSyncControllerRunning is true while a sync controller is running
+	// for the resource.
+	ConditionSyncControllerRunning = "SyncControllerRunning"
+
+	// ConditionRelatedResourcesResolved is false if the kinds of some related resources
+	// could not be resolved into resources in kcp, which prevents the Sync Agent
+	// from claiming permissions for them.
+	ConditionRelatedResourcesResolved = "RelatedResourcesResolved"
+
+	// ConditionProjectionReconciled is false if the objects in kcp that still use a
+	// previously projected GVK could not be handled according to the
+	// ProjectionChangePolicy.
+	ConditionProjectionReconciled = "ProjectionReconciled"
+
+	// ConditionSchemaUpToDate is false if the schema of the local CRD has drifted
+	// from the published APIResourceSchema and the SchemaUpdatePolicy does not
+	// allow to publish the changed schema.
+	ConditionSchemaUpToDate = "SchemaUpToDate"
+)
+
+// SyncStatistics contains counters about the synchronization of objects.
+type SyncStatistics struct {
+	// SyncedObjects is the number of successful object synchronizations.
+	SyncedObjects int64 `json:"syncedObjects"`
+
+	// Errors is the number of failed object synchronizations.
+	Errors int64 `json:"errors"`
+
+	// LastSyncTime is the time of the last successful object synchronization.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Latency summarizes how long it took for recent changes to objects in kcp to
+	// be fully synchronized.
+	Latency *SyncLatency `json:"latency,omitempty"`
+}
+
+// SyncLatency contains percentiles of the time between objects being changed in kcp
+// and the changes being fully synchronized.
+type SyncLatency struct {
+	// Samples is the number of recent synchronizations the percentiles are based on.
+	Samples int `json:"samples"`
+
+	P50 metav1.Duration `json:"p50"`
+	P95 metav1.Duration `json:"p95"`
+	P99 metav1.Duration `json:"p99"`
+}
+
+// UnpublishStatus describes the progress of unpublishing a resource.
+type UnpublishStatus struct {
+	// RemainingObjects is the number of objects in kcp workspaces that still use
+	// the published resource.
+	RemainingObjects int `json:"remainingObjects"`
+
+	// ResourceSchemaName is the name of the APIResourceSchema in kcp which is still
+	// part of the APIExport until the PublishedResource is gone.
+	ResourceSchemaName string `json:"resourceSchemaName,omitempty"`
+}
+
+// GroupVersionKind unambiguously identifies a kind.
+type GroupVersionKind struct {
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// ProjectionLeftover describes a previously used GVK and how many objects of
+// that GVK still exist in kcp.
+type ProjectionLeftover struct {
+	GroupVersionKind `json:",inline"`
+
+	// Objects is the number of remaining objects across all workspaces.
+	Objects int `json:"objects"`
 }
 
 // +kubebuilder:object:root=true
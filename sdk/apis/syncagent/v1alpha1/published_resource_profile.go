@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// PublishedResourceProfile is a reusable set of settings that PublishedResources
+// can reference via their spec.profile field. This allows to keep common conventions
+// (naming schemes, filters, mutations, related resources) in one place instead of
+// copying them into many PublishedResources.
+type PublishedResourceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PublishedResourceProfileSpec `json:"spec"`
+}
+
+// PublishedResourceProfileSpec contains the settings that are inherited by every
+// PublishedResource referencing the profile. Settings configured directly on a
+// PublishedResource always take precedence over those from the profile.
+type PublishedResourceProfileSpec struct {
+	// If specified, the filter will be applied to the resources in a workspace
+	// and allow restricting which of them will be handled by the Sync Agent.
+	// A filter on the PublishedResource replaces this filter entirely.
+	Filter *ResourceFilter `json:"filter,omitempty"`
+
+	// Naming can be used to control how the namespace and names for local objects
+	// are formed. A naming configuration on the PublishedResource replaces this one
+	// entirely.
+	Naming *ResourceNaming `json:"naming,omitempty"`
+
+	// EnableWorkspacePaths toggles whether the Sync Agent will not just store the kcp
+	// cluster name as a label on each locally synced object, but also the full workspace
+	// path. If enabled in either the profile or the PublishedResource, workspace
+	// paths are enabled.
+	EnableWorkspacePaths bool `json:"enableWorkspacePaths,omitempty"`
+
+	// Mutation allows to configure "rewrite rules" to modify the objects in both
+	// directions during the synchronization. Mutations on the PublishedResource
+	// replace these mutations entirely.
+	Mutation *ResourceMutationSpec `json:"mutation,omitempty"`
+
+	// Related resources are merged with the related resources of the PublishedResource,
+	// using their identifier as the key. If both define a related resource with the
+	// same identifier, the one on the PublishedResource is used.
+	Related []RelatedResourceSpec `json:"related,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PublishedResourceProfileList contains a list of PublishedResourceProfiles.
+type PublishedResourceProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PublishedResourceProfile `json:"items"`
+}
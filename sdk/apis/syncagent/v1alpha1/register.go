@@ -51,8 +51,18 @@ func Resource(resource string) schema.GroupResource {
 // Adds the list of known types to api.Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Announcement{},
+		&AnnouncementList{},
+		&ObjectState{},
+		&ObjectStateList{},
 		&PublishedResource{},
 		&PublishedResourceList{},
+		&PublishedResourceProfile{},
+		&PublishedResourceProfileList{},
+		&SyncAgentStatus{},
+		&SyncAgentStatusList{},
+		&WorkspaceMapping{},
+		&WorkspaceMappingList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
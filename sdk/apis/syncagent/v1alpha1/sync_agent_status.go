@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="APIExport",type="string",JSONPath=".status.apiExport"
+// +kubebuilder:printcolumn:name="Connection",type="string",JSONPath=".status.connectionState"
+// +kubebuilder:printcolumn:name="Controllers",type="integer",JSONPath=".status.syncControllers"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SyncAgentStatus is maintained by a Sync Agent to report on its connection to the
+// APIExport's virtual workspace. Each agent manages the object named after its agent
+// name; objects are never created by users.
+type SyncAgentStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Status contains the current state of the Sync Agent.
+	Status SyncAgentStatusStatus `json:"status,omitempty"`
+}
+
+// ConnectionState describes the connection between a Sync Agent and the APIExport's
+// virtual workspace.
+type ConnectionState string
+
+const (
+	// ConnectionStatePending means the APIExport does not yet provide a virtual
+	// workspace URL.
+	ConnectionStatePending ConnectionState = "Pending"
+	// ConnectionStateConnected means the virtual workspace cluster is running.
+	ConnectionStateConnected ConnectionState = "Connected"
+	// ConnectionStateDisconnected means the virtual workspace cluster has stopped
+	// unexpectedly.
+	ConnectionStateDisconnected ConnectionState = "Disconnected"
+	// ConnectionStateDraining means the Sync Agent is shutting down.
+	ConnectionStateDraining ConnectionState = "Draining"
+)
+
+// ConditionConnected is true while the Sync Agent is connected to the virtual
+// workspace of its APIExport.
+const ConditionConnected = "Connected"
+
+// SyncAgentStatusStatus describes the current state of a Sync Agent.
+type SyncAgentStatusStatus struct {
+	// APIExport is the name of the APIExport served by the Sync Agent.
+	APIExport string `json:"apiExport,omitempty"`
+
+	// APIExportResourceVersion is the resourceVersion of the APIExport that was
+	// last observed by the Sync Agent.
+	APIExportResourceVersion string `json:"apiExportResourceVersion,omitempty"`
+
+	// VirtualWorkspaceURL is the URL of the APIExport's virtual workspace that the
+	// Sync Agent is connected to.
+	VirtualWorkspaceURL string `json:"virtualWorkspaceURL,omitempty"`
+
+	// ConnectionState describes the connection to the virtual workspace.
+	ConnectionState ConnectionState `json:"connectionState,omitempty"`
+
+	// SyncControllers is the number of currently running sync controllers, one
+	// for each PublishedResource.
+	SyncControllers int `json:"syncControllers"`
+
+	// LastHeartbeatTime is refreshed regularly while the Sync Agent is running.
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+
+	// ControllerStops counts how often the controllers managed by the Sync Agent
+	// have been stopped (and usually restarted), by controller and reason. The
+	// counters are reset whenever the Sync Agent restarts.
+	// +listType=map
+	// +listMapKey=controller
+	// +listMapKey=reason
+	ControllerStops []ControllerStops `json:"controllerStops,omitempty"`
+
+	// Conditions contain the Connected condition, whose last transition time tells
+	// since when the current connection state applies.
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ControllerStops counts how often one type of controller was stopped for one reason.
+type ControllerStops struct {
+	// Controller is the type of controller, e.g. "sync" or "announcement".
+	Controller string `json:"controller"`
+
+	// Reason is why the controller was stopped, e.g. "pr-updated", "pr-removed",
+	// "pr-paused", "vw-url-changed", "controller-failed" or "shutdown".
+	Reason string `json:"reason"`
+
+	// Count is the number of times the controller was stopped for this reason.
+	Count int64 `json:"count"`
+
+	// LastStopTime is the time of the most recent stop.
+	LastStopTime metav1.Time `json:"lastStopTime"`
+}
+
+// +kubebuilder:object:root=true
+
+// SyncAgentStatusList contains a list of SyncAgentStatuses.
+type SyncAgentStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SyncAgentStatus `json:"items"`
+}
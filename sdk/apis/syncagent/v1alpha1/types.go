@@ -18,10 +18,129 @@ package v1alpha1
 
 const (
 	// AgentNameAnnotation records which Sync Agent has created an APIResourceSchema.
+	// On APIExports it records the Sync Agent powering the APIExport; when multiple
+	// shards share the APIExport, it is maintained by the first shard.
 	AgentNameAnnotation = "syncagent.kcp.io/agent-name"
 
 	// SourceGenerationAnnotation is the annotation on APIResourceSchemas that tells us
 	// what generation of the CRD it was based on. This can be helpful in debugging,
 	// as ARS resources cannot be updated, i.e. changes to CRDs are not reflected in ARS.
 	SourceGenerationAnnotation = "syncagent.kcp.io/source-generation"
+
+	// PublishedResourceAnnotation records the name of the PublishedResource on
+	// APIResourceSchemas and synced local objects.
+	PublishedResourceAnnotation = "syncagent.kcp.io/published-resource"
+
+	// SourceGVKAnnotation records the GVK of a published resource on the service
+	// cluster, in the form "Kind.version.group".
+	SourceGVKAnnotation = "syncagent.kcp.io/source-gvk"
+
+	// ProjectedGVKAnnotation records the GVK of a published resource in kcp, in the
+	// form "Kind.version.group".
+	ProjectedGVKAnnotation = "syncagent.kcp.io/projected-gvk"
+
+	// SchemaGarbageCollectionAnnotation can be set to "true" on PublishedResources to
+	// allow the Sync Agent to remove their APIResourceSchemas from the APIExport once
+	// the PublishedResource has been deleted. The annotation is copied onto the
+	// APIResourceSchemas.
+	SchemaGarbageCollectionAnnotation = "syncagent.kcp.io/schema-gc"
+
+	// OrphanedSinceAnnotation records on APIResourceSchemas since when their
+	// PublishedResource does not exist anymore, as an RFC3339 timestamp.
+	OrphanedSinceAnnotation = "syncagent.kcp.io/orphaned-since"
+
+	// TargetClusterLabel must be set on objects on the service cluster that belong to a
+	// PublishedResource with origin "service". Its value is the logical cluster name of
+	// the kcp workspace into which the object is published.
+	TargetClusterLabel = "syncagent.kcp.io/target-cluster"
+
+	// TargetNamespaceAnnotation can be set on objects on the service cluster that belong
+	// to a PublishedResource with origin "service" to control the namespace of the object
+	// in kcp. If not set, the object's own namespace is used.
+	TargetNamespaceAnnotation = "syncagent.kcp.io/target-namespace"
+
+	// RelatedObjectsAnnotation is placed on primary objects in kcp and lists all related
+	// objects that were synchronized from the service cluster into kcp, as a JSON list.
+	// Use the sdk/related package to parse it.
+	RelatedObjectsAnnotation = "syncagent.kcp.io/related-objects"
+
+	// SyncErrorAnnotation is placed on objects in kcp whose synchronization to the
+	// service cluster failed. It contains a sanitized error message and is removed
+	// once the object has been synchronized successfully.
+	SyncErrorAnnotation = "syncagent.kcp.io/sync-error"
+
+	// FailedRelatedResourcesAnnotation is placed on objects in kcp if some of their
+	// related resources could not be synchronized. It contains a comma-separated list
+	// of the related resources' identifiers and is removed once all related resources
+	// have been synchronized successfully.
+	FailedRelatedResourcesAnnotation = "syncagent.kcp.io/failed-related-resources"
+
+	// RetainedFromAnnotation is placed on objects on the service cluster that were kept
+	// because of the PublishedResource's deletion policy after their object in kcp was
+	// deleted. It contains the kcp cluster, namespace and name of the deleted object.
+	RetainedFromAnnotation = "syncagent.kcp.io/retained-from"
+
+	// PausedAnnotation can be placed on objects in kcp or their copies on the service
+	// cluster. If set to "true", the Sync Agent does not synchronize the object (or
+	// its related objects) until the annotation is removed again.
+	PausedAnnotation = "syncagent.kcp.io/paused"
+
+	// AllowIncompatibleSchemaAnnotation can be set to "true" on PublishedResources to
+	// add their APIResourceSchema to the APIExport even if it is incompatible with the
+	// schema that is currently published for the same resource.
+	AllowIncompatibleSchemaAnnotation = "syncagent.kcp.io/allow-incompatible-schema"
+
+	// SkipAnnotation can be placed on the LogicalCluster object of a kcp workspace. If
+	// set to "true", the Sync Agent does not synchronize any objects of this workspace,
+	// for example to quarantine a misbehaving tenant. The annotation is cached for a
+	// short time, so it takes up to a minute to become effective.
+	SkipAnnotation = "syncagent.kcp.io/skip"
+
+	// ProvenanceAnnotationPrefix is the common prefix of all provenance annotations,
+	// which are placed on objects on the service cluster if configured in the
+	// PublishedResource's provenance policy. Annotations with this prefix are never
+	// copied from kcp, so they cannot be forged by consumers.
+	ProvenanceAnnotationPrefix = "provenance.syncagent.kcp.io/"
+
+	// ProvenanceWorkspacePathAnnotation contains the path of the kcp workspace an
+	// object originates from.
+	ProvenanceWorkspacePathAnnotation = ProvenanceAnnotationPrefix + "workspace-path"
+
+	// ProvenanceCreatedAtAnnotation contains the creation timestamp (RFC 3339) of the
+	// object in kcp.
+	ProvenanceCreatedAtAnnotation = ProvenanceAnnotationPrefix + "created-at"
+
+	// ProvenanceCreatedByAnnotation contains the identity of the user who created the
+	// object in kcp, as found in the annotation configured in the provenance policy.
+	ProvenanceCreatedByAnnotation = ProvenanceAnnotationPrefix + "created-by"
+
+	// ProvenanceLastSyncedAtAnnotation contains the time (RFC 3339) at which the Sync
+	// Agent last changed the object.
+	ProvenanceLastSyncedAtAnnotation = ProvenanceAnnotationPrefix + "last-synced-at"
+
+	// ShardLabel can be placed on PublishedResources to assign them to a specific
+	// shard when multiple Sync Agents share the same APIExport. The value is the
+	// zero-based index of the shard. PublishedResources without this label are
+	// assigned to a shard based on a hash of their name.
+	ShardLabel = "syncagent.kcp.io/shard"
+
+	// PublishAnnotation can be set to "true" on CRDs on the service cluster to have
+	// the Sync Agent generate a PublishedResource for them, if enabled. The generated
+	// PublishedResource is named after the CRD and owned by it.
+	PublishAnnotation = "syncagent.kcp.io/publish"
+
+	// PublishGroupAnnotation, PublishKindAnnotation and PublishPluralAnnotation can be
+	// set on CRDs with the PublishAnnotation to configure the projection of the
+	// generated PublishedResource.
+	PublishGroupAnnotation  = "syncagent.kcp.io/publish-group"
+	PublishKindAnnotation   = "syncagent.kcp.io/publish-kind"
+	PublishPluralAnnotation = "syncagent.kcp.io/publish-plural"
+
+	// PausedCondition is the condition that is set on paused objects in kcp, if
+	// their resource has a status subresource.
+	PausedCondition = "SyncPaused"
+
+	// ImmutableFieldsRevertedCondition is set on objects in kcp whose immutable fields
+	// have been changed and reverted, if their resource has a status subresource.
+	ImmutableFieldsRevertedCondition = "ImmutableFieldsReverted"
 )
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".clusterName"
+// +kubebuilder:printcolumn:name="Path",type="string",JSONPath=".workspacePath"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkspaceMapping records which namespaces on the service cluster the Sync Agent has
+// created for objects from a kcp workspace. There is one WorkspaceMapping per agent and
+// workspace. They are only maintained if the Sync Agent is started with
+// --workspace-mappings and must not be modified by anyone else.
+type WorkspaceMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ClusterName is the logical cluster name of the kcp workspace.
+	ClusterName string `json:"clusterName"`
+
+	// WorkspacePath is the path of the kcp workspace. It is only known if the
+	// PublishedResources have enableWorkspacePaths enabled.
+	WorkspacePath string `json:"workspacePath,omitempty"`
+
+	// Namespaces is the sorted list of namespaces on the service cluster that have
+	// been created for objects from the workspace.
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkspaceMappingList contains a list of WorkspaceMappings.
+type WorkspaceMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceMapping `json:"items"`
+}
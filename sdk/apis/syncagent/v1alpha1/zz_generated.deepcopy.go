@@ -26,7 +26,7 @@ import (
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedResource) DeepCopyInto(out *PublishedResource) {
+func (in *Announcement) DeepCopyInto(out *Announcement) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -34,18 +34,18 @@ func (in *PublishedResource) DeepCopyInto(out *PublishedResource) {
 	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResource.
-func (in *PublishedResource) DeepCopy() *PublishedResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Announcement.
+func (in *Announcement) DeepCopy() *Announcement {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedResource)
+	out := new(Announcement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PublishedResource) DeepCopyObject() runtime.Object {
+func (in *Announcement) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,31 +53,31 @@ func (in *PublishedResource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedResourceList) DeepCopyInto(out *PublishedResourceList) {
+func (in *AnnouncementList) DeepCopyInto(out *AnnouncementList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PublishedResource, len(*in))
+		*out = make([]Announcement, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceList.
-func (in *PublishedResourceList) DeepCopy() *PublishedResourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementList.
+func (in *AnnouncementList) DeepCopy() *AnnouncementList {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedResourceList)
+	out := new(AnnouncementList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PublishedResourceList) DeepCopyObject() runtime.Object {
+func (in *AnnouncementList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -85,407 +85,1560 @@ func (in *PublishedResourceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedResourceSpec) DeepCopyInto(out *PublishedResourceSpec) {
+func (in *AnnouncementSpec) DeepCopyInto(out *AnnouncementSpec) {
 	*out = *in
-	out.Resource = in.Resource
-	if in.Filter != nil {
-		in, out := &in.Filter, &out.Filter
-		*out = new(ResourceFilter)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Naming != nil {
-		in, out := &in.Naming, &out.Naming
-		*out = new(ResourceNaming)
-		**out = **in
-	}
-	if in.Projection != nil {
-		in, out := &in.Projection, &out.Projection
-		*out = new(ResourceProjection)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Mutation != nil {
-		in, out := &in.Mutation, &out.Mutation
-		*out = new(ResourceMutationSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Related != nil {
-		in, out := &in.Related, &out.Related
-		*out = make([]RelatedResourceSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Object.DeepCopyInto(&out.Object)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceSpec.
-func (in *PublishedResourceSpec) DeepCopy() *PublishedResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementSpec.
+func (in *AnnouncementSpec) DeepCopy() *AnnouncementSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedResourceSpec)
+	out := new(AnnouncementSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedResourceStatus) DeepCopyInto(out *PublishedResourceStatus) {
+func (in *AnnouncementStatus) DeepCopyInto(out *AnnouncementStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceStatus.
-func (in *PublishedResourceStatus) DeepCopy() *PublishedResourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnouncementStatus.
+func (in *AnnouncementStatus) DeepCopy() *AnnouncementStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedResourceStatus)
+	out := new(AnnouncementStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RegularExpression) DeepCopyInto(out *RegularExpression) {
+func (in *ControllerStops) DeepCopyInto(out *ControllerStops) {
 	*out = *in
+	in.LastStopTime.DeepCopyInto(&out.LastStopTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegularExpression.
-func (in *RegularExpression) DeepCopy() *RegularExpression {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerStops.
+func (in *ControllerStops) DeepCopy() *ControllerStops {
 	if in == nil {
 		return nil
 	}
-	out := new(RegularExpression)
+	out := new(ControllerStops)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceObject) DeepCopyInto(out *RelatedResourceObject) {
+func (in *GroupVersionKind) DeepCopyInto(out *GroupVersionKind) {
 	*out = *in
-	in.RelatedResourceObjectSpec.DeepCopyInto(&out.RelatedResourceObjectSpec)
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(RelatedResourceObjectSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObject.
-func (in *RelatedResourceObject) DeepCopy() *RelatedResourceObject {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupVersionKind.
+func (in *GroupVersionKind) DeepCopy() *GroupVersionKind {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceObject)
+	out := new(GroupVersionKind)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceObjectReference) DeepCopyInto(out *RelatedResourceObjectReference) {
+func (in *InitialSyncSettings) DeepCopyInto(out *InitialSyncSettings) {
 	*out = *in
-	if in.Regex != nil {
-		in, out := &in.Regex, &out.Regex
-		*out = new(RegularExpression)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectReference.
-func (in *RelatedResourceObjectReference) DeepCopy() *RelatedResourceObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitialSyncSettings.
+func (in *InitialSyncSettings) DeepCopy() *InitialSyncSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceObjectReference)
+	out := new(InitialSyncSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceObjectSelector) DeepCopyInto(out *RelatedResourceObjectSelector) {
+func (in *MetadataDirectionFilters) DeepCopyInto(out *MetadataDirectionFilters) {
 	*out = *in
-	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
-	in.Rewrite.DeepCopyInto(&out.Rewrite)
+	if in.Down != nil {
+		in, out := &in.Down, &out.Down
+		*out = new(MetadataKeyFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Up != nil {
+		in, out := &in.Up, &out.Up
+		*out = new(MetadataKeyFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectSelector.
-func (in *RelatedResourceObjectSelector) DeepCopy() *RelatedResourceObjectSelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataDirectionFilters.
+func (in *MetadataDirectionFilters) DeepCopy() *MetadataDirectionFilters {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceObjectSelector)
+	out := new(MetadataDirectionFilters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceObjectSpec) DeepCopyInto(out *RelatedResourceObjectSpec) {
+func (in *MetadataKeyFilter) DeepCopyInto(out *MetadataKeyFilter) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(RelatedResourceObjectSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Reference != nil {
-		in, out := &in.Reference, &out.Reference
-		*out = new(RelatedResourceObjectReference)
-		(*in).DeepCopyInto(*out)
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(TemplateExpression)
-		**out = **in
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectSpec.
-func (in *RelatedResourceObjectSpec) DeepCopy() *RelatedResourceObjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataKeyFilter.
+func (in *MetadataKeyFilter) DeepCopy() *MetadataKeyFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceObjectSpec)
+	out := new(MetadataKeyFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceSelectorRewrite) DeepCopyInto(out *RelatedResourceSelectorRewrite) {
+func (in *MetadataSyncPolicy) DeepCopyInto(out *MetadataSyncPolicy) {
 	*out = *in
-	if in.Regex != nil {
-		in, out := &in.Regex, &out.Regex
-		*out = new(RegularExpression)
-		**out = **in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = new(MetadataDirectionFilters)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(TemplateExpression)
-		**out = **in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = new(MetadataDirectionFilters)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceSelectorRewrite.
-func (in *RelatedResourceSelectorRewrite) DeepCopy() *RelatedResourceSelectorRewrite {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataSyncPolicy.
+func (in *MetadataSyncPolicy) DeepCopy() *MetadataSyncPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceSelectorRewrite)
+	out := new(MetadataSyncPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RelatedResourceSpec) DeepCopyInto(out *RelatedResourceSpec) {
+func (in *NamespaceLabelMapping) DeepCopyInto(out *NamespaceLabelMapping) {
 	*out = *in
-	in.Object.DeepCopyInto(&out.Object)
-	if in.Mutation != nil {
-		in, out := &in.Mutation, &out.Mutation
-		*out = new(ResourceMutationSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceSpec.
-func (in *RelatedResourceSpec) DeepCopy() *RelatedResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelMapping.
+func (in *NamespaceLabelMapping) DeepCopy() *NamespaceLabelMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(RelatedResourceSpec)
+	out := new(NamespaceLabelMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceDeleteMutation) DeepCopyInto(out *ResourceDeleteMutation) {
+func (in *NamespaceNameFilter) DeepCopyInto(out *NamespaceNameFilter) {
 	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDeleteMutation.
-func (in *ResourceDeleteMutation) DeepCopy() *ResourceDeleteMutation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceNameFilter.
+func (in *NamespaceNameFilter) DeepCopy() *NamespaceNameFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceDeleteMutation)
+	out := new(NamespaceNameFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceFilter) DeepCopyInto(out *ResourceFilter) {
+func (in *NamespaceSync) DeepCopyInto(out *NamespaceSync) {
 	*out = *in
-	if in.Namespace != nil {
-		in, out := &in.Namespace, &out.Namespace
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Resource != nil {
-		in, out := &in.Resource, &out.Resource
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFilter.
-func (in *ResourceFilter) DeepCopy() *ResourceFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSync.
+func (in *NamespaceSync) DeepCopy() *NamespaceSync {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceFilter)
+	out := new(NamespaceSync)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMutation) DeepCopyInto(out *ResourceMutation) {
+func (in *NamingHash) DeepCopyInto(out *NamingHash) {
 	*out = *in
-	if in.Delete != nil {
-		in, out := &in.Delete, &out.Delete
-		*out = new(ResourceDeleteMutation)
-		**out = **in
-	}
-	if in.Regex != nil {
-		in, out := &in.Regex, &out.Regex
-		*out = new(ResourceRegexMutation)
-		**out = **in
-	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(ResourceTemplateMutation)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMutation.
-func (in *ResourceMutation) DeepCopy() *ResourceMutation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingHash.
+func (in *NamingHash) DeepCopy() *NamingHash {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMutation)
+	out := new(NamingHash)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMutationSpec) DeepCopyInto(out *ResourceMutationSpec) {
+func (in *ObjectLimits) DeepCopyInto(out *ObjectLimits) {
 	*out = *in
-	if in.Spec != nil {
-		in, out := &in.Spec, &out.Spec
-		*out = make([]ResourceMutation, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = make([]ResourceMutation, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMutationSpec.
-func (in *ResourceMutationSpec) DeepCopy() *ResourceMutationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectLimits.
+func (in *ObjectLimits) DeepCopy() *ObjectLimits {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMutationSpec)
+	out := new(ObjectLimits)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceNaming) DeepCopyInto(out *ResourceNaming) {
+func (in *ObjectState) DeepCopyInto(out *ObjectState) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string][]byte, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]byte, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNaming.
-func (in *ResourceNaming) DeepCopy() *ResourceNaming {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectState.
+func (in *ObjectState) DeepCopy() *ObjectState {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceNaming)
+	out := new(ObjectState)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceProjection) DeepCopyInto(out *ResourceProjection) {
+func (in *ObjectStateList) DeepCopyInto(out *ObjectStateList) {
 	*out = *in
-	if in.ShortNames != nil {
-		in, out := &in.ShortNames, &out.ShortNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Categories != nil {
-		in, out := &in.Categories, &out.Categories
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ObjectState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceProjection.
-func (in *ResourceProjection) DeepCopy() *ResourceProjection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStateList.
+func (in *ObjectStateList) DeepCopy() *ObjectStateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceProjection)
+	out := new(ObjectStateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ObjectStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRegexMutation) DeepCopyInto(out *ResourceRegexMutation) {
+func (in *OrphanSettings) DeepCopyInto(out *OrphanSettings) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRegexMutation.
-func (in *ResourceRegexMutation) DeepCopy() *ResourceRegexMutation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanSettings.
+func (in *OrphanSettings) DeepCopy() *OrphanSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRegexMutation)
+	out := new(OrphanSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceTemplateMutation) DeepCopyInto(out *ResourceTemplateMutation) {
+func (in *ProjectionLeftover) DeepCopyInto(out *ProjectionLeftover) {
 	*out = *in
+	out.GroupVersionKind = in.GroupVersionKind
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateMutation.
-func (in *ResourceTemplateMutation) DeepCopy() *ResourceTemplateMutation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectionLeftover.
+func (in *ProjectionLeftover) DeepCopy() *ProjectionLeftover {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceTemplateMutation)
+	out := new(ProjectionLeftover)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SourceResourceDescriptor) DeepCopyInto(out *SourceResourceDescriptor) {
+func (in *ProvenancePolicy) DeepCopyInto(out *ProvenancePolicy) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceResourceDescriptor.
-func (in *SourceResourceDescriptor) DeepCopy() *SourceResourceDescriptor {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvenancePolicy.
+func (in *ProvenancePolicy) DeepCopy() *ProvenancePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(SourceResourceDescriptor)
+	out := new(ProvenancePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemplateExpression) DeepCopyInto(out *TemplateExpression) {
+func (in *PublishedResource) DeepCopyInto(out *PublishedResource) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateExpression.
-func (in *TemplateExpression) DeepCopy() *TemplateExpression {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResource.
+func (in *PublishedResource) DeepCopy() *PublishedResource {
 	if in == nil {
 		return nil
 	}
-	out := new(TemplateExpression)
+	out := new(PublishedResource)
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceList) DeepCopyInto(out *PublishedResourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PublishedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceList.
+func (in *PublishedResourceList) DeepCopy() *PublishedResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceProfile) DeepCopyInto(out *PublishedResourceProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceProfile.
+func (in *PublishedResourceProfile) DeepCopy() *PublishedResourceProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedResourceProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceProfileList) DeepCopyInto(out *PublishedResourceProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PublishedResourceProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceProfileList.
+func (in *PublishedResourceProfileList) DeepCopy() *PublishedResourceProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedResourceProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceProfileSpec) DeepCopyInto(out *PublishedResourceProfileSpec) {
+	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(ResourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(ResourceNaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mutation != nil {
+		in, out := &in.Mutation, &out.Mutation
+		*out = new(ResourceMutationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Related != nil {
+		in, out := &in.Related, &out.Related
+		*out = make([]RelatedResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceProfileSpec.
+func (in *PublishedResourceProfileSpec) DeepCopy() *PublishedResourceProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceSpec) DeepCopyInto(out *PublishedResourceSpec) {
+	*out = *in
+	in.Resource.DeepCopyInto(&out.Resource)
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(ResourceFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(ResourceNaming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Projection != nil {
+		in, out := &in.Projection, &out.Projection
+		*out = new(ResourceProjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchemaProjection != nil {
+		in, out := &in.SchemaProjection, &out.SchemaProjection
+		*out = new(SchemaProjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mutation != nil {
+		in, out := &in.Mutation, &out.Mutation
+		*out = new(ResourceMutationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatusProjection != nil {
+		in, out := &in.StatusProjection, &out.StatusProjection
+		*out = new(StatusProjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImmutableFields != nil {
+		in, out := &in.ImmutableFields, &out.ImmutableFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BidirectionalFields != nil {
+		in, out := &in.BidirectionalFields, &out.BidirectionalFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceLabels != nil {
+		in, out := &in.NamespaceLabels, &out.NamespaceLabels
+		*out = make([]NamespaceLabelMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSync != nil {
+		in, out := &in.NamespaceSync, &out.NamespaceSync
+		*out = new(NamespaceSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetadataSync != nil {
+		in, out := &in.MetadataSync, &out.MetadataSync
+		*out = new(MetadataSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(ProvenancePolicy)
+		**out = **in
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ResourceReadiness)
+		**out = **in
+	}
+	if in.InitialSync != nil {
+		in, out := &in.InitialSync, &out.InitialSync
+		*out = new(InitialSyncSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(SyncSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(ObjectLimits)
+		**out = **in
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = new(OrphanSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Related != nil {
+		in, out := &in.Related, &out.Related
+		*out = make([]RelatedResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceSpec.
+func (in *PublishedResourceSpec) DeepCopy() *PublishedResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedResourceStatus) DeepCopyInto(out *PublishedResourceStatus) {
+	*out = *in
+	if in.ProjectedGVK != nil {
+		in, out := &in.ProjectedGVK, &out.ProjectedGVK
+		*out = new(GroupVersionKind)
+		**out = **in
+	}
+	if in.ProjectionLeftovers != nil {
+		in, out := &in.ProjectionLeftovers, &out.ProjectionLeftovers
+		*out = make([]ProjectionLeftover, len(*in))
+		copy(*out, *in)
+	}
+	if in.Unpublish != nil {
+		in, out := &in.Unpublish, &out.Unpublish
+		*out = new(UnpublishStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Statistics != nil {
+		in, out := &in.Statistics, &out.Statistics
+		*out = new(SyncStatistics)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedResourceStatus.
+func (in *PublishedResourceStatus) DeepCopy() *PublishedResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegularExpression) DeepCopyInto(out *RegularExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegularExpression.
+func (in *RegularExpression) DeepCopy() *RegularExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(RegularExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceComposedKey) DeepCopyInto(out *RelatedResourceComposedKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceComposedKey.
+func (in *RelatedResourceComposedKey) DeepCopy() *RelatedResourceComposedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceComposedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceDataTransformation) DeepCopyInto(out *RelatedResourceDataTransformation) {
+	*out = *in
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make([]RelatedResourceKeyRename, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drop != nil {
+		in, out := &in.Drop, &out.Drop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compose != nil {
+		in, out := &in.Compose, &out.Compose
+		*out = make([]RelatedResourceComposedKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceDataTransformation.
+func (in *RelatedResourceDataTransformation) DeepCopy() *RelatedResourceDataTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceDataTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceKeyRename) DeepCopyInto(out *RelatedResourceKeyRename) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceKeyRename.
+func (in *RelatedResourceKeyRename) DeepCopy() *RelatedResourceKeyRename {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceKeyRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceObject) DeepCopyInto(out *RelatedResourceObject) {
+	*out = *in
+	in.RelatedResourceObjectSpec.DeepCopyInto(&out.RelatedResourceObjectSpec)
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(RelatedResourceObjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObject.
+func (in *RelatedResourceObject) DeepCopy() *RelatedResourceObject {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceObjectReference) DeepCopyInto(out *RelatedResourceObjectReference) {
+	*out = *in
+	if in.Regex != nil {
+		in, out := &in.Regex, &out.Regex
+		*out = new(RegularExpression)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectReference.
+func (in *RelatedResourceObjectReference) DeepCopy() *RelatedResourceObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceObjectSelector) DeepCopyInto(out *RelatedResourceObjectSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	in.Rewrite.DeepCopyInto(&out.Rewrite)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectSelector.
+func (in *RelatedResourceObjectSelector) DeepCopy() *RelatedResourceObjectSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceObjectSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceObjectSpec) DeepCopyInto(out *RelatedResourceObjectSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(RelatedResourceObjectSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reference != nil {
+		in, out := &in.Reference, &out.Reference
+		*out = new(RelatedResourceObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(TemplateExpression)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceObjectSpec.
+func (in *RelatedResourceObjectSpec) DeepCopy() *RelatedResourceObjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceObjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceSelectorRewrite) DeepCopyInto(out *RelatedResourceSelectorRewrite) {
+	*out = *in
+	if in.Regex != nil {
+		in, out := &in.Regex, &out.Regex
+		*out = new(RegularExpression)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(TemplateExpression)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceSelectorRewrite.
+func (in *RelatedResourceSelectorRewrite) DeepCopy() *RelatedResourceSelectorRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceSelectorRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelatedResourceSpec) DeepCopyInto(out *RelatedResourceSpec) {
+	*out = *in
+	in.Object.DeepCopyInto(&out.Object)
+	if in.Mutation != nil {
+		in, out := &in.Mutation, &out.Mutation
+		*out = new(ResourceMutationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(RelatedResourceDataTransformation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Related != nil {
+		in, out := &in.Related, &out.Related
+		*out = make([]RelatedResourceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelatedResourceSpec.
+func (in *RelatedResourceSpec) DeepCopy() *RelatedResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RelatedResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueBackoff) DeepCopyInto(out *RequeueBackoff) {
+	*out = *in
+	if in.Initial != nil {
+		in, out := &in.Initial, &out.Initial
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueBackoff.
+func (in *RequeueBackoff) DeepCopy() *RequeueBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDeleteMutation) DeepCopyInto(out *ResourceDeleteMutation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDeleteMutation.
+func (in *ResourceDeleteMutation) DeepCopy() *ResourceDeleteMutation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDeleteMutation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFilter) DeepCopyInto(out *ResourceFilter) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceNames != nil {
+		in, out := &in.NamespaceNames, &out.NamespaceNames
+		*out = new(NamespaceNameFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFilter.
+func (in *ResourceFilter) DeepCopy() *ResourceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMutation) DeepCopyInto(out *ResourceMutation) {
+	*out = *in
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(ResourceDeleteMutation)
+		**out = **in
+	}
+	if in.Regex != nil {
+		in, out := &in.Regex, &out.Regex
+		*out = new(ResourceRegexMutation)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ResourceTemplateMutation)
+		**out = **in
+	}
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = make([]ResourcePatchOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Merge != nil {
+		in, out := &in.Merge, &out.Merge
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(ResourceMutationCondition)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMutation.
+func (in *ResourceMutation) DeepCopy() *ResourceMutation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMutation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMutationCondition) DeepCopyInto(out *ResourceMutationCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMutationCondition.
+func (in *ResourceMutationCondition) DeepCopy() *ResourceMutationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMutationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMutationSpec) DeepCopyInto(out *ResourceMutationSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = make([]ResourceMutation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = make([]ResourceMutation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMutationSpec.
+func (in *ResourceMutationSpec) DeepCopy() *ResourceMutationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMutationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNaming) DeepCopyInto(out *ResourceNaming) {
+	*out = *in
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = new(NamingHash)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNaming.
+func (in *ResourceNaming) DeepCopy() *ResourceNaming {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNaming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePatchOperation) DeepCopyInto(out *ResourcePatchOperation) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePatchOperation.
+func (in *ResourcePatchOperation) DeepCopy() *ResourcePatchOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePatchOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceProjection) DeepCopyInto(out *ResourceProjection) {
+	*out = *in
+	if in.ShortNames != nil {
+		in, out := &in.ShortNames, &out.ShortNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceProjection.
+func (in *ResourceProjection) DeepCopy() *ResourceProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceReadiness) DeepCopyInto(out *ResourceReadiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceReadiness.
+func (in *ResourceReadiness) DeepCopy() *ResourceReadiness {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceReadiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRegexMutation) DeepCopyInto(out *ResourceRegexMutation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRegexMutation.
+func (in *ResourceRegexMutation) DeepCopy() *ResourceRegexMutation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRegexMutation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTemplateMutation) DeepCopyInto(out *ResourceTemplateMutation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTemplateMutation.
+func (in *ResourceTemplateMutation) DeepCopy() *ResourceTemplateMutation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTemplateMutation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceVersionProjection) DeepCopyInto(out *ResourceVersionProjection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceVersionProjection.
+func (in *ResourceVersionProjection) DeepCopy() *ResourceVersionProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceVersionProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaDefault) DeepCopyInto(out *SchemaDefault) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaDefault.
+func (in *SchemaDefault) DeepCopy() *SchemaDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaProjection) DeepCopyInto(out *SchemaProjection) {
+	*out = *in
+	if in.Drop != nil {
+		in, out := &in.Drop, &out.Drop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = make([]SchemaDefault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaProjection.
+func (in *SchemaProjection) DeepCopy() *SchemaProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceResourceDescriptor) DeepCopyInto(out *SourceResourceDescriptor) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]SourceResourceVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceResourceDescriptor.
+func (in *SourceResourceDescriptor) DeepCopy() *SourceResourceDescriptor {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceResourceDescriptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceResourceVersion) DeepCopyInto(out *SourceResourceVersion) {
+	*out = *in
+	if in.Projection != nil {
+		in, out := &in.Projection, &out.Projection
+		*out = new(ResourceVersionProjection)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceResourceVersion.
+func (in *SourceResourceVersion) DeepCopy() *SourceResourceVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceResourceVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusProjection) DeepCopyInto(out *StatusProjection) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusProjection.
+func (in *StatusProjection) DeepCopy() *StatusProjection {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusProjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncAgentStatus) DeepCopyInto(out *SyncAgentStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncAgentStatus.
+func (in *SyncAgentStatus) DeepCopy() *SyncAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SyncAgentStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncAgentStatusList) DeepCopyInto(out *SyncAgentStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SyncAgentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncAgentStatusList.
+func (in *SyncAgentStatusList) DeepCopy() *SyncAgentStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncAgentStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SyncAgentStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncAgentStatusStatus) DeepCopyInto(out *SyncAgentStatusStatus) {
+	*out = *in
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ControllerStops != nil {
+		in, out := &in.ControllerStops, &out.ControllerStops
+		*out = make([]ControllerStops, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncAgentStatusStatus.
+func (in *SyncAgentStatusStatus) DeepCopy() *SyncAgentStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncAgentStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncLatency) DeepCopyInto(out *SyncLatency) {
+	*out = *in
+	out.P50 = in.P50
+	out.P95 = in.P95
+	out.P99 = in.P99
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncLatency.
+func (in *SyncLatency) DeepCopy() *SyncLatency {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncLatency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSettings) DeepCopyInto(out *SyncSettings) {
+	*out = *in
+	if in.RequeueBackoff != nil {
+		in, out := &in.RequeueBackoff, &out.RequeueBackoff
+		*out = new(RequeueBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResyncPeriod != nil {
+		in, out := &in.ResyncPeriod, &out.ResyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSettings.
+func (in *SyncSettings) DeepCopy() *SyncSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncStatistics) DeepCopyInto(out *SyncStatistics) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(SyncLatency)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatistics.
+func (in *SyncStatistics) DeepCopy() *SyncStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateExpression) DeepCopyInto(out *TemplateExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateExpression.
+func (in *TemplateExpression) DeepCopy() *TemplateExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnpublishStatus) DeepCopyInto(out *UnpublishStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnpublishStatus.
+func (in *UnpublishStatus) DeepCopy() *UnpublishStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnpublishStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceMapping) DeepCopyInto(out *WorkspaceMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceMapping.
+func (in *WorkspaceMapping) DeepCopy() *WorkspaceMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceMappingList) DeepCopyInto(out *WorkspaceMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceMappingList.
+func (in *WorkspaceMappingList) DeepCopy() *WorkspaceMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -18,16 +18,43 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+)
+
 // PublishedResourceSpecApplyConfiguration represents a declarative configuration of the PublishedResourceSpec type for use
 // with apply.
 type PublishedResourceSpecApplyConfiguration struct {
-	Resource             *SourceResourceDescriptorApplyConfiguration `json:"resource,omitempty"`
-	Filter               *ResourceFilterApplyConfiguration           `json:"filter,omitempty"`
-	Naming               *ResourceNamingApplyConfiguration           `json:"naming,omitempty"`
-	EnableWorkspacePaths *bool                                       `json:"enableWorkspacePaths,omitempty"`
-	Projection           *ResourceProjectionApplyConfiguration       `json:"projection,omitempty"`
-	Mutation             *ResourceMutationSpecApplyConfiguration     `json:"mutation,omitempty"`
-	Related              []RelatedResourceSpecApplyConfiguration     `json:"related,omitempty"`
+	Resource                *SourceResourceDescriptorApplyConfiguration `json:"resource,omitempty"`
+	Origin                  *v1alpha1.PublishedResourceOrigin           `json:"origin,omitempty"`
+	Paused                  *bool                                       `json:"paused,omitempty"`
+	Filter                  *ResourceFilterApplyConfiguration           `json:"filter,omitempty"`
+	Naming                  *ResourceNamingApplyConfiguration           `json:"naming,omitempty"`
+	EnableWorkspacePaths    *bool                                       `json:"enableWorkspacePaths,omitempty"`
+	EnableRemoteEvents      *bool                                       `json:"enableRemoteEvents,omitempty"`
+	Projection              *ResourceProjectionApplyConfiguration       `json:"projection,omitempty"`
+	ProjectionChangePolicy  *v1alpha1.ProjectionChangePolicy            `json:"projectionChangePolicy,omitempty"`
+	SchemaProjection        *SchemaProjectionApplyConfiguration         `json:"schemaProjection,omitempty"`
+	Mutation                *ResourceMutationSpecApplyConfiguration     `json:"mutation,omitempty"`
+	StatusProjection        *StatusProjectionApplyConfiguration         `json:"statusProjection,omitempty"`
+	ImmutableFields         []string                                    `json:"immutableFields,omitempty"`
+	BidirectionalFields     []string                                    `json:"bidirectionalFields,omitempty"`
+	NamespaceLabels         []NamespaceLabelMappingApplyConfiguration   `json:"namespaceLabels,omitempty"`
+	NamespaceSync           *NamespaceSyncApplyConfiguration            `json:"namespaceSync,omitempty"`
+	MetadataSync            *MetadataSyncPolicyApplyConfiguration       `json:"metadataSync,omitempty"`
+	Provenance              *ProvenancePolicyApplyConfiguration         `json:"provenance,omitempty"`
+	StateNamespace          *string                                     `json:"stateNamespace,omitempty"`
+	Readiness               *ResourceReadinessApplyConfiguration        `json:"readiness,omitempty"`
+	InitialSync             *InitialSyncSettingsApplyConfiguration      `json:"initialSync,omitempty"`
+	Sync                    *SyncSettingsApplyConfiguration             `json:"sync,omitempty"`
+	Limits                  *ObjectLimitsApplyConfiguration             `json:"limits,omitempty"`
+	Orphans                 *OrphanSettingsApplyConfiguration           `json:"orphans,omitempty"`
+	Related                 []RelatedResourceSpecApplyConfiguration     `json:"related,omitempty"`
+	RelatedObjectReferences *v1alpha1.RelatedObjectReferencesMode       `json:"relatedObjectReferences,omitempty"`
+	SchemaUpdatePolicy      *v1alpha1.SchemaUpdatePolicy                `json:"schemaUpdatePolicy,omitempty"`
+	DeletionPolicy          *v1alpha1.DeletionPolicy                    `json:"deletionPolicy,omitempty"`
+	Profile                 *string                                     `json:"profile,omitempty"`
+	Unpublish               *bool                                       `json:"unpublish,omitempty"`
 }
 
 // PublishedResourceSpecApplyConfiguration constructs a declarative configuration of the PublishedResourceSpec type for use with
@@ -44,6 +71,22 @@ func (b *PublishedResourceSpecApplyConfiguration) WithResource(value *SourceReso
 	return b
 }
 
+// WithOrigin sets the Origin field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Origin field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithOrigin(value v1alpha1.PublishedResourceOrigin) *PublishedResourceSpecApplyConfiguration {
+	b.Origin = &value
+	return b
+}
+
+// WithPaused sets the Paused field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Paused field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithPaused(value bool) *PublishedResourceSpecApplyConfiguration {
+	b.Paused = &value
+	return b
+}
+
 // WithFilter sets the Filter field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Filter field is set to the value of the last call.
@@ -68,6 +111,14 @@ func (b *PublishedResourceSpecApplyConfiguration) WithEnableWorkspacePaths(value
 	return b
 }
 
+// WithEnableRemoteEvents sets the EnableRemoteEvents field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableRemoteEvents field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithEnableRemoteEvents(value bool) *PublishedResourceSpecApplyConfiguration {
+	b.EnableRemoteEvents = &value
+	return b
+}
+
 // WithProjection sets the Projection field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Projection field is set to the value of the last call.
@@ -76,6 +127,22 @@ func (b *PublishedResourceSpecApplyConfiguration) WithProjection(value *Resource
 	return b
 }
 
+// WithProjectionChangePolicy sets the ProjectionChangePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProjectionChangePolicy field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithProjectionChangePolicy(value v1alpha1.ProjectionChangePolicy) *PublishedResourceSpecApplyConfiguration {
+	b.ProjectionChangePolicy = &value
+	return b
+}
+
+// WithSchemaProjection sets the SchemaProjection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchemaProjection field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithSchemaProjection(value *SchemaProjectionApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.SchemaProjection = value
+	return b
+}
+
 // WithMutation sets the Mutation field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Mutation field is set to the value of the last call.
@@ -84,6 +151,119 @@ func (b *PublishedResourceSpecApplyConfiguration) WithMutation(value *ResourceMu
 	return b
 }
 
+// WithStatusProjection sets the StatusProjection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StatusProjection field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithStatusProjection(value *StatusProjectionApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.StatusProjection = value
+	return b
+}
+
+// WithImmutableFields adds the given value to the ImmutableFields field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImmutableFields field.
+func (b *PublishedResourceSpecApplyConfiguration) WithImmutableFields(values ...string) *PublishedResourceSpecApplyConfiguration {
+	for i := range values {
+		b.ImmutableFields = append(b.ImmutableFields, values[i])
+	}
+	return b
+}
+
+// WithBidirectionalFields adds the given value to the BidirectionalFields field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the BidirectionalFields field.
+func (b *PublishedResourceSpecApplyConfiguration) WithBidirectionalFields(values ...string) *PublishedResourceSpecApplyConfiguration {
+	for i := range values {
+		b.BidirectionalFields = append(b.BidirectionalFields, values[i])
+	}
+	return b
+}
+
+// WithNamespaceLabels adds the given value to the NamespaceLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NamespaceLabels field.
+func (b *PublishedResourceSpecApplyConfiguration) WithNamespaceLabels(values ...*NamespaceLabelMappingApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaceLabels")
+		}
+		b.NamespaceLabels = append(b.NamespaceLabels, *values[i])
+	}
+	return b
+}
+
+// WithNamespaceSync sets the NamespaceSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSync field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithNamespaceSync(value *NamespaceSyncApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.NamespaceSync = value
+	return b
+}
+
+// WithMetadataSync sets the MetadataSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetadataSync field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithMetadataSync(value *MetadataSyncPolicyApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.MetadataSync = value
+	return b
+}
+
+// WithProvenance sets the Provenance field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provenance field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithProvenance(value *ProvenancePolicyApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.Provenance = value
+	return b
+}
+
+// WithStateNamespace sets the StateNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StateNamespace field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithStateNamespace(value string) *PublishedResourceSpecApplyConfiguration {
+	b.StateNamespace = &value
+	return b
+}
+
+// WithReadiness sets the Readiness field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Readiness field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithReadiness(value *ResourceReadinessApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.Readiness = value
+	return b
+}
+
+// WithInitialSync sets the InitialSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InitialSync field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithInitialSync(value *InitialSyncSettingsApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.InitialSync = value
+	return b
+}
+
+// WithSync sets the Sync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Sync field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithSync(value *SyncSettingsApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.Sync = value
+	return b
+}
+
+// WithLimits sets the Limits field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Limits field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithLimits(value *ObjectLimitsApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.Limits = value
+	return b
+}
+
+// WithOrphans sets the Orphans field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Orphans field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithOrphans(value *OrphanSettingsApplyConfiguration) *PublishedResourceSpecApplyConfiguration {
+	b.Orphans = value
+	return b
+}
+
 // WithRelated adds the given value to the Related field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Related field.
@@ -96,3 +276,43 @@ func (b *PublishedResourceSpecApplyConfiguration) WithRelated(values ...*Related
 	}
 	return b
 }
+
+// WithRelatedObjectReferences sets the RelatedObjectReferences field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RelatedObjectReferences field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithRelatedObjectReferences(value v1alpha1.RelatedObjectReferencesMode) *PublishedResourceSpecApplyConfiguration {
+	b.RelatedObjectReferences = &value
+	return b
+}
+
+// WithSchemaUpdatePolicy sets the SchemaUpdatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchemaUpdatePolicy field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithSchemaUpdatePolicy(value v1alpha1.SchemaUpdatePolicy) *PublishedResourceSpecApplyConfiguration {
+	b.SchemaUpdatePolicy = &value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithDeletionPolicy(value v1alpha1.DeletionPolicy) *PublishedResourceSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
+// WithProfile sets the Profile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Profile field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithProfile(value string) *PublishedResourceSpecApplyConfiguration {
+	b.Profile = &value
+	return b
+}
+
+// WithUnpublish sets the Unpublish field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unpublish field is set to the value of the last call.
+func (b *PublishedResourceSpecApplyConfiguration) WithUnpublish(value bool) *PublishedResourceSpecApplyConfiguration {
+	b.Unpublish = &value
+	return b
+}
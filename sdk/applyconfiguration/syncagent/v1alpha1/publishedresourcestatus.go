@@ -18,10 +18,19 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
 // PublishedResourceStatusApplyConfiguration represents a declarative configuration of the PublishedResourceStatus type for use
 // with apply.
 type PublishedResourceStatusApplyConfiguration struct {
-	ResourceSchemaName *string `json:"resourceSchemaName,omitempty"`
+	ResourceSchemaName  *string                                `json:"resourceSchemaName,omitempty"`
+	ProjectedGVK        *GroupVersionKindApplyConfiguration    `json:"projectedGVK,omitempty"`
+	ProjectionLeftovers []ProjectionLeftoverApplyConfiguration `json:"projectionLeftovers,omitempty"`
+	Unpublish           *UnpublishStatusApplyConfiguration     `json:"unpublish,omitempty"`
+	Conditions          []v1.ConditionApplyConfiguration       `json:"conditions,omitempty"`
+	Statistics          *SyncStatisticsApplyConfiguration      `json:"statistics,omitempty"`
 }
 
 // PublishedResourceStatusApplyConfiguration constructs a declarative configuration of the PublishedResourceStatus type for use with
@@ -37,3 +46,53 @@ func (b *PublishedResourceStatusApplyConfiguration) WithResourceSchemaName(value
 	b.ResourceSchemaName = &value
 	return b
 }
+
+// WithProjectedGVK sets the ProjectedGVK field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProjectedGVK field is set to the value of the last call.
+func (b *PublishedResourceStatusApplyConfiguration) WithProjectedGVK(value *GroupVersionKindApplyConfiguration) *PublishedResourceStatusApplyConfiguration {
+	b.ProjectedGVK = value
+	return b
+}
+
+// WithProjectionLeftovers adds the given value to the ProjectionLeftovers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ProjectionLeftovers field.
+func (b *PublishedResourceStatusApplyConfiguration) WithProjectionLeftovers(values ...*ProjectionLeftoverApplyConfiguration) *PublishedResourceStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithProjectionLeftovers")
+		}
+		b.ProjectionLeftovers = append(b.ProjectionLeftovers, *values[i])
+	}
+	return b
+}
+
+// WithUnpublish sets the Unpublish field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Unpublish field is set to the value of the last call.
+func (b *PublishedResourceStatusApplyConfiguration) WithUnpublish(value *UnpublishStatusApplyConfiguration) *PublishedResourceStatusApplyConfiguration {
+	b.Unpublish = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *PublishedResourceStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *PublishedResourceStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithStatistics sets the Statistics field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Statistics field is set to the value of the last call.
+func (b *PublishedResourceStatusApplyConfiguration) WithStatistics(value *SyncStatisticsApplyConfiguration) *PublishedResourceStatusApplyConfiguration {
+	b.Statistics = value
+	return b
+}
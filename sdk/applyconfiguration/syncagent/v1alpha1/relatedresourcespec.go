@@ -18,14 +18,25 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+)
+
 // RelatedResourceSpecApplyConfiguration represents a declarative configuration of the RelatedResourceSpec type for use
 // with apply.
 type RelatedResourceSpecApplyConfiguration struct {
-	Identifier *string                                  `json:"identifier,omitempty"`
-	Origin     *string                                  `json:"origin,omitempty"`
-	Kind       *string                                  `json:"kind,omitempty"`
-	Object     *RelatedResourceObjectApplyConfiguration `json:"object,omitempty"`
-	Mutation   *ResourceMutationSpecApplyConfiguration  `json:"mutation,omitempty"`
+	Identifier        *string                                              `json:"identifier,omitempty"`
+	Origin            *string                                              `json:"origin,omitempty"`
+	ConflictPolicy    *v1alpha1.RelatedResourceConflictPolicy              `json:"conflictPolicy,omitempty"`
+	APIGroup          *string                                              `json:"apiGroup,omitempty"`
+	Version           *string                                              `json:"version,omitempty"`
+	Kind              *string                                              `json:"kind,omitempty"`
+	Object            *RelatedResourceObjectApplyConfiguration             `json:"object,omitempty"`
+	Mutation          *ResourceMutationSpecApplyConfiguration              `json:"mutation,omitempty"`
+	Transform         *RelatedResourceDataTransformationApplyConfiguration `json:"transform,omitempty"`
+	DeletionPolicy    *v1alpha1.RelatedResourceDeletionPolicy              `json:"deletionPolicy,omitempty"`
+	SetOwnerReference *bool                                                `json:"setOwnerReference,omitempty"`
+	Related           []RelatedResourceSpecApplyConfiguration              `json:"related,omitempty"`
 }
 
 // RelatedResourceSpecApplyConfiguration constructs a declarative configuration of the RelatedResourceSpec type for use with
@@ -50,6 +61,30 @@ func (b *RelatedResourceSpecApplyConfiguration) WithOrigin(value string) *Relate
 	return b
 }
 
+// WithConflictPolicy sets the ConflictPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConflictPolicy field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithConflictPolicy(value v1alpha1.RelatedResourceConflictPolicy) *RelatedResourceSpecApplyConfiguration {
+	b.ConflictPolicy = &value
+	return b
+}
+
+// WithAPIGroup sets the APIGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIGroup field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithAPIGroup(value string) *RelatedResourceSpecApplyConfiguration {
+	b.APIGroup = &value
+	return b
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithVersion(value string) *RelatedResourceSpecApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
 // WithKind sets the Kind field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Kind field is set to the value of the last call.
@@ -73,3 +108,40 @@ func (b *RelatedResourceSpecApplyConfiguration) WithMutation(value *ResourceMuta
 	b.Mutation = value
 	return b
 }
+
+// WithTransform sets the Transform field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Transform field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithTransform(value *RelatedResourceDataTransformationApplyConfiguration) *RelatedResourceSpecApplyConfiguration {
+	b.Transform = value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithDeletionPolicy(value v1alpha1.RelatedResourceDeletionPolicy) *RelatedResourceSpecApplyConfiguration {
+	b.DeletionPolicy = &value
+	return b
+}
+
+// WithSetOwnerReference sets the SetOwnerReference field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SetOwnerReference field is set to the value of the last call.
+func (b *RelatedResourceSpecApplyConfiguration) WithSetOwnerReference(value bool) *RelatedResourceSpecApplyConfiguration {
+	b.SetOwnerReference = &value
+	return b
+}
+
+// WithRelated adds the given value to the Related field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Related field.
+func (b *RelatedResourceSpecApplyConfiguration) WithRelated(values ...*RelatedResourceSpecApplyConfiguration) *RelatedResourceSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRelated")
+		}
+		b.Related = append(b.Related, *values[i])
+	}
+	return b
+}
@@ -25,8 +25,11 @@ import (
 // ResourceFilterApplyConfiguration represents a declarative configuration of the ResourceFilter type for use
 // with apply.
 type ResourceFilterApplyConfiguration struct {
-	Namespace *v1.LabelSelectorApplyConfiguration `json:"namespace,omitempty"`
-	Resource  *v1.LabelSelectorApplyConfiguration `json:"resource,omitempty"`
+	Namespace        *v1.LabelSelectorApplyConfiguration    `json:"namespace,omitempty"`
+	NamespaceNames   *NamespaceNameFilterApplyConfiguration `json:"namespaceNames,omitempty"`
+	Resource         *v1.LabelSelectorApplyConfiguration    `json:"resource,omitempty"`
+	Expression       *string                                `json:"expression,omitempty"`
+	AnnotateExcluded *bool                                  `json:"annotateExcluded,omitempty"`
 }
 
 // ResourceFilterApplyConfiguration constructs a declarative configuration of the ResourceFilter type for use with
@@ -43,6 +46,14 @@ func (b *ResourceFilterApplyConfiguration) WithNamespace(value *v1.LabelSelector
 	return b
 }
 
+// WithNamespaceNames sets the NamespaceNames field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceNames field is set to the value of the last call.
+func (b *ResourceFilterApplyConfiguration) WithNamespaceNames(value *NamespaceNameFilterApplyConfiguration) *ResourceFilterApplyConfiguration {
+	b.NamespaceNames = value
+	return b
+}
+
 // WithResource sets the Resource field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Resource field is set to the value of the last call.
@@ -50,3 +61,19 @@ func (b *ResourceFilterApplyConfiguration) WithResource(value *v1.LabelSelectorA
 	b.Resource = value
 	return b
 }
+
+// WithExpression sets the Expression field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expression field is set to the value of the last call.
+func (b *ResourceFilterApplyConfiguration) WithExpression(value string) *ResourceFilterApplyConfiguration {
+	b.Expression = &value
+	return b
+}
+
+// WithAnnotateExcluded sets the AnnotateExcluded field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AnnotateExcluded field is set to the value of the last call.
+func (b *ResourceFilterApplyConfiguration) WithAnnotateExcluded(value bool) *ResourceFilterApplyConfiguration {
+	b.AnnotateExcluded = &value
+	return b
+}
@@ -18,12 +18,19 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
 // ResourceMutationApplyConfiguration represents a declarative configuration of the ResourceMutation type for use
 // with apply.
 type ResourceMutationApplyConfiguration struct {
-	Delete   *ResourceDeleteMutationApplyConfiguration   `json:"delete,omitempty"`
-	Regex    *ResourceRegexMutationApplyConfiguration    `json:"regex,omitempty"`
-	Template *ResourceTemplateMutationApplyConfiguration `json:"template,omitempty"`
+	Delete    *ResourceDeleteMutationApplyConfiguration    `json:"delete,omitempty"`
+	Regex     *ResourceRegexMutationApplyConfiguration     `json:"regex,omitempty"`
+	Template  *ResourceTemplateMutationApplyConfiguration  `json:"template,omitempty"`
+	Patch     []ResourcePatchOperationApplyConfiguration   `json:"patch,omitempty"`
+	Merge     *runtime.RawExtension                        `json:"merge,omitempty"`
+	Condition *ResourceMutationConditionApplyConfiguration `json:"condition,omitempty"`
 }
 
 // ResourceMutationApplyConfiguration constructs a declarative configuration of the ResourceMutation type for use with
@@ -55,3 +62,32 @@ func (b *ResourceMutationApplyConfiguration) WithTemplate(value *ResourceTemplat
 	b.Template = value
 	return b
 }
+
+// WithPatch adds the given value to the Patch field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Patch field.
+func (b *ResourceMutationApplyConfiguration) WithPatch(values ...*ResourcePatchOperationApplyConfiguration) *ResourceMutationApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPatch")
+		}
+		b.Patch = append(b.Patch, *values[i])
+	}
+	return b
+}
+
+// WithMerge sets the Merge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Merge field is set to the value of the last call.
+func (b *ResourceMutationApplyConfiguration) WithMerge(value runtime.RawExtension) *ResourceMutationApplyConfiguration {
+	b.Merge = &value
+	return b
+}
+
+// WithCondition sets the Condition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Condition field is set to the value of the last call.
+func (b *ResourceMutationApplyConfiguration) WithCondition(value *ResourceMutationConditionApplyConfiguration) *ResourceMutationApplyConfiguration {
+	b.Condition = value
+	return b
+}
@@ -21,8 +21,9 @@ package v1alpha1
 // ResourceMutationSpecApplyConfiguration represents a declarative configuration of the ResourceMutationSpec type for use
 // with apply.
 type ResourceMutationSpecApplyConfiguration struct {
-	Spec   []ResourceMutationApplyConfiguration `json:"spec,omitempty"`
-	Status []ResourceMutationApplyConfiguration `json:"status,omitempty"`
+	Spec         []ResourceMutationApplyConfiguration `json:"spec,omitempty"`
+	Status       []ResourceMutationApplyConfiguration `json:"status,omitempty"`
+	IgnoreFields []string                             `json:"ignoreFields,omitempty"`
 }
 
 // ResourceMutationSpecApplyConfiguration constructs a declarative configuration of the ResourceMutationSpec type for use with
@@ -56,3 +57,13 @@ func (b *ResourceMutationSpecApplyConfiguration) WithStatus(values ...*ResourceM
 	}
 	return b
 }
+
+// WithIgnoreFields adds the given value to the IgnoreFields field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IgnoreFields field.
+func (b *ResourceMutationSpecApplyConfiguration) WithIgnoreFields(values ...string) *ResourceMutationSpecApplyConfiguration {
+	for i := range values {
+		b.IgnoreFields = append(b.IgnoreFields, values[i])
+	}
+	return b
+}
@@ -21,8 +21,11 @@ package v1alpha1
 // ResourceNamingApplyConfiguration represents a declarative configuration of the ResourceNaming type for use
 // with apply.
 type ResourceNamingApplyConfiguration struct {
-	Name      *string `json:"name,omitempty"`
-	Namespace *string `json:"namespace,omitempty"`
+	Name              *string                       `json:"name,omitempty"`
+	Namespace         *string                       `json:"namespace,omitempty"`
+	NameTemplate      *string                       `json:"nameTemplate,omitempty"`
+	NamespaceTemplate *string                       `json:"namespaceTemplate,omitempty"`
+	Hash              *NamingHashApplyConfiguration `json:"hash,omitempty"`
 }
 
 // ResourceNamingApplyConfiguration constructs a declarative configuration of the ResourceNaming type for use with
@@ -46,3 +49,27 @@ func (b *ResourceNamingApplyConfiguration) WithNamespace(value string) *Resource
 	b.Namespace = &value
 	return b
 }
+
+// WithNameTemplate sets the NameTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NameTemplate field is set to the value of the last call.
+func (b *ResourceNamingApplyConfiguration) WithNameTemplate(value string) *ResourceNamingApplyConfiguration {
+	b.NameTemplate = &value
+	return b
+}
+
+// WithNamespaceTemplate sets the NamespaceTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceTemplate field is set to the value of the last call.
+func (b *ResourceNamingApplyConfiguration) WithNamespaceTemplate(value string) *ResourceNamingApplyConfiguration {
+	b.NamespaceTemplate = &value
+	return b
+}
+
+// WithHash sets the Hash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hash field is set to the value of the last call.
+func (b *ResourceNamingApplyConfiguration) WithHash(value *NamingHashApplyConfiguration) *ResourceNamingApplyConfiguration {
+	b.Hash = value
+	return b
+}
@@ -21,9 +21,10 @@ package v1alpha1
 // SourceResourceDescriptorApplyConfiguration represents a declarative configuration of the SourceResourceDescriptor type for use
 // with apply.
 type SourceResourceDescriptorApplyConfiguration struct {
-	APIGroup *string `json:"apiGroup,omitempty"`
-	Version  *string `json:"version,omitempty"`
-	Kind     *string `json:"kind,omitempty"`
+	APIGroup *string                                   `json:"apiGroup,omitempty"`
+	Version  *string                                   `json:"version,omitempty"`
+	Kind     *string                                   `json:"kind,omitempty"`
+	Versions []SourceResourceVersionApplyConfiguration `json:"versions,omitempty"`
 }
 
 // SourceResourceDescriptorApplyConfiguration constructs a declarative configuration of the SourceResourceDescriptor type for use with
@@ -55,3 +56,16 @@ func (b *SourceResourceDescriptorApplyConfiguration) WithKind(value string) *Sou
 	b.Kind = &value
 	return b
 }
+
+// WithVersions adds the given value to the Versions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Versions field.
+func (b *SourceResourceDescriptorApplyConfiguration) WithVersions(values ...*SourceResourceVersionApplyConfiguration) *SourceResourceDescriptorApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVersions")
+		}
+		b.Versions = append(b.Versions, *values[i])
+	}
+	return b
+}
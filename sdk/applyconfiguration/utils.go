@@ -34,14 +34,58 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=syncagent.kcp.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Announcement"):
+		return &syncagentv1alpha1.AnnouncementApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AnnouncementSpec"):
+		return &syncagentv1alpha1.AnnouncementSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AnnouncementStatus"):
+		return &syncagentv1alpha1.AnnouncementStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ControllerStops"):
+		return &syncagentv1alpha1.ControllerStopsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GroupVersionKind"):
+		return &syncagentv1alpha1.GroupVersionKindApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("InitialSyncSettings"):
+		return &syncagentv1alpha1.InitialSyncSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MetadataDirectionFilters"):
+		return &syncagentv1alpha1.MetadataDirectionFiltersApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MetadataKeyFilter"):
+		return &syncagentv1alpha1.MetadataKeyFilterApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MetadataSyncPolicy"):
+		return &syncagentv1alpha1.MetadataSyncPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceLabelMapping"):
+		return &syncagentv1alpha1.NamespaceLabelMappingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceNameFilter"):
+		return &syncagentv1alpha1.NamespaceNameFilterApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceSync"):
+		return &syncagentv1alpha1.NamespaceSyncApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamingHash"):
+		return &syncagentv1alpha1.NamingHashApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ObjectLimits"):
+		return &syncagentv1alpha1.ObjectLimitsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OrphanSettings"):
+		return &syncagentv1alpha1.OrphanSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ProjectionLeftover"):
+		return &syncagentv1alpha1.ProjectionLeftoverApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ProvenancePolicy"):
+		return &syncagentv1alpha1.ProvenancePolicyApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PublishedResource"):
 		return &syncagentv1alpha1.PublishedResourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PublishedResourceProfile"):
+		return &syncagentv1alpha1.PublishedResourceProfileApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PublishedResourceProfileSpec"):
+		return &syncagentv1alpha1.PublishedResourceProfileSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PublishedResourceSpec"):
 		return &syncagentv1alpha1.PublishedResourceSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PublishedResourceStatus"):
 		return &syncagentv1alpha1.PublishedResourceStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RegularExpression"):
 		return &syncagentv1alpha1.RegularExpressionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceComposedKey"):
+		return &syncagentv1alpha1.RelatedResourceComposedKeyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceDataTransformation"):
+		return &syncagentv1alpha1.RelatedResourceDataTransformationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceKeyRename"):
+		return &syncagentv1alpha1.RelatedResourceKeyRenameApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceObject"):
 		return &syncagentv1alpha1.RelatedResourceObjectApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceObjectReference"):
@@ -54,26 +98,56 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &syncagentv1alpha1.RelatedResourceSelectorRewriteApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RelatedResourceSpec"):
 		return &syncagentv1alpha1.RelatedResourceSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RequeueBackoff"):
+		return &syncagentv1alpha1.RequeueBackoffApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceDeleteMutation"):
 		return &syncagentv1alpha1.ResourceDeleteMutationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceFilter"):
 		return &syncagentv1alpha1.ResourceFilterApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceMutation"):
 		return &syncagentv1alpha1.ResourceMutationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceMutationCondition"):
+		return &syncagentv1alpha1.ResourceMutationConditionApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceMutationSpec"):
 		return &syncagentv1alpha1.ResourceMutationSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceNaming"):
 		return &syncagentv1alpha1.ResourceNamingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourcePatchOperation"):
+		return &syncagentv1alpha1.ResourcePatchOperationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceProjection"):
 		return &syncagentv1alpha1.ResourceProjectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceReadiness"):
+		return &syncagentv1alpha1.ResourceReadinessApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceRegexMutation"):
 		return &syncagentv1alpha1.ResourceRegexMutationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ResourceTemplateMutation"):
 		return &syncagentv1alpha1.ResourceTemplateMutationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceVersionProjection"):
+		return &syncagentv1alpha1.ResourceVersionProjectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SchemaDefault"):
+		return &syncagentv1alpha1.SchemaDefaultApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SchemaProjection"):
+		return &syncagentv1alpha1.SchemaProjectionApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("SourceResourceDescriptor"):
 		return &syncagentv1alpha1.SourceResourceDescriptorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SourceResourceVersion"):
+		return &syncagentv1alpha1.SourceResourceVersionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("StatusProjection"):
+		return &syncagentv1alpha1.StatusProjectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SyncAgentStatus"):
+		return &syncagentv1alpha1.SyncAgentStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SyncAgentStatusStatus"):
+		return &syncagentv1alpha1.SyncAgentStatusStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SyncLatency"):
+		return &syncagentv1alpha1.SyncLatencyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SyncSettings"):
+		return &syncagentv1alpha1.SyncSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SyncStatistics"):
+		return &syncagentv1alpha1.SyncStatisticsApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TemplateExpression"):
 		return &syncagentv1alpha1.TemplateExpressionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UnpublishStatus"):
+		return &syncagentv1alpha1.UnpublishStatusApplyConfiguration{}
 
 	}
 	return nil
@@ -48,6 +48,18 @@ func (c *SyncagentV1alpha1ClusterClient) PublishedResources() kcpsyncagentv1alph
 	return &publishedResourcesClusterClient{Fake: c.Fake}
 }
 
+func (c *SyncagentV1alpha1ClusterClient) SyncAgentStatuses() kcpsyncagentv1alpha1.SyncAgentStatusClusterInterface {
+	return &syncAgentStatusesClusterClient{Fake: c.Fake}
+}
+
+func (c *SyncagentV1alpha1ClusterClient) Announcements() kcpsyncagentv1alpha1.AnnouncementClusterInterface {
+	return &announcementsClusterClient{Fake: c.Fake}
+}
+
+func (c *SyncagentV1alpha1ClusterClient) PublishedResourceProfiles() kcpsyncagentv1alpha1.PublishedResourceProfileClusterInterface {
+	return &publishedResourceProfilesClusterClient{Fake: c.Fake}
+}
+
 var _ syncagentv1alpha1.SyncagentV1alpha1Interface = (*SyncagentV1alpha1Client)(nil)
 
 type SyncagentV1alpha1Client struct {
@@ -63,3 +75,15 @@ func (c *SyncagentV1alpha1Client) RESTClient() rest.Interface {
 func (c *SyncagentV1alpha1Client) PublishedResources() syncagentv1alpha1.PublishedResourceInterface {
 	return &publishedResourcesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
 }
+
+func (c *SyncagentV1alpha1Client) SyncAgentStatuses() syncagentv1alpha1.SyncAgentStatusInterface {
+	return &syncAgentStatusesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
+func (c *SyncagentV1alpha1Client) Announcements() syncagentv1alpha1.AnnouncementInterface {
+	return &announcementsClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
+
+func (c *SyncagentV1alpha1Client) PublishedResourceProfiles() syncagentv1alpha1.PublishedResourceProfileInterface {
+	return &publishedResourceProfilesClient{Fake: c.Fake, ClusterPath: c.ClusterPath}
+}
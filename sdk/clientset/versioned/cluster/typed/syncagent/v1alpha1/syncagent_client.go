@@ -35,6 +35,9 @@ import (
 type SyncagentV1alpha1ClusterInterface interface {
 	SyncagentV1alpha1ClusterScoper
 	PublishedResourcesClusterGetter
+	SyncAgentStatusesClusterGetter
+	AnnouncementsClusterGetter
+	PublishedResourceProfilesClusterGetter
 }
 
 type SyncagentV1alpha1ClusterScoper interface {
@@ -56,6 +59,18 @@ func (c *SyncagentV1alpha1ClusterClient) PublishedResources() PublishedResourceC
 	return &publishedResourcesClusterInterface{clientCache: c.clientCache}
 }
 
+func (c *SyncagentV1alpha1ClusterClient) SyncAgentStatuses() SyncAgentStatusClusterInterface {
+	return &syncAgentStatusesClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *SyncagentV1alpha1ClusterClient) Announcements() AnnouncementClusterInterface {
+	return &announcementsClusterInterface{clientCache: c.clientCache}
+}
+
+func (c *SyncagentV1alpha1ClusterClient) PublishedResourceProfiles() PublishedResourceProfileClusterInterface {
+	return &publishedResourceProfilesClusterInterface{clientCache: c.clientCache}
+}
+
 // NewForConfig creates a new SyncagentV1alpha1ClusterClient for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -33,6 +33,18 @@ func (c *FakeSyncagentV1alpha1) PublishedResources() v1alpha1.PublishedResourceI
 	return &FakePublishedResources{c}
 }
 
+func (c *FakeSyncagentV1alpha1) SyncAgentStatuses() v1alpha1.SyncAgentStatusInterface {
+	return &FakeSyncAgentStatuses{c}
+}
+
+func (c *FakeSyncagentV1alpha1) Announcements() v1alpha1.AnnouncementInterface {
+	return &FakeAnnouncements{c}
+}
+
+func (c *FakeSyncagentV1alpha1) PublishedResourceProfiles() v1alpha1.PublishedResourceProfileInterface {
+	return &FakePublishedResourceProfiles{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeSyncagentV1alpha1) RESTClient() rest.Interface {
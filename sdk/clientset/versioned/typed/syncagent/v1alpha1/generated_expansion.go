@@ -19,3 +19,9 @@ limitations under the License.
 package v1alpha1
 
 type PublishedResourceExpansion interface{}
+
+type SyncAgentStatusExpansion interface{}
+
+type AnnouncementExpansion interface{}
+
+type PublishedResourceProfileExpansion interface{}
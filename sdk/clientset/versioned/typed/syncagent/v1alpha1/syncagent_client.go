@@ -31,6 +31,9 @@ import (
 type SyncagentV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	PublishedResourcesGetter
+	SyncAgentStatusesGetter
+	AnnouncementsGetter
+	PublishedResourceProfilesGetter
 }
 
 // SyncagentV1alpha1Client is used to interact with features provided by the syncagent.kcp.io group.
@@ -42,6 +45,18 @@ func (c *SyncagentV1alpha1Client) PublishedResources() PublishedResourceInterfac
 	return newPublishedResources(c)
 }
 
+func (c *SyncagentV1alpha1Client) SyncAgentStatuses() SyncAgentStatusInterface {
+	return newSyncAgentStatuses(c)
+}
+
+func (c *SyncagentV1alpha1Client) Announcements() AnnouncementInterface {
+	return newAnnouncements(c)
+}
+
+func (c *SyncagentV1alpha1Client) PublishedResourceProfiles() PublishedResourceProfileInterface {
+	return newPublishedResourceProfiles(c)
+}
+
 // NewForConfig creates a new SyncagentV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crypto provides the hash functions the Sync Agent uses to derive names
+// and checksums, so that external tools can compute the same values.
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Algorithm is a hash algorithm supported by HashWith.
+type Algorithm string
+
+const (
+	SHA1   Algorithm = "sha1"
+	SHA256 Algorithm = "sha256"
+)
+
+// Encoding is how HashWith encodes the hash sum.
+type Encoding string
+
+const (
+	Hex    Encoding = "hex"
+	Base36 Encoding = "base36"
+)
+
+func Hash(data any) string {
+	return HashWith(SHA1, Hex, data)
+}
+
+func ShortHash(data any) string {
+	return Hash(data)[:20]
+}
+
+// HashWith hashes the given data using the algorithm and returns the encoded
+// sum. Empty or unknown algorithms and encodings fall back to SHA-1 and hex.
+// The result always has the length returned by EncodedLength.
+func HashWith(algorithm Algorithm, encoding Encoding, data any) string {
+	hash := newHash(algorithm)
+
+	var err error
+	switch asserted := data.(type) {
+	case string:
+		_, err = hash.Write([]byte(asserted))
+	case []byte:
+		_, err = hash.Write(asserted)
+	default:
+		err = json.NewEncoder(hash).Encode(data)
+	}
+
+	if err != nil {
+		// This is not something that should ever happen at runtime and is also not
+		// something we can really gracefully handle, so crashing and restarting might
+		// be a good way to signal the service owner that something is up.
+		panic(fmt.Sprintf("Failed to hash: %v", err))
+	}
+
+	sum := hash.Sum(nil)
+
+	if encoding == Base36 {
+		encoded := new(big.Int).SetBytes(sum).Text(36)
+
+		// pad with leading zeros so all hashes have the same length
+		return strings.Repeat("0", EncodedLength(algorithm, encoding)-len(encoded)) + encoded
+	}
+
+	return hex.EncodeToString(sum)
+}
+
+// EncodedLength returns the number of characters of hashes returned by HashWith.
+func EncodedLength(algorithm Algorithm, encoding Encoding) int {
+	bits := newHash(algorithm).Size() * 8
+
+	if encoding == Base36 {
+		return int(math.Ceil(float64(bits) / math.Log2(36)))
+	}
+
+	return bits / 4
+}
+
+func newHash(algorithm Algorithm) hash.Hash {
+	if algorithm == SHA256 {
+		return sha256.New()
+	}
+
+	return sha1.New()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filter evaluates CEL expressions that decide whether objects in kcp
+// should be synchronized.
+package filter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Expression is a compiled filter expression. It has access to the object in
+// kcp via the `object` variable and to its namespace via `namespaceObject`
+// (which is null for cluster-scoped objects) and must evaluate to a boolean.
+type Expression struct {
+	program cel.Program
+}
+
+// NewEnv returns the CEL environment used for filter expressions.
+func NewEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("namespaceObject", cel.DynType),
+	)
+}
+
+// NewExpression compiles the given expression.
+func NewExpression(expression string) (*Expression, error) {
+	env, err := NewEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, issues := env.Compile(strings.TrimSpace(expression))
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must evaluate to a boolean, but returns %v", ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create program: %w", err)
+	}
+
+	return &Expression{program: program}, nil
+}
+
+// Matches evaluates the expression for the given object and its namespace,
+// which can be nil.
+func (e *Expression) Matches(ctx context.Context, obj *unstructured.Unstructured, namespace *corev1.Namespace) (bool, error) {
+	var ns any = types.NullValue
+	if namespace != nil {
+		converted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(namespace)
+		if err != nil {
+			return false, fmt.Errorf("failed to convert namespace: %w", err)
+		}
+
+		ns = converted
+	}
+
+	out, _, err := e.program.ContextEval(ctx, map[string]any{
+		"object":          obj.Object,
+		"namespaceObject": ns,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression: %w", err)
+	}
+
+	matches, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression did not evaluate to a boolean, but %v", out.Type())
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExpression(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Thing",
+		"metadata": map[string]any{
+			"name":      "my-thing",
+			"namespace": "default",
+			"labels": map[string]any{
+				"env": "prod",
+			},
+		},
+		"spec": map[string]any{
+			"size": int64(12),
+		},
+	}}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+			Labels: map[string]string{
+				"tier": "gold",
+			},
+		},
+	}
+
+	testcases := []struct {
+		name       string
+		expression string
+		namespace  *corev1.Namespace
+		expected   bool
+		compileErr bool
+		evalErr    bool
+	}{
+		{
+			name:       "matching object",
+			expression: `object.spec.size > 10 && object.metadata.labels.env != "sandbox"`,
+			expected:   true,
+		},
+		{
+			name:       "non-matching object",
+			expression: `object.spec.size > 20`,
+			expected:   false,
+		},
+		{
+			name:       "namespace is accessible",
+			expression: `namespaceObject.metadata.labels.tier == "gold"`,
+			namespace:  namespace,
+			expected:   true,
+		},
+		{
+			name:       "namespace is null for cluster-scoped objects",
+			expression: `namespaceObject == null`,
+			expected:   true,
+		},
+		{
+			name:       "syntax error",
+			expression: `object.spec.size >`,
+			compileErr: true,
+		},
+		{
+			name:       "non-boolean result",
+			expression: `object.metadata.name + "x"`,
+			compileErr: true,
+		},
+		{
+			name:       "dynamic non-boolean result",
+			expression: `object.spec.size`,
+			evalErr:    true,
+		},
+		{
+			name:       "missing field",
+			expression: `object.spec.color == "red"`,
+			evalErr:    true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			expr, err := NewExpression(testcase.expression)
+			if (err != nil) != testcase.compileErr {
+				t.Fatalf("Expected compile error = %v, got %v", testcase.compileErr, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			matches, err := expr.Matches(context.Background(), obj, testcase.namespace)
+			if (err != nil) != testcase.evalErr {
+				t.Fatalf("Expected evaluation error = %v, got %v", testcase.evalErr, err)
+			}
+
+			if err == nil && matches != testcase.expected {
+				t.Fatalf("Expected %v, got %v", testcase.expected, matches)
+			}
+		})
+	}
+}
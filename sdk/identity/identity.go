@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package identity provides helpers to map between the identity of a published
+// resource on the service cluster and its projected identity in kcp, as recorded
+// by the Sync Agent in annotations on APIResourceSchemas and local objects.
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Identity describes both identities of a published resource.
+type Identity struct {
+	// PublishedResource is the name of the PublishedResource on the service cluster.
+	PublishedResource string
+	// Source is the GVK of the resource on the service cluster.
+	Source schema.GroupVersionKind
+	// Projected is the GVK of the resource in kcp.
+	Projected schema.GroupVersionKind
+}
+
+// Annotations returns the annotations that record this identity.
+func (i Identity) Annotations() map[string]string {
+	return map[string]string{
+		syncagentv1alpha1.PublishedResourceAnnotation: i.PublishedResource,
+		syncagentv1alpha1.SourceGVKAnnotation:         FormatGVK(i.Source),
+		syncagentv1alpha1.ProjectedGVKAnnotation:      FormatGVK(i.Projected),
+	}
+}
+
+// FromAnnotations parses the identity annotations. If none of the annotations exist,
+// nil is returned.
+func FromAnnotations(annotations map[string]string) (*Identity, error) {
+	pubRes, hasPubRes := annotations[syncagentv1alpha1.PublishedResourceAnnotation]
+	source, hasSource := annotations[syncagentv1alpha1.SourceGVKAnnotation]
+	projected, hasProjected := annotations[syncagentv1alpha1.ProjectedGVKAnnotation]
+
+	if !hasPubRes && !hasSource && !hasProjected {
+		return nil, nil
+	}
+
+	if !hasPubRes || !hasSource || !hasProjected {
+		return nil, errors.New("identity annotations are incomplete")
+	}
+
+	sourceGVK, err := ParseGVK(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source GVK: %w", err)
+	}
+
+	projectedGVK, err := ParseGVK(projected)
+	if err != nil {
+		return nil, fmt.Errorf("invalid projected GVK: %w", err)
+	}
+
+	return &Identity{
+		PublishedResource: pubRes,
+		Source:            sourceGVK,
+		Projected:         projectedGVK,
+	}, nil
+}
+
+// FormatGVK returns the GVK in the form "Kind.version.group" (e.g. "Deployment.v1.apps").
+// For the core API group, the group is omitted (e.g. "Pod.v1").
+func FormatGVK(gvk schema.GroupVersionKind) string {
+	if gvk.Group == "" {
+		return fmt.Sprintf("%s.%s", gvk.Kind, gvk.Version)
+	}
+
+	return fmt.Sprintf("%s.%s.%s", gvk.Kind, gvk.Version, gvk.Group)
+}
+
+// ParseGVK parses a GVK in the format created by FormatGVK.
+func ParseGVK(s string) (schema.GroupVersionKind, error) {
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("%q is not in the form Kind.version[.group]", s)
+	}
+
+	gvk := schema.GroupVersionKind{
+		Kind:    parts[0],
+		Version: parts[1],
+	}
+
+	if len(parts) == 3 {
+		gvk.Group = parts[2]
+	}
+
+	return gvk, nil
+}
@@ -88,6 +88,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=syncagent.kcp.io, Version=V1alpha1
 	case syncagentv1alpha1.SchemeGroupVersion.WithResource("publishedresources"):
 		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Syncagent().V1alpha1().PublishedResources().Informer()}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("syncagentstatuses"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Syncagent().V1alpha1().SyncAgentStatuses().Informer()}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("announcements"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Syncagent().V1alpha1().Announcements().Informer()}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("publishedresourceprofiles"):
+		return &genericClusterInformer{resource: resource.GroupResource(), informer: f.Syncagent().V1alpha1().PublishedResourceProfiles().Informer()}, nil
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
@@ -101,6 +107,15 @@ func (f *sharedScopedInformerFactory) ForResource(resource schema.GroupVersionRe
 	case syncagentv1alpha1.SchemeGroupVersion.WithResource("publishedresources"):
 		informer := f.Syncagent().V1alpha1().PublishedResources().Informer()
 		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("syncagentstatuses"):
+		informer := f.Syncagent().V1alpha1().SyncAgentStatuses().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("announcements"):
+		informer := f.Syncagent().V1alpha1().Announcements().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
+	case syncagentv1alpha1.SchemeGroupVersion.WithResource("publishedresourceprofiles"):
+		informer := f.Syncagent().V1alpha1().PublishedResourceProfiles().Informer()
+		return &genericInformer{lister: cache.NewGenericLister(informer.GetIndexer(), resource.GroupResource()), informer: informer}, nil
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
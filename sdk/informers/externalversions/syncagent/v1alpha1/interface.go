@@ -28,6 +28,12 @@ import (
 type ClusterInterface interface {
 	// PublishedResources returns a PublishedResourceClusterInformer
 	PublishedResources() PublishedResourceClusterInformer
+	// SyncAgentStatuses returns a SyncAgentStatusClusterInformer
+	SyncAgentStatuses() SyncAgentStatusClusterInformer
+	// Announcements returns a AnnouncementClusterInformer
+	Announcements() AnnouncementClusterInformer
+	// PublishedResourceProfiles returns a PublishedResourceProfileClusterInformer
+	PublishedResourceProfiles() PublishedResourceProfileClusterInformer
 }
 
 type version struct {
@@ -45,9 +51,30 @@ func (v *version) PublishedResources() PublishedResourceClusterInformer {
 	return &publishedResourceClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// SyncAgentStatuses returns a SyncAgentStatusClusterInformer
+func (v *version) SyncAgentStatuses() SyncAgentStatusClusterInformer {
+	return &syncAgentStatusClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Announcements returns a AnnouncementClusterInformer
+func (v *version) Announcements() AnnouncementClusterInformer {
+	return &announcementClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PublishedResourceProfiles returns a PublishedResourceProfileClusterInformer
+func (v *version) PublishedResourceProfiles() PublishedResourceProfileClusterInformer {
+	return &publishedResourceProfileClusterInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 type Interface interface {
 	// PublishedResources returns a PublishedResourceInformer
 	PublishedResources() PublishedResourceInformer
+	// SyncAgentStatuses returns a SyncAgentStatusInformer
+	SyncAgentStatuses() SyncAgentStatusInformer
+	// Announcements returns a AnnouncementInformer
+	Announcements() AnnouncementInformer
+	// PublishedResourceProfiles returns a PublishedResourceProfileInformer
+	PublishedResourceProfiles() PublishedResourceProfileInformer
 }
 
 type scopedVersion struct {
@@ -65,3 +92,18 @@ func NewScoped(f internalinterfaces.SharedScopedInformerFactory, namespace strin
 func (v *scopedVersion) PublishedResources() PublishedResourceInformer {
 	return &publishedResourceScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// SyncAgentStatuses returns a SyncAgentStatusInformer
+func (v *scopedVersion) SyncAgentStatuses() SyncAgentStatusInformer {
+	return &syncAgentStatusScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Announcements returns a AnnouncementInformer
+func (v *scopedVersion) Announcements() AnnouncementInformer {
+	return &announcementScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// PublishedResourceProfiles returns a PublishedResourceProfileInformer
+func (v *scopedVersion) PublishedResourceProfiles() PublishedResourceProfileInformer {
+	return &publishedResourceProfileScopedInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
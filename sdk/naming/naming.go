@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package naming provides helpers to determine the names the Sync Agent uses
+// for local copies of objects in kcp workspaces.
+package naming
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultScheme is the naming scheme used when a PublishedResource does not
+// configure its own naming rules.
+var DefaultScheme = syncagentv1alpha1.ResourceNaming{
+	Namespace: syncagentv1alpha1.PlaceholderRemoteClusterName,
+	Name:      fmt.Sprintf("%s-%s", syncagentv1alpha1.PlaceholderRemoteNamespaceHash, syncagentv1alpha1.PlaceholderRemoteNameHash),
+}
+
+// LocalObjectName returns the namespace and name that the local copy of the given
+// remote object will have on the service cluster. Note that for cluster-scoped
+// local resources, the namespace is ignored by the Sync Agent. The workspace path
+// is only required if the naming rules make use of it (see UsesWorkspacePath).
+// An error is returned if a naming template cannot be evaluated.
+func LocalObjectName(naming *syncagentv1alpha1.ResourceNaming, clusterName logicalcluster.Name, workspacePath logicalcluster.Path, remoteObject metav1.Object) (types.NamespacedName, error) {
+	if naming == nil {
+		naming = &syncagentv1alpha1.ResourceNaming{}
+	}
+
+	hash := hashFunc(naming.Hash)
+
+	replacer := strings.NewReplacer(
+		// order of elements is important here, "$fooHash" needs to be defined before "$foo"
+		syncagentv1alpha1.PlaceholderRemoteClusterName, clusterName.String(),
+		syncagentv1alpha1.PlaceholderRemoteWorkspacePathHash, hash(workspacePath.String()),
+		syncagentv1alpha1.PlaceholderRemoteWorkspacePath, strings.ReplaceAll(workspacePath.String(), ":", "-"),
+		syncagentv1alpha1.PlaceholderRemoteNamespaceHash, hash(remoteObject.GetNamespace()),
+		syncagentv1alpha1.PlaceholderRemoteNamespace, remoteObject.GetNamespace(),
+		syncagentv1alpha1.PlaceholderRemoteNameHash, hash(remoteObject.GetName()),
+		syncagentv1alpha1.PlaceholderRemoteName, remoteObject.GetName(),
+	)
+
+	data := TemplateContext{
+		ClusterName:     clusterName.String(),
+		WorkspacePath:   workspacePath.String(),
+		RemoteNamespace: remoteObject.GetNamespace(),
+		RemoteName:      remoteObject.GetName(),
+		Labels:          remoteObject.GetLabels(),
+		Annotations:     remoteObject.GetAnnotations(),
+	}
+
+	result := types.NamespacedName{}
+
+	if naming.NamespaceTemplate != "" {
+		namespace, err := renderTemplate(naming.NamespaceTemplate, data, hash)
+		if err != nil {
+			return result, fmt.Errorf("failed to evaluate namespace template: %w", err)
+		}
+
+		result.Namespace = namespace
+	} else {
+		pattern := naming.Namespace
+		if pattern == "" {
+			pattern = DefaultScheme.Namespace
+		}
+
+		result.Namespace = replacer.Replace(pattern)
+	}
+
+	if naming.NameTemplate != "" {
+		name, err := renderTemplate(naming.NameTemplate, data, hash)
+		if err != nil {
+			return result, fmt.Errorf("failed to evaluate name template: %w", err)
+		}
+
+		if name == "" {
+			return result, errors.New("name template resulted in an empty name")
+		}
+
+		result.Name = name
+	} else {
+		pattern := naming.Name
+		if pattern == "" {
+			pattern = DefaultScheme.Name
+		}
+
+		result.Name = replacer.Replace(pattern)
+	}
+
+	return result, nil
+}
+
+// DefaultHashLength is the number of characters used from hashes in local object
+// names if the PublishedResource does not configure a length.
+const DefaultHashLength = 20
+
+// hashFunc returns a function that hashes values according to the given settings.
+// Without any settings, the first 20 hex characters of the SHA-1 hash are used.
+func hashFunc(settings *syncagentv1alpha1.NamingHash) func(string) string {
+	if settings == nil {
+		settings = &syncagentv1alpha1.NamingHash{}
+	}
+
+	algorithm := crypto.Algorithm(settings.Algorithm)
+	encoding := crypto.Encoding(settings.Encoding)
+
+	length := settings.Length
+	if length <= 0 {
+		length = DefaultHashLength
+	}
+
+	// invalid lengths are rejected by the validation, but must not cause panics
+	length = min(length, MaxHashLength(settings.Algorithm, settings.Encoding))
+
+	return func(value string) string {
+		return crypto.HashWith(algorithm, encoding, value)[:length]
+	}
+}
+
+// MaxHashLength returns the length of the full encoded hash for the given algorithm
+// and encoding, which is the maximum length that can be configured.
+func MaxHashLength(algorithm syncagentv1alpha1.NamingHashAlgorithm, encoding syncagentv1alpha1.NamingHashEncoding) int {
+	return crypto.EncodedLength(crypto.Algorithm(algorithm), crypto.Encoding(encoding))
+}
+
+// UsesWorkspacePath returns true if the naming rules contain one of the workspace
+// path placeholders or templates referring to it, in which case the workspace path
+// must be known to determine local object names.
+func UsesWorkspacePath(naming *syncagentv1alpha1.ResourceNaming) bool {
+	if naming == nil {
+		return false
+	}
+
+	// "$remoteWorkspacePath" is a prefix of "$remoteWorkspacePathHash"
+	return strings.Contains(naming.Namespace, syncagentv1alpha1.PlaceholderRemoteWorkspacePath) ||
+		strings.Contains(naming.Name, syncagentv1alpha1.PlaceholderRemoteWorkspacePath) ||
+		strings.Contains(naming.NamespaceTemplate, ".WorkspacePath") ||
+		strings.Contains(naming.NameTemplate, ".WorkspacePath")
+}
+
+// UsesTemplates returns true if the naming rules use templates, which can depend on
+// the remote object's labels and annotations, in which case the remote object must
+// be known to determine local object names.
+func UsesTemplates(naming *syncagentv1alpha1.ResourceNaming) bool {
+	return naming != nil && (naming.NameTemplate != "" || naming.NamespaceTemplate != "")
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+)
+
+// TemplateContext is the data available to naming templates.
+type TemplateContext struct {
+	// ClusterName is the logical cluster name of the kcp workspace.
+	ClusterName string
+	// WorkspacePath is the workspace path (e.g. "root:org:team"), which is only
+	// available if workspace paths are enabled for the PublishedResource.
+	WorkspacePath string
+	// RemoteNamespace is the namespace of the object in kcp.
+	RemoteNamespace string
+	// RemoteName is the name of the object in kcp.
+	RemoteName string
+	// Labels are the labels of the object in kcp.
+	Labels map[string]string
+	// Annotations are the annotations of the object in kcp.
+	Annotations map[string]string
+}
+
+// ParseTemplate parses a naming template, so that syntax errors can be detected
+// before the template is used.
+func ParseTemplate(tpl string) error {
+	_, err := parseTemplate(tpl, hashFunc(nil))
+	return err
+}
+
+func parseTemplate(tpl string, hash func(string) string) (*template.Template, error) {
+	funcs := sprig.TxtFuncMap()
+	funcs["sha1"] = func(value string) string {
+		return crypto.HashWith(crypto.SHA1, crypto.Hex, value)
+	}
+	funcs["sha256"] = func(value string) string {
+		return crypto.HashWith(crypto.SHA256, crypto.Hex, value)
+	}
+	// hash uses the same settings as the hash placeholders
+	funcs["hash"] = hash
+
+	return template.New("naming").Funcs(funcs).Option("missingkey=error").Parse(tpl)
+}
+
+func renderTemplate(tpl string, data TemplateContext, hash func(string) string) (string, error) {
+	parsed, err := parseTemplate(tpl, hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", tpl, err)
+	}
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tpl, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package related provides helpers to discover the related objects (e.g. connection
+// details) that the Sync Agent has synchronized into kcp for a primary object.
+package related
+
+import (
+	"encoding/json"
+	"fmt"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+)
+
+// ObjectReference points to a related object in the same kcp workspace as the
+// primary object.
+type ObjectReference struct {
+	// Identifier is the identifier of the related resource in the PublishedResource.
+	Identifier string `json:"identifier"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// FromAnnotations parses the related objects annotation. If the annotation does not
+// exist, nil is returned.
+func FromAnnotations(annotations map[string]string) ([]ObjectReference, error) {
+	value, ok := annotations[syncagentv1alpha1.RelatedObjectsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	refs := []ObjectReference{}
+	if err := json.Unmarshal([]byte(value), &refs); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", syncagentv1alpha1.RelatedObjectsAnnotation, err)
+	}
+
+	return refs, nil
+}
+
+// Encode returns the value for the related objects annotation.
+func Encode(refs []ObjectReference) (string, error) {
+	encoded, err := json.Marshal(refs)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode related objects: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+// ForIdentifier returns all references that belong to the given related resource.
+func ForIdentifier(refs []ObjectReference, identifier string) []ObjectReference {
+	result := []ObjectReference{}
+
+	for _, ref := range refs {
+		if ref.Identifier == identifier {
+			result = append(result, ref)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// MigratingBackend reads states from a previous backend if they do not exist in the
+// current backend yet, and removes them from the previous backend once they have been
+// written to the current one.
+type MigratingBackend struct {
+	current  Backend
+	previous Backend
+}
+
+var _ Backend = &MigratingBackend{}
+
+// NewMigratingBackend returns a backend that migrates states from previous to current.
+func NewMigratingBackend(current, previous Backend) *MigratingBackend {
+	return &MigratingBackend{
+		current:  current,
+		previous: previous,
+	}
+}
+
+func (b *MigratingBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := b.current.Get(ctx, key)
+	if err != nil || data != nil {
+		return data, err
+	}
+
+	return b.previous.Get(ctx, key)
+}
+
+func (b *MigratingBackend) Put(ctx context.Context, key string, data []byte) error {
+	if err := b.current.Put(ctx, key, data); err != nil {
+		return err
+	}
+
+	if err := b.previous.Delete(ctx, key); err != nil {
+		return fmt.Errorf("failed to remove state from previous backend: %w", err)
+	}
+
+	return nil
+}
+
+func (b *MigratingBackend) Delete(ctx context.Context, key string) error {
+	if err := b.current.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	return b.previous.Delete(ctx, key)
+}
+
+func (b *MigratingBackend) Keys(ctx context.Context) ([]string, error) {
+	current, err := b.current.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := b.previous.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sets.List(sets.New(current...).Insert(previous...)), nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectStateBackend stores states compressed in ObjectState objects.
+type ObjectStateBackend struct {
+	client ctrlruntimeclient.Client
+	name   types.NamespacedName
+	labels labels.Set
+
+	// current serves the same purpose as in the SecretBackend
+	current *syncagentv1alpha1.ObjectState
+}
+
+var _ Backend = &ObjectStateBackend{}
+
+// NewObjectStateBackend returns a backend for the ObjectState with the given name.
+func NewObjectStateBackend(client ctrlruntimeclient.Client, name types.NamespacedName, stateLabels labels.Set) *ObjectStateBackend {
+	return &ObjectStateBackend{
+		client: client,
+		name:   name,
+		labels: stateLabels,
+	}
+}
+
+func (b *ObjectStateBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	state, err := b.getObjectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := state.Data[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return decompress(data)
+}
+
+func (b *ObjectStateBackend) Put(ctx context.Context, key string, data []byte) error {
+	state, err := b.getObjectState(ctx)
+	if err != nil {
+		return err
+	}
+
+	if unchanged(state.Data[key], data) && labels.Equals(state.Labels, b.labels) {
+		return nil
+	}
+
+	compressed, err := compress(data)
+	if err != nil {
+		return err
+	}
+
+	if state.Data == nil {
+		state.Data = map[string][]byte{}
+	}
+
+	state.Data[key] = compressed
+	state.Labels = b.labels
+
+	if state.Namespace == "" {
+		state.Name = b.name.Name
+		state.Namespace = b.name.Namespace
+
+		err = b.client.Create(ctx, state)
+	} else {
+		err = b.client.Update(ctx, state)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	b.current = state
+
+	return nil
+}
+
+func (b *ObjectStateBackend) Delete(ctx context.Context, key string) error {
+	state, err := b.getObjectState(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := state.Data[key]; !ok {
+		return nil
+	}
+
+	delete(state.Data, key)
+
+	if len(state.Data) == 0 {
+		err = b.client.Delete(ctx, state)
+		if ctrlruntimeclient.IgnoreNotFound(err) == nil {
+			b.current = &syncagentv1alpha1.ObjectState{}
+		}
+	} else {
+		err = b.client.Update(ctx, state)
+		if err == nil {
+			b.current = state
+		}
+	}
+
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *ObjectStateBackend) Keys(ctx context.Context) ([]string, error) {
+	state, err := b.getObjectState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedKeys(state.Data), nil
+}
+
+func (b *ObjectStateBackend) getObjectState(ctx context.Context) (*syncagentv1alpha1.ObjectState, error) {
+	if b.current == nil {
+		state := &syncagentv1alpha1.ObjectState{}
+		if err := b.client.Get(ctx, b.name, state); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+
+		b.current = state
+	}
+
+	return b.current.DeepCopy(), nil
+}
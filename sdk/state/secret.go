@@ -0,0 +1,364 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaxChunkSize is the maximum size of a single state in the state Secret. As
+// the entire Secret cannot be larger than 1 MiB, larger states are split into chunks
+// that are stored in separate Secrets, with only an index remaining in the state
+// Secret.
+const MaxChunkSize = 512 * 1024
+
+// chunkDataKey is the key in chunk Secrets that holds the chunk's data.
+const chunkDataKey = "chunk"
+
+// chunkIndexPrefix marks states that have been split into chunks. States are JSON
+// documents or gzip streams and can therefore never start with this prefix.
+var chunkIndexPrefix = []byte("chunks:")
+
+// chunkIndex is stored in place of a chunked state and lists the Secrets that
+// make up the state, in order.
+type chunkIndex struct {
+	// Hash is the hash of the entire (possibly compressed) state.
+	Hash   string   `json:"hash"`
+	Chunks []string `json:"chunks"`
+}
+
+func parseChunkIndex(data []byte) (*chunkIndex, error) {
+	if !bytes.HasPrefix(data, chunkIndexPrefix) {
+		return nil, nil
+	}
+
+	index := &chunkIndex{}
+	if err := json.Unmarshal(bytes.TrimPrefix(data, chunkIndexPrefix), index); err != nil {
+		return nil, fmt.Errorf("failed to decode chunk index: %w", err)
+	}
+
+	return index, nil
+}
+
+// SecretBackend stores states in Secrets, optionally compressed. States that are
+// too large for a single Secret are split into chunks.
+type SecretBackend struct {
+	client ctrlruntimeclient.Client
+	name   types.NamespacedName
+	labels labels.Set
+
+	// compress enables gzip compression for newly written states; compressed
+	// states are always read, regardless of this setting, and states larger than
+	// the CompressionThreshold are always compressed
+	compress bool
+
+	// current is the state Secret as it was last read or written by this backend.
+	// The client is usually backed by a cache, which might not have caught up with
+	// our own writes yet, so the Secret is only read once and then kept up to date.
+	// Concurrent modifications are detected by the resourceVersion precondition of
+	// the next Update. A Secret without a namespace does not exist (anymore).
+	current *corev1.Secret
+}
+
+var _ Backend = &SecretBackend{}
+
+// NewSecretBackend returns a backend for the state Secret with the given name.
+func NewSecretBackend(client ctrlruntimeclient.Client, name types.NamespacedName, stateLabels labels.Set, compress bool) *SecretBackend {
+	return &SecretBackend{
+		client:   client,
+		name:     name,
+		labels:   stateLabels,
+		compress: compress,
+	}
+}
+
+func (b *SecretBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	secret, err := b.getSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return b.readState(ctx, data)
+}
+
+func (b *SecretBackend) Put(ctx context.Context, key string, data []byte) error {
+	secret, err := b.getSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	// do not needlessly update the Secret if the state has not changed
+	previous := secret.Data[key]
+
+	if previous != nil && labels.Equals(secret.Labels, b.labels) {
+		if current, err := b.readState(ctx, previous); err == nil && bytes.Equal(current, data) {
+			return nil
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	if b.compress || len(data) > CompressionThreshold {
+		data, err = compress(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	secret.Labels = b.labels
+
+	// states that are too large even when compressed are split into chunks
+	if len(data) > MaxChunkSize {
+		// chunks are owned by the state Secret, so that they are garbage collected
+		// together with it, even if they end up not being referenced by it because
+		// the Update below fails; the Secret therefore has to exist first
+		if secret.Namespace == "" {
+			secret.Name = b.name.Name
+			secret.Namespace = b.name.Namespace
+
+			if err := b.client.Create(ctx, secret); err != nil {
+				return err
+			}
+
+			b.current = secret.DeepCopy()
+		}
+
+		data, err = b.writeChunks(ctx, secret, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	secret.Data[key] = data
+
+	if secret.Namespace == "" {
+		secret.Name = b.name.Name
+		secret.Namespace = b.name.Namespace
+
+		err = b.client.Create(ctx, secret)
+	} else {
+		err = b.client.Update(ctx, secret)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	b.current = secret
+
+	return b.deleteObsoleteChunks(ctx, previous, data)
+}
+
+func (b *SecretBackend) Delete(ctx context.Context, key string) error {
+	secret, err := b.getSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous, ok := secret.Data[key]
+	if !ok {
+		return nil
+	}
+
+	delete(secret.Data, key)
+
+	if len(secret.Data) == 0 {
+		err = b.client.Delete(ctx, secret)
+		if ctrlruntimeclient.IgnoreNotFound(err) == nil {
+			b.current = &corev1.Secret{}
+		}
+	} else {
+		err = b.client.Update(ctx, secret)
+		if err == nil {
+			b.current = secret
+		}
+	}
+
+	if ctrlruntimeclient.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	return b.deleteObsoleteChunks(ctx, previous, nil)
+}
+
+func (b *SecretBackend) Keys(ctx context.Context) ([]string, error) {
+	secret, err := b.getSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedKeys(secret.Data), nil
+}
+
+// getSecret returns the state Secret (or an empty Secret if it does not exist yet).
+// The Secret is only read from the client once, afterwards the version this backend
+// has last read or written is returned.
+func (b *SecretBackend) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	if b.current == nil {
+		secret := &corev1.Secret{}
+		if err := b.client.Get(ctx, b.name, secret); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+
+		b.current = secret
+	}
+
+	return b.current.DeepCopy(), nil
+}
+
+// readState returns the uncompressed state, reassembling it from its chunks if
+// necessary.
+func (b *SecretBackend) readState(ctx context.Context, stored []byte) ([]byte, error) {
+	index, err := parseChunkIndex(stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if index != nil {
+		stored, err = b.readChunks(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return decompress(stored)
+}
+
+func (b *SecretBackend) readChunks(ctx context.Context, index *chunkIndex) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, name := range index.Chunks {
+		chunk := &corev1.Secret{}
+		if err := b.client.Get(ctx, types.NamespacedName{Namespace: b.name.Namespace, Name: name}, chunk); err != nil {
+			return nil, fmt.Errorf("failed to get state chunk %s: %w", name, err)
+		}
+
+		buf.Write(chunk.Data[chunkDataKey])
+	}
+
+	data := buf.Bytes()
+	if crypto.ShortHash(data) != index.Hash {
+		return nil, fmt.Errorf("state chunks for %s are incomplete or corrupted", b.name.Name)
+	}
+
+	return data, nil
+}
+
+// writeChunks stores the data in chunk Secrets owned by the given state Secret and
+// returns the index to store in the state Secret instead. Chunks are named after
+// the hash of the data, so existing chunks never have to be updated.
+func (b *SecretBackend) writeChunks(ctx context.Context, owner *corev1.Secret, data []byte) ([]byte, error) {
+	index := chunkIndex{
+		Hash: crypto.ShortHash(data),
+	}
+
+	for i := 0; len(data) > 0; i++ {
+		size := min(len(data), MaxChunkSize)
+
+		chunk := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%d", b.name.Name, index.Hash, i),
+				Namespace: b.name.Namespace,
+				Labels:    b.chunkLabels(),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "Secret",
+					Name:       owner.Name,
+					UID:        owner.UID,
+				}},
+			},
+			Data: map[string][]byte{
+				chunkDataKey: data[:size],
+			},
+		}
+
+		if err := b.client.Create(ctx, chunk); err != nil && !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("failed to create state chunk: %w", err)
+		}
+
+		index.Chunks = append(index.Chunks, chunk.Name)
+		data = data[size:]
+	}
+
+	encoded, err := json.Marshal(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chunk index: %w", err)
+	}
+
+	return append(bytes.Clone(chunkIndexPrefix), encoded...), nil
+}
+
+// chunkLabels returns the labels for chunk Secrets, which are the same as for the
+// state Secret, except that they are marked as chunks instead of states.
+func (b *SecretBackend) chunkLabels() labels.Set {
+	chunkLabels := labels.Merge(b.labels, labels.Set{ChunkLabelName: LabelValue})
+	delete(chunkLabels, LabelName)
+
+	return chunkLabels
+}
+
+// deleteObsoleteChunks removes the chunk Secrets that were referenced by the
+// previous value, but are not referenced by the current value anymore.
+func (b *SecretBackend) deleteObsoleteChunks(ctx context.Context, previous, current []byte) error {
+	previousIndex, err := parseChunkIndex(previous)
+	if err != nil || previousIndex == nil {
+		return err
+	}
+
+	obsolete := sets.New(previousIndex.Chunks...)
+
+	currentIndex, err := parseChunkIndex(current)
+	if err != nil {
+		return err
+	}
+
+	if currentIndex != nil {
+		obsolete.Delete(currentIndex.Chunks...)
+	}
+
+	for _, name := range sets.List(obsolete) {
+		chunk := &corev1.Secret{}
+		chunk.Name = name
+		chunk.Namespace = b.name.Namespace
+
+		if err := b.client.Delete(ctx, chunk); ctrlruntimeclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete state chunk %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
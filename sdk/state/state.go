@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package state provides access to the last-known states of synced objects,
+// which the Sync Agent keeps on the service cluster to compute three-way merge
+// patches. All states that belong to the same primary object (i.e. the object
+// itself and all its related objects) are stored together in a single state
+// object (a Secret or an ObjectState), where they are identified by a key.
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/kcp-dev/logicalcluster/v3"
+
+	"github.com/kcp-dev/api-syncagent/sdk/crypto"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// LabelName is put on all state objects to allow for easier mass deletions
+	// if ever necessary.
+	LabelName = "syncagent.kcp.io/object-state"
+
+	// LabelValue is the value of the LabelName label.
+	LabelValue = "true"
+
+	// ChunkLabelName is put on the Secrets that hold chunks of large states instead
+	// of LabelName, so that chunks are never mistaken for state Secrets.
+	ChunkLabelName = "syncagent.kcp.io/object-state-chunk"
+)
+
+// CompressionThreshold is the size above which states are always compressed, even
+// if the backend is not configured to compress states, so that large objects do not
+// exceed the size limit of Secrets.
+const CompressionThreshold = 64 * 1024
+
+// gzipMagic are the first bytes of every gzip stream; states are JSON documents and
+// can therefore never start with these bytes.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// BackendType describes where the object states are stored.
+type BackendType string
+
+const (
+	// BackendSecret stores the states uncompressed in Secrets.
+	BackendSecret BackendType = "secret"
+	// BackendCompressedSecret stores the states gzip-compressed in Secrets.
+	BackendCompressedSecret BackendType = "compressed-secret"
+	// BackendObjectState stores the states compressed in ObjectState objects,
+	// which requires the ObjectState CRD to be installed on the service cluster.
+	BackendObjectState BackendType = "objectstate"
+)
+
+// BackendTypes lists all supported backends.
+var BackendTypes = []BackendType{
+	BackendSecret,
+	BackendCompressedSecret,
+	BackendObjectState,
+}
+
+// Backend reads and writes the states stored in a single state object. Backends
+// remember the version of the state object they have last seen and refuse to
+// work with a state object that was modified in the meantime, so that no stale
+// state is used or stored. A backend should therefore only be used for a single
+// reconciliation.
+type Backend interface {
+	// Get returns the uncompressed state for the given key, or nil if no state
+	// is stored for it.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores the uncompressed state for the given key.
+	Put(ctx context.Context, key string, data []byte) error
+	// Delete removes the state for the given key; the state object is deleted
+	// once it holds no more states.
+	Delete(ctx context.Context, key string) error
+	// Keys returns the keys of all states in the state object.
+	Keys(ctx context.Context) ([]string, error)
+}
+
+// NewBackend returns a backend of the given type for the state object with the
+// given name. The labels are put on the state object when states are written.
+func NewBackend(client ctrlruntimeclient.Client, backendType BackendType, name types.NamespacedName, stateLabels labels.Set) Backend {
+	switch backendType {
+	case BackendObjectState:
+		return NewObjectStateBackend(client, name, stateLabels)
+	case BackendCompressedSecret:
+		return NewSecretBackend(client, name, stateLabels, true)
+	default:
+		return NewSecretBackend(client, name, stateLabels, false)
+	}
+}
+
+// IsSecretBackend returns true if the backend type stores states in Secrets.
+// Secrets and compressed Secrets share the same objects, so switching between
+// them does not require a migration.
+func IsSecretBackend(backendType BackendType) bool {
+	return backendType != BackendObjectState
+}
+
+// ObjectName returns the name of the Secret or ObjectState that stores the
+// states for the given primary object and its related objects.
+func ObjectName(clusterName logicalcluster.Name, primaryObject *unstructured.Unstructured) string {
+	hash := crypto.ShortHash(map[string]any{
+		"apiVersion": primaryObject.GetAPIVersion(),
+		"kind":       primaryObject.GetKind(),
+		"namespace":  primaryObject.GetNamespace(),
+		"name":       primaryObject.GetName(),
+	})
+
+	return fmt.Sprintf("obj-state-%s-%s", clusterName, hash)
+}
+
+// stateKey has the same fields as the object keys used by the Sync Agent
+// internally, so that the hashes are identical.
+type stateKey struct {
+	ClusterName   logicalcluster.Name
+	WorkspacePath logicalcluster.Path
+	Namespace     string
+	Name          string
+}
+
+// Key returns the key under which the state of the given object is stored. The
+// cluster name is the logical cluster of the object's source side (empty for
+// objects that originate on the service cluster).
+func Key(clusterName logicalcluster.Name, obj metav1.Object) string {
+	return crypto.Hash(stateKey{
+		ClusterName:   clusterName,
+		WorkspacePath: logicalcluster.None,
+		Namespace:     obj.GetNamespace(),
+		Name:          obj.GetName(),
+	})
+}
+
+// OriginalKey returns the key under which the state of the given object is stored
+// in its original form, i.e. before any mutations were applied. Such states are only
+// stored for objects with immutable or bidirectional fields, which have to be
+// compared to the object in kcp.
+func OriginalKey(clusterName logicalcluster.Name, obj metav1.Object) string {
+	return Key(clusterName, obj) + ".original"
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress state: %w", err)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress state: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// IsCompressed returns true if the stored state is gzip-compressed.
+func IsCompressed(stored []byte) bool {
+	return bytes.HasPrefix(stored, gzipMagic)
+}
+
+// decompress returns the uncompressed state; uncompressed data is returned as-is.
+func decompress(data []byte) ([]byte, error) {
+	if !IsCompressed(data) {
+		return data, nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress state: %w", err)
+	}
+	defer r.Close()
+
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress state: %w", err)
+	}
+
+	return decompressed, nil
+}
+
+// unchanged returns true if the stored (possibly compressed) state is identical
+// to the given uncompressed state, in which case writing it again can be skipped.
+func unchanged(stored []byte, data []byte) bool {
+	if stored == nil {
+		return false
+	}
+
+	decompressed, err := decompress(stored)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(decompressed, data)
+}
+
+// sortedKeys returns the keys of a state object's data.
+func sortedKeys(data map[string][]byte) []string {
+	return slices.Sorted(maps.Keys(data))
+}
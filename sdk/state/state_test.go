@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(t *testing.T) ctrlruntimeclient.Client {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register core/v1: %v", err)
+	}
+
+	if err := syncagentv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to register syncagent/v1alpha1: %v", err)
+	}
+
+	return fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).Build()
+}
+
+func TestKeyAndObjectName(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Thing")
+	obj.SetNamespace("default")
+	obj.SetName("my-thing")
+
+	// the key and name must never change, or else all existing states would be lost
+	if key := Key("abc123", obj); key != "0004820cfa5b9aa9d24b22187ee979d5b0fb52d9" {
+		t.Errorf("Unexpected key %q.", key)
+	}
+
+	if name := ObjectName("abc123", obj); name != "obj-state-abc123-524e7e23779a63474922" {
+		t.Errorf("Unexpected object name %q.", name)
+	}
+}
+
+func TestBackends(t *testing.T) {
+	name := types.NamespacedName{Namespace: "kcp-system", Name: "obj-state-test"}
+	stateLabels := labels.Set{LabelName: LabelValue}
+
+	testcases := []struct {
+		name            string
+		backendType     BackendType
+		previousBackend BackendType
+	}{
+		{
+			name:        "Secret",
+			backendType: BackendSecret,
+		},
+		{
+			name:        "compressed Secret",
+			backendType: BackendCompressedSecret,
+		},
+		{
+			name:        "ObjectState",
+			backendType: BackendObjectState,
+		},
+		{
+			name:            "migration from Secret to ObjectState",
+			backendType:     BackendObjectState,
+			previousBackend: BackendSecret,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeClient(t)
+			newBackend := func() Backend {
+				current := NewBackend(client, testcase.backendType, name, stateLabels)
+				if testcase.previousBackend == "" {
+					return current
+				}
+
+				return NewMigratingBackend(current, NewBackend(client, testcase.previousBackend, name, stateLabels))
+			}
+
+			if testcase.previousBackend != "" {
+				if err := NewBackend(client, testcase.previousBackend, name, stateLabels).Put(ctx, "old", []byte(`{"old":true}`)); err != nil {
+					t.Fatalf("Failed to prime previous backend: %v", err)
+				}
+			}
+
+			// backends must not be reused across reconciliations, so every step uses a new one
+			if err := newBackend().Put(ctx, "a", []byte(`{"a":1}`)); err != nil {
+				t.Fatalf("Failed to put state: %v", err)
+			}
+
+			if err := newBackend().Put(ctx, "b", []byte(`{"b":2}`)); err != nil {
+				t.Fatalf("Failed to put state: %v", err)
+			}
+
+			data, err := newBackend().Get(ctx, "a")
+			if err != nil {
+				t.Fatalf("Failed to get state: %v", err)
+			}
+
+			if string(data) != `{"a":1}` {
+				t.Fatalf("Expected state %q, got %q.", `{"a":1}`, string(data))
+			}
+
+			keys, err := newBackend().Keys(ctx)
+			if err != nil {
+				t.Fatalf("Failed to list keys: %v", err)
+			}
+
+			if !slices.Contains(keys, "a") || !slices.Contains(keys, "b") {
+				t.Fatalf("Expected keys to contain a and b, got %v.", keys)
+			}
+
+			for _, key := range keys {
+				if err := newBackend().Delete(ctx, key); err != nil {
+					t.Fatalf("Failed to delete state %q: %v", key, err)
+				}
+			}
+
+			data, err = newBackend().Get(ctx, "b")
+			if err != nil {
+				t.Fatalf("Failed to get state: %v", err)
+			}
+
+			if data != nil {
+				t.Fatalf("Expected no state after deletion, got %q.", string(data))
+			}
+
+			// with all states gone, the state objects must have been removed
+			secrets := &corev1.SecretList{}
+			if err := client.List(ctx, secrets); err != nil {
+				t.Fatalf("Failed to list Secrets: %v", err)
+			}
+
+			objectStates := &syncagentv1alpha1.ObjectStateList{}
+			if err := client.List(ctx, objectStates); err != nil {
+				t.Fatalf("Failed to list ObjectStates: %v", err)
+			}
+
+			if count := len(secrets.Items) + len(objectStates.Items); count > 0 {
+				t.Fatalf("Expected all state objects to be deleted, but %d remain.", count)
+			}
+		})
+	}
+}
+
+// laggingClient serves reads from a cache that never catches up with the writes,
+// like an informer that has not yet seen the latest changes.
+type laggingClient struct {
+	ctrlruntimeclient.Client
+
+	cache ctrlruntimeclient.Reader
+}
+
+func (c *laggingClient) Get(ctx context.Context, key types.NamespacedName, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.GetOption) error {
+	return c.cache.Get(ctx, key, obj, opts...)
+}
+
+func TestBackendsWithLaggingCache(t *testing.T) {
+	name := types.NamespacedName{Namespace: "kcp-system", Name: "obj-state-test"}
+	stateLabels := labels.Set{LabelName: LabelValue}
+
+	testcases := []struct {
+		name        string
+		backendType BackendType
+	}{
+		{
+			name:        "Secret",
+			backendType: BackendSecret,
+		},
+		{
+			name:        "compressed Secret",
+			backendType: BackendCompressedSecret,
+		},
+		{
+			name:        "ObjectState",
+			backendType: BackendObjectState,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := &laggingClient{
+				Client: newFakeClient(t),
+				cache:  newFakeClient(t),
+			}
+
+			// a single backend is used for an entire reconciliation, during which
+			// multiple states are written
+			backend := NewBackend(client, testcase.backendType, name, stateLabels)
+
+			if err := backend.Put(ctx, "a", []byte(`{"a":1}`)); err != nil {
+				t.Fatalf("Failed to put first state: %v", err)
+			}
+
+			if err := backend.Put(ctx, "b", []byte(`{"b":2}`)); err != nil {
+				t.Fatalf("Failed to put second state: %v", err)
+			}
+
+			for key, expected := range map[string]string{"a": `{"a":1}`, "b": `{"b":2}`} {
+				data, err := backend.Get(ctx, key)
+				if err != nil {
+					t.Fatalf("Failed to get state %q: %v", key, err)
+				}
+
+				if string(data) != expected {
+					t.Fatalf("Expected state %q, got %q.", expected, string(data))
+				}
+			}
+
+			for _, key := range []string{"a", "b"} {
+				if err := backend.Delete(ctx, key); err != nil {
+					t.Fatalf("Failed to delete state %q: %v", key, err)
+				}
+			}
+
+			if err := backend.Put(ctx, "c", []byte(`{"c":3}`)); err != nil {
+				t.Fatalf("Failed to put state after deleting all others: %v", err)
+			}
+		})
+	}
+}
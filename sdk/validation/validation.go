@@ -0,0 +1,1068 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package validation provides functions to validate the Sync Agent's API types.
+// The same rules are enforced by the CRDs (as far as they can be expressed in
+// OpenAPI/CEL), but this package can also be used by other tools to validate
+// objects before they are applied and is used by the Sync Agent itself to reject
+// invalid PublishedResources early.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+	resourcefilter "github.com/kcp-dev/api-syncagent/sdk/filter"
+	"github.com/kcp-dev/api-syncagent/sdk/naming"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidatePublishedResource validates the spec of the given PublishedResource.
+func ValidatePublishedResource(pubRes *syncagentv1alpha1.PublishedResource) field.ErrorList {
+	return validatePublishedResourceSpec(&pubRes.Spec, field.NewPath("spec"))
+}
+
+// ValidatePublishedResourceProfile validates the spec of the given PublishedResourceProfile.
+func ValidatePublishedResourceProfile(profile *syncagentv1alpha1.PublishedResourceProfile) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateFilter(profile.Spec.Filter, specPath.Child("filter"))...)
+	allErrs = append(allErrs, validateNaming(profile.Spec.Naming, specPath.Child("naming"))...)
+	allErrs = append(allErrs, validateMutationSpec(profile.Spec.Mutation, specPath.Child("mutation"))...)
+	allErrs = append(allErrs, validateRelatedResources(profile.Spec.Related, specPath.Child("related"))...)
+
+	return allErrs
+}
+
+func validatePublishedResourceSpec(spec *syncagentv1alpha1.PublishedResourceSpec, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateSourceResource(spec.Resource, specPath.Child("resource"))...)
+	allErrs = append(allErrs, validateFilter(spec.Filter, specPath.Child("filter"))...)
+	allErrs = append(allErrs, validateProjection(spec.Projection, specPath.Child("projection"))...)
+	allErrs = append(allErrs, validateBuiltInResource(spec, specPath)...)
+	allErrs = append(allErrs, validateSchemaProjection(spec.SchemaProjection, specPath.Child("schemaProjection"))...)
+	allErrs = append(allErrs, validateMutationSpec(spec.Mutation, specPath.Child("mutation"))...)
+	allErrs = append(allErrs, validateReadiness(spec.Readiness, specPath.Child("readiness"))...)
+	allErrs = append(allErrs, validateRelatedResources(spec.Related, specPath.Child("related"))...)
+
+	allErrs = append(allErrs, validateNaming(spec.Naming, specPath.Child("naming"))...)
+	allErrs = append(allErrs, validateOrigin(spec, specPath)...)
+	allErrs = append(allErrs, validateSyncSettings(spec.Sync, specPath.Child("sync"))...)
+
+	if orphans := spec.Orphans; orphans != nil {
+		orphansPath := specPath.Child("orphans")
+
+		switch orphans.Policy {
+		case "", syncagentv1alpha1.OrphanPolicyReport, syncagentv1alpha1.OrphanPolicyDelete:
+		default:
+			allErrs = append(allErrs, field.NotSupported(orphansPath.Child("policy"), orphans.Policy, []syncagentv1alpha1.OrphanPolicy{
+				syncagentv1alpha1.OrphanPolicyReport,
+				syncagentv1alpha1.OrphanPolicyDelete,
+			}))
+		}
+
+		if orphans.Interval != nil && orphans.Interval.Duration < time.Minute {
+			allErrs = append(allErrs, field.Invalid(orphansPath.Child("interval"), orphans.Interval.Duration.String(), "must be at least 1m"))
+		}
+	}
+
+	if ns := spec.StateNamespace; ns != "" {
+		for _, msg := range utilvalidation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("stateNamespace"), ns, msg))
+		}
+	}
+
+	if spec.StatusProjection != nil {
+		fieldsPath := specPath.Child("statusProjection", "fields")
+
+		if len(spec.StatusProjection.Fields) == 0 {
+			allErrs = append(allErrs, field.Required(fieldsPath, "at least one field must be selected"))
+		}
+
+		for i, path := range spec.StatusProjection.Fields {
+			if strings.TrimSpace(path) == "" {
+				allErrs = append(allErrs, field.Required(fieldsPath.Index(i), "path must not be empty"))
+			}
+		}
+	}
+
+	switch spec.RelatedObjectReferences {
+	case "",
+		syncagentv1alpha1.RelatedObjectReferencesStructured,
+		syncagentv1alpha1.RelatedObjectReferencesLegacy,
+		syncagentv1alpha1.RelatedObjectReferencesBoth,
+		syncagentv1alpha1.RelatedObjectReferencesNone:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("relatedObjectReferences"), spec.RelatedObjectReferences, []syncagentv1alpha1.RelatedObjectReferencesMode{
+			syncagentv1alpha1.RelatedObjectReferencesStructured,
+			syncagentv1alpha1.RelatedObjectReferencesLegacy,
+			syncagentv1alpha1.RelatedObjectReferencesBoth,
+			syncagentv1alpha1.RelatedObjectReferencesNone,
+		}))
+	}
+
+	switch spec.SchemaUpdatePolicy {
+	case "", syncagentv1alpha1.SchemaUpdateManual, syncagentv1alpha1.SchemaUpdateRecreate:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("schemaUpdatePolicy"), spec.SchemaUpdatePolicy, []syncagentv1alpha1.SchemaUpdatePolicy{
+			syncagentv1alpha1.SchemaUpdateManual,
+			syncagentv1alpha1.SchemaUpdateRecreate,
+		}))
+	}
+
+	switch spec.DeletionPolicy {
+	case "", syncagentv1alpha1.DeletionPolicyDelete, syncagentv1alpha1.DeletionPolicyRetain, syncagentv1alpha1.DeletionPolicySnapshot:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("deletionPolicy"), spec.DeletionPolicy, []syncagentv1alpha1.DeletionPolicy{
+			syncagentv1alpha1.DeletionPolicyDelete,
+			syncagentv1alpha1.DeletionPolicyRetain,
+			syncagentv1alpha1.DeletionPolicySnapshot,
+		}))
+	}
+
+	if limits := spec.Limits; limits != nil {
+		limitsPath := specPath.Child("limits")
+
+		if limits.MaxObjectsPerWorkspace < 0 {
+			allErrs = append(allErrs, field.Invalid(limitsPath.Child("maxObjectsPerWorkspace"), limits.MaxObjectsPerWorkspace, "must not be negative"))
+		}
+
+		if limits.MaxObjects < 0 {
+			allErrs = append(allErrs, field.Invalid(limitsPath.Child("maxObjects"), limits.MaxObjects, "must not be negative"))
+		}
+	}
+
+	for i, path := range spec.ImmutableFields {
+		if strings.TrimSpace(path) == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("immutableFields").Index(i), "path must not be empty"))
+		}
+	}
+
+	for i, path := range spec.BidirectionalFields {
+		if strings.TrimSpace(path) == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("bidirectionalFields").Index(i), "path must not be empty"))
+		}
+	}
+
+	for i, mapping := range spec.NamespaceLabels {
+		if mapping.Label == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("namespaceLabels").Index(i).Child("label"), "label must not be empty"))
+		}
+	}
+
+	if nsSync := spec.NamespaceSync; nsSync != nil {
+		nsSyncPath := specPath.Child("namespaceSync")
+
+		for i, label := range nsSync.Labels {
+			if label == "" {
+				allErrs = append(allErrs, field.Required(nsSyncPath.Child("labels").Index(i), "label must not be empty"))
+			}
+		}
+
+		for i, annotation := range nsSync.Annotations {
+			if annotation == "" {
+				allErrs = append(allErrs, field.Required(nsSyncPath.Child("annotations").Index(i), "annotation must not be empty"))
+			}
+		}
+	}
+
+	if policy := spec.MetadataSync; policy != nil {
+		policyPath := specPath.Child("metadataSync")
+
+		allErrs = append(allErrs, validateMetadataDirectionFilters(policy.Labels, policyPath.Child("labels"))...)
+		allErrs = append(allErrs, validateMetadataDirectionFilters(policy.Annotations, policyPath.Child("annotations"))...)
+	}
+
+	if provenance := spec.Provenance; provenance != nil {
+		provenancePath := specPath.Child("provenance")
+
+		if provenance.WorkspacePath && !spec.EnableWorkspacePaths {
+			allErrs = append(allErrs, field.Invalid(provenancePath.Child("workspacePath"), provenance.WorkspacePath, "requires enableWorkspacePaths"))
+		}
+
+		if provenance.UserAnnotation != "" {
+			for _, msg := range utilvalidation.IsQualifiedName(provenance.UserAnnotation) {
+				allErrs = append(allErrs, field.Invalid(provenancePath.Child("userAnnotation"), provenance.UserAnnotation, msg))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateMetadataDirectionFilters(filters *syncagentv1alpha1.MetadataDirectionFilters, fldPath *field.Path) field.ErrorList {
+	if filters == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateMetadataKeyFilter(filters.Down, fldPath.Child("down"))...)
+	allErrs = append(allErrs, validateMetadataKeyFilter(filters.Up, fldPath.Child("up"))...)
+
+	return allErrs
+}
+
+func validateMetadataKeyFilter(filter *syncagentv1alpha1.MetadataKeyFilter, fldPath *field.Path) field.ErrorList {
+	if filter == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateGlobPatterns(filter.Allow, fldPath.Child("allow"))...)
+	allErrs = append(allErrs, validateGlobPatterns(filter.Deny, fldPath.Child("deny"))...)
+
+	return allErrs
+}
+
+func validateGlobPatterns(patterns []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), pattern, "must be a valid, non-empty glob pattern"))
+		}
+	}
+
+	return allErrs
+}
+
+// minHashLength is the minimum length of hashes in local object names, to keep
+// the probability of collisions reasonably low.
+const minHashLength = 8
+
+func validateNaming(spec *syncagentv1alpha1.ResourceNaming, fldPath *field.Path) field.ErrorList {
+	if spec == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if spec.Name != "" && spec.NameTemplate != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.NameTemplate, "name and nameTemplate are mutually exclusive"))
+	}
+
+	if spec.Namespace != "" && spec.NamespaceTemplate != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.NamespaceTemplate, "namespace and namespaceTemplate are mutually exclusive"))
+	}
+
+	if spec.NameTemplate != "" {
+		if err := naming.ParseTemplate(spec.NameTemplate); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nameTemplate"), spec.NameTemplate, err.Error()))
+		}
+	}
+
+	if spec.NamespaceTemplate != "" {
+		if err := naming.ParseTemplate(spec.NamespaceTemplate); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceTemplate"), spec.NamespaceTemplate, err.Error()))
+		}
+	}
+
+	hash := spec.Hash
+	if hash == nil {
+		return allErrs
+	}
+	hashPath := fldPath.Child("hash")
+
+	switch hash.Algorithm {
+	case "", syncagentv1alpha1.NamingHashSHA1, syncagentv1alpha1.NamingHashSHA256:
+	default:
+		allErrs = append(allErrs, field.NotSupported(hashPath.Child("algorithm"), hash.Algorithm, []syncagentv1alpha1.NamingHashAlgorithm{
+			syncagentv1alpha1.NamingHashSHA1,
+			syncagentv1alpha1.NamingHashSHA256,
+		}))
+	}
+
+	switch hash.Encoding {
+	case "", syncagentv1alpha1.NamingHashHex, syncagentv1alpha1.NamingHashBase36:
+	default:
+		allErrs = append(allErrs, field.NotSupported(hashPath.Child("encoding"), hash.Encoding, []syncagentv1alpha1.NamingHashEncoding{
+			syncagentv1alpha1.NamingHashHex,
+			syncagentv1alpha1.NamingHashBase36,
+		}))
+	}
+
+	if hash.Length != 0 && hash.Length < minHashLength {
+		allErrs = append(allErrs, field.Invalid(hashPath.Child("length"), hash.Length, fmt.Sprintf("must be at least %d characters", minHashLength)))
+	} else if maxLength := naming.MaxHashLength(hash.Algorithm, hash.Encoding); hash.Length > maxLength {
+		allErrs = append(allErrs, field.Invalid(hashPath.Child("length"), hash.Length, fmt.Sprintf("must not exceed %d characters for this algorithm and encoding", maxLength)))
+	}
+
+	return allErrs
+}
+
+func validateOrigin(spec *syncagentv1alpha1.PublishedResourceSpec, specPath *field.Path) field.ErrorList {
+	switch spec.Origin {
+	case "", syncagentv1alpha1.PublishedResourceOriginKcp:
+		return nil
+	case syncagentv1alpha1.PublishedResourceOriginService:
+		// these features rely on the primary object originating in kcp
+		allErrs := field.ErrorList{}
+		const msg = "not supported for resources originating on the service cluster"
+
+		if len(spec.Related) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("related"), msg))
+		}
+
+		if len(spec.ImmutableFields) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("immutableFields"), msg))
+		}
+
+		if len(spec.BidirectionalFields) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("bidirectionalFields"), msg))
+		}
+
+		if len(spec.NamespaceLabels) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("namespaceLabels"), msg))
+		}
+
+		if spec.NamespaceSync != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("namespaceSync"), msg))
+		}
+
+		if spec.StatusProjection != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("statusProjection"), msg))
+		}
+
+		if spec.Orphans != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("orphans"), msg))
+		}
+
+		if spec.InitialSync != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("initialSync"), msg))
+		}
+
+		if spec.Readiness != nil && spec.Readiness.RemoteCondition != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("readiness", "remoteCondition"), msg))
+		}
+
+		if spec.Limits != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("limits"), msg))
+		}
+
+		if p := spec.DeletionPolicy; p != "" && p != syncagentv1alpha1.DeletionPolicyDelete {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("deletionPolicy"), msg))
+		}
+
+		if filter := spec.Filter; filter != nil {
+			if filter.NamespaceNames != nil {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("filter", "namespaceNames"), msg))
+			}
+
+			if filter.Expression != "" {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("filter", "expression"), msg))
+			}
+		}
+
+		return allErrs
+	default:
+		return field.ErrorList{field.NotSupported(specPath.Child("origin"), spec.Origin, []syncagentv1alpha1.PublishedResourceOrigin{
+			syncagentv1alpha1.PublishedResourceOriginKcp,
+			syncagentv1alpha1.PublishedResourceOriginService,
+		})}
+	}
+}
+
+func validateSyncSettings(settings *syncagentv1alpha1.SyncSettings, fldPath *field.Path) field.ErrorList {
+	if settings == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if settings.MaxConcurrentReconciles < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentReconciles"), settings.MaxConcurrentReconciles, "must not be negative"))
+	}
+
+	if settings.MaxConcurrentRelatedObjects < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentRelatedObjects"), settings.MaxConcurrentRelatedObjects, "must not be negative"))
+	}
+
+	if settings.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), settings.QPS, "must not be negative"))
+	}
+
+	if settings.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), settings.Burst, "must not be negative"))
+	}
+
+	switch settings.Strategy {
+	case "", syncagentv1alpha1.SyncStrategyMergePatch, syncagentv1alpha1.SyncStrategyServerSideApply:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("strategy"), settings.Strategy, []syncagentv1alpha1.SyncStrategy{
+			syncagentv1alpha1.SyncStrategyMergePatch,
+			syncagentv1alpha1.SyncStrategyServerSideApply,
+		}))
+	}
+
+	if backoff := settings.RequeueBackoff; backoff != nil {
+		backoffPath := fldPath.Child("requeueBackoff")
+
+		if backoff.Initial != nil && backoff.Initial.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("initial"), backoff.Initial.Duration.String(), "must be positive"))
+		}
+
+		if backoff.Max != nil && backoff.Max.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("max"), backoff.Max.Duration.String(), "must be positive"))
+		}
+
+		if backoff.Initial != nil && backoff.Max != nil && backoff.Initial.Duration > backoff.Max.Duration {
+			allErrs = append(allErrs, field.Invalid(backoffPath.Child("max"), backoff.Max.Duration.String(), "must not be smaller than the initial delay"))
+		}
+	}
+
+	if settings.ResyncPeriod != nil && settings.ResyncPeriod.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("resyncPeriod"), settings.ResyncPeriod.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+func validateSourceResource(res syncagentv1alpha1.SourceResourceDescriptor, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if res.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), "version must be set"))
+	}
+
+	if res.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), "kind must be set"))
+	}
+
+	versions := sets.New(res.Version)
+	for i, version := range res.Versions {
+		versionPath := fldPath.Child("versions").Index(i)
+
+		switch {
+		case version.Name == "":
+			allErrs = append(allErrs, field.Required(versionPath.Child("name"), "name must be set"))
+		case versions.Has(version.Name):
+			allErrs = append(allErrs, field.Duplicate(versionPath.Child("name"), version.Name))
+		default:
+			versions.Insert(version.Name)
+		}
+	}
+
+	return allErrs
+}
+
+// kcpBuiltInGroupKinds are resources that kcp itself serves in every workspace.
+// Published resources must not conflict with them.
+var kcpBuiltInGroupKinds = sets.New(
+	schema.GroupKind{Kind: "ConfigMap"},
+	schema.GroupKind{Kind: "Event"},
+	schema.GroupKind{Kind: "LimitRange"},
+	schema.GroupKind{Kind: "Namespace"},
+	schema.GroupKind{Kind: "ResourceQuota"},
+	schema.GroupKind{Kind: "Secret"},
+	schema.GroupKind{Kind: "ServiceAccount"},
+)
+
+// kcpBuiltInGroups are API groups that kcp itself serves in every workspace.
+var kcpBuiltInGroups = sets.New(
+	"admissionregistration.k8s.io",
+	"apiextensions.k8s.io",
+	"authentication.k8s.io",
+	"authorization.k8s.io",
+	"certificates.k8s.io",
+	"coordination.k8s.io",
+	"events.k8s.io",
+	"flowcontrol.apiserver.k8s.io",
+	"rbac.authorization.k8s.io",
+)
+
+// validateBuiltInResource ensures that native Kubernetes resources which kcp also
+// serves itself are projected into a different API group or kind.
+func validateBuiltInResource(spec *syncagentv1alpha1.PublishedResourceSpec, specPath *field.Path) field.ErrorList {
+	gk := schema.GroupKind{Group: spec.Resource.APIGroup, Kind: spec.Resource.Kind}
+
+	if projection := spec.Projection; projection != nil {
+		if projection.Group != "" {
+			gk.Group = projection.Group
+		}
+
+		if projection.Kind != "" {
+			gk.Kind = projection.Kind
+		}
+	}
+
+	if kcpBuiltInGroupKinds.Has(gk) || kcpBuiltInGroups.Has(gk.Group) {
+		return field.ErrorList{field.Invalid(specPath.Child("projection"), gk.String(), "conflicts with a resource built into kcp, the resource must be projected into a different API group")}
+	}
+
+	return nil
+}
+
+func validateFilter(filter *syncagentv1alpha1.ResourceFilter, fldPath *field.Path) field.ErrorList {
+	if filter == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	opts := metav1validation.LabelSelectorValidationOptions{}
+
+	if filter.Namespace != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(filter.Namespace, opts, fldPath.Child("namespace"))...)
+	}
+
+	if filter.Resource != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(filter.Resource, opts, fldPath.Child("resource"))...)
+	}
+
+	if filter.Expression != "" {
+		if _, err := resourcefilter.NewExpression(filter.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("expression"), filter.Expression, err.Error()))
+		}
+	}
+
+	if names := filter.NamespaceNames; names != nil {
+		allErrs = append(allErrs, validateNamePatterns(names.Include, fldPath.Child("namespaceNames", "include"))...)
+		allErrs = append(allErrs, validateNamePatterns(names.Exclude, fldPath.Child("namespaceNames", "exclude"))...)
+	}
+
+	return allErrs
+}
+
+func validateNamePatterns(patterns []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, pattern := range patterns {
+		if pattern == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "pattern must not be empty"))
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), pattern, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+func validateProjection(projection *syncagentv1alpha1.ResourceProjection, fldPath *field.Path) field.ErrorList {
+	if projection == nil {
+		return nil
+	}
+
+	switch projection.Scope {
+	case "", syncagentv1alpha1.ClusterScoped, syncagentv1alpha1.NamespaceScoped:
+		return nil
+	default:
+		return field.ErrorList{field.NotSupported(fldPath.Child("scope"), projection.Scope, []syncagentv1alpha1.ResourceScope{
+			syncagentv1alpha1.ClusterScoped,
+			syncagentv1alpha1.NamespaceScoped,
+		})}
+	}
+}
+
+func validateSchemaProjection(projection *syncagentv1alpha1.SchemaProjection, fldPath *field.Path) field.ErrorList {
+	if projection == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	validatePath := func(fldPath *field.Path, path string) []string {
+		segments := strings.Split(strings.TrimPrefix(path, "."), ".")
+		if slices.Contains(segments, "") {
+			allErrs = append(allErrs, field.Invalid(fldPath, path, "path must not be empty or contain empty segments"))
+			return nil
+		}
+
+		switch segments[0] {
+		case "apiVersion", "kind", "metadata":
+			allErrs = append(allErrs, field.Invalid(fldPath, path, "type and object metadata cannot be projected"))
+			return nil
+		}
+
+		return segments
+	}
+
+	for i, path := range projection.Drop {
+		validatePath(fldPath.Child("drop").Index(i), path)
+	}
+
+	for i, path := range projection.ReadOnly {
+		if segments := validatePath(fldPath.Child("readOnly").Index(i), path); len(segments) > 0 && segments[0] == "status" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("readOnly").Index(i), path, "status fields cannot be made read-only"))
+		}
+	}
+
+	for i, def := range projection.Defaults {
+		defPath := fldPath.Child("defaults").Index(i)
+		validatePath(defPath.Child("path"), def.Path)
+
+		if len(def.Value.Raw) == 0 || !json.Valid(def.Value.Raw) {
+			allErrs = append(allErrs, field.Invalid(defPath.Child("value"), string(def.Value.Raw), "must be a valid JSON value"))
+		}
+	}
+
+	for key := range projection.Annotations {
+		for _, msg := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations"), key, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateReadiness(readiness *syncagentv1alpha1.ResourceReadiness, fldPath *field.Path) field.ErrorList {
+	if readiness == nil {
+		return nil
+	}
+
+	configured := 0
+	if readiness.Condition != "" {
+		configured++
+	}
+
+	if readiness.Path != "" {
+		configured++
+	}
+
+	return validateExactlyOne(configured, fldPath, "exactly one of condition or path must be set")
+}
+
+func validateMutationSpec(spec *syncagentv1alpha1.ResourceMutationSpec, fldPath *field.Path) field.ErrorList {
+	if spec == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	for i, mutation := range spec.Spec {
+		allErrs = append(allErrs, validateMutation(mutation, fldPath.Child("spec").Index(i))...)
+	}
+
+	for i, mutation := range spec.Status {
+		allErrs = append(allErrs, validateMutation(mutation, fldPath.Child("status").Index(i))...)
+	}
+
+	for i, path := range spec.IgnoreFields {
+		if strings.TrimSpace(strings.TrimPrefix(path, ".")) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("ignoreFields").Index(i), "path must not be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateMutation(mutation syncagentv1alpha1.ResourceMutation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	configured := 0
+
+	if mutation.Delete != nil {
+		configured++
+
+		if mutation.Delete.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("delete", "path"), "path must be set"))
+		}
+
+		allErrs = append(allErrs, validateJSONPath(mutation.Delete.Path, fldPath.Child("delete", "path"))...)
+	}
+
+	if mutation.Regex != nil {
+		configured++
+
+		if mutation.Regex.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("regex", "path"), "path must be set"))
+		}
+
+		allErrs = append(allErrs, validateJSONPath(mutation.Regex.Path, fldPath.Child("regex", "path"))...)
+		allErrs = append(allErrs, validatePattern(mutation.Regex.Pattern, fldPath.Child("regex", "pattern"))...)
+	}
+
+	if mutation.Template != nil {
+		configured++
+
+		if mutation.Template.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("template", "path"), "path must be set"))
+		}
+
+		allErrs = append(allErrs, validateJSONPath(mutation.Template.Path, fldPath.Child("template", "path"))...)
+		allErrs = append(allErrs, validateTemplate(mutation.Template.Template, fldPath.Child("template", "template"))...)
+	}
+
+	if len(mutation.Patch) > 0 {
+		configured++
+
+		for i, op := range mutation.Patch {
+			allErrs = append(allErrs, validatePatchOperation(op, fldPath.Child("patch").Index(i))...)
+		}
+	}
+
+	if mutation.Merge != nil {
+		configured++
+
+		var doc map[string]any
+		if err := json.Unmarshal(mutation.Merge.Raw, &doc); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("merge"), string(mutation.Merge.Raw), "merge patch must be a JSON object"))
+		}
+	}
+
+	allErrs = append(allErrs, validateExactlyOne(configured, fldPath, "exactly one of delete, regex, template, patch or merge must be set")...)
+
+	if mutation.Condition != nil {
+		if mutation.Condition.Path == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("condition", "path"), "path must be set"))
+		}
+
+		allErrs = append(allErrs, validateJSONPath(mutation.Condition.Path, fldPath.Child("condition", "path"))...)
+	}
+
+	return allErrs
+}
+
+func validatePatchOperation(op syncagentv1alpha1.ResourcePatchOperation, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if op.Path == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), "path must be set"))
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("value"), fmt.Sprintf("value must be set for %q operations", op.Op)))
+		}
+	case "move", "copy":
+		if op.From == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("from"), fmt.Sprintf("from must be set for %q operations", op.Op)))
+		}
+	case "remove":
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("op"), op.Op, []string{"add", "remove", "replace", "move", "copy", "test"}))
+	}
+
+	return allErrs
+}
+
+// maxRelatedResourceDepth is the maximum number of nested related resource levels.
+const maxRelatedResourceDepth = 5
+
+func validateRelatedResources(related []syncagentv1alpha1.RelatedResourceSpec, fldPath *field.Path) field.ErrorList {
+	return validateRelatedResourceLevel(related, fldPath, sets.New[string](), 1)
+}
+
+func validateRelatedResourceLevel(related []syncagentv1alpha1.RelatedResourceSpec, fldPath *field.Path, identifiers sets.Set[string], depth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(related) > 0 && depth > maxRelatedResourceDepth {
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("related resources must not be nested more than %d levels deep", maxRelatedResourceDepth)))
+	}
+
+	for i, relRes := range related {
+		relPath := fldPath.Index(i)
+
+		switch {
+		case relRes.Identifier == "":
+			allErrs = append(allErrs, field.Required(relPath.Child("identifier"), "identifier must be set"))
+		case identifiers.Has(relRes.Identifier):
+			allErrs = append(allErrs, field.Duplicate(relPath.Child("identifier"), relRes.Identifier))
+		default:
+			identifiers.Insert(relRes.Identifier)
+		}
+
+		if relRes.Origin != "service" && relRes.Origin != "kcp" && relRes.Origin != "both" {
+			allErrs = append(allErrs, field.NotSupported(relPath.Child("origin"), relRes.Origin, []string{"service", "kcp", "both"}))
+		}
+
+		switch relRes.ConflictPolicy {
+		case "":
+		case syncagentv1alpha1.RelatedResourceConflictPolicyKcpWins, syncagentv1alpha1.RelatedResourceConflictPolicyServiceWins, syncagentv1alpha1.RelatedResourceConflictPolicyNewestWins:
+			if relRes.Origin != "both" {
+				allErrs = append(allErrs, field.Forbidden(relPath.Child("conflictPolicy"), `conflict policy can only be configured for origin "both"`))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(relPath.Child("conflictPolicy"), relRes.ConflictPolicy, []syncagentv1alpha1.RelatedResourceConflictPolicy{
+				syncagentv1alpha1.RelatedResourceConflictPolicyKcpWins,
+				syncagentv1alpha1.RelatedResourceConflictPolicyServiceWins,
+				syncagentv1alpha1.RelatedResourceConflictPolicyNewestWins,
+			}))
+		}
+
+		if relRes.Kind == "" {
+			allErrs = append(allErrs, field.Required(relPath.Child("kind"), "kind must be set"))
+		}
+
+		switch relRes.DeletionPolicy {
+		case "", syncagentv1alpha1.RelatedResourceDeletionPolicyOrphan, syncagentv1alpha1.RelatedResourceDeletionPolicyDelete:
+		default:
+			allErrs = append(allErrs, field.NotSupported(relPath.Child("deletionPolicy"), relRes.DeletionPolicy, []syncagentv1alpha1.RelatedResourceDeletionPolicy{
+				syncagentv1alpha1.RelatedResourceDeletionPolicyOrphan,
+				syncagentv1alpha1.RelatedResourceDeletionPolicyDelete,
+			}))
+		}
+
+		objPath := relPath.Child("object")
+		allErrs = append(allErrs, validateRelatedObjectSpec(relRes.Object.RelatedResourceObjectSpec, objPath)...)
+
+		if relRes.Object.Namespace != nil {
+			allErrs = append(allErrs, validateRelatedObjectSpec(*relRes.Object.Namespace, objPath.Child("namespace"))...)
+		}
+
+		allErrs = append(allErrs, validateMutationSpec(relRes.Mutation, relPath.Child("mutation"))...)
+		allErrs = append(allErrs, validateDataTransformation(relRes, relPath.Child("transform"))...)
+		allErrs = append(allErrs, validateRelatedResourceLevel(relRes.Related, relPath.Child("related"), identifiers, depth+1)...)
+	}
+
+	return allErrs
+}
+
+func validateDataTransformation(relRes syncagentv1alpha1.RelatedResourceSpec, fldPath *field.Path) field.ErrorList {
+	transform := relRes.Transform
+	if transform == nil {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	if relRes.APIGroup != "" || (relRes.Kind != "ConfigMap" && relRes.Kind != "Secret") {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "data transformations can only be configured for ConfigMaps and Secrets"))
+	}
+
+	validateKey := func(key string, keyPath *field.Path) {
+		for _, msg := range utilvalidation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, msg))
+		}
+	}
+
+	for i, rename := range transform.Rename {
+		renamePath := fldPath.Child("rename").Index(i)
+		validateKey(rename.From, renamePath.Child("from"))
+		validateKey(rename.To, renamePath.Child("to"))
+	}
+
+	for i, key := range transform.Drop {
+		validateKey(key, fldPath.Child("drop").Index(i))
+	}
+
+	composed := sets.New[string]()
+	for i, compose := range transform.Compose {
+		composePath := fldPath.Child("compose").Index(i)
+		validateKey(compose.Key, composePath.Child("key"))
+
+		if composed.Has(compose.Key) {
+			allErrs = append(allErrs, field.Duplicate(composePath.Child("key"), compose.Key))
+		}
+		composed.Insert(compose.Key)
+
+		if compose.Template == "" {
+			allErrs = append(allErrs, field.Required(composePath.Child("template"), "template must be set"))
+		}
+		allErrs = append(allErrs, validateTemplate(compose.Template, composePath.Child("template"))...)
+	}
+
+	return allErrs
+}
+
+func validateRelatedObjectSpec(spec syncagentv1alpha1.RelatedResourceObjectSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	configured := 0
+
+	if spec.Selector != nil {
+		configured++
+
+		selectorPath := fldPath.Child("selector")
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&spec.Selector.LabelSelector, metav1validation.LabelSelectorValidationOptions{}, selectorPath)...)
+
+		rewrite := spec.Selector.Rewrite
+		rewritePath := selectorPath.Child("rewrite")
+
+		rewrites := 0
+
+		if rewrite.Regex != nil {
+			rewrites++
+			allErrs = append(allErrs, validatePattern(rewrite.Regex.Pattern, rewritePath.Child("regex", "pattern"))...)
+		}
+
+		if rewrite.Template != nil {
+			rewrites++
+			allErrs = append(allErrs, validateTemplate(rewrite.Template.Template, rewritePath.Child("template", "template"))...)
+		}
+
+		allErrs = append(allErrs, validateExactlyOne(rewrites, rewritePath, "exactly one of regex or template must be set")...)
+	}
+
+	if spec.Reference != nil {
+		configured++
+
+		refPath := fldPath.Child("reference")
+
+		if spec.Reference.Path == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("path"), "path must be set"))
+		}
+
+		allErrs = append(allErrs, validateJSONPath(spec.Reference.Path, refPath.Child("path"))...)
+
+		if spec.Reference.Regex != nil {
+			allErrs = append(allErrs, validatePattern(spec.Reference.Regex.Pattern, refPath.Child("regex", "pattern"))...)
+		}
+	}
+
+	if spec.Template != nil {
+		configured++
+
+		if spec.Template.Template == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("template", "template"), "template must be set"))
+		}
+
+		allErrs = append(allErrs, validateTemplate(spec.Template.Template, fldPath.Child("template", "template"))...)
+	}
+
+	allErrs = append(allErrs, validateExactlyOne(configured, fldPath, "exactly one of selector, reference or template must be set")...)
+
+	return allErrs
+}
+
+// validateExactlyOne is used for structs with mutually exclusive fields, of which
+// exactly one must be configured.
+func validateExactlyOne(configured int, fldPath *field.Path, message string) field.ErrorList {
+	switch {
+	case configured == 0:
+		return field.ErrorList{field.Required(fldPath, message)}
+	case configured > 1:
+		return field.ErrorList{field.Forbidden(fldPath, message)}
+	default:
+		return nil
+	}
+}
+
+func validatePattern(pattern string, fldPath *field.Path) field.ErrorList {
+	if pattern == "" {
+		return nil
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, pattern, fmt.Sprintf("invalid regular expression: %v", err))}
+	}
+
+	return nil
+}
+
+// validateTemplate only parses the template, as its data is not known until
+// the template is rendered during synchronization. The function map must be
+// kept in sync with the one used by the mutation and related resource code.
+func validateTemplate(tpl string, fldPath *field.Path) field.ErrorList {
+	if tpl == "" {
+		return nil
+	}
+
+	funcs := sprig.TxtFuncMap()
+	funcs["join"] = strings.Join
+
+	if _, err := template.New("validation").Funcs(funcs).Parse(tpl); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, tpl, fmt.Sprintf("invalid template: %v", err))}
+	}
+
+	return nil
+}
+
+// validateJSONPath performs a structural check on a gjson/sjson path. Neither
+// library offers a way to validate a path, and both silently treat malformed
+// paths as paths that simply do not match anything, so this catches the most
+// common typos like empty segments or unbalanced query brackets.
+func validateJSONPath(path string, fldPath *field.Path) field.ErrorList {
+	if path == "" {
+		return nil
+	}
+
+	if err := checkJSONPath(path); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, path, fmt.Sprintf("invalid path: %v", err))}
+	}
+
+	return nil
+}
+
+func checkJSONPath(path string) error {
+	closing := map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+	var (
+		stack         []rune
+		escaped       bool
+		inString      bool
+		segmentLength int
+	)
+
+	for i, r := range path {
+		switch {
+		case escaped:
+			escaped = false
+			segmentLength++
+			continue
+
+		case r == '\\':
+			escaped = true
+			continue
+
+		case inString:
+			if r == '"' {
+				inString = false
+			}
+			continue
+
+		// strings only occur within query expressions
+		case r == '"' && len(stack) > 0:
+			inString = true
+
+		case closing[r] != 0:
+			stack = append(stack, closing[r])
+
+		case r == ')' || r == ']' || r == '}':
+			if len(stack) == 0 || stack[len(stack)-1] != r {
+				return fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+			stack = stack[:len(stack)-1]
+
+		case (r == '.' || r == '|') && len(stack) == 0:
+			if segmentLength == 0 {
+				return fmt.Errorf("empty segment before position %d", i)
+			}
+			segmentLength = 0
+			continue
+		}
+
+		segmentLength++
+	}
+
+	switch {
+	case escaped:
+		return errors.New("path must not end with an escape character")
+	case inString:
+		return errors.New("unterminated string")
+	case len(stack) > 0:
+		return fmt.Errorf("missing %q", stack[len(stack)-1])
+	case segmentLength == 0:
+		return errors.New("path must not end with a separator")
+	}
+
+	return nil
+}
@@ -0,0 +1,744 @@
+/*
+Copyright 2025 The KCP Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	syncagentv1alpha1 "github.com/kcp-dev/api-syncagent/sdk/apis/syncagent/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidatePublishedResource(t *testing.T) {
+	validResource := syncagentv1alpha1.SourceResourceDescriptor{
+		APIGroup: "example.com",
+		Version:  "v1",
+		Kind:     "Thing",
+	}
+
+	testcases := []struct {
+		name           string
+		spec           syncagentv1alpha1.PublishedResourceSpec
+		expectedFields []string
+	}{
+		{
+			name: "minimal valid spec",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+			},
+		},
+		{
+			name: "missing kind",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: syncagentv1alpha1.SourceResourceDescriptor{Version: "v1"},
+			},
+			expectedFields: []string{"spec.resource.kind"},
+		},
+		{
+			name: "duplicate versions",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: syncagentv1alpha1.SourceResourceDescriptor{
+					Version: "v1",
+					Kind:    "Thing",
+					Versions: []syncagentv1alpha1.SourceResourceVersion{
+						{Name: "v1"},
+					},
+				},
+			},
+			expectedFields: []string{"spec.resource.versions[0].name"},
+		},
+		{
+			name: "mutation without any option",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0]"},
+		},
+		{
+			name: "mutation with multiple options",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Status: []syncagentv1alpha1.ResourceMutation{{
+						Delete: &syncagentv1alpha1.ResourceDeleteMutation{Path: "status.foo"},
+						Regex:  &syncagentv1alpha1.ResourceRegexMutation{Path: "status.bar"},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.status[0]"},
+		},
+		{
+			name: "patch mutation with incomplete operations",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Patch: []syncagentv1alpha1.ResourcePatchOperation{
+							{Op: "add", Path: "/spec/foo"},
+							{Op: "move", Path: "/spec/bar"},
+							{Op: "remove", Path: "/spec/baz"},
+						},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].patch[0].value", "spec.mutation.spec[0].patch[1].from"},
+		},
+		{
+			name: "mutation condition without path",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Delete:    &syncagentv1alpha1.ResourceDeleteMutation{Path: "spec.foo"},
+						Condition: &syncagentv1alpha1.ResourceMutationCondition{Value: "premium"},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].condition.path"},
+		},
+		{
+			name: "merge mutation that is not an object",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Merge: &runtime.RawExtension{Raw: []byte(`[1,2]`)},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].merge"},
+		},
+		{
+			name: "empty ignored field",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					IgnoreFields: []string{"spec.clusterIP", "."},
+				},
+			},
+			expectedFields: []string{"spec.mutation.ignoreFields[1]"},
+		},
+		{
+			name: "template mutation with unparseable template",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Template: &syncagentv1alpha1.ResourceTemplateMutation{
+							Path:     "spec.tier",
+							Template: "{{ .Value.String | upper }",
+						},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].template.template"},
+		},
+		{
+			name: "template mutation with unknown function",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Template: &syncagentv1alpha1.ResourceTemplateMutation{
+							Path:     "spec.tier",
+							Template: "{{ .Value.String | shout }}",
+						},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].template.template"},
+		},
+		{
+			name: "mutations with malformed paths",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{
+						{
+							Delete: &syncagentv1alpha1.ResourceDeleteMutation{Path: "spec..foo"},
+						},
+						{
+							Regex:     &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.containers.#(name==\"app\".image"},
+							Condition: &syncagentv1alpha1.ResourceMutationCondition{Path: "spec.tier."},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].delete.path", "spec.mutation.spec[1].regex.path", "spec.mutation.spec[1].condition.path"},
+		},
+		{
+			name: "invalid regular expression",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Mutation: &syncagentv1alpha1.ResourceMutationSpec{
+					Spec: []syncagentv1alpha1.ResourceMutation{{
+						Regex: &syncagentv1alpha1.ResourceRegexMutation{Path: "spec.foo", Pattern: "(unclosed"},
+					}},
+				},
+			},
+			expectedFields: []string{"spec.mutation.spec[0].regex.pattern"},
+		},
+		{
+			name: "readiness with condition and path",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Readiness: &syncagentv1alpha1.ResourceReadiness{
+					Condition: "Ready",
+					Path:      "status.phase",
+				},
+			},
+			expectedFields: []string{"spec.readiness"},
+		},
+		{
+			name: "service origin with unsupported features",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:        validResource,
+				Origin:          syncagentv1alpha1.PublishedResourceOriginService,
+				ImmutableFields: []string{"spec.foo"},
+				InitialSync:     &syncagentv1alpha1.InitialSyncSettings{ChunkSize: 10},
+			},
+			expectedFields: []string{"spec.immutableFields", "spec.initialSync"},
+		},
+		{
+			name: "valid namespace name filter",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Filter: &syncagentv1alpha1.ResourceFilter{
+					NamespaceNames: &syncagentv1alpha1.NamespaceNameFilter{
+						Include: []string{"prod-*", "default"},
+						Exclude: []string{"kube-*"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid namespace name patterns",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Filter: &syncagentv1alpha1.ResourceFilter{
+					NamespaceNames: &syncagentv1alpha1.NamespaceNameFilter{
+						Include: []string{"prod-[a-"},
+						Exclude: []string{""},
+					},
+				},
+			},
+			expectedFields: []string{"spec.filter.namespaceNames.include[0]", "spec.filter.namespaceNames.exclude[0]"},
+		},
+		{
+			name: "invalid filter expression",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Filter: &syncagentv1alpha1.ResourceFilter{
+					Expression: "object.spec.size >",
+				},
+			},
+			expectedFields: []string{"spec.filter.expression"},
+		},
+		{
+			name: "invalid deletion policy",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:       validResource,
+				DeletionPolicy: "Archive",
+			},
+			expectedFields: []string{"spec.deletionPolicy"},
+		},
+		{
+			name: "retaining objects originating on the service cluster",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:       validResource,
+				Origin:         syncagentv1alpha1.PublishedResourceOriginService,
+				DeletionPolicy: syncagentv1alpha1.DeletionPolicyRetain,
+			},
+			expectedFields: []string{"spec.deletionPolicy"},
+		},
+		{
+			name: "empty bidirectional field",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:            validResource,
+				BidirectionalFields: []string{"spec.port", " "},
+			},
+			expectedFields: []string{"spec.bidirectionalFields[1]"},
+		},
+		{
+			name: "invalid metadata sync pattern",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				MetadataSync: &syncagentv1alpha1.MetadataSyncPolicy{
+					Annotations: &syncagentv1alpha1.MetadataDirectionFilters{
+						Down: &syncagentv1alpha1.MetadataKeyFilter{
+							Deny: []string{"internal.corp/*", "[invalid"},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.metadataSync.annotations.down.deny[1]"},
+		},
+		{
+			name: "requeue backoff with max smaller than initial",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Sync: &syncagentv1alpha1.SyncSettings{
+					RequeueBackoff: &syncagentv1alpha1.RequeueBackoff{
+						Initial: &metav1.Duration{Duration: time.Minute},
+						Max:     &metav1.Duration{Duration: time.Second},
+					},
+				},
+			},
+			expectedFields: []string{"spec.sync.requeueBackoff.max"},
+		},
+		{
+			name: "negative resync period",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Sync: &syncagentv1alpha1.SyncSettings{
+					ResyncPeriod: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			expectedFields: []string{"spec.sync.resyncPeriod"},
+		},
+		{
+			name: "negative object limit",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Limits: &syncagentv1alpha1.ObjectLimits{
+					MaxObjects: -1,
+				},
+			},
+			expectedFields: []string{"spec.limits.maxObjects"},
+		},
+		{
+			name: "unknown sync strategy",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Sync: &syncagentv1alpha1.SyncSettings{
+					Strategy: "Replace",
+				},
+			},
+			expectedFields: []string{"spec.sync.strategy"},
+		},
+		{
+			name: "orphan settings with unknown policy and short interval",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Orphans: &syncagentv1alpha1.OrphanSettings{
+					Policy:   "Archive",
+					Interval: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			expectedFields: []string{"spec.orphans.policy", "spec.orphans.interval"},
+		},
+		{
+			name: "invalid state namespace",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:       validResource,
+				StateNamespace: "Team_A",
+			},
+			expectedFields: []string{"spec.stateNamespace"},
+		},
+		{
+			name: "unknown schema update policy",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource:           validResource,
+				SchemaUpdatePolicy: "Always",
+			},
+			expectedFields: []string{"spec.schemaUpdatePolicy"},
+		},
+		{
+			name: "valid schema projection",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				SchemaProjection: &syncagentv1alpha1.SchemaProjection{
+					Drop:     []string{"spec.internalTuning"},
+					ReadOnly: []string{".spec.size"},
+				},
+			},
+		},
+		{
+			name: "invalid schema projection",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				SchemaProjection: &syncagentv1alpha1.SchemaProjection{
+					Drop:     []string{"metadata.labels", "spec..foo"},
+					ReadOnly: []string{"status.phase"},
+				},
+			},
+			expectedFields: []string{"spec.schemaProjection.drop[0]", "spec.schemaProjection.drop[1]", "spec.schemaProjection.readOnly[0]"},
+		},
+		{
+			name: "invalid schema defaults",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				SchemaProjection: &syncagentv1alpha1.SchemaProjection{
+					Defaults: []syncagentv1alpha1.SchemaDefault{{
+						Path:  "spec.tier",
+						Value: runtime.RawExtension{Raw: []byte(`"gold`)},
+					}},
+					Annotations: map[string]string{"not a key": "value"},
+				},
+			},
+			expectedFields: []string{"spec.schemaProjection.defaults[0].value", "spec.schemaProjection.annotations"},
+		},
+		{
+			name: "valid related resource",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "credentials",
+					Origin:     "service",
+					Kind:       "Secret",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+						},
+					},
+				}},
+			},
+		},
+		{
+			name: "related resource with broken templates",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "credentials",
+					Origin:     "service",
+					Kind:       "Secret",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Template: &syncagentv1alpha1.TemplateExpression{Template: "{{ .Object.spec.secretName"},
+						},
+						Namespace: &syncagentv1alpha1.RelatedResourceObjectSpec{
+							Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: ".metadata.namespace"},
+						},
+					},
+				}},
+			},
+			expectedFields: []string{"spec.related[0].object.template.template", "spec.related[0].object.namespace.reference.path"},
+		},
+		{
+			name: "valid data transformation",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "credentials",
+					Origin:     "service",
+					Kind:       "Secret",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+						},
+					},
+					Transform: &syncagentv1alpha1.RelatedResourceDataTransformation{
+						Rename:  []syncagentv1alpha1.RelatedResourceKeyRename{{From: "password", To: "DB_PASSWORD"}},
+						Drop:    []string{"ca.crt"},
+						Compose: []syncagentv1alpha1.RelatedResourceComposedKey{{Key: "DSN", Template: "{{ .Data.username }}@db"}},
+					},
+				}},
+			},
+		},
+		{
+			name: "invalid data transformation",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "certificate",
+					Origin:     "service",
+					APIGroup:   "cert-manager.io",
+					Kind:       "Certificate",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.certName"},
+						},
+					},
+					Transform: &syncagentv1alpha1.RelatedResourceDataTransformation{
+						Rename: []syncagentv1alpha1.RelatedResourceKeyRename{{From: "password", To: "not a key"}},
+						Compose: []syncagentv1alpha1.RelatedResourceComposedKey{
+							{Key: "DSN", Template: "{{ .Data.username"},
+							{Key: "DSN", Template: "{{ .Data.username }}"},
+						},
+					},
+				}},
+			},
+			expectedFields: []string{
+				"spec.related[0].transform",
+				"spec.related[0].transform.rename[0].to",
+				"spec.related[0].transform.compose[0].template",
+				"spec.related[0].transform.compose[1].key",
+			},
+		},
+		{
+			name: "conflict policy requires origin both",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{
+					{
+						Identifier:     "credentials",
+						Origin:         "both",
+						ConflictPolicy: syncagentv1alpha1.RelatedResourceConflictPolicyNewestWins,
+						Kind:           "Secret",
+						Object: syncagentv1alpha1.RelatedResourceObject{
+							RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+								Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+							},
+						},
+					},
+					{
+						Identifier:     "config",
+						Origin:         "kcp",
+						ConflictPolicy: syncagentv1alpha1.RelatedResourceConflictPolicyKcpWins,
+						Kind:           "ConfigMap",
+						Object: syncagentv1alpha1.RelatedResourceObject{
+							RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+								Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.configMapName"},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.related[1].conflictPolicy"},
+		},
+		{
+			name: "related resources with conflicting settings",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{
+					{
+						Identifier: "credentials",
+						Origin:     "service",
+						Kind:       "Secret",
+						Object: syncagentv1alpha1.RelatedResourceObject{
+							RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+								Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+								Template:  &syncagentv1alpha1.TemplateExpression{Template: "foo"},
+							},
+						},
+					},
+					{
+						Identifier: "credentials",
+						Origin:     "elsewhere",
+						Kind:       "Secret",
+						Object: syncagentv1alpha1.RelatedResourceObject{
+							RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+								Selector: &syncagentv1alpha1.RelatedResourceObjectSelector{},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: []string{
+				"spec.related[0].object",
+				"spec.related[1].identifier",
+				"spec.related[1].origin",
+				"spec.related[1].object.selector.rewrite",
+			},
+		},
+		{
+			name: "nested related resource with duplicate identifier",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Related: []syncagentv1alpha1.RelatedResourceSpec{{
+					Identifier: "certificate",
+					Origin:     "service",
+					Kind:       "Certificate",
+					Object: syncagentv1alpha1.RelatedResourceObject{
+						RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+							Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.certificateName"},
+						},
+					},
+					Related: []syncagentv1alpha1.RelatedResourceSpec{{
+						Identifier: "certificate",
+						Origin:     "service",
+						Kind:       "Secret",
+						Object: syncagentv1alpha1.RelatedResourceObject{
+							RelatedResourceObjectSpec: syncagentv1alpha1.RelatedResourceObjectSpec{
+								Reference: &syncagentv1alpha1.RelatedResourceObjectReference{Path: "spec.secretName"},
+							},
+						},
+					}},
+				}},
+			},
+			expectedFields: []string{
+				"spec.related[0].related[0].identifier",
+			},
+		},
+		{
+			name: "valid naming hash",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Naming: &syncagentv1alpha1.ResourceNaming{
+					Hash: &syncagentv1alpha1.NamingHash{
+						Algorithm: syncagentv1alpha1.NamingHashSHA256,
+						Encoding:  syncagentv1alpha1.NamingHashBase36,
+						Length:    50,
+					},
+				},
+			},
+		},
+		{
+			name: "naming hash longer than the encoded hash",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Naming: &syncagentv1alpha1.ResourceNaming{
+					Hash: &syncagentv1alpha1.NamingHash{
+						Encoding: syncagentv1alpha1.NamingHashBase36,
+						Length:   32,
+					},
+				},
+			},
+			expectedFields: []string{"spec.naming.hash.length"},
+		},
+		{
+			name: "naming hash too short and with unknown algorithm",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Naming: &syncagentv1alpha1.ResourceNaming{
+					Hash: &syncagentv1alpha1.NamingHash{
+						Algorithm: "md5",
+						Length:    4,
+					},
+				},
+			},
+			expectedFields: []string{"spec.naming.hash.algorithm", "spec.naming.hash.length"},
+		},
+		{
+			name: "name and nameTemplate",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Naming: &syncagentv1alpha1.ResourceNaming{
+					Name:         "$remoteName",
+					NameTemplate: "{{ .RemoteName }}",
+				},
+			},
+			expectedFields: []string{"spec.naming"},
+		},
+		{
+			name: "invalid namespace template",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Naming: &syncagentv1alpha1.ResourceNaming{
+					NamespaceTemplate: "{{ .RemoteNamespace",
+				},
+			},
+			expectedFields: []string{"spec.naming.namespaceTemplate"},
+		},
+		{
+			name: "native resource",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: syncagentv1alpha1.SourceResourceDescriptor{Version: "v1", Kind: "Service"},
+			},
+		},
+		{
+			name: "native resource conflicting with kcp",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: syncagentv1alpha1.SourceResourceDescriptor{Version: "v1", Kind: "ConfigMap"},
+			},
+			expectedFields: []string{"spec.projection"},
+		},
+		{
+			name: "native resource projected into another group",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: syncagentv1alpha1.SourceResourceDescriptor{Version: "v1", Kind: "ConfigMap"},
+				Projection: &syncagentv1alpha1.ResourceProjection{
+					Group: "config.example.com",
+				},
+			},
+		},
+		{
+			name: "provenance workspace path without workspace paths",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Provenance: &syncagentv1alpha1.ProvenancePolicy{
+					WorkspacePath:  true,
+					UserAnnotation: "example.com/created by",
+				},
+			},
+			expectedFields: []string{"spec.provenance.workspacePath", "spec.provenance.userAnnotation"},
+		},
+		{
+			name: "projection into a group served by kcp",
+			spec: syncagentv1alpha1.PublishedResourceSpec{
+				Resource: validResource,
+				Projection: &syncagentv1alpha1.ResourceProjection{
+					Group: "rbac.authorization.k8s.io",
+				},
+			},
+			expectedFields: []string{"spec.projection"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			pubRes := &syncagentv1alpha1.PublishedResource{Spec: testcase.spec}
+
+			errs := ValidatePublishedResource(pubRes)
+
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+
+			if len(fields) != len(testcase.expectedFields) {
+				t.Fatalf("Expected errors for %v, but got %v.", testcase.expectedFields, errs)
+			}
+
+			for i, field := range fields {
+				if field != testcase.expectedFields[i] {
+					t.Errorf("Expected error #%d to be for %q, but got %q.", i, testcase.expectedFields[i], field)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckJSONPath(t *testing.T) {
+	testcases := []struct {
+		path  string
+		valid bool
+	}{
+		{path: "spec.secretName", valid: true},
+		{path: "spec.containers.#.image", valid: true},
+		{path: `metadata.labels.app\.kubernetes\.io/name`, valid: true},
+		{path: `spec.containers.#(name=="app").image`, valid: true},
+		{path: `spec.containers.#(name=="a)b").image`, valid: true},
+		{path: "spec.ports|@reverse", valid: true},
+		{path: ".spec"},
+		{path: "spec."},
+		{path: "spec..name"},
+		{path: `spec.name\`},
+		{path: `spec.containers.#(name=="app"`},
+		{path: `spec.containers.#(name=="app).image`},
+		{path: "spec.containers.#(name==app]"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.path, func(t *testing.T) {
+			err := checkJSONPath(testcase.path)
+			if testcase.valid && err != nil {
+				t.Fatalf("Expected path to be valid, but got: %v", err)
+			}
+
+			if !testcase.valid && err == nil {
+				t.Fatal("Expected path to be invalid, but got no error.")
+			}
+		})
+	}
+}
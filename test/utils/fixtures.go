@@ -158,6 +158,12 @@ func CreateAPIExport(t *testing.T, ctx context.Context, client ctrlruntimeclient
 					ResourceNames: []string{name},
 					Verbs:         []string{"get", "list", "watch", "patch", "update"},
 				},
+				{
+					APIGroups:     []string{"apis.kcp.io"},
+					Resources:     []string{"apiexportendpointslices"},
+					ResourceNames: []string{name},
+					Verbs:         []string{"get"},
+				},
 				{
 					APIGroups: []string{"apis.kcp.io"},
 					Resources: []string{"apiresourceschemas"},